@@ -327,6 +327,8 @@ func (ac *AdminController) HandleSearch(c *fiber.Ctx) error {
 		return ac.handleUserSearch(c, query)
 	case "images":
 		return ac.handleImageSearch(c, query)
+	case "tags":
+		return ac.handleImageSearch(c, "tag:"+query)
 	default:
 		return c.Redirect("/admin")
 	}
@@ -476,7 +478,7 @@ func (ac *AdminController) HandleSettings(c *fiber.Ctx) error {
 	csrfToken := c.Locals("csrf").(string)
 
 	// Render settings page
-	settingsView := admin_views.Settings(*settings, csrfToken)
+	settingsView := admin_views.Settings(settings, csrfToken)
 	home := views.HomeCtx(c, " | Einstellungen", userCtx.IsLoggedIn, false, flash.Get(c), settingsView, userCtx.IsAdmin, nil)
 
 	handler := adaptor.HTTPHandler(templ.Handler(home))
@@ -510,6 +512,21 @@ func (ac *AdminController) HandleSettingsUpdate(c *fiber.Ctx) error {
 	thumbnailAVIFEnabled := c.FormValue("thumbnail_avif_enabled") == "on"
 	// Replication settings
 	replicationRequireChecksum := c.FormValue("replication_require_checksum") == "on"
+	replicaCount := func(field string) int {
+		v, _ := strconv.Atoi(c.FormValue(field))
+		if v < 1 {
+			return 1
+		}
+		if v > 5 {
+			return 5
+		}
+		return v
+	}
+	replicasHot := replicaCount("replicas_hot")
+	replicasWarm := replicaCount("replicas_warm")
+	replicasCold := replicaCount("replicas_cold")
+	// Security settings
+	requireAdmin2FA := c.FormValue("require_admin_2fa") == "on"
 
 	jobQueueWorkerCount, _ := strconv.Atoi(c.FormValue("job_queue_worker_count"))
 	if jobQueueWorkerCount < 1 {
@@ -577,6 +594,40 @@ func (ac *AdminController) HandleSettingsUpdate(c *fiber.Ctx) error {
 		tieringSweepIntervalMinutes = 1440
 	}
 
+	transformCacheMaxMBPerPool, _ := strconv.Atoi(c.FormValue("transform_cache_max_mb_per_pool"))
+	if transformCacheMaxMBPerPool < 0 {
+		transformCacheMaxMBPerPool = 0
+	}
+	if transformCacheMaxMBPerPool > 10000000 {
+		transformCacheMaxMBPerPool = 10000000
+	}
+
+	similarImageMaxDistance, _ := strconv.Atoi(c.FormValue("similar_image_max_distance"))
+	if similarImageMaxDistance < 0 {
+		similarImageMaxDistance = 0
+	}
+	if similarImageMaxDistance > 32 {
+		similarImageMaxDistance = 32
+	}
+	nearDuplicateUploadWarning := c.FormValue("near_duplicate_upload_warning") == "on"
+	optimizationEnabled := c.FormValue("optimization_enabled") == "on"
+	optimizeOriginals := c.FormValue("optimize_originals") == "on"
+	integrityScrubEnabled := c.FormValue("integrity_scrub_enabled") == "on"
+	integrityScrubIntervalHours, _ := strconv.Atoi(c.FormValue("integrity_scrub_interval_hours"))
+	if integrityScrubIntervalHours < 1 {
+		integrityScrubIntervalHours = 1
+	}
+	if integrityScrubIntervalHours > 8760 {
+		integrityScrubIntervalHours = 8760
+	}
+	integrityScrubFilesPerSecond, _ := strconv.Atoi(c.FormValue("integrity_scrub_files_per_second"))
+	if integrityScrubFilesPerSecond < 1 {
+		integrityScrubFilesPerSecond = 1
+	}
+	if integrityScrubFilesPerSecond > 10000 {
+		integrityScrubFilesPerSecond = 10000
+	}
+
 	// Create new settings
 	newSettings := &models.AppSettings{
 		SiteTitle:                    siteTitle,
@@ -591,6 +642,9 @@ func (ac *AdminController) HandleSettingsUpdate(c *fiber.Ctx) error {
 		JobQueueWorkerCount:          jobQueueWorkerCount,
 		APIRateLimitPerMinute:        apiRateLimitPerMinute,
 		ReplicationRequireChecksum:   replicationRequireChecksum,
+		ReplicasHot:                  replicasHot,
+		ReplicasWarm:                 replicasWarm,
+		ReplicasCold:                 replicasCold,
 		// Tiering
 		TieringEnabled:               tieringEnabled,
 		HotKeepDaysAfterUpload:       hotKeepDaysAfterUpload,
@@ -600,6 +654,20 @@ func (ac *AdminController) HandleSettingsUpdate(c *fiber.Ctx) error {
 		HotWatermarkLow:              hotWatermarkLow,
 		MaxTieringCandidatesPerSweep: maxTieringCandidatesPerSweep,
 		TieringSweepIntervalMinutes:  tieringSweepIntervalMinutes,
+		// Security
+		RequireAdmin2FA: requireAdmin2FA,
+		// Transformations
+		TransformCacheMaxMBPerPool: transformCacheMaxMBPerPool,
+		// Near-duplicate detection
+		SimilarImageMaxDistance:    similarImageMaxDistance,
+		NearDuplicateUploadWarning: nearDuplicateUploadWarning,
+		// Optimisation
+		OptimizationEnabled: optimizationEnabled,
+		OptimizeOriginals:   optimizeOriginals,
+		// Integrity scrub
+		IntegrityScrubEnabled:        integrityScrubEnabled,
+		IntegrityScrubIntervalHours:  integrityScrubIntervalHours,
+		IntegrityScrubFilesPerSecond: integrityScrubFilesPerSecond,
 	}
 
 	// Save settings using repository
@@ -59,6 +59,26 @@ func HandleAdminImages(c *fiber.Ctx) error {
 	return GetAdminImagesController().HandleAdminImages(c)
 }
 
+// HandleAdminNearDuplicates - Adapter for the near-duplicate report
+func HandleAdminNearDuplicates(c *fiber.Ctx) error {
+	return GetAdminImagesController().HandleAdminNearDuplicates(c)
+}
+
+// HandleAdminNearDuplicatesBackfill - Adapter for the perceptual hash backfill of existing images
+func HandleAdminNearDuplicatesBackfill(c *fiber.Ctx) error {
+	return GetAdminImagesController().HandleAdminNearDuplicatesBackfill(c)
+}
+
+// HandleAdminImagePlaceholders - Adapter for the placeholder backfill of existing images
+func HandleAdminImagePlaceholders(c *fiber.Ctx) error {
+	return GetAdminImagesController().HandleAdminImagePlaceholders(c)
+}
+
+// HandleAdminImageScrubMetadata - Adapter for the metadata scrub of existing images
+func HandleAdminImageScrubMetadata(c *fiber.Ctx) error {
+	return GetAdminImagesController().HandleAdminImageScrubMetadata(c)
+}
+
 // HandleAdminSearch - Adapter for search functionality
 func HandleAdminSearch(c *fiber.Ctx) error {
 	return GetAdminController().HandleSearch(c)
@@ -1,7 +1,9 @@
 package controllers
 
 import (
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/a-h/templ"
@@ -91,8 +93,16 @@ func (aic *AdminImagesController) HandleAdminImages(c *fiber.Ctx) error {
 // HandleAdminImageSearch searches for images using repository
 func (aic *AdminImagesController) HandleAdminImageSearch(c *fiber.Ctx, query string) error {
 	userCtx := usercontext.GetUserContext(c)
-	// Search images using repository
-	images, err := aic.imageRepo.Search(query)
+	// Search images using repository; "tag:<name>" restricts the search to a tag
+	var (
+		images []models.Image
+		err    error
+	)
+	if text, tag := splitTagFilter(query); tag != "" {
+		images, err = aic.imageRepo.SearchWithTag(text, tag)
+	} else {
+		images, err = aic.imageRepo.Search(query)
+	}
 	if err != nil {
 		return aic.handleError(c, "Image search failed", err)
 	}
@@ -120,6 +130,22 @@ func (aic *AdminImagesController) HandleAdminImageSearch(c *fiber.Ctx, query str
 	return handler(c)
 }
 
+// splitTagFilter extracts a "tag:<name>" token from a search query and returns the remaining text and the normalized tag
+func splitTagFilter(query string) (string, string) {
+	var (
+		rest []string
+		tag  string
+	)
+	for _, field := range strings.Fields(query) {
+		if strings.HasPrefix(strings.ToLower(field), "tag:") && tag == "" {
+			tag = models.NormalizeTagName(field[len("tag:"):])
+			continue
+		}
+		rest = append(rest, field)
+	}
+	return strings.Join(rest, " "), tag
+}
+
 // HandleAdminImageEdit renders the image edit page using repository pattern
 func (aic *AdminImagesController) HandleAdminImageEdit(c *fiber.Ctx) error {
 	userCtx := usercontext.GetUserContext(c)
@@ -286,3 +312,116 @@ func (aic *AdminImagesController) handleError(c *fiber.Ctx, message string, err
 
 	return flash.WithError(c, fm).Redirect("/admin/images")
 }
+
+// HandleAdminImageScrubMetadata enqueues the one-off job that applies the metadata policies to existing originals
+func (aic *AdminImagesController) HandleAdminImageScrubMetadata(c *fiber.Ctx) error {
+	if _, err := jobqueue.GetManager().GetQueue().EnqueueMetadataScrub(); err != nil {
+		fm := fiber.Map{
+			"type":    "error",
+			"message": "Bereinigung der Metadaten konnte nicht gestartet werden: " + err.Error(),
+		}
+		return flash.WithError(c, fm).Redirect("/admin/images")
+	}
+
+	fm := fiber.Map{
+		"type":    "success",
+		"message": "Bereinigung der Metadaten gestartet. Die Originale werden im Hintergrund gemäß den Einstellungen der Nutzer bereinigt.",
+	}
+	return flash.WithSuccess(c, fm).Redirect("/admin/images")
+}
+
+// nearDuplicatePairLimit caps the pairs loaded for the near-duplicate report
+const nearDuplicatePairLimit = 2000
+
+// HandleAdminNearDuplicates renders the near-duplicate clusters per user, optionally filtered by ?user_id=
+func (aic *AdminImagesController) HandleAdminNearDuplicates(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	userID, _ := strconv.ParseUint(c.Query("user_id", "0"), 10, 64)
+	maxDistance := models.GetAppSettings().GetSimilarImageMaxDistance()
+
+	pairs, err := aic.imageRepo.FindNearDuplicatePairs(uint(userID), maxDistance, nearDuplicatePairLimit)
+	if err != nil {
+		return aic.handleError(c, "Ähnliche Bilder konnten nicht ermittelt werden", err)
+	}
+	clusters := models.GroupNearDuplicatePairs(pairs)
+
+	var ids []uint
+	for _, cluster := range clusters {
+		ids = append(ids, cluster.ImageIDs...)
+	}
+	images, err := aic.imageRepo.GetByIDs(ids)
+	if err != nil {
+		return aic.handleError(c, "Ähnliche Bilder konnten nicht geladen werden", err)
+	}
+	byID := make(map[uint]models.Image, len(images))
+	for _, img := range images {
+		byID[img.ID] = img
+	}
+
+	var reports []admin_views.NearDuplicateUserReport
+	reportIndex := make(map[uint]int)
+	for _, cluster := range clusters {
+		for _, id := range cluster.ImageIDs {
+			if img, ok := byID[id]; ok {
+				cluster.Images = append(cluster.Images, img)
+			}
+		}
+		if len(cluster.Images) < 2 {
+			continue
+		}
+		i, ok := reportIndex[cluster.UserID]
+		if !ok {
+			i = len(reports)
+			reportIndex[cluster.UserID] = i
+			reports = append(reports, admin_views.NearDuplicateUserReport{User: cluster.Images[0].User})
+		}
+		reports[i].Clusters = append(reports[i].Clusters, cluster)
+		reports[i].ReclaimableBytes += cluster.ReclaimableBytes()
+	}
+	// Users with the most reclaimable storage first
+	sort.SliceStable(reports, func(a, b int) bool { return reports[a].ReclaimableBytes > reports[b].ReclaimableBytes })
+
+	var missingHashes int64
+	database.GetDB().Model(&models.Image{}).Where("perceptual_hash IS NULL").Count(&missingHashes)
+
+	csrfToken, _ := c.Locals("csrf").(string)
+	truncated := len(pairs) >= nearDuplicatePairLimit
+	cmp := admin_views.NearDuplicatesPage(reports, maxDistance, uint(userID), truncated, missingHashes, csrfToken)
+	home := views.HomeCtx(c, " | Ähnliche Bilder", userCtx.IsLoggedIn, false, flash.Get(c), cmp, userCtx.IsAdmin, nil)
+	handler := adaptor.HTTPHandler(templ.Handler(home))
+	return handler(c)
+}
+
+// HandleAdminNearDuplicatesBackfill enqueues the one-off job that computes perceptual hashes of existing images
+func (aic *AdminImagesController) HandleAdminNearDuplicatesBackfill(c *fiber.Ctx) error {
+	if _, err := jobqueue.GetManager().GetQueue().EnqueuePerceptualHashBackfill(); err != nil {
+		fm := fiber.Map{
+			"type":    "error",
+			"message": "Berechnung der Vergleichswerte konnte nicht gestartet werden: " + err.Error(),
+		}
+		return flash.WithError(c, fm).Redirect("/admin/images/near-duplicates")
+	}
+
+	fm := fiber.Map{
+		"type":    "success",
+		"message": "Berechnung der Vergleichswerte gestartet. Bestehende Bilder erscheinen nach und nach im Bericht.",
+	}
+	return flash.WithSuccess(c, fm).Redirect("/admin/images/near-duplicates")
+}
+
+// HandleAdminImagePlaceholders enqueues the one-off job that computes BlurHash and colors of existing images
+func (aic *AdminImagesController) HandleAdminImagePlaceholders(c *fiber.Ctx) error {
+	if _, err := jobqueue.GetManager().GetQueue().EnqueuePlaceholderBackfill(); err != nil {
+		fm := fiber.Map{
+			"type":    "error",
+			"message": "Berechnung der Platzhalter konnte nicht gestartet werden: " + err.Error(),
+		}
+		return flash.WithError(c, fm).Redirect("/admin/images")
+	}
+
+	fm := fiber.Map{
+		"type":    "success",
+		"message": "Berechnung der Platzhalter gestartet. Bestehende Bilder erhalten im Hintergrund Farbwerte und BlurHash.",
+	}
+	return flash.WithSuccess(c, fm).Redirect("/admin/images")
+}
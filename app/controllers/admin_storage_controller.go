@@ -106,6 +106,12 @@ func (asc *AdminStorageController) HandleAdminStorageManagement(c *fiber.Ctx) er
 		totalUsagePercentage = (float64(totalUsedSize) / float64(totalMaxSize)) * 100
 	}
 
+	// Bytes saved by the optimisation pass; a failure only hides the numbers
+	optimizationSavings, err := asc.storagePoolRepo.GetOptimizationSavings()
+	if err != nil {
+		optimizationSavings = models.OptimizationSavings{}
+	}
+
 	// Prepare view data
 	viewData := struct {
 		PoolStats            []models.StoragePoolStats
@@ -119,6 +125,7 @@ func (asc *AdminStorageController) HandleAdminStorageManagement(c *fiber.Ctx) er
 		TotalVariantCount    int64
 		TotalPoolsCount      int
 		HealthyPoolsCount    int
+		OptimizationSavings  models.OptimizationSavings
 	}{
 		PoolStats:            poolStats,
 		HealthStatus:         healthStatus,
@@ -131,6 +138,7 @@ func (asc *AdminStorageController) HandleAdminStorageManagement(c *fiber.Ctx) er
 		TotalVariantCount:    totalVariantCount,
 		TotalPoolsCount:      len(pools),
 		HealthyPoolsCount:    healthyPoolsCount,
+		OptimizationSavings:  optimizationSavings,
 	}
 
 	// Render storage management using the standard layout
@@ -290,6 +298,16 @@ func (asc *AdminStorageController) HandleAdminCreateStoragePoolPost(c *fiber.Ctx
 			pool.S3PathPrefix = nil
 		}
 
+		pool.S3DeliveryMode = models.S3DeliveryProxy
+		if c.FormValue("s3_delivery_mode") == models.S3DeliveryPresign {
+			pool.S3DeliveryMode = models.S3DeliveryPresign
+		}
+		if ttl, err := strconv.Atoi(strings.TrimSpace(c.FormValue("s3_presign_ttl"))); err == nil && ttl > 0 {
+			pool.S3PresignTTL = ttl
+		} else {
+			pool.S3PresignTTL = models.DefaultS3PresignTTL
+		}
+
 		// Set base path for S3 pools
 		if pool.S3BucketName != nil {
 			pool.BasePath = fmt.Sprintf("s3://%s", *pool.S3BucketName)
@@ -514,6 +532,16 @@ func (asc *AdminStorageController) HandleAdminEditStoragePoolPost(c *fiber.Ctx)
 			pool.S3PathPrefix = nil
 		}
 
+		pool.S3DeliveryMode = models.S3DeliveryProxy
+		if c.FormValue("s3_delivery_mode") == models.S3DeliveryPresign {
+			pool.S3DeliveryMode = models.S3DeliveryPresign
+		}
+		if ttl, err := strconv.Atoi(strings.TrimSpace(c.FormValue("s3_presign_ttl"))); err == nil && ttl > 0 {
+			pool.S3PresignTTL = ttl
+		} else {
+			pool.S3PresignTTL = models.DefaultS3PresignTTL
+		}
+
 		// Update base path for S3 pools
 		if pool.S3BucketName != nil {
 			pool.BasePath = fmt.Sprintf("s3://%s", *pool.S3BucketName)
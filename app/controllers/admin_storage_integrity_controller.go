@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/a-h/templ"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/sujit-baniya/flash"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/jobqueue"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+	"github.com/ManuelReschke/PixelFox/views"
+	admin_views "github.com/ManuelReschke/PixelFox/views/admin_views"
+)
+
+const adminIntegrityFindingsPerPage = 50
+
+// integrityRedirect returns to the integrity page, keeping the pool filter of the form
+func integrityRedirect(c *fiber.Ctx) string {
+	if poolID, err := strconv.ParseUint(c.FormValue("pool_id"), 10, 64); err == nil && poolID > 0 {
+		return fmt.Sprintf("/admin/storage/integrity?pool_id=%d", poolID)
+	}
+	return "/admin/storage/integrity"
+}
+
+// ADMIN – latest integrity scrub per pool and the findings to repair
+func HandleAdminStorageIntegrity(c *fiber.Ctx) error {
+	db := database.GetDB()
+	pools, err := models.FindAllStoragePools(db)
+	if err != nil {
+		pools = []models.StoragePool{}
+	}
+	reports, err := models.FindLatestScrubReports(db)
+	if err != nil {
+		reports = map[uint]models.ScrubReport{}
+	}
+
+	filter := admin_views.IntegrityFilter{Status: c.Query("status", models.ScrubFindingOpen)}
+	if poolID, err := strconv.ParseUint(c.Query("pool_id"), 10, 64); err == nil {
+		filter.PoolID = uint(poolID)
+	}
+	if filter.Status == "all" {
+		filter.Status = ""
+	}
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	findings, total, err := models.FindScrubFindings(db, filter.PoolID, filter.Status, adminIntegrityFindingsPerPage, (page-1)*adminIntegrityFindingsPerPage)
+	if err != nil {
+		findings = []models.ScrubFinding{}
+	}
+	filter.Page = page
+	filter.HasNext = int64(page*adminIntegrityFindingsPerPage) < total
+
+	csrfToken := c.Locals("csrf").(string)
+	userCtx := usercontext.GetUserContext(c)
+	cmp := admin_views.StorageIntegrityPage(pools, reports, findings, total, filter, csrfToken)
+	home := views.HomeCtx(c, " | Integritätsprüfung", userCtx.IsLoggedIn, false, flash.Get(c), cmp, userCtx.IsAdmin, nil)
+	handler := adaptor.HTTPHandler(templ.Handler(home))
+	return handler(c)
+}
+
+// ADMIN – start an integrity scrub of a pool now
+func HandleAdminStorageIntegrityStart(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return c.Redirect("/admin/storage/integrity", fiber.StatusSeeOther)
+	}
+	pool, err := models.FindStoragePoolByID(database.GetDB(), uint(id))
+	if err != nil {
+		fm := fiber.Map{"type": "error", "message": "Speicherpool nicht gefunden."}
+		return flash.WithError(c, fm).Redirect("/admin/storage/integrity")
+	}
+	if _, err := jobqueue.GetManager().GetQueue().StartIntegrityScrub(pool.ID); err != nil {
+		message := "Prüfung konnte nicht gestartet werden: " + err.Error()
+		if errors.Is(err, jobqueue.ErrScrubRunning) {
+			message = fmt.Sprintf("Für \"%s\" läuft bereits eine Prüfung.", pool.Name)
+		}
+		fm := fiber.Map{"type": "error", "message": message}
+		return flash.WithError(c, fm).Redirect("/admin/storage/integrity")
+	}
+	fm := fiber.Map{"type": "success", "message": fmt.Sprintf("Prüfung von \"%s\" wurde gestartet.", pool.Name)}
+	return flash.WithSuccess(c, fm).Redirect("/admin/storage/integrity")
+}
+
+// ADMIN – repair a single finding
+func HandleAdminStorageIntegrityRepair(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return c.Redirect(integrityRedirect(c), fiber.StatusSeeOther)
+	}
+	if err := jobqueue.GetManager().GetQueue().EnqueueScrubRepair(uint(id), c.FormValue("action")); err != nil {
+		message := "Reparatur konnte nicht gestartet werden: " + err.Error()
+		if errors.Is(err, jobqueue.ErrScrubRepairNotApplicable) {
+			message = "Diese Aktion ist für den Befund nicht möglich."
+		}
+		fm := fiber.Map{"type": "error", "message": message}
+		return flash.WithError(c, fm).Redirect(integrityRedirect(c))
+	}
+	fm := fiber.Map{"type": "success", "message": "Reparatur wurde in die Queue gestellt."}
+	return flash.WithSuccess(c, fm).Redirect(integrityRedirect(c))
+}
+
+// ADMIN – apply an action to all open findings of a pool it fits
+func HandleAdminStorageIntegrityRepairAll(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return c.Redirect("/admin/storage/integrity", fiber.StatusSeeOther)
+	}
+	redirect := fmt.Sprintf("/admin/storage/integrity?pool_id=%d", id)
+	count, err := jobqueue.GetManager().GetQueue().EnqueueScrubRepairs(uint(id), c.FormValue("action"))
+	if err != nil {
+		fm := fiber.Map{"type": "error", "message": fmt.Sprintf("Nach %d Reparaturen abgebrochen: %v", count, err)}
+		return flash.WithError(c, fm).Redirect(redirect)
+	}
+	fm := fiber.Map{"type": "success", "message": fmt.Sprintf("%d Reparaturen wurden in die Queue gestellt.", count)}
+	return flash.WithSuccess(c, fm).Redirect(redirect)
+}
@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/a-h/templ"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/sujit-baniya/flash"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/app/repository"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/entitlements"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/jobqueue"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+	"github.com/ManuelReschke/PixelFox/views"
+	admin_views "github.com/ManuelReschke/PixelFox/views/admin_views"
+)
+
+// variantProfilePlans lists the plans a variant profile can be restricted to
+var variantProfilePlans = []string{string(entitlements.PlanFree), string(entitlements.PlanPremium), string(entitlements.PlanPremiumMax)}
+
+// enqueueVariantBackfill starts the background sync of all images with the variant profiles
+func enqueueVariantBackfill() bool {
+	if _, err := jobqueue.GetManager().GetQueue().EnqueueVariantBackfill(); err != nil {
+		log.Errorf("[VariantProfiles] Failed to enqueue variant backfill: %v", err)
+		return false
+	}
+	return true
+}
+
+// variantProfileSuccess redirects with a success message that mentions the enqueued backfill
+func variantProfileSuccess(c *fiber.Ctx, message string, backfill bool) error {
+	if backfill {
+		if enqueueVariantBackfill() {
+			message += " Die Varianten werden im Hintergrund angepasst."
+		} else {
+			fm := fiber.Map{"type": "error", "message": message + " Der Abgleich der Varianten konnte jedoch nicht gestartet werden."}
+			return flash.WithError(c, fm).Redirect("/admin/variant-profiles")
+		}
+	}
+	fm := fiber.Map{"type": "success", "message": message}
+	return flash.WithSuccess(c, fm).Redirect("/admin/variant-profiles")
+}
+
+// ADMIN – list variant profiles with the number of stored variants
+func HandleAdminVariantProfiles(c *fiber.Ctx) error {
+	profileRepo := repository.GetGlobalFactory().GetVariantProfileRepository()
+	profiles, err := profileRepo.List()
+	if err != nil {
+		profiles = []models.VariantProfile{}
+	}
+	counts, err := profileRepo.CountVariantsByType()
+	if err != nil {
+		counts = map[string]int64{}
+	}
+
+	csrfToken := c.Locals("csrf").(string)
+	userCtx := usercontext.GetUserContext(c)
+	cmp := admin_views.VariantProfilesPage(profiles, counts, variantProfilePlans, csrfToken)
+	home := views.HomeCtx(c, " | Varianten-Profile", userCtx.IsLoggedIn, false, flash.Get(c), cmp, userCtx.IsAdmin, nil)
+	handler := adaptor.HTTPHandler(templ.Handler(home))
+	return handler(c)
+}
+
+// ADMIN – add a variant profile and generate it for existing images
+func HandleAdminVariantProfileCreate(c *fiber.Ctx) error {
+	maxWidth, _ := strconv.Atoi(strings.TrimSpace(c.FormValue("max_width", "0")))
+	maxHeight, _ := strconv.Atoi(strings.TrimSpace(c.FormValue("max_height", "0")))
+	quality, _ := strconv.Atoi(strings.TrimSpace(c.FormValue("quality")))
+
+	var plans []string
+	for _, plan := range variantProfilePlans {
+		if c.FormValue("plan_"+plan) == "on" {
+			plans = append(plans, plan)
+		}
+	}
+
+	profile := &models.VariantProfile{
+		Name:      c.FormValue("name"),
+		MaxWidth:  maxWidth,
+		MaxHeight: maxHeight,
+		Format:    c.FormValue("format"),
+		Quality:   quality,
+		Plans:     strings.Join(plans, ","),
+		IsActive:  c.FormValue("is_active") == "on",
+	}
+	if err := profile.Validate(); err != nil {
+		fm := fiber.Map{"type": "error", "message": "Ungültiges Profil: " + err.Error()}
+		return flash.WithError(c, fm).Redirect("/admin/variant-profiles")
+	}
+
+	profileRepo := repository.GetGlobalFactory().GetVariantProfileRepository()
+	existing, err := profileRepo.List()
+	if err != nil {
+		fm := fiber.Map{"type": "error", "message": "Profile konnten nicht geladen werden."}
+		return flash.WithError(c, fm).Redirect("/admin/variant-profiles")
+	}
+	if err := profile.CheckFileNameConflict(existing); err != nil {
+		fm := fiber.Map{"type": "error", "message": "Ungültiges Profil: " + err.Error()}
+		return flash.WithError(c, fm).Redirect("/admin/variant-profiles")
+	}
+
+	if err := profileRepo.Create(profile); err != nil {
+		fm := fiber.Map{"type": "error", "message": "Profil konnte nicht gespeichert werden. Der Name muss eindeutig sein."}
+		return flash.WithError(c, fm).Redirect("/admin/variant-profiles")
+	}
+
+	return variantProfileSuccess(c, fmt.Sprintf("Profil \"%s\" wurde angelegt.", profile.Name), profile.IsActive)
+}
+
+// ADMIN – activate or deactivate a variant profile. Deactivated profiles keep their existing variants.
+func HandleAdminVariantProfileToggle(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return c.Redirect("/admin/variant-profiles", fiber.StatusSeeOther)
+	}
+	profileRepo := repository.GetGlobalFactory().GetVariantProfileRepository()
+	profile, err := profileRepo.GetByID(uint(id))
+	if err != nil {
+		fm := fiber.Map{"type": "error", "message": "Profil nicht gefunden."}
+		return flash.WithError(c, fm).Redirect("/admin/variant-profiles")
+	}
+	profile.IsActive = !profile.IsActive
+	if err := profileRepo.Update(profile); err != nil {
+		fm := fiber.Map{"type": "error", "message": "Profil konnte nicht aktualisiert werden."}
+		return flash.WithError(c, fm).Redirect("/admin/variant-profiles")
+	}
+
+	if profile.IsActive {
+		return variantProfileSuccess(c, fmt.Sprintf("Profil \"%s\" wurde aktiviert.", profile.Name), true)
+	}
+	return variantProfileSuccess(c, fmt.Sprintf("Profil \"%s\" wurde deaktiviert. Neue Bilder erhalten diese Variante nicht mehr.", profile.Name), false)
+}
+
+// ADMIN – delete a variant profile and remove its variants from all images
+func HandleAdminVariantProfileDelete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return c.Redirect("/admin/variant-profiles", fiber.StatusSeeOther)
+	}
+	if err := repository.GetGlobalFactory().GetVariantProfileRepository().Delete(uint(id)); err != nil {
+		fm := fiber.Map{"type": "error", "message": "Profil konnte nicht gelöscht werden."}
+		return flash.WithError(c, fm).Redirect("/admin/variant-profiles")
+	}
+	return variantProfileSuccess(c, "Profil wurde gelöscht.", true)
+}
+
+// ADMIN – manually sync the variants of all images with the variant profiles
+func HandleAdminVariantProfileBackfill(c *fiber.Ctx) error {
+	return variantProfileSuccess(c, "Abgleich gestartet.", true)
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"fmt"
+	"log"
 	"strconv"
 	"strings"
 
@@ -11,9 +12,11 @@ import (
 	"github.com/sujit-baniya/flash"
 
 	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/app/repository"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/entitlements"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/jobqueue"
 	metrics "github.com/ManuelReschke/PixelFox/internal/pkg/metrics/counter"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/viewmodel"
@@ -36,7 +39,7 @@ func imageToGalleryImage(img models.Image) user_views.GalleryImage {
 	}
 	// Final fallback to original image if no small thumbnail available
 	if smallPreviewPath == "" {
-		smallPreviewPath = imageprocessor.GetImageURL(&img, "original", "")
+		smallPreviewPath = imageprocessor.GetPublicOriginalURL(&img)
 	}
 
 	title := img.FileName
@@ -48,7 +51,8 @@ func imageToGalleryImage(img models.Image) user_views.GalleryImage {
 	if smallPreviewPath != "" {
 		smallPreviewPath = imageprocessor.MakeAbsoluteForImage(&img, smallPreviewPath)
 	}
-	originalPath := imageprocessor.GetImageAbsoluteURL(&img, "original", "")
+	// Galleries are shown to visitors, so watermarked images link their watermarked rendition
+	originalPath := imageprocessor.MakeAbsoluteForImage(&img, imageprocessor.GetPublicOriginalURL(&img))
 	return user_views.GalleryImage{
 		ID:               img.ID,
 		UUID:             img.UUID,
@@ -61,6 +65,8 @@ func imageToGalleryImage(img models.Image) user_views.GalleryImage {
 		Height:           img.Height,
 		FileSize:         img.FileSize,
 		CreatedAt:        img.CreatedAt.Format("02.01.2006 15:04"),
+		BlurHash:         img.BlurHash,
+		PlaceholderColor: img.PlaceholderColor(),
 	}
 }
 
@@ -171,6 +177,9 @@ func HandleUserAlbumEdit(c *fiber.Ctx) error {
 	// Increment album view counter (buffered in Redis)
 	_ = metrics.AddAlbumView(album.ID)
 
+	us, _ := models.GetOrCreateUserSettings(database.DB, userID)
+	canWatermark := canUserWatermark(us)
+
 	if c.Method() == "POST" {
 		title := c.FormValue("title")
 		description := c.FormValue("description")
@@ -197,18 +206,34 @@ func HandleUserAlbumEdit(c *fiber.Ctx) error {
 			}
 		}
 
+		wasOverride, before := album.WatermarkOverride, album.Watermark
+		if canWatermark {
+			album.WatermarkOverride = c.FormValue("watermark_override") == "on"
+			if err := parseWatermarkForm(c, &album.Watermark); err != nil {
+				flash.WithError(c, fiber.Map{"message": "Wasserzeichen ungültig: " + err.Error()})
+				return c.Redirect("/user/albums/edit/" + albumIDStr)
+			}
+		}
+
 		if err := database.DB.Save(&album).Error; err != nil {
 			flash.WithError(c, fiber.Map{"message": "Fehler beim Aktualisieren des Albums"})
 			return c.Redirect("/user/albums/edit/" + albumIDStr)
 		}
 
+		// Re-render the album's images when the override was switched or its watermark changed
+		if wasOverride != album.WatermarkOverride || (album.WatermarkOverride && !before.Equal(album.Watermark)) {
+			if _, err := jobqueue.GetManager().GetQueue().EnqueueWatermarkRerender(userID, album.ID); err != nil {
+				log.Printf("failed to enqueue watermark rerender for album %d: %v", album.ID, err)
+			}
+		}
+
 		flash.WithSuccess(c, fiber.Map{"message": "Album erfolgreich aktualisiert"})
 		return c.Redirect("/user/albums")
 	}
 
 	csrfToken := c.Locals("csrf").(string)
 
-	editIndex := user_views.AlbumEditIndex(username, csrfToken, album)
+	editIndex := user_views.AlbumEditIndex(username, csrfToken, album, canWatermark)
 	editPage := user_views.AlbumEdit(
 		" | Album bearbeiten", isLoggedIn(c), false, flash.Get(c), username, editIndex, isAdmin,
 	)
@@ -237,12 +262,17 @@ func HandleUserAlbumDelete(c *fiber.Ctx) error {
 		return c.Redirect("/user/albums")
 	}
 
+	var albumImageIDs []uint
+	if album.WatermarkOverride {
+		database.DB.Model(&models.AlbumImage{}).Where("album_id = ?", album.ID).Pluck("image_id", &albumImageIDs)
+	}
 	database.DB.Where("album_id = ?", album.ID).Delete(&models.AlbumImage{})
 
 	if err := database.DB.Delete(&album).Error; err != nil {
 		flash.WithError(c, fiber.Map{"message": "Fehler beim Löschen des Albums"})
 		return c.Redirect("/user/albums")
 	}
+	rerenderAlbumWatermarks(&album, albumImageIDs)
 
 	flash.WithSuccess(c, fiber.Map{"message": "Album erfolgreich gelöscht"})
 	return c.Redirect("/user/albums")
@@ -267,6 +297,11 @@ func HandleUserAlbumView(c *fiber.Ctx) error {
 		return c.Redirect("/user/albums")
 	}
 
+	// Album order (position) is kept in the join table
+	if images, err := repository.GetGlobalFactory().GetAlbumRepository().GetImages(album.ID); err == nil {
+		album.Images = images
+	}
+
 	var userImages []models.Image
 	database.DB.Preload("StoragePool").Where("user_id = ?", userID).Find(&userImages)
 
@@ -338,6 +373,8 @@ func HandleUserAlbumAddImage(c *fiber.Ctx) error {
 		return c.Redirect("/user/albums/" + albumIDStr)
 	}
 
+	rerenderAlbumWatermarks(&album, []uint{uint(imageID)})
+
 	flash.WithSuccess(c, fiber.Map{"message": "Bild erfolgreich hinzugefügt"})
 	return c.Redirect("/user/albums/" + albumIDStr)
 }
@@ -379,6 +416,7 @@ func HandleUserAlbumRemoveImage(c *fiber.Ctx) error {
 		album.CoverImageID = 0
 		database.DB.Save(&album)
 	}
+	rerenderAlbumWatermarks(&album, []uint{uint(imageID)})
 
 	flash.WithSuccess(c, fiber.Map{"message": "Bild erfolgreich entfernt"})
 	return c.Redirect("/user/albums/" + albumIDStr)
@@ -448,6 +486,11 @@ func HandleAlbumShareLink(c *fiber.Ctx) error {
 		return c.Redirect("/")
 	}
 
+	// Album order (position) is kept in the join table
+	if images, err := repository.GetGlobalFactory().GetAlbumRepository().GetImages(album.ID); err == nil {
+		album.Images = images
+	}
+
 	// Build gallery images
 	var galleryAlbumImages []user_views.GalleryImage
 	for _, img := range album.Images {
@@ -0,0 +1,436 @@
+package controllers
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/app/repository"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/entitlements"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+)
+
+const (
+	apiAlbumsDefaultPerPage = 30
+	apiAlbumsMaxPerPage     = 100
+	apiAlbumBulkMax         = 500
+	apiAlbumTitleMaxLen     = 255
+)
+
+// albumAPIPayload builds the JSON representation of an album
+func albumAPIPayload(album *models.Album, imageCount int64) fiber.Map {
+	payload := fiber.Map{
+		"id":          album.ID,
+		"title":       album.Title,
+		"description": album.Description,
+		"is_public":   album.IsPublic,
+		"view_url":    "/a/" + album.ShareLink,
+		"image_count": imageCount,
+		"view_count":  album.ViewCount,
+		"created_at":  album.CreatedAt.UTC(),
+		"updated_at":  album.UpdatedAt.UTC(),
+	}
+	payload["cover_image_uuid"] = nil
+	if album.CoverImageID != 0 {
+		var uuids []string
+		if err := database.GetDB().Model(&models.Image{}).Where("id = ?", album.CoverImageID).Limit(1).Pluck("uuid", &uuids).Error; err == nil && len(uuids) > 0 {
+			payload["cover_image_uuid"] = uuids[0]
+		}
+	}
+	return payload
+}
+
+// countAlbumImages returns the number of (not deleted) images of an album
+func countAlbumImages(albumID uint) int64 {
+	var count int64
+	if err := database.GetDB().Model(&models.Image{}).
+		Joins("JOIN album_images ON album_images.image_id = images.id").
+		Where("album_images.album_id = ?", albumID).
+		Count(&count).Error; err != nil {
+		log.Printf("api: failed to count images of album %d: %v", albumID, err)
+	}
+	return count
+}
+
+// loadOwnAlbumAPI resolves the album from the route; albums of other users are reported as not found
+func loadOwnAlbumAPI(c *fiber.Ctx, userID uint) (*models.Album, bool) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return nil, false
+	}
+	var album models.Album
+	if err := database.GetDB().Where("id = ? AND user_id = ?", id, userID).First(&album).Error; err != nil {
+		return nil, false
+	}
+	return &album, true
+}
+
+// resolveOwnImageIDs maps image UUIDs of the user to IDs keeping the given order; unknown UUIDs are returned separately
+func resolveOwnImageIDs(userID uint, uuids []string) ([]uint, []string, error) {
+	var images []models.Image
+	if err := database.GetDB().Select("id", "uuid").Where("user_id = ? AND uuid IN ?", userID, uuids).Find(&images).Error; err != nil {
+		return nil, nil, err
+	}
+	byUUID := make(map[string]uint, len(images))
+	for _, img := range images {
+		byUUID[img.UUID] = img.ID
+	}
+	ids := make([]uint, 0, len(uuids))
+	var missing []string
+	for _, uuid := range uuids {
+		if id, ok := byUUID[uuid]; ok {
+			ids = append(ids, id)
+		} else {
+			missing = append(missing, uuid)
+		}
+	}
+	return ids, missing, nil
+}
+
+// parseAlbumImageUUIDs reads {"image_uuids": [...]} from the request body
+func parseAlbumImageUUIDs(c *fiber.Ctx) ([]string, bool) {
+	var req struct {
+		ImageUUIDs []string `json:"image_uuids"`
+	}
+	if err := c.BodyParser(&req); err != nil || len(req.ImageUUIDs) == 0 || len(req.ImageUUIDs) > apiAlbumBulkMax {
+		return nil, false
+	}
+	return req.ImageUUIDs, true
+}
+
+// HandleListAlbumsAPI returns a page of the caller's albums, newest first
+// Security: API Key required via router middleware
+func HandleListAlbumsAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(c.Query("per_page", strconv.Itoa(apiAlbumsDefaultPerPage)))
+	if perPage < 1 || perPage > apiAlbumsMaxPerPage {
+		perPage = apiAlbumsDefaultPerPage
+	}
+
+	db := database.GetDB()
+	var (
+		albums []models.Album
+		total  int64
+	)
+	if err := db.Model(&models.Album{}).Where("user_id = ?", user.UserID).Count(&total).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to count albums"})
+	}
+	if err := db.Where("user_id = ?", user.UserID).Order("created_at DESC").
+		Offset((page - 1) * perPage).Limit(perPage).Find(&albums).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to load albums"})
+	}
+
+	items := make([]fiber.Map, 0, len(albums))
+	for i := range albums {
+		items = append(items, albumAPIPayload(&albums[i], countAlbumImages(albums[i].ID)))
+	}
+	return c.JSON(fiber.Map{
+		"items":    items,
+		"page":     page,
+		"per_page": perPage,
+		"total":    total,
+	})
+}
+
+// HandleCreateAlbumAPI creates an album within the plan's album limit
+// Security: API Key required via router middleware
+func HandleCreateAlbumAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+
+	var req struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		IsPublic    bool   `json:"is_public"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "invalid JSON body"})
+	}
+	title := strings.TrimSpace(req.Title)
+	if title == "" || utf8.RuneCountInString(title) > apiAlbumTitleMaxLen {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "title is required and must not exceed 255 characters"})
+	}
+
+	albumRepo := repository.GetGlobalFactory().GetAlbumRepository()
+	count, err := albumRepo.CountByUserID(user.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to count albums"})
+	}
+	if !entitlements.CanCreateAlbum(entitlements.Plan(user.Plan), int(count)) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "forbidden", "message": "album limit of your plan reached"})
+	}
+
+	album := models.Album{
+		UserID:      user.UserID,
+		Title:       title,
+		Description: strings.TrimSpace(req.Description),
+		IsPublic:    req.IsPublic,
+	}
+	if err := albumRepo.Create(&album); err != nil {
+		log.Printf("api: failed to create album for user %d: %v", user.UserID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to create album"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(albumAPIPayload(&album, 0))
+}
+
+// HandleGetAlbumAPI returns an own album including its images in album order
+// Security: API Key required via router middleware
+func HandleGetAlbumAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+	album, ok := loadOwnAlbumAPI(c, user.UserID)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "album not found"})
+	}
+	return renderAlbumWithImagesAPI(c, album)
+}
+
+// renderAlbumWithImagesAPI writes the album payload including the ordered image list
+func renderAlbumWithImagesAPI(c *fiber.Ctx, album *models.Album) error {
+	images, err := repository.GetGlobalFactory().GetAlbumRepository().GetImages(album.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to load album images"})
+	}
+	items := make([]fiber.Map, 0, len(images))
+	for i := range images {
+		items = append(items, imageAPIPayload(&images[i]))
+	}
+	payload := albumAPIPayload(album, int64(len(images)))
+	payload["images"] = items
+	return c.JSON(payload)
+}
+
+// HandleUpdateAlbumAPI partially updates title, description and visibility of an own album
+// Security: API Key required via router middleware
+func HandleUpdateAlbumAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+	album, ok := loadOwnAlbumAPI(c, user.UserID)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "album not found"})
+	}
+
+	var req struct {
+		Title       *string `json:"title"`
+		Description *string `json:"description"`
+		IsPublic    *bool   `json:"is_public"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "invalid JSON body"})
+	}
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" || utf8.RuneCountInString(title) > apiAlbumTitleMaxLen {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "title is required and must not exceed 255 characters"})
+		}
+		album.Title = title
+	}
+	if req.Description != nil {
+		album.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.IsPublic != nil {
+		album.IsPublic = *req.IsPublic
+	}
+	if err := repository.GetGlobalFactory().GetAlbumRepository().Update(album); err != nil {
+		log.Printf("api: failed to update album %d: %v", album.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to update album"})
+	}
+	return c.JSON(albumAPIPayload(album, countAlbumImages(album.ID)))
+}
+
+// HandleDeleteAlbumAPI deletes an own album; the images themselves are kept
+// Security: API Key required via router middleware
+func HandleDeleteAlbumAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+	album, ok := loadOwnAlbumAPI(c, user.UserID)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "album not found"})
+	}
+	var albumImageIDs []uint
+	if album.WatermarkOverride {
+		database.GetDB().Model(&models.AlbumImage{}).Where("album_id = ?", album.ID).Pluck("image_id", &albumImageIDs)
+	}
+	if err := repository.GetGlobalFactory().GetAlbumRepository().Delete(album.ID); err != nil {
+		log.Printf("api: failed to delete album %d: %v", album.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to delete album"})
+	}
+	rerenderAlbumWatermarks(album, albumImageIDs)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// HandleAddAlbumImagesAPI adds own images (by UUID) to an album
+// Security: API Key required via router middleware
+func HandleAddAlbumImagesAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+	album, ok := loadOwnAlbumAPI(c, user.UserID)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "album not found"})
+	}
+	uuids, ok := parseAlbumImageUUIDs(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "image_uuids must contain 1 to 500 entries"})
+	}
+	ids, missing, err := resolveOwnImageIDs(user.UserID, uuids)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to load images"})
+	}
+	added, err := repository.GetGlobalFactory().GetAlbumRepository().AddImages(album.ID, ids)
+	if err != nil {
+		log.Printf("api: failed to add images to album %d: %v", album.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to add images"})
+	}
+	rerenderAlbumWatermarks(album, ids)
+	if missing == nil {
+		missing = []string{}
+	}
+	return c.JSON(fiber.Map{
+		"added":       added,
+		"not_found":   missing,
+		"image_count": countAlbumImages(album.ID),
+	})
+}
+
+// HandleRemoveAlbumImagesAPI removes images (by UUID) from an album
+// Security: API Key required via router middleware
+func HandleRemoveAlbumImagesAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+	album, ok := loadOwnAlbumAPI(c, user.UserID)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "album not found"})
+	}
+	uuids, ok := parseAlbumImageUUIDs(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "image_uuids must contain 1 to 500 entries"})
+	}
+	ids, missing, err := resolveOwnImageIDs(user.UserID, uuids)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to load images"})
+	}
+	removed, err := repository.GetGlobalFactory().GetAlbumRepository().RemoveImages(album.ID, ids)
+	if err != nil {
+		log.Printf("api: failed to remove images from album %d: %v", album.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to remove images"})
+	}
+	rerenderAlbumWatermarks(album, ids)
+	if missing == nil {
+		missing = []string{}
+	}
+	return c.JSON(fiber.Map{
+		"removed":     removed,
+		"not_found":   missing,
+		"image_count": countAlbumImages(album.ID),
+	})
+}
+
+// HandleReorderAlbumImagesAPI stores a new image order; unlisted images follow in their current order
+// Security: API Key required via router middleware
+func HandleReorderAlbumImagesAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+	album, ok := loadOwnAlbumAPI(c, user.UserID)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "album not found"})
+	}
+	uuids, ok := parseAlbumImageUUIDs(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "image_uuids must contain 1 to 500 entries"})
+	}
+	ids, missing, err := resolveOwnImageIDs(user.UserID, uuids)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to load images"})
+	}
+	if len(missing) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "unknown image: " + missing[0]})
+	}
+	var inAlbum int64
+	if err := database.GetDB().Model(&models.AlbumImage{}).Where("album_id = ? AND image_id IN ?", album.ID, ids).Count(&inAlbum).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to load album images"})
+	}
+	if int(inAlbum) != len(uniqueUints(ids)) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "all images must be part of the album"})
+	}
+	if err := repository.GetGlobalFactory().GetAlbumRepository().ReorderImages(album.ID, ids); err != nil {
+		log.Printf("api: failed to reorder album %d: %v", album.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to reorder images"})
+	}
+	return renderAlbumWithImagesAPI(c, album)
+}
+
+// HandleSetAlbumCoverAPI sets the cover image of an album; the image must be part of it
+// Security: API Key required via router middleware
+func HandleSetAlbumCoverAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+	album, ok := loadOwnAlbumAPI(c, user.UserID)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "album not found"})
+	}
+	var req struct {
+		ImageUUID string `json:"image_uuid"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.ImageUUID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "image_uuid is required"})
+	}
+	ids, _, err := resolveOwnImageIDs(user.UserID, []string{req.ImageUUID})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to load image"})
+	}
+	if len(ids) == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "image not found"})
+	}
+	var rel models.AlbumImage
+	if err := database.GetDB().Where("album_id = ? AND image_id = ?", album.ID, ids[0]).First(&rel).Error; err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "image is not part of the album"})
+	}
+	album.CoverImageID = ids[0]
+	if err := repository.GetGlobalFactory().GetAlbumRepository().Update(album); err != nil {
+		log.Printf("api: failed to set cover of album %d: %v", album.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to set cover"})
+	}
+	return c.JSON(albumAPIPayload(album, countAlbumImages(album.ID)))
+}
+
+// uniqueUints returns the distinct values keeping the first occurrence
+func uniqueUints(values []uint) []uint {
+	seen := make(map[uint]bool, len(values))
+	out := make([]uint, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
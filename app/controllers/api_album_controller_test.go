@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+)
+
+// albumAPITestApp mounts the album handlers like the API router, optionally as a logged in user
+func albumAPITestApp(loggedIn bool) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		if loggedIn {
+			c.Locals("USER_CONTEXT", usercontext.UserContext{IsLoggedIn: true, UserID: 42})
+		}
+		return c.Next()
+	})
+	app.Get("/albums", HandleListAlbumsAPI)
+	app.Post("/albums", HandleCreateAlbumAPI)
+	app.Get("/albums/:id", HandleGetAlbumAPI)
+	app.Patch("/albums/:id", HandleUpdateAlbumAPI)
+	app.Delete("/albums/:id", HandleDeleteAlbumAPI)
+	app.Post("/albums/:id/images", HandleAddAlbumImagesAPI)
+	app.Delete("/albums/:id/images", HandleRemoveAlbumImagesAPI)
+	app.Put("/albums/:id/images/order", HandleReorderAlbumImagesAPI)
+	app.Put("/albums/:id/cover", HandleSetAlbumCoverAPI)
+	return app
+}
+
+func albumAPIRequest(t *testing.T, app *fiber.App, method, target, body string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var payload map[string]any
+	_ = json.NewDecoder(resp.Body).Decode(&payload)
+	return resp.StatusCode, payload
+}
+
+func TestAlbumAPIRequiresAuthentication(t *testing.T) {
+	app := albumAPITestApp(false)
+	for _, r := range []struct{ method, target string }{
+		{fiber.MethodGet, "/albums"},
+		{fiber.MethodPost, "/albums"},
+		{fiber.MethodGet, "/albums/1"},
+		{fiber.MethodPatch, "/albums/1"},
+		{fiber.MethodDelete, "/albums/1"},
+		{fiber.MethodPost, "/albums/1/images"},
+		{fiber.MethodDelete, "/albums/1/images"},
+		{fiber.MethodPut, "/albums/1/images/order"},
+		{fiber.MethodPut, "/albums/1/cover"},
+	} {
+		status, payload := albumAPIRequest(t, app, r.method, r.target, `{}`)
+		assert.Equal(t, fiber.StatusUnauthorized, status, r.method+" "+r.target)
+		assert.Equal(t, "unauthorized", payload["error"], r.method+" "+r.target)
+	}
+}
+
+func TestAlbumAPIRejectsInvalidAlbumID(t *testing.T) {
+	app := albumAPITestApp(true)
+	for _, r := range []struct{ method, target string }{
+		{fiber.MethodGet, "/albums/abc"},
+		{fiber.MethodPatch, "/albums/0"},
+		{fiber.MethodDelete, "/albums/-1"},
+		{fiber.MethodPost, "/albums/x/images"},
+		{fiber.MethodDelete, "/albums/x/images"},
+		{fiber.MethodPut, "/albums/x/images/order"},
+		{fiber.MethodPut, "/albums/x/cover"},
+	} {
+		status, payload := albumAPIRequest(t, app, r.method, r.target, `{"image_uuids":["a"]}`)
+		assert.Equal(t, fiber.StatusNotFound, status, r.method+" "+r.target)
+		assert.Equal(t, "not_found", payload["error"], r.method+" "+r.target)
+	}
+}
+
+func TestCreateAlbumAPIValidatesTitle(t *testing.T) {
+	app := albumAPITestApp(true)
+	for _, body := range []string{
+		`{`,
+		`{"title":"   "}`,
+		`{"title":"` + strings.Repeat("ä", apiAlbumTitleMaxLen+1) + `"}`,
+	} {
+		status, payload := albumAPIRequest(t, app, fiber.MethodPost, "/albums", body)
+		assert.Equal(t, fiber.StatusBadRequest, status, body)
+		assert.Equal(t, "bad_request", payload["error"], body)
+	}
+}
+
+func TestParseAlbumImageUUIDs(t *testing.T) {
+	tooMany := make([]string, apiAlbumBulkMax+1)
+	for i := range tooMany {
+		tooMany[i] = "uuid"
+	}
+	tooManyBody, err := json.Marshal(map[string]any{"image_uuids": tooMany})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		body string
+		want []string
+		ok   bool
+	}{
+		{`{"image_uuids":["b","a","b"]}`, []string{"b", "a", "b"}, true},
+		{`{"image_uuids":[]}`, nil, false},
+		{`{}`, nil, false},
+		{`not json`, nil, false},
+		{string(tooManyBody), nil, false},
+	} {
+		app := fiber.New()
+		app.Post("/", func(c *fiber.Ctx) error {
+			uuids, ok := parseAlbumImageUUIDs(c)
+			assert.Equal(t, tc.ok, ok, tc.body)
+			assert.Equal(t, tc.want, uuids, tc.body)
+			return c.SendStatus(fiber.StatusNoContent)
+		})
+		req := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(tc.body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	}
+}
+
+func TestUniqueUintsKeepsFirstOccurrence(t *testing.T) {
+	assert.Equal(t, []uint{3, 1, 2}, uniqueUints([]uint{3, 1, 3, 2, 1}))
+	assert.Empty(t, uniqueUints(nil))
+}
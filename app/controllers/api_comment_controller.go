@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/app/repository"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+)
+
+const apiCommentsMaxPerPage = 100
+
+// commentAPIPayload builds the JSON representation of a comment
+func commentAPIPayload(comment *models.Comment, image *models.Image, user usercontext.UserContext) fiber.Map {
+	return fiber.Map{
+		"id":      comment.ID,
+		"content": comment.Content,
+		"author": fiber.Map{
+			"id":   comment.UserID,
+			"name": comment.User.Name,
+		},
+		"created_at": comment.CreatedAt.UTC(),
+		"can_delete": comment.CanBeDeletedBy(user.UserID, image.UserID, user.IsAdmin),
+	}
+}
+
+// HandleListImageCommentsAPI returns a page of comments for an image
+// Security: API Key required via router middleware
+func HandleListImageCommentsAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+	image, ok := loadCommentableImage(c)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "image not found"})
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(c.Query("per_page", strconv.Itoa(commentsPerPage)))
+	if perPage < 1 || perPage > apiCommentsMaxPerPage {
+		perPage = commentsPerPage
+	}
+
+	commentRepo := repository.GetGlobalFactory().GetCommentRepository()
+	comments, err := commentRepo.GetByImageID(image.ID, (page-1)*perPage, perPage)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to load comments"})
+	}
+	total, err := commentRepo.CountByImageID(image.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to count comments"})
+	}
+
+	items := make([]fiber.Map, 0, len(comments))
+	for i := range comments {
+		items = append(items, commentAPIPayload(&comments[i], image, user))
+	}
+	return c.JSON(fiber.Map{
+		"items":    items,
+		"page":     page,
+		"per_page": perPage,
+		"total":    total,
+	})
+}
+
+// HandleCreateImageCommentAPI adds a comment to a public image
+// Security: API Key required via router middleware
+func HandleCreateImageCommentAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+	image, ok := loadCommentableImage(c)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "image not found"})
+	}
+
+	var req struct {
+		Content string `json:"content"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "invalid JSON body"})
+	}
+
+	comment, err := createImageComment(image, user.UserID, req.Content)
+	switch {
+	case err == nil:
+		return c.Status(fiber.StatusCreated).JSON(commentAPIPayload(comment, image, user))
+	case errors.Is(err, models.ErrCommentEmpty), errors.Is(err, models.ErrCommentTooLong):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": err.Error()})
+	case errors.Is(err, errCommentsDisabled):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "forbidden", "message": err.Error()})
+	case errors.Is(err, errCommentRateLimited):
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": "too_many_requests", "message": err.Error()})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to create comment"})
+	}
+}
+
+// HandleDeleteImageCommentAPI deletes a comment (author or image owner)
+// Security: API Key required via router middleware
+func HandleDeleteImageCommentAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+	image, ok := loadCommentableImage(c)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "image not found"})
+	}
+
+	commentID, err := strconv.ParseUint(c.Params("comment_id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "comment not found"})
+	}
+	commentRepo := repository.GetGlobalFactory().GetCommentRepository()
+	comment, err := commentRepo.GetByID(uint(commentID))
+	if err != nil || comment.ImageID != image.ID {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "comment not found"})
+	}
+	if !comment.CanBeDeletedBy(user.UserID, image.UserID, user.IsAdmin) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "forbidden", "message": "not allowed to delete this comment"})
+	}
+	if err := commentRepo.Delete(comment.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to delete comment"})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
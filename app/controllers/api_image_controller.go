@@ -1,12 +1,78 @@
 package controllers
 
 import (
+	"encoding/base64"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/ManuelReschke/PixelFox/app/models"
 	"github.com/ManuelReschke/PixelFox/app/repository"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/jobqueue"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
 )
 
+const (
+	apiImagesDefaultLimit = 30
+	apiImagesMaxLimit     = 100
+	apiImageTitleMaxLen   = 255
+	apiImageDescMaxLen    = 5000
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// encodeImageCursor turns the last image ID of a page into an opaque cursor
+func encodeImageCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(id), 10)))
+}
+
+// decodeImageCursor parses a cursor created by encodeImageCursor
+func decodeImageCursor(cursor string) (uint, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errInvalidCursor
+	}
+	id, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil || id == 0 {
+		return 0, errInvalidCursor
+	}
+	return uint(id), nil
+}
+
+// imageAPIPayload builds the image resource including editable fields and tags
+func imageAPIPayload(img *models.Image) fiber.Map {
+	payload := buildUploadResponseExtras(img)
+	delete(payload, "duplicate")
+	tags := make([]string, 0, len(img.Tags))
+	for _, tag := range img.Tags {
+		tags = append(tags, tag.Name)
+	}
+	payload["title"] = img.Title
+	payload["description"] = img.Description
+	payload["is_public"] = img.IsPublic
+	payload["width"] = img.Width
+	payload["height"] = img.Height
+	payload["file_size"] = img.FileSize
+	payload["tags"] = tags
+	payload["created_at"] = img.CreatedAt.UTC()
+	return payload
+}
+
+// loadOwnImageAPI resolves an image of the authenticated user; other users' images are reported as not found
+func loadOwnImageAPI(c *fiber.Ctx, userID uint) (*models.Image, bool) {
+	image, err := repository.GetGlobalFactory().GetImageRepository().GetByUUID(c.Params("uuid"))
+	if err != nil || image == nil || image.UserID != userID {
+		return nil, false
+	}
+	return image, true
+}
+
 // HandleGetImageResourceAPI returns the canonical image resource including direct links and variants
 // Security: API Key required via router middleware
 func HandleGetImageResourceAPI(c *fiber.Ctx) error {
@@ -38,3 +104,176 @@ func HandleGetImageResourceAPI(c *fiber.Ctx) error {
 	}
 	return c.JSON(payload)
 }
+
+// HandleListImagesAPI returns the caller's images, newest first, with cursor pagination
+// Security: API Key required via router middleware
+func HandleListImagesAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", strconv.Itoa(apiImagesDefaultLimit)))
+	if limit < 1 || limit > apiImagesMaxLimit {
+		limit = apiImagesDefaultLimit
+	}
+	filter := repository.ImageListFilter{UserID: user.UserID, Limit: limit + 1}
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		id, err := decodeImageCursor(cursor)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "invalid cursor"})
+		}
+		filter.BeforeID = id
+	}
+	if v := c.Query("album_id"); v != "" {
+		albumID, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "invalid album_id"})
+		}
+		album, err := repository.GetGlobalFactory().GetAlbumRepository().GetByID(uint(albumID))
+		if err != nil || album == nil || album.UserID != user.UserID {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "album not found"})
+		}
+		filter.AlbumID = album.ID
+	}
+	switch c.Query("visibility") {
+	case "":
+	case "public":
+		isPublic := true
+		filter.IsPublic = &isPublic
+	case "private":
+		isPublic := false
+		filter.IsPublic = &isPublic
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "visibility must be public or private"})
+	}
+	if v := c.Query("created_after"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "created_after must be RFC3339"})
+		}
+		filter.CreatedAfter = &t
+	}
+	if v := c.Query("created_before"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "created_before must be RFC3339"})
+		}
+		filter.CreatedBefore = &t
+	}
+
+	images, err := repository.GetGlobalFactory().GetImageRepository().ListByUser(filter)
+	if err != nil {
+		log.Printf("api: failed to list images of user %d: %v", user.UserID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to load images"})
+	}
+
+	var nextCursor *string
+	if len(images) > limit {
+		images = images[:limit]
+		cursor := encodeImageCursor(images[limit-1].ID)
+		nextCursor = &cursor
+	}
+	items := make([]fiber.Map, 0, len(images))
+	for i := range images {
+		items = append(items, imageAPIPayload(&images[i]))
+	}
+	return c.JSON(fiber.Map{
+		"items":       items,
+		"limit":       limit,
+		"next_cursor": nextCursor,
+	})
+}
+
+// HandleUpdateImageAPI partially updates title, description, visibility and tags of an own image
+// Security: API Key required via router middleware
+func HandleUpdateImageAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+	image, ok := loadOwnImageAPI(c, user.UserID)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "image not found"})
+	}
+
+	var req struct {
+		Title       *string   `json:"title"`
+		Description *string   `json:"description"`
+		IsPublic    *bool     `json:"is_public"`
+		Tags        *[]string `json:"tags"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "invalid JSON body"})
+	}
+
+	updates := map[string]any{}
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if utf8.RuneCountInString(title) > apiImageTitleMaxLen {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "title too long"})
+		}
+		updates["title"] = title
+	}
+	if req.Description != nil {
+		description := strings.TrimSpace(*req.Description)
+		if utf8.RuneCountInString(description) > apiImageDescMaxLen {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bad_request", "message": "description too long"})
+		}
+		updates["description"] = description
+	}
+	if req.IsPublic != nil {
+		updates["is_public"] = *req.IsPublic
+	}
+
+	if len(updates) > 0 {
+		if err := database.GetDB().Model(&models.Image{}).Where("id = ?", image.ID).Updates(updates).Error; err != nil {
+			log.Printf("api: failed to update image %d: %v", image.ID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to update image"})
+		}
+	}
+	if req.Tags != nil {
+		names := models.ParseTagList(strings.Join(*req.Tags, ","))
+		if _, err := repository.GetGlobalFactory().GetTagRepository().SetImageTags(image.ID, names); err != nil {
+			log.Printf("api: failed to update tags of image %d: %v", image.ID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to update tags"})
+		}
+	}
+
+	updated, err := repository.GetGlobalFactory().GetImageRepository().GetByID(image.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to load image"})
+	}
+	return c.JSON(imageAPIPayload(updated))
+}
+
+// HandleDeleteImageAPI schedules the deletion of an own image and hides it immediately
+// Security: API Key required via router middleware
+func HandleDeleteImageAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+	image, ok := loadOwnImageAPI(c, user.UserID)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "image not found"})
+	}
+
+	initiated := user.UserID
+	job, err := jobqueue.GetManager().GetQueue().EnqueueDeleteImageJob(image.ID, image.UUID, nil, &initiated)
+	if err != nil {
+		log.Printf("api: failed to enqueue delete job for image %d: %v", image.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to schedule deletion"})
+	}
+	// Immediate soft-delete to hide the image; files are removed by the job
+	if err := repository.GetGlobalFactory().GetImageRepository().Delete(image.ID); err != nil {
+		log.Printf("api: failed to soft-delete image %d: %v", image.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to delete image"})
+	}
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"image_uuid": image.UUID,
+		"job_id":     job.ID,
+		"status":     "scheduled",
+	})
+}
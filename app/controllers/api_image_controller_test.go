@@ -0,0 +1,326 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/app/repository"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/cache"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/jobqueue"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+)
+
+func TestImageCursorRoundTrip(t *testing.T) {
+	cursor := encodeImageCursor(4711)
+	id, err := decodeImageCursor(cursor)
+	require.NoError(t, err)
+	assert.Equal(t, uint(4711), id)
+}
+
+func TestDecodeImageCursorRejectsGarbage(t *testing.T) {
+	for _, cursor := range []string{"!!", encodeImageCursor(0), "YWJj"} {
+		_, err := decodeImageCursor(cursor)
+		assert.ErrorIs(t, err, errInvalidCursor, cursor)
+	}
+}
+
+// imageAPITestApp mounts the image handlers like the API router, as the given user (0 = anonymous)
+func imageAPITestApp(userID uint) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		if userID > 0 {
+			c.Locals("USER_CONTEXT", usercontext.UserContext{IsLoggedIn: true, UserID: userID})
+		}
+		return c.Next()
+	})
+	app.Get("/images", HandleListImagesAPI)
+	app.Get("/images/:uuid", HandleGetImageResourceAPI)
+	app.Patch("/images/:uuid", HandleUpdateImageAPI)
+	app.Delete("/images/:uuid", HandleDeleteImageAPI)
+	return app
+}
+
+func TestImageAPIRequiresAuthentication(t *testing.T) {
+	app := imageAPITestApp(0)
+	for _, r := range []struct{ method, target string }{
+		{fiber.MethodGet, "/images"},
+		{fiber.MethodGet, "/images/abc"},
+		{fiber.MethodPatch, "/images/abc"},
+		{fiber.MethodDelete, "/images/abc"},
+	} {
+		status, payload := albumAPIRequest(t, app, r.method, r.target, `{}`)
+		assert.Equal(t, fiber.StatusUnauthorized, status, r.method+" "+r.target)
+		assert.Equal(t, "unauthorized", payload["error"], r.method+" "+r.target)
+	}
+}
+
+func TestListImagesAPIRejectsInvalidQuery(t *testing.T) {
+	app := imageAPITestApp(42)
+	for _, query := range []string{
+		"cursor=!!",
+		"album_id=abc",
+		"visibility=friends",
+		"created_after=yesterday",
+		"created_before=2025-13-01",
+	} {
+		status, payload := albumAPIRequest(t, app, fiber.MethodGet, "/images?"+query, "")
+		assert.Equal(t, fiber.StatusBadRequest, status, query)
+		assert.Equal(t, "bad_request", payload["error"], query)
+	}
+}
+
+// imageAPIFixture holds the rows created for the database backed image API tests
+type imageAPIFixture struct {
+	db         *gorm.DB
+	owner      models.User
+	other      models.User
+	images     []models.Image // owner's images, oldest first
+	otherImage models.Image
+	album      models.Album
+	otherAlbum models.Album
+	created    []time.Time
+	tagPrefix  string
+}
+
+// newImageAPIFixture points the database and repositories at the MySQL database configured with
+// DB_* and creates two users with images and albums. It skips without a database.
+func newImageAPIFixture(t *testing.T) *imageAPIFixture {
+	t.Helper()
+	if os.Getenv("DB_USER") == "" || os.Getenv("DB_NAME") == "" {
+		t.Skip("Skipping MySQL-dependent test: DB_USER and DB_NAME are not set")
+	}
+	host, port := os.Getenv("DB_HOST"), os.Getenv("DB_PORT")
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	if port == "" {
+		port = "3306"
+	}
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local&timeout=2s",
+		os.Getenv("DB_USER"), os.Getenv("DB_PASSWORD"), host, port, os.Getenv("DB_NAME"))
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+		DisableForeignKeyConstraintWhenMigrating: true,
+		Logger:                                   logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Skipf("Skipping MySQL-dependent test: %v", err)
+	}
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.StoragePool{}, &models.Image{}, &models.ImageVariant{},
+		&models.ImageMetadata{}, &models.Comment{}, &models.Tag{}, &models.ImageTag{}, &models.Album{}, &models.AlbumImage{}))
+	database.DB = db
+	repository.InitializeFactory(db)
+
+	suffix := time.Now().UnixNano()
+	f := &imageAPIFixture{db: db, tagPrefix: fmt.Sprintf("apitest%d", suffix)}
+	f.owner = models.User{Name: "API Owner", Email: fmt.Sprintf("owner-%d@example.test", suffix), Password: "x"}
+	f.other = models.User{Name: "API Other", Email: fmt.Sprintf("other-%d@example.test", suffix), Password: "x"}
+	require.NoError(t, db.Create(&f.owner).Error)
+	require.NoError(t, db.Create(&f.other).Error)
+
+	now := time.Now().Truncate(time.Second)
+	for i, public := range []bool{false, true, true} {
+		created := now.Add(time.Duration(i-3) * time.Hour)
+		image := models.Image{
+			UserID:    f.owner.ID,
+			Title:     fmt.Sprintf("Bild %d", i+1),
+			FilePath:  "original/2025/08/10",
+			FileName:  "test.jpg",
+			FileType:  ".jpg",
+			FileHash:  fmt.Sprintf("%d-%d", suffix, i),
+			IsPublic:  public,
+			CreatedAt: created,
+		}
+		require.NoError(t, db.Create(&image).Error)
+		f.images = append(f.images, image)
+		f.created = append(f.created, created)
+	}
+	f.otherImage = models.Image{UserID: f.other.ID, FilePath: "original/2025/08/10", FileName: "other.jpg", FileType: ".jpg", FileHash: fmt.Sprintf("%d-other", suffix)}
+	require.NoError(t, db.Create(&f.otherImage).Error)
+
+	f.album = models.Album{UserID: f.owner.ID, Title: "Eigenes Album"}
+	f.otherAlbum = models.Album{UserID: f.other.ID, Title: "Fremdes Album"}
+	require.NoError(t, db.Create(&f.album).Error)
+	require.NoError(t, db.Create(&f.otherAlbum).Error)
+	require.NoError(t, db.Create(&models.AlbumImage{AlbumID: f.album.ID, ImageID: f.images[0].ID, Position: 1}).Error)
+
+	t.Cleanup(func() {
+		imageIDs := []uint{f.otherImage.ID}
+		for _, image := range f.images {
+			imageIDs = append(imageIDs, image.ID)
+		}
+		db.Where("album_id IN ?", []uint{f.album.ID, f.otherAlbum.ID}).Delete(&models.AlbumImage{})
+		db.Unscoped().Delete(&models.Album{}, []uint{f.album.ID, f.otherAlbum.ID})
+		db.Where("image_id IN ?", imageIDs).Delete(&models.ImageTag{})
+		db.Unscoped().Where("name LIKE ?", f.tagPrefix+"%").Delete(&models.Tag{})
+		db.Unscoped().Delete(&models.Image{}, imageIDs)
+		db.Unscoped().Delete(&models.User{}, []uint{f.owner.ID, f.other.ID})
+	})
+	return f
+}
+
+// imageUUIDs returns the UUIDs of the listed images in response order
+func imageUUIDs(payload map[string]any) []string {
+	items, _ := payload["items"].([]any)
+	uuids := make([]string, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]any); ok {
+			uuids = append(uuids, fmt.Sprint(m["image_uuid"]))
+		}
+	}
+	return uuids
+}
+
+func TestListImagesAPIPagesWithCursor(t *testing.T) {
+	f := newImageAPIFixture(t)
+	app := imageAPITestApp(f.owner.ID)
+
+	status, payload := albumAPIRequest(t, app, fiber.MethodGet, "/images?limit=2", "")
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, []string{f.images[2].UUID, f.images[1].UUID}, imageUUIDs(payload))
+	assert.EqualValues(t, 2, payload["limit"])
+	cursor, ok := payload["next_cursor"].(string)
+	require.True(t, ok, "a further page is announced")
+
+	status, payload = albumAPIRequest(t, app, fiber.MethodGet, "/images?limit=2&cursor="+cursor, "")
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, []string{f.images[0].UUID}, imageUUIDs(payload))
+	assert.Nil(t, payload["next_cursor"], "the last page has no cursor")
+
+	// A page that is filled exactly is still the last one
+	status, payload = albumAPIRequest(t, app, fiber.MethodGet, "/images?limit=3", "")
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, imageUUIDs(payload), 3)
+	assert.Nil(t, payload["next_cursor"])
+}
+
+func TestListImagesAPIFilters(t *testing.T) {
+	f := newImageAPIFixture(t)
+	app := imageAPITestApp(f.owner.ID)
+	list := func(query string) []string {
+		status, payload := albumAPIRequest(t, app, fiber.MethodGet, "/images?"+query, "")
+		require.Equal(t, fiber.StatusOK, status, query)
+		return imageUUIDs(payload)
+	}
+	middle := url.QueryEscape(f.created[1].Format(time.RFC3339))
+
+	assert.Equal(t, []string{f.images[0].UUID}, list(fmt.Sprintf("album_id=%d", f.album.ID)))
+	assert.Equal(t, []string{f.images[2].UUID, f.images[1].UUID}, list("visibility=public"))
+	assert.Equal(t, []string{f.images[0].UUID}, list("visibility=private"))
+	assert.Equal(t, []string{f.images[2].UUID, f.images[1].UUID}, list("created_after="+middle))
+	assert.Equal(t, []string{f.images[0].UUID}, list("created_before="+middle))
+	assert.Empty(t, list(fmt.Sprintf("album_id=%d&visibility=public", f.album.ID)))
+
+	// Albums of other users are not found
+	status, payload := albumAPIRequest(t, app, fiber.MethodGet, fmt.Sprintf("/images?album_id=%d", f.otherAlbum.ID), "")
+	assert.Equal(t, fiber.StatusNotFound, status)
+	assert.Equal(t, "not_found", payload["error"])
+}
+
+func TestImageAPIHidesOtherUsersImages(t *testing.T) {
+	f := newImageAPIFixture(t)
+	app := imageAPITestApp(f.owner.ID)
+
+	assert.NotContains(t, func() []string {
+		_, payload := albumAPIRequest(t, app, fiber.MethodGet, "/images", "")
+		return imageUUIDs(payload)
+	}(), f.otherImage.UUID)
+
+	for _, method := range []string{fiber.MethodGet, fiber.MethodPatch, fiber.MethodDelete} {
+		status, payload := albumAPIRequest(t, app, method, "/images/"+f.otherImage.UUID, `{"title":"Übernommen"}`)
+		assert.Equal(t, fiber.StatusNotFound, status, method)
+		assert.Equal(t, "not_found", payload["error"], method)
+	}
+	var unchanged models.Image
+	require.NoError(t, f.db.First(&unchanged, f.otherImage.ID).Error)
+	assert.Empty(t, unchanged.Title)
+
+	// Public images of other users can be read, not changed
+	require.NoError(t, f.db.Model(&f.otherImage).Update("is_public", true).Error)
+	status, _ := albumAPIRequest(t, app, fiber.MethodGet, "/images/"+f.otherImage.UUID, "")
+	assert.Equal(t, fiber.StatusOK, status)
+	status, _ = albumAPIRequest(t, app, fiber.MethodPatch, "/images/"+f.otherImage.UUID, `{"title":"Übernommen"}`)
+	assert.Equal(t, fiber.StatusNotFound, status)
+}
+
+func TestUpdateImageAPIChangesOnlyGivenFields(t *testing.T) {
+	f := newImageAPIFixture(t)
+	app := imageAPITestApp(f.owner.ID)
+	target := "/images/" + f.images[1].UUID
+	tagA, tagB := f.tagPrefix+"a", f.tagPrefix+"b"
+
+	body := fmt.Sprintf(`{"title":"  Neuer Titel  ","tags":["%s","%s","%s"]}`, tagB, tagA, tagB)
+	status, payload := albumAPIRequest(t, app, fiber.MethodPatch, target, body)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "Neuer Titel", payload["title"])
+	assert.Equal(t, true, payload["is_public"], "visibility is kept")
+	assert.ElementsMatch(t, []any{tagA, tagB}, payload["tags"])
+
+	status, payload = albumAPIRequest(t, app, fiber.MethodPatch, target, `{"description":"Beschreibung","is_public":false}`)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "Neuer Titel", payload["title"], "title is kept")
+	assert.Equal(t, "Beschreibung", payload["description"])
+	assert.Equal(t, false, payload["is_public"])
+	assert.Len(t, payload["tags"], 2, "tags are kept")
+
+	status, payload = albumAPIRequest(t, app, fiber.MethodPatch, target, `{"tags":[]}`)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Empty(t, payload["tags"])
+
+	for _, body := range []string{
+		`{`,
+		`{"title":"` + strings.Repeat("ä", apiImageTitleMaxLen+1) + `"}`,
+		`{"description":"` + strings.Repeat("x", apiImageDescMaxLen+1) + `"}`,
+	} {
+		status, payload := albumAPIRequest(t, app, fiber.MethodPatch, target, body)
+		assert.Equal(t, fiber.StatusBadRequest, status)
+		assert.Equal(t, "bad_request", payload["error"])
+	}
+	var stored models.Image
+	require.NoError(t, f.db.First(&stored, f.images[1].ID).Error)
+	assert.Equal(t, "Neuer Titel", stored.Title, "rejected updates change nothing")
+}
+
+func TestDeleteImageAPISchedulesDeletion(t *testing.T) {
+	f := newImageAPIFixture(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	client := cache.GetClient()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("Skipping Redis-dependent test: %v", err)
+	}
+	app := imageAPITestApp(f.owner.ID)
+
+	status, payload := albumAPIRequest(t, app, fiber.MethodDelete, "/images/"+f.images[0].UUID, "")
+	require.Equal(t, fiber.StatusAccepted, status)
+	assert.Equal(t, f.images[0].UUID, payload["image_uuid"])
+	assert.Equal(t, "scheduled", payload["status"])
+	jobID, _ := payload["job_id"].(string)
+	require.NotEmpty(t, jobID)
+	t.Cleanup(func() {
+		ctx := context.Background()
+		client.LRem(ctx, jobqueue.JobQueueKey, 1, jobID)
+		client.Del(ctx, jobqueue.JobKeyPrefix+jobID)
+		client.HIncrBy(ctx, jobqueue.JobStatsKey, string(jobqueue.JobStatusPending), -1)
+	})
+
+	// The image is hidden at once, the job removes the files
+	status, _ = albumAPIRequest(t, app, fiber.MethodGet, "/images/"+f.images[0].UUID, "")
+	assert.Equal(t, fiber.StatusNotFound, status)
+	var deleted models.Image
+	require.NoError(t, f.db.Unscoped().First(&deleted, f.images[0].ID).Error)
+	assert.True(t, deleted.DeletedAt.Valid)
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ManuelReschke/PixelFox/app/repository"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+)
+
+const apiLikesMaxPerPage = 100
+
+// handleSetImageLikeAPI is shared by the like and unlike endpoints
+func handleSetImageLikeAPI(c *fiber.Ctx, like bool) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+	image, ok := loadCommentableImage(c)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "image not found"})
+	}
+	if err := setImageLike(image, user.UserID, like); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to update like"})
+	}
+	return c.JSON(fiber.Map{
+		"liked":      like,
+		"like_count": imageLikeCount(image),
+	})
+}
+
+// HandleLikeImageAPI likes an image
+// Security: API Key required via router middleware
+func HandleLikeImageAPI(c *fiber.Ctx) error {
+	return handleSetImageLikeAPI(c, true)
+}
+
+// HandleUnlikeImageAPI removes a like from an image
+// Security: API Key required via router middleware
+func HandleUnlikeImageAPI(c *fiber.Ctx) error {
+	return handleSetImageLikeAPI(c, false)
+}
+
+// HandleListUserLikesAPI returns a page of images the caller liked
+// Security: API Key required via router middleware
+func HandleListUserLikesAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(c.Query("per_page", strconv.Itoa(favoritesPerPage)))
+	if perPage < 1 || perPage > apiLikesMaxPerPage {
+		perPage = favoritesPerPage
+	}
+
+	likeRepo := repository.GetGlobalFactory().GetLikeRepository()
+	images, err := likeRepo.GetLikedImages(user.UserID, (page-1)*perPage, perPage)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to load likes"})
+	}
+	total, err := likeRepo.CountByUserID(user.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to count likes"})
+	}
+
+	items := make([]fiber.Map, 0, len(images))
+	for i := range images {
+		payload := buildUploadResponseExtras(&images[i])
+		delete(payload, "duplicate")
+		items = append(items, payload)
+	}
+	return c.JSON(fiber.Map{
+		"items":    items,
+		"page":     page,
+		"per_page": perPage,
+		"total":    total,
+	})
+}
@@ -44,6 +44,11 @@ func HandleGetUserAccount(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "Failed to load user settings"})
 	}
 
+	apiKeyLastUsed, err := repository.GetGlobalFactory().GetAPIKeyRepository().LastUsedAtByUser(userCtx.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "Failed to load API keys"})
+	}
+
 	plan := entitlements.Plan(settings.Plan)
 	if plan == "" {
 		plan = entitlements.PlanFree
@@ -84,7 +89,7 @@ func HandleGetUserAccount(c *fiber.Ctx) error {
 		"plan":                 settings.Plan,
 		"created_at":           account.CreatedAt.UTC().Format(time.RFC3339),
 		"last_login_at":        formatTimePtr(account.LastLoginAt),
-		"api_key_last_used_at": formatTimePtr(settings.APIKeyLastUsedAt),
+		"api_key_last_used_at": formatTimePtr(apiKeyLastUsed),
 		"stats": fiber.Map{
 			"images": fiber.Map{
 				"count":                   stats.ImageCount,
@@ -107,6 +112,7 @@ func HandleGetUserAccount(c *fiber.Ctx) error {
 			"thumbnail_original": settings.PrefThumbOriginal,
 			"thumbnail_webp":     settings.PrefThumbWebP,
 			"thumbnail_avif":     settings.PrefThumbAVIF,
+			"metadata_policy":    models.NormalizeMetadataPolicy(settings.MetadataPolicy),
 		},
 	}
 
@@ -1,10 +1,13 @@
 package controllers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"time"
 
 	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
@@ -14,6 +17,7 @@ import (
 	"github.com/sujit-baniya/flash"
 
 	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/cache"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/hcaptcha"
@@ -43,10 +47,7 @@ func HandleAuthLogin(c *fiber.Ctx) error {
 	handler := adaptor.HTTPHandler(templ.Handler(login))
 
 	if c.Method() == fiber.MethodPost {
-		var (
-			user models.User
-			err  error
-		)
+		var user models.User
 		fm := fiber.Map{
 			"type": "error",
 		}
@@ -72,36 +73,23 @@ func HandleAuthLogin(c *fiber.Ctx) error {
 			return flash.WithError(c, fm).Redirect("/login")
 		}
 
-		sess, err := session.GetSessionStore().Get(c)
-		if err != nil {
-			fm["message"] = fmt.Sprintf("something went wrong: %s", err)
-
-			return flash.WithError(c, fm).Redirect("/login")
-		}
+		// Accounts with 2FA need a second step before the session is created
+		if user.HasTwoFactor() {
+			if err := beginTwoFactorLogin(c, user.ID); err != nil {
+				fm["message"] = fmt.Sprintf("something went wrong: %s", err)
 
-		sess.Set(usercontext.AuthKey, true)
-		sess.Set(usercontext.KeyUserID, user.ID)
-		sess.Set(usercontext.KeyUsername, user.Name)
-		sess.Set(usercontext.KeyIsAdmin, user.Role == "admin")
-
-		// Cache user plan in session for navbar/entitlements
-		if us, err := models.GetOrCreateUserSettings(database.GetDB(), user.ID); err == nil && us != nil {
-			if us.Plan == "" {
-				session.SetSessionValue(c, "user_plan", "free")
-			} else {
-				session.SetSessionValue(c, "user_plan", us.Plan)
+				return flash.WithError(c, fm).Redirect("/login")
 			}
+			c.Set("HX-Redirect", "/login/2fa")
+			return c.Redirect("/login/2fa")
 		}
 
-		err = sess.Save()
-		if err != nil {
+		if err := startUserSession(c, &user, false); err != nil {
 			fm["message"] = fmt.Sprintf("something went wrong: %s", err)
 
 			return flash.WithError(c, fm).Redirect("/login")
 		}
 
-		database.GetDB().Model(&user).UpdateColumn("last_login_at", time.Now())
-
 		fm = fiber.Map{
 			"type":    "success",
 			"message": "Glückwunsch du bist drin! Viel Spaß!",
@@ -115,6 +103,47 @@ func HandleAuthLogin(c *fiber.Ctx) error {
 	return handler(c)
 }
 
+// startUserSession logs the user in by populating the app session. twoFactorVerified
+// records whether the login passed the second factor (required for admins if enforced).
+func startUserSession(c *fiber.Ctx, user *models.User, twoFactorVerified bool) error {
+	sess, err := session.GetSessionStore().Get(c)
+	if err != nil {
+		return err
+	}
+
+	sess.Delete(sessionPending2FAUserID)
+	sess.Delete(sessionPending2FAStarted)
+	sess.Set(usercontext.AuthKey, true)
+	sess.Set(usercontext.KeyUserID, user.ID)
+	sess.Set(usercontext.KeyUsername, user.Name)
+	sess.Set(usercontext.KeyIsAdmin, user.Role == models.ROLE_ADMIN)
+	if twoFactorVerified {
+		sess.Set(usercontext.KeyTwoFactorVerified, "true")
+	} else {
+		sess.Delete(usercontext.KeyTwoFactorVerified)
+	}
+
+	// Cache user plan in session for navbar/entitlements
+	if us, err := models.GetOrCreateUserSettings(database.GetDB(), user.ID); err == nil && us != nil {
+		if us.Plan == "" {
+			sess.Set("user_plan", "free")
+		} else {
+			sess.Set("user_plan", us.Plan)
+		}
+	}
+
+	if err := sess.Save(); err != nil {
+		return err
+	}
+
+	if err := session.TrackUserSession(c, user.ID); err != nil {
+		log.Printf("failed to track session for user %d: %v", user.ID, err)
+	}
+
+	database.GetDB().Model(user).UpdateColumn("last_login_at", time.Now())
+	return nil
+}
+
 func HandleAuthLogout(c *fiber.Ctx) error {
 	fm := fiber.Map{
 		"type": "error",
@@ -286,3 +315,130 @@ func HandleAuthActivate(c *fiber.Ctx) error {
 	fm := fiber.Map{"type": "success", "message": "Konto aktiviert! Du kannst dich jetzt anmelden."}
 	return flash.WithSuccess(c, fm).Redirect("/login")
 }
+
+const (
+	passwordResetEmailLimitPerHour = 3
+	passwordResetIPLimitPerHour    = 10
+	passwordResetRequestedMessage  = "Falls ein Konto mit dieser E-Mail-Adresse existiert, haben wir dir einen Link zum Zurücksetzen gesendet."
+)
+
+// passwordResetRateLimited counts a reset request for the email and the client IP and
+// reports whether one of the hourly limits is exceeded. Cache errors fail open.
+func passwordResetRateLimited(c *fiber.Ctx, email string) bool {
+	ip := c.IP()
+	if ip == "" {
+		ip = "unknown"
+	}
+	if n, err := cache.IncrWithExpiry(fmt.Sprintf("rate:password_reset:ip:%s", ip), time.Hour); err == nil && n > passwordResetIPLimitPerHour {
+		return true
+	}
+	// Hash the address so raw emails do not end up in cache keys
+	sum := sha256.Sum256([]byte(strings.ToLower(email)))
+	emailKey := fmt.Sprintf("rate:password_reset:email:%s", hex.EncodeToString(sum[:]))
+	if n, err := cache.IncrWithExpiry(emailKey, time.Hour); err == nil && n > passwordResetEmailLimitPerHour {
+		return true
+	}
+	return false
+}
+
+// HandleAuthForgotPassword renders the "forgot password" form and issues reset links.
+// The response never reveals whether an account exists for the submitted email.
+func HandleAuthForgotPassword(c *fiber.Ctx) error {
+	fromProtected := isLoggedIn(c)
+	csrfToken := c.Locals("csrf").(string)
+
+	if c.Method() != fiber.MethodPost {
+		findex := authViews.ForgotPasswordIndex(fromProtected, csrfToken)
+		page := authViews.PasswordReset(" | Passwort vergessen", fromProtected, false, flash.Get(c), "", findex, false)
+		return adaptor.HTTPHandler(templ.Handler(page))(c)
+	}
+
+	email := strings.TrimSpace(c.FormValue("email"))
+	if email == "" {
+		return flash.WithError(c, fiber.Map{"type": "error", "message": "Bitte gib eine E-Mail-Adresse ein."}).Redirect("/forgot-password")
+	}
+	if passwordResetRateLimited(c, email) {
+		return flash.WithError(c, fiber.Map{"type": "error", "message": "Zu viele Anfragen. Bitte versuche es später erneut."}).Redirect("/forgot-password")
+	}
+
+	db := database.GetDB()
+	var user models.User
+	if err := db.Where("email = ?", email).First(&user).Error; err == nil && user.IsActive() {
+		if err := user.GeneratePasswordResetToken(); err != nil {
+			log.Printf("Password reset token error for user %d: %v", user.ID, err)
+		} else if err := db.Model(&user).Updates(map[string]any{
+			"password_reset_token":   user.PasswordResetToken,
+			"password_reset_sent_at": user.PasswordResetSentAt,
+		}).Error; err != nil {
+			log.Printf("Password reset token save error for user %d: %v", user.ID, err)
+		} else {
+			domain := env.GetEnv("PUBLIC_DOMAIN", "")
+			resetURL := fmt.Sprintf("%s/reset-password?token=%s", domain, user.PasswordResetToken)
+			rec := httptest.NewRecorder()
+			templ.Handler(emailViews.PasswordResetEmail(user.Email, templ.SafeURL(resetURL))).ServeHTTP(rec, &http.Request{})
+			body := rec.Body.String()
+			go func() {
+				if err := mail.SendMail(user.Email, "Passwort zurücksetzen - PIXELFOX.cc", body); err != nil {
+					log.Printf("Password reset email error: %v", err)
+				}
+			}()
+		}
+	}
+
+	fm := fiber.Map{"type": "success", "message": passwordResetRequestedMessage}
+	return flash.WithSuccess(c, fm).Redirect("/login")
+}
+
+// HandleAuthResetPassword validates a reset token, sets the new password and
+// logs the account out everywhere.
+func HandleAuthResetPassword(c *fiber.Ctx) error {
+	fromProtected := isLoggedIn(c)
+	csrfToken := c.Locals("csrf").(string)
+	invalidLink := fiber.Map{"type": "error", "message": "Ungültiger oder abgelaufener Link. Bitte fordere einen neuen an."}
+
+	var token string
+	if c.Method() == fiber.MethodPost {
+		token = c.FormValue("token")
+	} else {
+		token = c.Query("token", "")
+	}
+	if token == "" {
+		return flash.WithError(c, invalidLink).Redirect("/forgot-password")
+	}
+
+	db := database.GetDB()
+	var user models.User
+	if err := db.Where("password_reset_token = ?", token).First(&user).Error; err != nil || !user.IsPasswordResetTokenValid(token) {
+		return flash.WithError(c, invalidLink).Redirect("/forgot-password")
+	}
+
+	if c.Method() != fiber.MethodPost {
+		rindex := authViews.ResetPasswordIndex(fromProtected, csrfToken, token)
+		page := authViews.PasswordReset(" | Neues Passwort", fromProtected, false, flash.Get(c), "", rindex, false)
+		return adaptor.HTTPHandler(templ.Handler(page))(c)
+	}
+
+	retryURL := "/reset-password?token=" + token
+	password := c.FormValue("password")
+	if password != c.FormValue("password_confirm") {
+		return flash.WithError(c, fiber.Map{"type": "error", "message": "Die Passwörter stimmen nicht überein."}).Redirect(retryURL)
+	}
+	if len(password) < 6 {
+		return flash.WithError(c, fiber.Map{"type": "error", "message": "Das Passwort muss mindestens 6 Zeichen lang sein."}).Redirect(retryURL)
+	}
+	if err := user.SetPassword(password); err != nil {
+		return flash.WithError(c, fiber.Map{"type": "error", "message": "Fehler beim Setzen des neuen Passworts"}).Redirect(retryURL)
+	}
+	user.ClearPasswordResetRequest()
+	if err := db.Save(&user).Error; err != nil {
+		return flash.WithError(c, fiber.Map{"type": "error", "message": "Fehler beim Speichern des neuen Passworts"}).Redirect(retryURL)
+	}
+
+	if err := session.DestroyUserSessions(user.ID); err != nil {
+		log.Printf("failed to destroy sessions for user %d after password reset: %v", user.ID, err)
+	}
+
+	fm := fiber.Map{"type": "success", "message": "Passwort geändert. Du kannst dich jetzt mit dem neuen Passwort anmelden."}
+	c.Set("HX-Redirect", "/login")
+	return flash.WithSuccess(c, fm).Redirect("/login")
+}
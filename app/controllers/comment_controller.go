@@ -0,0 +1,234 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sujit-baniya/flash"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/app/repository"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/cache"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/viewmodel"
+	"github.com/ManuelReschke/PixelFox/views"
+)
+
+const (
+	commentsPerPage         = 20
+	commentRateLimit        = 5
+	commentRateLimitWindow  = time.Minute
+	commentHourlyLimit      = 30
+	commentHourlyLimitRange = time.Hour
+)
+
+var (
+	errCommentsDisabled   = errors.New("comments are disabled for this image")
+	errCommentRateLimited = errors.New("too many comments")
+)
+
+// commentRateLimited enforces a short burst limit and an hourly limit per user
+func commentRateLimited(userID uint) bool {
+	n, err := cache.IncrWithExpiry(fmt.Sprintf("rate:comment:user:%d", userID), commentRateLimitWindow)
+	if err != nil {
+		log.Printf("comment rate limit check failed for user %d: %v", userID, err)
+		return false
+	}
+	if n > commentRateLimit {
+		return true
+	}
+	n, err = cache.IncrWithExpiry(fmt.Sprintf("rate:comment:user:%d:hour", userID), commentHourlyLimitRange)
+	if err != nil {
+		log.Printf("comment rate limit check failed for user %d: %v", userID, err)
+		return false
+	}
+	return n > commentHourlyLimit
+}
+
+// createImageComment validates and stores a new comment; shared by web and API handlers
+func createImageComment(image *models.Image, userID uint, content string) (*models.Comment, error) {
+	if !image.AllowsComments() {
+		return nil, errCommentsDisabled
+	}
+	content, err := models.NormalizeCommentContent(content)
+	if err != nil {
+		return nil, err
+	}
+	if commentRateLimited(userID) {
+		return nil, errCommentRateLimited
+	}
+	comment := &models.Comment{
+		UserID:  userID,
+		ImageID: image.ID,
+		Content: content,
+	}
+	commentRepo := repository.GetGlobalFactory().GetCommentRepository()
+	if err := commentRepo.Create(comment); err != nil {
+		log.Printf("failed to create comment on image %d by user %d: %v", image.ID, userID, err)
+		return nil, err
+	}
+	return commentRepo.GetByID(comment.ID)
+}
+
+// commentErrorMessage maps comment errors to user facing messages
+func commentErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, models.ErrCommentEmpty):
+		return "Der Kommentar darf nicht leer sein."
+	case errors.Is(err, models.ErrCommentTooLong):
+		return fmt.Sprintf("Der Kommentar darf höchstens %d Zeichen lang sein.", models.CommentMaxLength)
+	case errors.Is(err, errCommentsDisabled):
+		return "Kommentare sind für dieses Bild deaktiviert."
+	case errors.Is(err, errCommentRateLimited):
+		return "Du kommentierst zu schnell. Bitte warte einen Moment."
+	default:
+		return "Kommentar konnte nicht gespeichert werden."
+	}
+}
+
+// loadCommentableImage resolves a public image for the comment routes
+func loadCommentableImage(c *fiber.Ctx) (*models.Image, bool) {
+	image, err := repository.GetGlobalFactory().GetImageRepository().GetByUUID(c.Params("uuid"))
+	if err != nil || image == nil {
+		return nil, false
+	}
+	userCtx := usercontext.GetUserContext(c)
+	if !image.IsPublic && image.UserID != userCtx.UserID {
+		return nil, false
+	}
+	return image, true
+}
+
+// buildCommentThread loads one page of comments and prepares the view model
+func buildCommentThread(c *fiber.Ctx, image *models.Image, page int) (viewmodel.CommentThread, error) {
+	userCtx := usercontext.GetUserContext(c)
+	commentRepo := repository.GetGlobalFactory().GetCommentRepository()
+
+	if page < 1 {
+		page = 1
+	}
+	comments, err := commentRepo.GetByImageID(image.ID, (page-1)*commentsPerPage, commentsPerPage)
+	if err != nil {
+		return viewmodel.CommentThread{}, err
+	}
+	total, err := commentRepo.CountByImageID(image.ID)
+	if err != nil {
+		return viewmodel.CommentThread{}, err
+	}
+
+	thread := viewmodel.CommentThread{
+		ImageUUID:  image.UUID,
+		Total:      total,
+		IsLoggedIn: userCtx.IsLoggedIn,
+		CanComment: userCtx.IsLoggedIn && image.AllowsComments(),
+		Disabled:   image.CommentsDisabled,
+		CSRFToken:  c.Locals("csrf").(string),
+		Comments:   make([]viewmodel.Comment, 0, len(comments)),
+	}
+	if int64(page*commentsPerPage) < total {
+		thread.NextPage = page + 1
+	}
+	for _, comment := range comments {
+		author := comment.User.Name
+		if author == "" {
+			author = "Gelöschter Benutzer"
+		}
+		thread.Comments = append(thread.Comments, viewmodel.Comment{
+			ID:         comment.ID,
+			AuthorName: author,
+			Content:    comment.Content,
+			CreatedAt:  comment.CreatedAt.In(time.Local).Format("02.01.2006 15:04"),
+			CanDelete:  comment.CanBeDeletedBy(userCtx.UserID, image.UserID, userCtx.IsAdmin),
+		})
+	}
+	return thread, nil
+}
+
+// HandleImageComments renders the comment thread (page 1) or a further page as HTMX partial
+func HandleImageComments(c *fiber.Ctx) error {
+	image, ok := loadCommentableImage(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	thread, err := buildCommentThread(c, image, page)
+	if err != nil {
+		log.Printf("failed to load comments for image %d: %v", image.ID, err)
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}
+
+	c.Type("html")
+	if page > 1 {
+		return views.ImageCommentsPage(thread).Render(c.Context(), c.Response().BodyWriter())
+	}
+	return views.ImageComments(thread).Render(c.Context(), c.Response().BodyWriter())
+}
+
+// HandleImageCommentCreate stores a new comment and re-renders the thread
+func HandleImageCommentCreate(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	uuid := c.Params("uuid")
+	image, ok := loadCommentableImage(c)
+	if !ok {
+		return c.Redirect("/")
+	}
+
+	content := c.FormValue("content")
+	_, createErr := createImageComment(image, userCtx.UserID, content)
+
+	if !isHTMXRequest(c) {
+		if createErr != nil {
+			return flash.WithError(c, fiber.Map{"type": "error", "message": commentErrorMessage(createErr)}).Redirect("/image/" + uuid)
+		}
+		return flash.WithSuccess(c, fiber.Map{"type": "success", "message": "Kommentar gespeichert"}).Redirect("/image/" + uuid)
+	}
+
+	thread, err := buildCommentThread(c, image, 1)
+	if err != nil {
+		log.Printf("failed to load comments for image %d: %v", image.ID, err)
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}
+	if createErr != nil {
+		thread.Error = commentErrorMessage(createErr)
+		thread.Draft = content
+	}
+	c.Type("html")
+	return views.ImageComments(thread).Render(c.Context(), c.Response().BodyWriter())
+}
+
+// HandleImageCommentDelete removes a comment (author, image owner or admin)
+func HandleImageCommentDelete(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	uuid := c.Params("uuid")
+	image, ok := loadCommentableImage(c)
+	if !ok {
+		return c.Redirect("/")
+	}
+
+	commentID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	commentRepo := repository.GetGlobalFactory().GetCommentRepository()
+	comment, err := commentRepo.GetByID(uint(commentID))
+	if err != nil || comment.ImageID != image.ID {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+	if !comment.CanBeDeletedBy(userCtx.UserID, image.UserID, userCtx.IsAdmin) {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+	if err := commentRepo.Delete(comment.ID); err != nil {
+		log.Printf("failed to delete comment %d: %v", comment.ID, err)
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}
+
+	if isHTMXRequest(c) {
+		// Empty response removes the comment element via outerHTML swap
+		return c.SendString("")
+	}
+	return flash.WithSuccess(c, fiber.Map{"type": "success", "message": "Kommentar gelöscht"}).Redirect("/image/" + uuid)
+}
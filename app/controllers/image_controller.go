@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/a-h/templ"
 	"github.com/gofiber/fiber/v2"
@@ -21,6 +22,47 @@ import (
 	"github.com/ManuelReschke/PixelFox/views"
 )
 
+// nearDuplicateWarningWindow limits the near-duplicate warning to freshly uploaded images
+const nearDuplicateWarningWindow = 15 * time.Minute
+
+// findSimilarImages returns the owner's images whose perceptual hash is close to the one of the given image
+func findSimilarImages(image *models.Image, limit int) []models.Image {
+	if image == nil || image.PerceptualHash == nil {
+		return nil
+	}
+	maxDistance := 7
+	if settings := models.GetAppSettings(); settings != nil {
+		maxDistance = settings.GetSimilarImageMaxDistance()
+	}
+	similar, err := repository.GetGlobalFactory().GetImageRepository().
+		FindSimilarByUserID(image.UserID, *image.PerceptualHash, maxDistance, image.ID, limit)
+	if err != nil {
+		fiberlog.Warnf("[SimilarImages] Lookup failed for image %d: %v", image.ID, err)
+		return nil
+	}
+	return similar
+}
+
+// nearDuplicateWarning lists near-duplicates of a freshly uploaded image for its owner, if the warning is enabled
+func nearDuplicateWarning(image *models.Image, currentUserID uint) []viewmodel.SimilarImage {
+	settings := models.GetAppSettings()
+	if settings == nil || !settings.IsNearDuplicateUploadWarningEnabled() {
+		return nil
+	}
+	if currentUserID == 0 || image.UserID != currentUserID || time.Since(image.CreatedAt) > nearDuplicateWarningWindow {
+		return nil
+	}
+	var result []viewmodel.SimilarImage
+	for _, s := range findSimilarImages(image, 4) {
+		result = append(result, viewmodel.SimilarImage{
+			UUID:        s.UUID,
+			Title:       s.Title,
+			PreviewPath: imageprocessor.GetBestPreviewURL(&s),
+		})
+	}
+	return result
+}
+
 // formatBytes formats a byte size into a human-readable string like "900 KB" or "1.2 MB"
 func formatBytes(size int64) string {
 	const unit = 1024
@@ -88,8 +130,8 @@ func HandleImageViewer(c *fiber.Ctx) error {
 	// Touch last viewed at (Redis -> periodic DB flush)
 	_ = metrics.AddImageLastViewed(image.ID)
 
-	// Korrekte URL-Konstruktion (absolut) für das Original-Bild
-	filePathComplete := imageprocessor.GetImageURL(image, "original", "")
+	// Korrekte URL-Konstruktion (absolut) für das Original-Bild; wasserzeichenfreie Originale sieht nur der Besitzer
+	filePathComplete := imageprocessor.GetOriginalURLFor(image, currentUserID)
 	fiberlog.Debugf("[ImageController] Original-Pfad: %s", filePathComplete)
 	filePathWithDomain := imageprocessor.MakeAbsoluteURL(domain, filePathComplete)
 
@@ -155,8 +197,14 @@ func HandleImageViewer(c *fiber.Ctx) error {
 		mediumThumbOriginalPath = path
 	}
 
+	// Originals browsers cannot display (HEIC, TIFF, JPEG XL) fall back to their web variant
+	webPath := imagePaths[models.VariantTypeWeb]
+
 	// Use the medium thumbnail for the preview
 	previewPath := filePathComplete
+	if webPath != "" {
+		previewPath = webPath
+	}
 	previewWebpPath := ""
 	previewAvifPath := ""
 
@@ -246,6 +294,12 @@ func HandleImageViewer(c *fiber.Ctx) error {
 		OptimizedWebPPath:    optimizedWebpPath,
 		OptimizedAVIFPath:    optimizedAvifPath,
 		OriginalPath:         filePathComplete,
+		WebPath:              webPath,
+		IsAnimated:           image.FrameCount > 1,
+		FrameCount:           image.FrameCount,
+		DurationMs:           image.DurationMs,
+		VideoMP4Path:         imagePaths[models.VariantTypeVideoMP4],
+		VideoWebMPath:        imagePaths[models.VariantTypeVideoWebM],
 		Width:                image.Width,
 		Height:               image.Height,
 		UUID:                 image.UUID,
@@ -275,6 +329,7 @@ func HandleImageViewer(c *fiber.Ctx) error {
 			}
 			return ""
 		}(),
+		LocationHidden: image.Metadata != nil && image.Metadata.LocationHidden,
 		ExposureTime: func() string {
 			if image.Metadata != nil && image.Metadata.ExposureTime != nil {
 				return *image.Metadata.ExposureTime
@@ -319,9 +374,17 @@ func HandleImageViewer(c *fiber.Ctx) error {
 		SmallOriginalBytes:     bytesMap[models.VariantTypeThumbnailSmallOrig],
 		SmallWebPBytes:         bytesMap[models.VariantTypeThumbnailSmallWebP],
 		SmallAVIFBytes:         bytesMap[models.VariantTypeThumbnailSmallAVIF],
+		// Comment threads are only available for public images
+		ShowComments:  image.IsPublic,
+		ShowLikes:     image.IsPublic || image.UserID == currentUserID,
+		SimilarImages: nearDuplicateWarning(image, currentUserID),
 	}
 
-	imageViewer := views.ImageViewerWithUser(imageModel, currentUserID, image.UserID)
+	if tags, err := repository.GetGlobalFactory().GetTagRepository().GetByImageID(image.ID); err == nil {
+		imageModel.Tags = models.TagNames(tags)
+	}
+
+	imageViewer := views.ImageViewerPage(imageModel, currentUserID, image.UserID)
 
 	ogViewModel := &viewmodel.OpenGraph{
 		URL:         shareURL,
@@ -419,6 +482,7 @@ func HandleImageProcessingStatus(c *fiber.Ctx) error {
 				}
 				return ""
 			}(),
+			LocationHidden: image.Metadata != nil && image.Metadata.LocationHidden,
 			ExposureTime: func() string {
 				if image.Metadata != nil && image.Metadata.ExposureTime != nil {
 					return *image.Metadata.ExposureTime
@@ -500,10 +564,16 @@ func HandleImageProcessingStatus(c *fiber.Ctx) error {
 
 	// Base URL for storage domain and relative original path
 	base := imageprocessor.GetPublicBaseURLForImage(image)
-	originalPath := imageprocessor.GetImageURL(image, "original", "")
+	originalPath := imageprocessor.GetOriginalURLFor(image, currentUserID)
+
+	// Originals browsers cannot display (HEIC, TIFF, JPEG XL) fall back to their web variant
+	webPath := imagePaths[models.VariantTypeWeb]
 
 	// Use the medium thumbnail for the preview
 	previewPath := originalPath
+	if webPath != "" {
+		previewPath = webPath
+	}
 	previewWebPPath := ""
 	previewAVIFPath := ""
 
@@ -557,6 +627,12 @@ func HandleImageProcessingStatus(c *fiber.Ctx) error {
 		OptimizedWebPPath:    optimizedWebpPath,
 		OptimizedAVIFPath:    optimizedAvifPath,
 		OriginalPath:         originalPath,
+		WebPath:              webPath,
+		IsAnimated:           image.FrameCount > 1,
+		FrameCount:           image.FrameCount,
+		DurationMs:           image.DurationMs,
+		VideoMP4Path:         imagePaths[models.VariantTypeVideoMP4],
+		VideoWebMPath:        imagePaths[models.VariantTypeVideoWebM],
 		DisplayName:          displayName,
 		HasWebP:              variantInfoAjax.HasWebP,
 		HasAVIF:              variantInfoAjax.HasAVIF,
@@ -591,6 +667,7 @@ func HandleImageProcessingStatus(c *fiber.Ctx) error {
 			}
 			return ""
 		}(),
+		LocationHidden: image.Metadata != nil && image.Metadata.LocationHidden,
 		ExposureTime: func() string {
 			if image.Metadata != nil && image.Metadata.ExposureTime != nil {
 				return *image.Metadata.ExposureTime
@@ -635,6 +712,7 @@ func HandleImageProcessingStatus(c *fiber.Ctx) error {
 		SmallOriginalBytes:     bytesMap[models.VariantTypeThumbnailSmallOrig],
 		SmallWebPBytes:         bytesMap[models.VariantTypeThumbnailSmallWebP],
 		SmallAVIFBytes:         bytesMap[models.VariantTypeThumbnailSmallAVIF],
+		SimilarImages:          nearDuplicateWarning(image, currentUserID),
 	}
 
 	// Render the entire card with the ImageViewer
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/a-h/templ"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/sujit-baniya/flash"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/app/repository"
+	metrics "github.com/ManuelReschke/PixelFox/internal/pkg/metrics/counter"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/viewmodel"
+	"github.com/ManuelReschke/PixelFox/views"
+	user_views "github.com/ManuelReschke/PixelFox/views/user"
+)
+
+const favoritesPerPage = 30
+
+// imageLikeCount returns the persisted like count plus the not yet flushed Redis delta
+func imageLikeCount(image *models.Image) int64 {
+	count := int64(image.LikeCount) + metrics.PendingImageLikes(image.ID)
+	if count < 0 {
+		return 0
+	}
+	return count
+}
+
+// setImageLike likes or unlikes an image and updates the buffered counter if the state changed.
+// Shared by web and API handlers.
+func setImageLike(image *models.Image, userID uint, like bool) error {
+	likeRepo := repository.GetGlobalFactory().GetLikeRepository()
+	var (
+		changed bool
+		err     error
+		delta   int64 = 1
+	)
+	if like {
+		changed, err = likeRepo.Like(userID, image.ID)
+	} else {
+		changed, err = likeRepo.Unlike(userID, image.ID)
+		delta = -1
+	}
+	if err != nil {
+		return err
+	}
+	if changed {
+		if err := metrics.AddImageLike(image.ID, delta); err != nil {
+			log.Printf("failed to buffer like counter for image %d: %v", image.ID, err)
+		}
+	}
+	return nil
+}
+
+// buildLikeButton prepares the like button view model for the current user
+func buildLikeButton(c *fiber.Ctx, image *models.Image) viewmodel.LikeButton {
+	userCtx := usercontext.GetUserContext(c)
+	btn := viewmodel.LikeButton{
+		ImageUUID:  image.UUID,
+		Count:      imageLikeCount(image),
+		IsLoggedIn: userCtx.IsLoggedIn,
+		CSRFToken:  c.Locals("csrf").(string),
+		Compact:    c.Query("compact") == "1",
+	}
+	if userCtx.IsLoggedIn {
+		liked, err := repository.GetGlobalFactory().GetLikeRepository().HasLiked(userCtx.UserID, image.ID)
+		if err != nil {
+			log.Printf("failed to check like of image %d for user %d: %v", image.ID, userCtx.UserID, err)
+		}
+		btn.Liked = liked
+	}
+	return btn
+}
+
+// HandleImageLikeButton renders the like button of an image as HTMX partial
+func HandleImageLikeButton(c *fiber.Ctx) error {
+	image, ok := loadCommentableImage(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+	c.Type("html")
+	return views.LikeButton(buildLikeButton(c, image)).Render(c.Context(), c.Response().BodyWriter())
+}
+
+// HandleImageLikeToggle likes or unlikes an image for the current user
+func HandleImageLikeToggle(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	uuid := c.Params("uuid")
+	image, ok := loadCommentableImage(c)
+	if !ok {
+		return c.Redirect("/")
+	}
+
+	liked, err := repository.GetGlobalFactory().GetLikeRepository().HasLiked(userCtx.UserID, image.ID)
+	if err == nil {
+		err = setImageLike(image, userCtx.UserID, !liked)
+	}
+	if err != nil {
+		log.Printf("failed to toggle like of image %d for user %d: %v", image.ID, userCtx.UserID, err)
+		if isHTMXRequest(c) {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		return flash.WithError(c, fiber.Map{"type": "error", "message": "Favorit konnte nicht gespeichert werden."}).Redirect("/image/" + uuid)
+	}
+
+	if !isHTMXRequest(c) {
+		return c.Redirect("/image/" + uuid)
+	}
+	c.Type("html")
+	return views.LikeButton(buildLikeButton(c, image)).Render(c.Context(), c.Response().BodyWriter())
+}
+
+// HandleUserFavorites lists the images the current user liked
+func HandleUserFavorites(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	likeRepo := repository.GetGlobalFactory().GetLikeRepository()
+	total, err := likeRepo.CountByUserID(userCtx.UserID)
+	if err != nil {
+		flash.WithError(c, fiber.Map{"message": "Fehler beim Laden der Favoriten: " + err.Error()})
+		return c.Redirect("/")
+	}
+	images, err := likeRepo.GetLikedImages(userCtx.UserID, (page-1)*favoritesPerPage, favoritesPerPage)
+	if err != nil {
+		flash.WithError(c, fiber.Map{"message": "Fehler beim Laden der Favoriten: " + err.Error()})
+		return c.Redirect("/")
+	}
+
+	galleryImages := make([]user_views.GalleryImage, 0, len(images))
+	for _, img := range images {
+		galleryImages = append(galleryImages, imageToGalleryImage(img))
+	}
+
+	totalPages := int((total + favoritesPerPage - 1) / favoritesPerPage)
+	favoritesIndex := user_views.FavoritesIndex(galleryImages, total, page, totalPages)
+	favoritesPage := user_views.Favorites(
+		" | Favoriten", isLoggedIn(c), false, flash.Get(c), userCtx.Username, userCtx.Plan, favoritesIndex, userCtx.IsAdmin,
+	)
+	return adaptor.HTTPHandler(templ.Handler(favoritesPage))(c)
+}
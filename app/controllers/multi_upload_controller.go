@@ -70,6 +70,13 @@ func HandleCreateUploadBatch(c *fiber.Ctx) error {
 	if err := cache.Set(key, string(b), 30*time.Minute); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to persist batch"})
 	}
+
+	_ = repository.GetGlobalFactory().GetNotificationRepository().Create(&models.Notification{
+		UserID:  user.UserID,
+		Type:    models.NotificationTypeUploadBatch,
+		Content: fmt.Sprintf("Dein Mehrfach-Upload mit %d Bildern ist abgeschlossen.", len(payload.Items)),
+		Link:    "/user/images",
+	})
 	return c.JSON(fiber.Map{"batch_id": batchID, "expires_at": time.Now().Add(30 * time.Minute).Unix()})
 }
 
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/sujit-baniya/flash"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/app/repository"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/viewmodel"
+	"github.com/ManuelReschke/PixelFox/views"
+)
+
+const (
+	notificationsPerPage  = 20
+	notificationBellItems = 8
+)
+
+// toNotificationViewModels converts notifications for rendering
+func toNotificationViewModels(notifications []models.Notification) []viewmodel.Notification {
+	items := make([]viewmodel.Notification, 0, len(notifications))
+	for _, n := range notifications {
+		items = append(items, viewmodel.Notification{
+			ID:        n.ID,
+			Type:      n.Type,
+			Content:   n.Content,
+			Link:      n.Link,
+			CreatedAt: n.CreatedAt.In(time.Local).Format("02.01.2006 15:04"),
+			IsRead:    n.IsRead,
+		})
+	}
+	return items
+}
+
+// renderNotificationBell renders the bell dropdown for the current user
+func renderNotificationBell(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	notificationRepo := repository.GetGlobalFactory().GetNotificationRepository()
+
+	unread, err := notificationRepo.CountUnread(userCtx.UserID)
+	if err != nil {
+		log.Printf("failed to count notifications of user %d: %v", userCtx.UserID, err)
+	}
+	latest, err := notificationRepo.GetByUserID(userCtx.UserID, 0, notificationBellItems)
+	if err != nil {
+		log.Printf("failed to load notifications of user %d: %v", userCtx.UserID, err)
+	}
+
+	bell := viewmodel.NotificationBell{
+		Unread:    unread,
+		Items:     toNotificationViewModels(latest),
+		CSRFToken: c.Locals("csrf").(string),
+	}
+	c.Type("html")
+	return views.NotificationBell(bell).Render(c.Context(), c.Response().BodyWriter())
+}
+
+// HandleNotificationBell renders the navbar bell as HTMX partial
+func HandleNotificationBell(c *fiber.Ctx) error {
+	return renderNotificationBell(c)
+}
+
+// HandleUserNotifications renders the paginated notification center
+func HandleUserNotifications(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	notificationRepo := repository.GetGlobalFactory().GetNotificationRepository()
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	total, err := notificationRepo.CountByUserID(userCtx.UserID)
+	if err != nil {
+		flash.WithError(c, fiber.Map{"message": "Fehler beim Laden der Benachrichtigungen"})
+		return c.Redirect("/")
+	}
+	unread, _ := notificationRepo.CountUnread(userCtx.UserID)
+	notifications, err := notificationRepo.GetByUserID(userCtx.UserID, (page-1)*notificationsPerPage, notificationsPerPage)
+	if err != nil {
+		flash.WithError(c, fiber.Map{"message": "Fehler beim Laden der Benachrichtigungen"})
+		return c.Redirect("/")
+	}
+
+	list := viewmodel.NotificationList{
+		Items:      toNotificationViewModels(notifications),
+		Total:      total,
+		Unread:     unread,
+		Page:       page,
+		TotalPages: int((total + notificationsPerPage - 1) / notificationsPerPage),
+		CSRFToken:  c.Locals("csrf").(string),
+	}
+	cmp := views.NotificationsIndex(list)
+	home := views.HomeCtx(c, " | Benachrichtigungen", userCtx.IsLoggedIn, false, flash.Get(c), cmp, userCtx.IsAdmin, nil)
+	return adaptor.HTTPHandler(templ.Handler(home))(c)
+}
+
+// HandleNotificationOpen marks a notification as read and follows its link
+func HandleNotificationOpen(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Redirect("/user/notifications")
+	}
+	notificationRepo := repository.GetGlobalFactory().GetNotificationRepository()
+	notification, err := notificationRepo.GetByID(userCtx.UserID, uint(id))
+	if err != nil {
+		return c.Redirect("/user/notifications")
+	}
+	if !notification.IsRead {
+		if err := notificationRepo.MarkAsRead(userCtx.UserID, notification.ID); err != nil {
+			log.Printf("failed to mark notification %d as read: %v", notification.ID, err)
+		}
+	}
+	// Only follow local links
+	if strings.HasPrefix(notification.Link, "/") && !strings.HasPrefix(notification.Link, "//") {
+		return c.Redirect(notification.Link)
+	}
+	return c.Redirect("/user/notifications")
+}
+
+// HandleNotificationRead marks a single notification as read
+func HandleNotificationRead(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	if err := repository.GetGlobalFactory().GetNotificationRepository().MarkAsRead(userCtx.UserID, uint(id)); err != nil {
+		log.Printf("failed to mark notification %d as read: %v", id, err)
+	}
+	if isHTMXRequest(c) {
+		return renderNotificationBell(c)
+	}
+	return c.Redirect("/user/notifications")
+}
+
+// HandleNotificationReadAll marks all notifications of the current user as read
+func HandleNotificationReadAll(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	if err := repository.GetGlobalFactory().GetNotificationRepository().MarkAllAsRead(userCtx.UserID); err != nil {
+		log.Printf("failed to mark notifications of user %d as read: %v", userCtx.UserID, err)
+	}
+	if isHTMXRequest(c) {
+		return renderNotificationBell(c)
+	}
+	return flash.WithSuccess(c, fiber.Map{"type": "success", "message": "Alle Benachrichtigungen als gelesen markiert"}).Redirect("/user/notifications")
+}
@@ -11,8 +11,6 @@ import (
 
 	"github.com/ManuelReschke/PixelFox/app/models"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
-	"github.com/ManuelReschke/PixelFox/internal/pkg/session"
-	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
 )
 
 // HandleOAuthCallback completes the provider flow and logs the user in
@@ -93,30 +91,20 @@ func HandleOAuthCallback(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).SendString(fmt.Sprintf("db error: %v", res.Error))
 	}
 
-	// Create app session
-	sess, err := session.GetSessionStore().Get(c)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).SendString("session init failed")
-	}
-	sess.Set(usercontext.AuthKey, true)
-	sess.Set(usercontext.KeyUserID, appUser.ID)
-	sess.Set(usercontext.KeyUsername, appUser.Name)
-	sess.Set(usercontext.KeyIsAdmin, appUser.Role == "admin")
-	// Cache user plan in session for navbar/entitlements
-	if us, err := models.GetOrCreateUserSettings(db, appUser.ID); err == nil && us != nil {
-		if us.Plan == "" {
-			session.SetSessionValue(c, "user_plan", "free")
-		} else {
-			session.SetSessionValue(c, "user_plan", us.Plan)
+	// Accounts with 2FA have to pass the second factor before the session is created
+	if appUser.HasTwoFactor() {
+		if err := beginTwoFactorLogin(c, appUser.ID); err != nil {
+			return c.Status(fiber.StatusInternalServerError).SendString("session init failed")
 		}
+		c.Set("HX-Redirect", "/login/2fa")
+		return c.Redirect("/login/2fa", fiber.StatusSeeOther)
 	}
-	if err := sess.Save(); err != nil {
+
+	// Create app session
+	if err := startUserSession(c, &appUser, false); err != nil {
 		return c.Status(fiber.StatusInternalServerError).SendString("session save failed")
 	}
 
-	// Update last login timestamp
-	_ = db.Model(&appUser).UpdateColumn("last_login_at", time.Now()).Error
-
 	// Ensure HTMX boosted flows perform a full redirect and refresh head/meta
 	c.Set("HX-Redirect", "/")
 	return c.Redirect("/", fiber.StatusSeeOther)
@@ -3,9 +3,11 @@ package controllers
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/app/repository"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/hcaptcha"
@@ -21,6 +23,9 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// adminReportCommentsLimit caps the number of comments shown on the report detail page
+const adminReportCommentsLimit = 50
+
 const imageAlreadyReportedMessage = "Dieses Bild wurde bereits gemeldet und wird innerhalb von 24 Stunden überprüft."
 
 var errImageAlreadyReported = errors.New("image already has an open report")
@@ -193,9 +198,14 @@ func HandleAdminReportShow(c *fiber.Ctx) error {
 	if err := db.Preload("Image").Preload("Reporter").Preload("ResolvedBy").First(&report, id).Error; err != nil {
 		return c.Redirect("/admin/reports", fiber.StatusSeeOther)
 	}
+	// Comments of the reported image so admins can remove abusive ones directly
+	var comments []models.Comment
+	if report.Image != nil {
+		comments, _ = repository.GetGlobalFactory().GetCommentRepository().GetByImageID(report.Image.ID, 0, adminReportCommentsLimit)
+	}
 	csrfToken := c.Locals("csrf").(string)
 	userCtx := usercontext.GetUserContext(c)
-	cmp := admin_views.AdminReportShow(&report, csrfToken)
+	cmp := admin_views.AdminReportShow(&report, comments, csrfToken)
 	title := fmt.Sprintf(" | Meldung #%d", report.ID)
 	home := views.HomeCtx(c, title, userCtx.IsLoggedIn, false, flash.Get(c), cmp, userCtx.IsAdmin, nil)
 	handler := adaptor.HTTPHandler(templ.Handler(home))
@@ -218,10 +228,27 @@ func HandleAdminReportResolve(c *fiber.Ctx) error {
 	report.ResolvedByID = &uctx.UserID
 	t := time.Now()
 	report.ResolvedAt = &t
-	_ = db.Save(&report).Error
+	if err := db.Save(&report).Error; err == nil {
+		notifyReportResolved(&report)
+	}
 	return c.Redirect("/admin/reports/"+id, fiber.StatusSeeOther)
 }
 
+// notifyReportResolved informs the owner of the reported image
+func notifyReportResolved(report *models.ImageReport) {
+	image, err := repository.GetGlobalFactory().GetImageRepository().GetByID(report.ImageID)
+	if err != nil || image == nil {
+		return
+	}
+	_ = repository.GetGlobalFactory().GetNotificationRepository().Create(&models.Notification{
+		UserID:      image.UserID,
+		Type:        models.NotificationTypeReportResolved,
+		Content:     fmt.Sprintf("Eine Meldung zu deinem Bild \"%s\" wurde bearbeitet.", image.DisplayName()),
+		Link:        "/image/" + image.UUID,
+		ReferenceID: report.ID,
+	})
+}
+
 // ADMIN – dismiss
 func HandleAdminReportDismiss(c *fiber.Ctx) error {
 	db := database.GetDB()
@@ -241,3 +268,29 @@ func HandleAdminReportDismiss(c *fiber.Ctx) error {
 	_ = db.Save(&report).Error
 	return c.Redirect("/admin/reports/"+id, fiber.StatusSeeOther)
 }
+
+// ADMIN – remove a comment of the reported image
+func HandleAdminReportCommentDelete(c *fiber.Ctx) error {
+	db := database.GetDB()
+	id := c.Params("id")
+	var report models.ImageReport
+	if err := db.First(&report, id).Error; err != nil {
+		return c.Redirect("/admin/reports", fiber.StatusSeeOther)
+	}
+	commentID, err := strconv.ParseUint(c.Params("commentID"), 10, 64)
+	if err != nil {
+		return c.Redirect("/admin/reports/"+id, fiber.StatusSeeOther)
+	}
+	commentRepo := repository.GetGlobalFactory().GetCommentRepository()
+	comment, err := commentRepo.GetByID(uint(commentID))
+	if err != nil || comment.ImageID != report.ImageID {
+		flash.WithError(c, fiber.Map{"type": "error", "message": "Kommentar nicht gefunden"})
+		return c.Redirect("/admin/reports/"+id, fiber.StatusSeeOther)
+	}
+	if err := commentRepo.Delete(comment.ID); err != nil {
+		flash.WithError(c, fiber.Map{"type": "error", "message": "Kommentar konnte nicht gelöscht werden"})
+		return c.Redirect("/admin/reports/"+id, fiber.StatusSeeOther)
+	}
+	flash.WithSuccess(c, fiber.Map{"type": "success", "message": "Kommentar gelöscht"})
+	return c.Redirect("/admin/reports/"+id, fiber.StatusSeeOther)
+}
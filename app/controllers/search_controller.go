@@ -0,0 +1,272 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/sujit-baniya/flash"
+
+	"github.com/ManuelReschke/PixelFox/app/repository"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/viewmodel"
+	"github.com/ManuelReschke/PixelFox/views"
+)
+
+const (
+	searchPerPage       = 30
+	apiSearchMaxPerPage = 100
+	searchDateLayout    = "2006-01-02"
+
+	searchTypeImages = "images"
+	searchTypeAlbums = "albums"
+	searchTypeUsers  = "users"
+)
+
+// searchFormats maps the format filter values to the stored file extensions
+var searchFormats = map[string][]string{
+	"jpg":  {".jpg", ".jpeg"},
+	"jpeg": {".jpg", ".jpeg"},
+	"png":  {".png"},
+	"gif":  {".gif"},
+	"webp": {".webp"},
+	"avif": {".avif"},
+	"bmp":  {".bmp"},
+	"heic": {".heic", ".heif"},
+	"tiff": {".tif", ".tiff"},
+	"jxl":  {".jxl"},
+}
+
+// parseSearchFilters reads the search query and filters shared by the search page and the API.
+// Invalid filter values are ignored rather than rejected.
+func parseSearchFilters(c *fiber.Ctx, viewerID uint) (viewmodel.SearchFilters, repository.SearchParams) {
+	filters := viewmodel.SearchFilters{
+		Query:       strings.TrimSpace(c.Query("q")),
+		Type:        c.Query("type", searchTypeImages),
+		Orientation: c.Query("orientation"),
+		Mine:        viewerID > 0 && c.Query("mine") != "" && c.Query("mine") != "0" && c.Query("mine") != "false",
+	}
+	switch filters.Type {
+	case searchTypeImages, searchTypeAlbums, searchTypeUsers:
+	default:
+		filters.Type = searchTypeImages
+	}
+
+	params := repository.SearchParams{
+		Query:    filters.Query,
+		ViewerID: viewerID,
+	}
+	if filters.Mine {
+		params.OwnerID = viewerID
+	} else if owner, err := strconv.ParseUint(c.Query("user"), 10, 64); err == nil && owner > 0 {
+		filters.UserID = uint(owner)
+		params.OwnerID = uint(owner)
+	}
+
+	if from, err := time.ParseInLocation(searchDateLayout, c.Query("from"), time.Local); err == nil {
+		filters.From = from.Format(searchDateLayout)
+		params.From = &from
+	}
+	if to, err := time.ParseInLocation(searchDateLayout, c.Query("to"), time.Local); err == nil {
+		filters.To = to.Format(searchDateLayout)
+		// Inclusive end date: everything before the following day
+		end := to.AddDate(0, 0, 1)
+		params.To = &end
+	}
+
+	// Formats may be passed comma separated (API) or as repeated checkboxes (search form)
+	var formats []string
+	for _, raw := range c.Context().QueryArgs().PeekMulti("format") {
+		formats = append(formats, strings.Split(strings.ToLower(string(raw)), ",")...)
+	}
+	seen := map[string]bool{}
+	for _, value := range formats {
+		value = strings.TrimPrefix(strings.TrimSpace(value), ".")
+		exts, ok := searchFormats[value]
+		if !ok {
+			continue
+		}
+		if value == "jpeg" {
+			value = "jpg"
+		}
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		filters.Formats = append(filters.Formats, value)
+		params.Formats = append(params.Formats, exts...)
+	}
+
+	if w, err := strconv.Atoi(c.Query("min_width")); err == nil && w > 0 {
+		filters.MinWidth = w
+		params.MinWidth = w
+	}
+	if h, err := strconv.Atoi(c.Query("min_height")); err == nil && h > 0 {
+		filters.MinHeight = h
+		params.MinHeight = h
+	}
+	switch filters.Orientation {
+	case repository.OrientationLandscape, repository.OrientationPortrait, repository.OrientationSquare:
+		params.Orientation = filters.Orientation
+	default:
+		filters.Orientation = ""
+	}
+
+	return filters, params
+}
+
+// HandleSearch renders the public search page for images, albums and users
+func HandleSearch(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	filters, params := parseSearchFilters(c, userCtx.UserID)
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	params.Offset = (page - 1) * searchPerPage
+	params.Limit = searchPerPage
+
+	result := viewmodel.SearchResults{Filters: filters, Page: page, IsLoggedIn: userCtx.IsLoggedIn}
+	searchRepo := repository.GetGlobalFactory().GetSearchRepository()
+	var err error
+	switch filters.Type {
+	case searchTypeAlbums:
+		var albums []repository.AlbumSearchResult
+		albums, result.Total, err = searchRepo.SearchAlbums(params)
+		for _, hit := range albums {
+			link := "/a/" + hit.Album.ShareLink
+			if !hit.Album.IsPublic {
+				link = fmt.Sprintf("/user/albums/%d", hit.Album.ID)
+			}
+			result.Albums = append(result.Albums, viewmodel.SearchAlbum{
+				Title:       hit.Album.Title,
+				Description: hit.Album.Description,
+				OwnerName:   hit.Album.User.Name,
+				ImageCount:  hit.ImageCount,
+				URL:         link,
+				IsPublic:    hit.Album.IsPublic,
+			})
+		}
+	case searchTypeUsers:
+		var users []repository.UserSearchResult
+		users, result.Total, err = searchRepo.SearchUsers(params)
+		for _, hit := range users {
+			result.Users = append(result.Users, viewmodel.SearchUser{
+				ID:               hit.User.ID,
+				Name:             hit.User.Name,
+				PublicImageCount: hit.PublicImageCount,
+			})
+		}
+	default:
+		images, total, searchErr := searchRepo.SearchImages(params)
+		result.Total, err = total, searchErr
+		for _, img := range images {
+			gi := imageToGalleryImage(img)
+			result.Images = append(result.Images, viewmodel.SearchImage{
+				UUID:        img.UUID,
+				Title:       gi.Title,
+				PreviewPath: gi.PreviewPath,
+				Width:       img.Width,
+				Height:      img.Height,
+				IsPublic:    img.IsPublic,
+			})
+		}
+	}
+	if err != nil {
+		log.Printf("search failed (type=%s, q=%q): %v", filters.Type, filters.Query, err)
+		result.Error = "Die Suche ist gerade nicht verfügbar. Bitte versuche es später erneut."
+	}
+	result.TotalPages = int((result.Total + searchPerPage - 1) / searchPerPage)
+
+	if isHTMXRequest(c) && c.Get("HX-Target") == "search-results" {
+		c.Type("html")
+		return views.SearchResultsList(result).Render(c.Context(), c.Response().BodyWriter())
+	}
+
+	title := "| Suche"
+	if filters.Query != "" {
+		title = fmt.Sprintf("| Suche: %s", filters.Query)
+	}
+	cmp := views.SearchIndex(result)
+	home := views.HomeCtx(c, title, userCtx.IsLoggedIn, false, flash.Get(c), cmp, userCtx.IsAdmin, nil)
+	return adaptor.HTTPHandler(templ.Handler(home))(c)
+}
+
+// HandleSearchAPI returns ranked search results for images, albums or users.
+// Security: public; a valid API key additionally includes the caller's private content
+func HandleSearchAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	filters, params := parseSearchFilters(c, user.UserID)
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(c.Query("per_page", strconv.Itoa(searchPerPage)))
+	if perPage < 1 || perPage > apiSearchMaxPerPage {
+		perPage = searchPerPage
+	}
+	params.Offset = (page - 1) * perPage
+	params.Limit = perPage
+
+	searchRepo := repository.GetGlobalFactory().GetSearchRepository()
+	items := make([]fiber.Map, 0, perPage)
+	var (
+		total int64
+		err   error
+	)
+	switch filters.Type {
+	case searchTypeAlbums:
+		var albums []repository.AlbumSearchResult
+		albums, total, err = searchRepo.SearchAlbums(params)
+		for _, hit := range albums {
+			item := fiber.Map{
+				"id":          hit.Album.ID,
+				"title":       hit.Album.Title,
+				"description": hit.Album.Description,
+				"is_public":   hit.Album.IsPublic,
+				"image_count": hit.ImageCount,
+				"owner":       fiber.Map{"id": hit.Album.UserID, "name": hit.Album.User.Name},
+				"created_at":  hit.Album.CreatedAt.UTC(),
+			}
+			if hit.Album.IsPublic {
+				item["view_url"] = "/a/" + hit.Album.ShareLink
+			}
+			items = append(items, item)
+		}
+	case searchTypeUsers:
+		var users []repository.UserSearchResult
+		users, total, err = searchRepo.SearchUsers(params)
+		for _, hit := range users {
+			items = append(items, fiber.Map{
+				"id":                 hit.User.ID,
+				"name":               hit.User.Name,
+				"public_image_count": hit.PublicImageCount,
+			})
+		}
+	default:
+		images, count, searchErr := searchRepo.SearchImages(params)
+		total, err = count, searchErr
+		for i := range images {
+			items = append(items, imageAPIPayload(&images[i]))
+		}
+	}
+	if err != nil {
+		log.Printf("api search failed (type=%s, q=%q): %v", filters.Type, filters.Query, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "search failed"})
+	}
+
+	return c.JSON(fiber.Map{
+		"type":     filters.Type,
+		"items":    items,
+		"page":     page,
+		"per_page": perPage,
+		"total":    total,
+	})
+}
@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ManuelReschke/PixelFox/app/repository"
+)
+
+func TestParseSearchFilters(t *testing.T) {
+	app := fiber.New()
+	app.Get("/search", func(c *fiber.Ctx) error {
+		filters, params := parseSearchFilters(c, 7)
+
+		assert.Equal(t, "sunset beach", filters.Query)
+		assert.Equal(t, searchTypeAlbums, filters.Type)
+		assert.Equal(t, []string{"jpg", "png"}, filters.Formats)
+		assert.Equal(t, []string{".jpg", ".jpeg", ".png"}, params.Formats)
+		assert.Equal(t, 1920, params.MinWidth)
+		assert.Zero(t, params.MinHeight)
+		assert.Equal(t, repository.OrientationLandscape, params.Orientation)
+		assert.Equal(t, uint(7), params.ViewerID)
+		assert.Equal(t, uint(7), params.OwnerID)
+		require.NotNil(t, params.From)
+		require.NotNil(t, params.To)
+		assert.Equal(t, "2025-01-01", filters.From)
+		// the end date is inclusive
+		assert.Equal(t, "2025-02-01", params.To.Format(searchDateLayout))
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/search?q=+sunset%20beach+&type=albums&format=jpeg,png&format=jpg,svg&min_width=1920&min_height=-5&orientation=landscape&from=2025-01-01&to=2025-01-31&mine=1", nil)
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+}
+
+func TestParseSearchFiltersIgnoresInvalidValues(t *testing.T) {
+	app := fiber.New()
+	app.Get("/search", func(c *fiber.Ctx) error {
+		filters, params := parseSearchFilters(c, 0)
+
+		assert.Equal(t, searchTypeImages, filters.Type)
+		assert.Empty(t, filters.Orientation)
+		assert.False(t, filters.Mine)
+		assert.Zero(t, params.OwnerID)
+		assert.Nil(t, params.From)
+		assert.Empty(t, params.Formats)
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/search?type=secret&orientation=diagonal&mine=1&from=yesterday&format=svg", nil)
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+}
+
+func TestFulltextBooleanQuery(t *testing.T) {
+	assert.Equal(t, "sun* beach*", repository.FulltextBooleanQuery("  Sun +beach "))
+	assert.Equal(t, "canon* eos*", repository.FulltextBooleanQuery(`"Canon" -EOS*`))
+	assert.Empty(t, repository.FulltextBooleanQuery(" +-*() "))
+}
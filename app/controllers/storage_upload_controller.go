@@ -286,17 +286,18 @@ func HandleStorageDirectUpload(c *fiber.Ctx) error {
 	mimeExt := ext // reuse
 	ipv4, ipv6 := GetClientIP(c)
 	image := models.Image{
-		UUID:          imageUUID,
-		UserID:        claims.UserID,
-		StoragePoolID: pool.ID,
-		FileName:      fileName,
-		FilePath:      filepath.Join("original", relativePath),
-		FileSize:      file.Size,
-		FileType:      mimeExt,
-		Title:         file.Filename,
-		FileHash:      fileHash,
-		IPv4:          ipv4,
-		IPv6:          ipv6,
+		UUID:           imageUUID,
+		UserID:         claims.UserID,
+		StoragePoolID:  pool.ID,
+		FileName:       fileName,
+		FilePath:       filepath.Join("original", relativePath),
+		FileSize:       file.Size,
+		FileType:       mimeExt,
+		Title:          file.Filename,
+		FileHash:       fileHash,
+		MetadataPolicy: uploadMetadataPolicy(c),
+		IPv4:           ipv4,
+		IPv6:           ipv6,
 	}
 	if err := imgRepo.Create(&image); err != nil {
 		// Roll back physical file to avoid orphaned objects/files when DB write fails.
@@ -404,6 +405,16 @@ func buildUploadResponseExtras(img *models.Image) fiber.Map {
 		basePayload["available_variants"] = available
 	}
 
+	// Loading placeholder (computed during processing)
+	if img.BlurHash != "" {
+		basePayload["placeholder"] = fiber.Map{
+			"blurhash":           img.BlurHash,
+			"dominant_color":     img.DominantColor,
+			"average_color":      img.AverageColor,
+			"mostly_transparent": img.IsMostlyTransparent,
+		}
+	}
+
 	// If not actually a duplicate (new upload path), correct the flag
 	// Heuristic: when ShareLink equals computed view_url but request path sets this as new
 	// The caller can override by resetting duplicate=false before returning.
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/a-h/templ"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/sujit-baniya/flash"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/app/repository"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+	"github.com/ManuelReschke/PixelFox/views"
+	user_views "github.com/ManuelReschke/PixelFox/views/user"
+)
+
+const (
+	tagSuggestLimit    = 8
+	tagImagesPerPage   = 30
+	tagSuggestMinInput = 1
+)
+
+// HandleTagSuggest renders autocomplete suggestions for the last tag typed into the edit form
+func HandleTagSuggest(c *fiber.Ctx) error {
+	input := c.Query("tags", c.Query("q", ""))
+	if i := strings.LastIndexAny(input, ",;"); i >= 0 {
+		input = input[i+1:]
+	}
+	prefix := strings.TrimLeft(strings.TrimSpace(strings.ToLower(input)), "#")
+	prefix = strings.Join(strings.Fields(prefix), "-")
+
+	c.Type("html")
+	if len([]rune(prefix)) < tagSuggestMinInput {
+		return c.SendString("")
+	}
+	tags, err := repository.GetGlobalFactory().GetTagRepository().Suggest(prefix, tagSuggestLimit)
+	if err != nil {
+		log.Printf("failed to load tag suggestions for %q: %v", prefix, err)
+		return c.SendString("")
+	}
+	return user_views.TagSuggestions(tags).Render(c.Context(), c.Response().BodyWriter())
+}
+
+// HandleTagGallery renders the public gallery of all public images with a tag
+func HandleTagGallery(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	raw, err := url.PathUnescape(c.Params("name"))
+	if err != nil {
+		return c.Redirect("/")
+	}
+	name := models.NormalizeTagName(raw)
+	if name == "" {
+		return c.Redirect("/")
+	}
+	if name != raw {
+		return c.Redirect("/tag/"+url.PathEscape(name), fiber.StatusMovedPermanently)
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	tagRepo := repository.GetGlobalFactory().GetTagRepository()
+	var (
+		galleryImages []user_views.GalleryImage
+		total         int64
+	)
+	if tag, err := tagRepo.GetByName(name); err == nil {
+		total, err = tagRepo.CountPublicImages(tag.ID)
+		if err != nil {
+			log.Printf("failed to count images of tag %s: %v", name, err)
+		}
+		images, err := tagRepo.GetPublicImages(tag.ID, (page-1)*tagImagesPerPage, tagImagesPerPage)
+		if err != nil {
+			log.Printf("failed to load images of tag %s: %v", name, err)
+		}
+		for _, img := range images {
+			galleryImages = append(galleryImages, imageToGalleryImage(img))
+		}
+	}
+
+	totalPages := int((total + tagImagesPerPage - 1) / tagImagesPerPage)
+	cmp := user_views.TagGallery(name, galleryImages, total, page, totalPages)
+	home := views.HomeCtx(c, fmt.Sprintf("| #%s", name), userCtx.IsLoggedIn, false, flash.Get(c), cmp, userCtx.IsAdmin, nil)
+	return adaptor.HTTPHandler(templ.Handler(home))(c)
+}
@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/a-h/templ"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/sujit-baniya/flash"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/app/repository"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/security"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+	"github.com/ManuelReschke/PixelFox/views"
+	admin_views "github.com/ManuelReschke/PixelFox/views/admin_views"
+)
+
+// transformSecret returns the HMAC secret for transformation URLs; empty disables the feature
+func transformSecret() string {
+	return env.GetEnv("TRANSFORM_URL_SECRET", "")
+}
+
+// signedTransformURL builds the absolute, signed URL of a transformation.
+// The URL points to the node that hosts the image's storage pool. Edited images
+// get their revision appended so caches do not keep serving the old rendition.
+func signedTransformURL(image *models.Image, spec string) string {
+	signature := security.SignTransform(spec, image.ShareLink, transformSecret())
+	path := fmt.Sprintf("/t/%s/%s/%s", signature, spec, image.ShareLink)
+	if image.Revision > 0 {
+		path += "?v=" + strconv.Itoa(image.Revision)
+	}
+	return imageprocessor.MakeAbsoluteForImage(image, path)
+}
+
+// HandleTransform renders (or serves from cache) an allow-listed transformation of an image.
+// Route: GET /t/:signature/:spec/:sharelink
+func HandleTransform(c *fiber.Ctx) error {
+	secret := transformSecret()
+	if secret == "" {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+
+	rawSpec, err := url.PathUnescape(c.Params("spec"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("invalid transformation")
+	}
+	spec, err := imageprocessor.ParseTransformSpec(rawSpec)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("invalid transformation: " + err.Error())
+	}
+	canonical := spec.String()
+	shareLink := c.Params("sharelink")
+	if !security.VerifyTransformSignature(c.Params("signature"), canonical, shareLink, secret) {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	factory := repository.GetGlobalFactory()
+	if _, err := factory.GetTransformRepository().GetPresetBySpec(canonical); err != nil {
+		// Only allow-listed specs are rendered, even with a valid signature
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	image, err := factory.GetImageRepository().GetByShareLink(shareLink)
+	if err != nil || image == nil {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+
+	derived, absPath, err := imageprocessor.GetOrCreateDerivedVariant(image, spec)
+	if err != nil {
+		if errors.Is(err, imageprocessor.ErrTransformUnsupported) {
+			return c.SendStatus(fiber.StatusUnsupportedMediaType)
+		}
+		log.Errorf("[Transform] Failed to render %s for image %s: %v", canonical, image.UUID, err)
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}
+
+	c.Set(fiber.HeaderContentType, derived.ContentType)
+	c.Set(fiber.HeaderCacheControl, "public, max-age=31536000, immutable")
+	return c.SendFile(absPath)
+}
+
+// HandleImageTransformURLsAPI returns signed transformation URLs for all presets of an own image
+// Security: API Key required via router middleware
+func HandleImageTransformURLsAPI(c *fiber.Ctx) error {
+	user := usercontext.GetUserContext(c)
+	if !user.IsLoggedIn {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Missing or invalid authentication"})
+	}
+	image, ok := loadOwnImageAPI(c, user.UserID)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "not_found", "message": "image not found"})
+	}
+	if transformSecret() == "" {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "not_configured", "message": "transformations are not enabled"})
+	}
+
+	presets, err := repository.GetGlobalFactory().GetTransformRepository().ListPresets()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "failed to load presets"})
+	}
+	items := make([]fiber.Map, 0, len(presets))
+	for _, p := range presets {
+		items = append(items, fiber.Map{
+			"name": p.Name,
+			"spec": p.Spec,
+			"url":  signedTransformURL(image, p.Spec),
+		})
+	}
+	return c.JSON(fiber.Map{"image_uuid": image.UUID, "transforms": items})
+}
+
+// ADMIN – list transformation presets and cache usage
+func HandleAdminTransformPresets(c *fiber.Ctx) error {
+	transformRepo := repository.GetGlobalFactory().GetTransformRepository()
+	presets, err := transformRepo.ListPresets()
+	if err != nil {
+		presets = []models.TransformPreset{}
+	}
+	stats, err := transformRepo.CacheStatsByPool()
+	if err != nil {
+		stats = []repository.TransformCacheStats{}
+	}
+
+	csrfToken := c.Locals("csrf").(string)
+	userCtx := usercontext.GetUserContext(c)
+	cmp := admin_views.TransformPresetsPage(presets, stats, transformSecret() != "", models.GetAppSettings().GetTransformCacheMaxMBPerPool(), csrfToken)
+	home := views.HomeCtx(c, " | Transformationen", userCtx.IsLoggedIn, false, flash.Get(c), cmp, userCtx.IsAdmin, nil)
+	handler := adaptor.HTTPHandler(templ.Handler(home))
+	return handler(c)
+}
+
+// ADMIN – add a preset to the allow-list
+func HandleAdminTransformPresetCreate(c *fiber.Ctx) error {
+	name := strings.TrimSpace(c.FormValue("name"))
+	if name == "" || len([]rune(name)) > 100 {
+		fm := fiber.Map{"type": "error", "message": "Bitte einen Namen mit höchstens 100 Zeichen angeben."}
+		return flash.WithError(c, fm).Redirect("/admin/transform-presets")
+	}
+
+	raw := fmt.Sprintf("%sx%s,fit=%s,fmt=%s", strings.TrimSpace(c.FormValue("width", "0")), strings.TrimSpace(c.FormValue("height", "0")), c.FormValue("fit"), c.FormValue("format"))
+	if q := strings.TrimSpace(c.FormValue("quality")); q != "" {
+		raw += ",q=" + q
+	}
+	spec, err := imageprocessor.ParseTransformSpec(raw)
+	if err != nil {
+		fm := fiber.Map{"type": "error", "message": "Ungültige Transformation: " + err.Error()}
+		return flash.WithError(c, fm).Redirect("/admin/transform-presets")
+	}
+
+	preset := &models.TransformPreset{Name: name, Spec: spec.String()}
+	if err := repository.GetGlobalFactory().GetTransformRepository().CreatePreset(preset); err != nil {
+		fm := fiber.Map{"type": "error", "message": "Preset konnte nicht gespeichert werden. Name und Transformation müssen eindeutig sein."}
+		return flash.WithError(c, fm).Redirect("/admin/transform-presets")
+	}
+
+	fm := fiber.Map{"type": "success", "message": fmt.Sprintf("Preset \"%s\" (%s) wurde angelegt.", preset.Name, preset.Spec)}
+	return flash.WithSuccess(c, fm).Redirect("/admin/transform-presets")
+}
+
+// ADMIN – remove a preset from the allow-list
+func HandleAdminTransformPresetDelete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return c.Redirect("/admin/transform-presets", fiber.StatusSeeOther)
+	}
+	if err := repository.GetGlobalFactory().GetTransformRepository().DeletePreset(uint(id)); err != nil {
+		fm := fiber.Map{"type": "error", "message": "Preset konnte nicht gelöscht werden."}
+		return flash.WithError(c, fm).Redirect("/admin/transform-presets")
+	}
+	fm := fiber.Map{"type": "success", "message": "Preset wurde gelöscht."}
+	return flash.WithSuccess(c, fm).Redirect("/admin/transform-presets")
+}
@@ -0,0 +1,354 @@
+package controllers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/skip2/go-qrcode"
+	"github.com/sujit-baniya/flash"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/cache"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/security"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/session"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+	authViews "github.com/ManuelReschke/PixelFox/views/auth"
+	user_views "github.com/ManuelReschke/PixelFox/views/user"
+)
+
+const (
+	// Session keys for a login that still waits for its second factor
+	sessionPending2FAUserID  = "pending_2fa_user_id"
+	sessionPending2FAStarted = "pending_2fa_started"
+	// Session keys used during enrollment
+	sessionPending2FASecret = "pending_2fa_secret"
+	sessionNewRecoveryCodes = "user_new_recovery_codes"
+
+	twoFactorIssuer         = "PixelFox"
+	twoFactorPendingTTL     = 5 * time.Minute
+	twoFactorAttemptsLimit  = 5
+	twoFactorAttemptsWindow = 5 * time.Minute
+)
+
+var totpCodePattern = regexp.MustCompile(`^[0-9]{6}$`)
+
+// beginTwoFactorLogin remembers a password/OAuth-authenticated user that still has to
+// provide the second factor. No app session is created until the code is verified.
+func beginTwoFactorLogin(c *fiber.Ctx, userID uint) error {
+	sess, err := session.GetSessionStore().Get(c)
+	if err != nil {
+		return err
+	}
+	sess.Set(sessionPending2FAUserID, userID)
+	sess.Set(sessionPending2FAStarted, time.Now().Unix())
+	return sess.Save()
+}
+
+// pendingTwoFactorUser returns the user waiting for the second login step, if still valid
+func pendingTwoFactorUser(c *fiber.Ctx) (*models.User, bool) {
+	sess, err := session.GetSessionStore().Get(c)
+	if err != nil {
+		return nil, false
+	}
+	userID, ok := sess.Get(sessionPending2FAUserID).(uint)
+	if !ok || userID == 0 {
+		return nil, false
+	}
+	started, ok := sess.Get(sessionPending2FAStarted).(int64)
+	if !ok || time.Since(time.Unix(started, 0)) > twoFactorPendingTTL {
+		sess.Delete(sessionPending2FAUserID)
+		sess.Delete(sessionPending2FAStarted)
+		_ = sess.Save()
+		return nil, false
+	}
+	var user models.User
+	if err := database.GetDB().First(&user, userID).Error; err != nil {
+		return nil, false
+	}
+	if !user.IsActive() || !user.HasTwoFactor() {
+		return nil, false
+	}
+	return &user, true
+}
+
+// twoFactorRateLimited limits code attempts per user to slow down brute forcing
+func twoFactorRateLimited(userID uint) bool {
+	n, err := cache.IncrWithExpiry(fmt.Sprintf("rate:2fa:user:%d", userID), twoFactorAttemptsWindow)
+	if err != nil {
+		log.Printf("2fa rate limit check failed for user %d: %v", userID, err)
+		return false
+	}
+	return n > twoFactorAttemptsLimit
+}
+
+// verifyTOTP checks a TOTP code and atomically consumes its time step so that a
+// code cannot be replayed within its validity window.
+func verifyTOTP(user *models.User, code string) bool {
+	step, ok := security.ValidateTOTP(user.TwoFactorSecret, code, time.Now())
+	if !ok || step <= user.TwoFactorLastStep {
+		return false
+	}
+	res := database.GetDB().Model(&models.User{}).
+		Where("id = ? AND two_factor_last_step < ?", user.ID, step).
+		UpdateColumn("two_factor_last_step", step)
+	if res.Error != nil || res.RowsAffected == 0 {
+		return false
+	}
+	user.TwoFactorLastStep = step
+	return true
+}
+
+// verifySecondFactor accepts either a TOTP code or an unused recovery code
+func verifySecondFactor(user *models.User, code string) bool {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if code == "" {
+		return false
+	}
+	if totpCodePattern.MatchString(code) {
+		return verifyTOTP(user, code)
+	}
+	used, err := models.UseRecoveryCode(database.GetDB(), user.ID, code)
+	if err != nil {
+		log.Printf("failed to check recovery code for user %d: %v", user.ID, err)
+		return false
+	}
+	return used
+}
+
+// HandleAuthLoginTwoFactor is the second login step for accounts with 2FA enabled
+func HandleAuthLoginTwoFactor(c *fiber.Ctx) error {
+	fm := fiber.Map{
+		"type": "error",
+	}
+
+	user, ok := pendingTwoFactorUser(c)
+	if !ok {
+		fm["message"] = "Die Anmeldung ist abgelaufen. Bitte melde dich erneut an."
+		return flash.WithError(c, fm).Redirect("/login")
+	}
+
+	if c.Method() == fiber.MethodPost {
+		if twoFactorRateLimited(user.ID) {
+			fm["message"] = "Zu viele Versuche. Bitte warte einige Minuten."
+			return flash.WithError(c, fm).Redirect("/login/2fa")
+		}
+
+		if !verifySecondFactor(user, c.FormValue("code")) {
+			fm["message"] = "Der Code ist ungültig"
+			return flash.WithError(c, fm).Redirect("/login/2fa")
+		}
+
+		if err := startUserSession(c, user, true); err != nil {
+			fm["message"] = fmt.Sprintf("something went wrong: %s", err)
+			return flash.WithError(c, fm).Redirect("/login")
+		}
+
+		fm = fiber.Map{
+			"type":    "success",
+			"message": "Glückwunsch du bist drin! Viel Spaß!",
+		}
+		c.Set("HX-Redirect", "/")
+		return flash.WithSuccess(c, fm).Redirect("/")
+	}
+
+	csrfToken := c.Locals("csrf").(string)
+	tindex := authViews.TwoFactorIndex(csrfToken)
+	page := authViews.TwoFactor(
+		" | Zwei-Faktor-Authentifizierung", false, false, flash.Get(c), "", tindex, false,
+	)
+
+	return adaptor.HTTPHandler(templ.Handler(page))(c)
+}
+
+// HandleUserTwoFactor renders the security tab with 2FA enrollment or management
+func HandleUserTwoFactor(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	csrfToken := c.Locals("csrf").(string)
+	db := database.GetDB()
+
+	var user models.User
+	if err := db.First(&user, userCtx.UserID).Error; err != nil {
+		flash.WithError(c, fiber.Map{"message": "Benutzer konnte nicht geladen werden"})
+		return c.Redirect("/user/settings")
+	}
+
+	tf := user_views.TwoFactorSettings{
+		Enabled: user.HasTwoFactor(),
+	}
+	if app := models.GetAppSettings(); app != nil {
+		tf.RequiredForAdmins = userCtx.IsAdmin && app.IsAdmin2FARequired()
+	}
+
+	if codes := session.GetSessionValue(c, sessionNewRecoveryCodes); codes != "" {
+		tf.NewRecoveryCodes = strings.Split(codes, ",")
+		if err := session.SetSessionValue(c, sessionNewRecoveryCodes, ""); err != nil {
+			log.Printf("failed to clear recovery codes session value for user %d: %v", user.ID, err)
+		}
+	}
+
+	if tf.Enabled {
+		tf.EnabledAt = user.TwoFactorEnabledAt.In(time.Local).Format("02.01.2006 15:04")
+		if n, err := models.CountUnusedRecoveryCodes(db, user.ID); err == nil {
+			tf.RemainingCodes = n
+		}
+	} else {
+		// Keep the secret stable across reloads until enrollment is confirmed
+		secret := session.GetSessionValue(c, sessionPending2FASecret)
+		if secret == "" {
+			var err error
+			secret, err = security.GenerateTOTPSecret()
+			if err != nil {
+				log.Printf("failed to generate totp secret for user %d: %v", user.ID, err)
+				flash.WithError(c, fiber.Map{"message": "2FA-Einrichtung konnte nicht gestartet werden"})
+				return c.Redirect("/user/settings")
+			}
+			if err := session.SetSessionValue(c, sessionPending2FASecret, secret); err != nil {
+				log.Printf("failed to stash totp secret in session for user %d: %v", user.ID, err)
+			}
+		}
+		tf.Secret = secret
+		tf.ProvisioningURI = security.TOTPProvisioningURI(twoFactorIssuer, user.Email, secret)
+		if png, err := qrcode.Encode(tf.ProvisioningURI, qrcode.Medium, 256); err == nil {
+			tf.QRCodeDataURI = "data:image/png;base64," + base64.StdEncoding.EncodeToString(png)
+		} else {
+			log.Printf("failed to render totp qr code for user %d: %v", user.ID, err)
+		}
+	}
+
+	index := user_views.TwoFactorIndex(userCtx.Username, csrfToken, tf)
+	page := user_views.Settings(
+		" | Sicherheit", userCtx.IsLoggedIn, false, flash.Get(c), userCtx.Username, userCtx.Plan, index, userCtx.IsAdmin,
+	)
+
+	return adaptor.HTTPHandler(templ.Handler(page))(c)
+}
+
+// HandleUserTwoFactorEnable confirms the pending secret with a TOTP code and enables 2FA
+func HandleUserTwoFactorEnable(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	db := database.GetDB()
+
+	var user models.User
+	if err := db.First(&user, userCtx.UserID).Error; err != nil {
+		flash.WithError(c, fiber.Map{"message": "Benutzer konnte nicht geladen werden"})
+		return c.Redirect("/user/settings/2fa")
+	}
+	if user.HasTwoFactor() {
+		return c.Redirect("/user/settings/2fa")
+	}
+
+	secret := session.GetSessionValue(c, sessionPending2FASecret)
+	if secret == "" {
+		flash.WithError(c, fiber.Map{"message": "Die Einrichtung ist abgelaufen. Bitte erneut versuchen."})
+		return c.Redirect("/user/settings/2fa")
+	}
+	if twoFactorRateLimited(user.ID) {
+		flash.WithError(c, fiber.Map{"message": "Zu viele Versuche. Bitte warte einige Minuten."})
+		return c.Redirect("/user/settings/2fa")
+	}
+	step, ok := security.ValidateTOTP(secret, strings.TrimSpace(c.FormValue("code")), time.Now())
+	if !ok {
+		flash.WithError(c, fiber.Map{"message": "Der Code ist ungültig"})
+		return c.Redirect("/user/settings/2fa")
+	}
+
+	user.EnableTwoFactor(secret, step)
+	if err := db.Model(&user).Select("two_factor_secret", "two_factor_enabled_at", "two_factor_last_step").Updates(&user).Error; err != nil {
+		log.Printf("failed to enable 2fa for user %d: %v", user.ID, err)
+		flash.WithError(c, fiber.Map{"message": "2FA konnte nicht aktiviert werden"})
+		return c.Redirect("/user/settings/2fa")
+	}
+	codes, err := models.RegenerateRecoveryCodes(db, user.ID)
+	if err != nil {
+		log.Printf("failed to create recovery codes for user %d: %v", user.ID, err)
+	}
+
+	sess, err := session.GetSessionStore().Get(c)
+	if err == nil {
+		sess.Delete(sessionPending2FASecret)
+		sess.Set(sessionNewRecoveryCodes, strings.Join(codes, ","))
+		// The current session just proved possession of the authenticator
+		sess.Set(usercontext.KeyTwoFactorVerified, "true")
+		if err := sess.Save(); err != nil {
+			log.Printf("failed to update session after enabling 2fa for user %d: %v", user.ID, err)
+		}
+	}
+
+	flash.WithSuccess(c, fiber.Map{"message": "Zwei-Faktor-Authentifizierung aktiviert. Bitte speichere deine Wiederherstellungscodes."})
+	return c.Redirect("/user/settings/2fa")
+}
+
+// HandleUserTwoFactorDisable turns 2FA off after confirming with a valid code
+func HandleUserTwoFactorDisable(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	db := database.GetDB()
+
+	var user models.User
+	if err := db.First(&user, userCtx.UserID).Error; err != nil || !user.HasTwoFactor() {
+		return c.Redirect("/user/settings/2fa")
+	}
+	if twoFactorRateLimited(user.ID) {
+		flash.WithError(c, fiber.Map{"message": "Zu viele Versuche. Bitte warte einige Minuten."})
+		return c.Redirect("/user/settings/2fa")
+	}
+	if !verifySecondFactor(&user, c.FormValue("code")) {
+		flash.WithError(c, fiber.Map{"message": "Der Code ist ungültig"})
+		return c.Redirect("/user/settings/2fa")
+	}
+
+	user.DisableTwoFactor()
+	if err := db.Model(&user).Select("two_factor_secret", "two_factor_enabled_at", "two_factor_last_step").Updates(&user).Error; err != nil {
+		log.Printf("failed to disable 2fa for user %d: %v", user.ID, err)
+		flash.WithError(c, fiber.Map{"message": "2FA konnte nicht deaktiviert werden"})
+		return c.Redirect("/user/settings/2fa")
+	}
+	if err := models.DeleteRecoveryCodes(db, user.ID); err != nil {
+		log.Printf("failed to delete recovery codes for user %d: %v", user.ID, err)
+	}
+	if err := session.SetSessionValue(c, usercontext.KeyTwoFactorVerified, ""); err != nil {
+		log.Printf("failed to clear 2fa session flag for user %d: %v", user.ID, err)
+	}
+
+	flash.WithSuccess(c, fiber.Map{"message": "Zwei-Faktor-Authentifizierung deaktiviert"})
+	return c.Redirect("/user/settings/2fa")
+}
+
+// HandleUserTwoFactorRecoveryCodes replaces all recovery codes with a fresh set
+func HandleUserTwoFactorRecoveryCodes(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	db := database.GetDB()
+
+	var user models.User
+	if err := db.First(&user, userCtx.UserID).Error; err != nil || !user.HasTwoFactor() {
+		return c.Redirect("/user/settings/2fa")
+	}
+	if twoFactorRateLimited(user.ID) {
+		flash.WithError(c, fiber.Map{"message": "Zu viele Versuche. Bitte warte einige Minuten."})
+		return c.Redirect("/user/settings/2fa")
+	}
+	if !verifyTOTP(&user, strings.TrimSpace(c.FormValue("code"))) {
+		flash.WithError(c, fiber.Map{"message": "Der Code ist ungültig"})
+		return c.Redirect("/user/settings/2fa")
+	}
+
+	codes, err := models.RegenerateRecoveryCodes(db, user.ID)
+	if err != nil {
+		log.Printf("failed to regenerate recovery codes for user %d: %v", user.ID, err)
+		flash.WithError(c, fiber.Map{"message": "Wiederherstellungscodes konnten nicht erstellt werden"})
+		return c.Redirect("/user/settings/2fa")
+	}
+	if err := session.SetSessionValue(c, sessionNewRecoveryCodes, strings.Join(codes, ",")); err != nil {
+		log.Printf("failed to stash recovery codes in session for user %d: %v", user.ID, err)
+	}
+
+	flash.WithSuccess(c, fiber.Map{"message": "Neue Wiederherstellungscodes erstellt"})
+	return c.Redirect("/user/settings/2fa")
+}
@@ -246,17 +246,18 @@ func (w *uploadWorkflow) persistUpload(file *multipart.FileHeader, src multipart
 
 	ipv4, ipv6 := GetClientIP(w.c)
 	image := &models.Image{
-		UUID:          imageUUID,
-		UserID:        w.userCtx.UserID,
-		StoragePoolID: selectedPool.ID,
-		FileName:      fileName,
-		FilePath:      filepath.Join("original", relativePath),
-		FileSize:      file.Size,
-		FileType:      fileExt,
-		Title:         file.Filename,
-		FileHash:      fileHash,
-		IPv4:          ipv4,
-		IPv6:          ipv6,
+		UUID:           imageUUID,
+		UserID:         w.userCtx.UserID,
+		StoragePoolID:  selectedPool.ID,
+		FileName:       fileName,
+		FilePath:       filepath.Join("original", relativePath),
+		FileSize:       file.Size,
+		FileType:       fileExt,
+		Title:          file.Filename,
+		FileHash:       fileHash,
+		MetadataPolicy: uploadMetadataPolicy(w.c),
+		IPv4:           ipv4,
+		IPv6:           ipv6,
 	}
 
 	if err := w.imageRepo.Create(image); err != nil {
@@ -321,6 +322,15 @@ func (w *uploadWorkflow) respondSuccess(fileName, imageUUID string) error {
 	return w.c.Redirect(fmt.Sprintf("/image/%s", imageUUID))
 }
 
+// uploadMetadataPolicy returns the metadata policy chosen for this upload; empty uses the owner's setting
+func uploadMetadataPolicy(c *fiber.Ctx) string {
+	policy := strings.ToLower(strings.TrimSpace(c.FormValue("metadata_policy")))
+	if models.IsValidMetadataPolicy(policy) {
+		return policy
+	}
+	return ""
+}
+
 func isHTMXRequest(c *fiber.Ctx) bool {
 	return c.Get("HX-Request") == "true"
 }
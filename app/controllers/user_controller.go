@@ -1,7 +1,9 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
+	"image"
 	"log"
 	"net/http"
 	"net/http/httptest"
@@ -12,6 +14,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/sujit-baniya/flash"
+	"gorm.io/gorm"
 
 	"github.com/ManuelReschke/PixelFox/app/models"
 	"github.com/ManuelReschke/PixelFox/app/repository"
@@ -107,18 +110,9 @@ func HandleUserSettings(c *fiber.Ctx) error {
 			log.Printf("failed to clear api key session value for user %d: %v", userCtx.UserID, err)
 		}
 	}
-	hasAPIKey := us.HasActiveAPIKey()
-	maskedAPIKey := ""
-	if hasAPIKey && us.APIKeyPrefix != "" {
-		maskedAPIKey = fmt.Sprintf("%s********", us.APIKeyPrefix)
-	}
-	apiKeyCreated := ""
-	if us.APIKeyCreatedAt != nil {
-		apiKeyCreated = us.APIKeyCreatedAt.In(time.Local).Format("02.01.2006 15:04")
-	}
-	apiKeyLastUsed := ""
-	if us.APIKeyLastUsedAt != nil {
-		apiKeyLastUsed = us.APIKeyLastUsedAt.In(time.Local).Format("02.01.2006 15:04")
+	apiKeys, err := repository.GetGlobalFactory().GetAPIKeyRepository().ListByUser(userCtx.UserID)
+	if err != nil {
+		log.Printf("failed to load api keys for user %d: %v", userCtx.UserID, err)
 	}
 	// Compute entitlements against app settings
 	app := models.GetAppSettings()
@@ -130,8 +124,8 @@ func HandleUserSettings(c *fiber.Ctx) error {
 
 	settingsIndex := user_views.SettingsIndex(username, csrfToken, us.Plan,
 		allowedOrig && adminOrig, allowedWebp && adminWebp, allowedAvif && adminAvif,
-		us.PrefThumbOriginal, us.PrefThumbWebP, us.PrefThumbAVIF,
-		newAPIKey, hasAPIKey, maskedAPIKey, apiKeyCreated, apiKeyLastUsed)
+		us.PrefThumbOriginal, us.PrefThumbWebP, us.PrefThumbAVIF, models.NormalizeEmailDigest(us.EmailDigest),
+		models.NormalizeMetadataPolicy(us.MetadataPolicy), canUserWatermark(us), us.Watermark, newAPIKey, apiKeys)
 	settings := user_views.Settings(
 		" | Einstellungen", userCtx.IsLoggedIn, false, flash.Get(c), username, us.Plan, settingsIndex, isAdmin,
 	)
@@ -251,6 +245,8 @@ func HandleUserSettingsPost(c *fiber.Ctx) error {
 	us.PrefThumbOriginal = wantOrig && allowOrig && app.IsThumbnailOriginalEnabled()
 	us.PrefThumbWebP = wantWebp && allowWebp && app.IsThumbnailWebPEnabled()
 	us.PrefThumbAVIF = wantAvif && allowAvif && app.IsThumbnailAVIFEnabled()
+	us.EmailDigest = models.NormalizeEmailDigest(c.FormValue("email_digest"))
+	us.MetadataPolicy = models.NormalizeMetadataPolicy(c.FormValue("metadata_policy"))
 
 	if err := db.Save(us).Error; err != nil {
 		flash.WithError(c, fiber.Map{"message": "Einstellungen speichern fehlgeschlagen"})
@@ -260,25 +256,48 @@ func HandleUserSettingsPost(c *fiber.Ctx) error {
 	return c.Redirect("/user/settings")
 }
 
-// HandleUserAPIKeyGenerate issues or rotates the user's API key.
+// apiKeyExpiryOptions are the selectable lifetimes (in days) of a new API key; 0 never expires
+var apiKeyExpiryOptions = map[int]bool{0: true, 30: true, 90: true, 365: true}
+
+// HandleUserAPIKeyGenerate issues an additional named API key with the selected scopes.
 func HandleUserAPIKeyGenerate(c *fiber.Ctx) error {
 	userCtx := usercontext.GetUserContext(c)
 	if !userCtx.IsLoggedIn {
 		return c.Redirect("/login")
 	}
-	db := database.GetDB()
-	us, err := models.GetOrCreateUserSettings(db, userCtx.UserID)
+	repo := repository.GetGlobalFactory().GetAPIKeyRepository()
+	active, err := repo.CountActiveByUser(userCtx.UserID)
 	if err != nil {
+		log.Printf("failed to count api keys for user %d: %v", userCtx.UserID, err)
 		flash.WithError(c, fiber.Map{"message": "API-Einstellungen konnten nicht geladen werden"})
 		return c.Redirect("/user/settings")
 	}
-	rawKey, err := us.IssueAPIKey()
+	if active >= models.MaxAPIKeysPerUser {
+		flash.WithError(c, fiber.Map{"message": fmt.Sprintf("Du kannst höchstens %d aktive API-Schlüssel besitzen.", models.MaxAPIKeysPerUser)})
+		return c.Redirect("/user/settings")
+	}
+
+	var scopes []string
+	for _, raw := range c.Context().PostArgs().PeekMulti("scopes") {
+		scopes = append(scopes, string(raw))
+	}
+	days, err := strconv.Atoi(c.FormValue("expires_in_days", "0"))
+	if err != nil || !apiKeyExpiryOptions[days] {
+		flash.WithError(c, fiber.Map{"message": "Ungültige Gültigkeitsdauer"})
+		return c.Redirect("/user/settings")
+	}
+	var expiresAt *time.Time
+	if days > 0 {
+		t := time.Now().AddDate(0, 0, days)
+		expiresAt = &t
+	}
+
+	key, rawKey, err := models.NewAPIKey(userCtx.UserID, c.FormValue("name"), scopes, expiresAt)
 	if err != nil {
-		log.Printf("failed to issue api key for user %d: %v", userCtx.UserID, err)
-		flash.WithError(c, fiber.Map{"message": "API-Schlüssel konnte nicht erstellt werden"})
+		flash.WithError(c, fiber.Map{"message": "Bitte gib einen Namen (max. 100 Zeichen) an und wähle mindestens eine Berechtigung."})
 		return c.Redirect("/user/settings")
 	}
-	if err := db.Save(us).Error; err != nil {
+	if err := repo.Create(key); err != nil {
 		log.Printf("failed to persist api key for user %d: %v", userCtx.UserID, err)
 		flash.WithError(c, fiber.Map{"message": "API-Schlüssel konnte nicht gespeichert werden"})
 		return c.Redirect("/user/settings")
@@ -290,35 +309,44 @@ func HandleUserAPIKeyGenerate(c *fiber.Ctx) error {
 	return c.Redirect("/user/settings")
 }
 
-// HandleUserAPIKeyRevoke removes the current API key and marks it as revoked.
+// HandleUserAPIKeyRevoke revokes a single API key of the user.
 func HandleUserAPIKeyRevoke(c *fiber.Ctx) error {
 	userCtx := usercontext.GetUserContext(c)
 	if !userCtx.IsLoggedIn {
 		return c.Redirect("/login")
 	}
-	db := database.GetDB()
-	us, err := models.GetOrCreateUserSettings(db, userCtx.UserID)
+	keyID, err := strconv.ParseUint(c.Params("id"), 10, 64)
 	if err != nil {
-		flash.WithError(c, fiber.Map{"message": "API-Einstellungen konnten nicht geladen werden"})
+		flash.WithError(c, fiber.Map{"message": "Ungültiger API-Schlüssel"})
 		return c.Redirect("/user/settings")
 	}
-	if !us.HasActiveAPIKey() {
-		flash.WithInfo(c, fiber.Map{"message": "Es ist kein aktiver API-Schlüssel vorhanden."})
-		return c.Redirect("/user/settings")
-	}
-	us.RevokeAPIKey()
-	if err := db.Save(us).Error; err != nil {
-		log.Printf("failed to revoke api key for user %d: %v", userCtx.UserID, err)
+	if err := repository.GetGlobalFactory().GetAPIKeyRepository().Revoke(userCtx.UserID, uint(keyID)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			flash.WithInfo(c, fiber.Map{"message": "Dieser API-Schlüssel ist nicht mehr aktiv."})
+			return c.Redirect("/user/settings")
+		}
+		log.Printf("failed to revoke api key %d for user %d: %v", keyID, userCtx.UserID, err)
 		flash.WithError(c, fiber.Map{"message": "API-Schlüssel konnte nicht widerrufen werden"})
 		return c.Redirect("/user/settings")
 	}
 	if err := session.SetSessionValue(c, sessionNewAPIKey, ""); err != nil {
 		log.Printf("failed to clear api key session value for user %d: %v", userCtx.UserID, err)
 	}
-	flash.WithSuccess(c, fiber.Map{"message": "API-Schlüssel wurde entfernt."})
+	flash.WithSuccess(c, fiber.Map{"message": "API-Schlüssel wurde widerrufen."})
 	return c.Redirect("/user/settings")
 }
 
+// userImagesTagFilterLimit is the number of tags offered as quick filter in the gallery
+const userImagesTagFilterLimit = 20
+
+// applyImageTagFilter restricts an image query to images carrying the given normalized tag
+func applyImageTagFilter(query *gorm.DB, tag string) *gorm.DB {
+	if tag == "" {
+		return query
+	}
+	return query.Where("images.id IN (SELECT image_tags.image_id FROM image_tags JOIN tags ON tags.id = image_tags.tag_id WHERE tags.name = ?)", tag)
+}
+
 func HandleUserImages(c *fiber.Ctx) error {
 	userCtx := usercontext.GetUserContext(c)
 	userID := userCtx.UserID
@@ -333,12 +361,16 @@ func HandleUserImages(c *fiber.Ctx) error {
 		}
 	}
 
+	// Optional tag filter
+	selectedTag := models.NormalizeTagName(c.Query("tag", ""))
+
 	// Total count for header
 	var totalCount int64
 	countQuery := database.DB.Model(&models.Image{}).Where("user_id = ?", userID)
 	if selectedYear > 0 {
 		countQuery = countQuery.Where("YEAR(created_at) = ?", selectedYear)
 	}
+	countQuery = applyImageTagFilter(countQuery, selectedTag)
 	countQuery.Count(&totalCount)
 
 	// Initial page: load first 25 items (rest via HTMX)
@@ -347,6 +379,7 @@ func HandleUserImages(c *fiber.Ctx) error {
 	if selectedYear > 0 {
 		query = query.Where("YEAR(created_at) = ?", selectedYear)
 	}
+	query = applyImageTagFilter(query, selectedTag)
 	result := query.Order("created_at DESC").Limit(25).Find(&images)
 	if result.Error != nil {
 		// Fehler beim Laden der Bilder
@@ -387,23 +420,25 @@ func HandleUserImages(c *fiber.Ctx) error {
 		renderHeader := i == 0 || group != prevGroup
 
 		galleryImages = append(galleryImages, user_views.GalleryImage{
-			ID:              img.ID,
-			UUID:            img.UUID,
-			Title:           title,
-			ShareLink:       img.ShareLink,
-			PreviewPath:     previewPath,
-			OriginalPath:    originalPath,
-			CreatedAt:       img.CreatedAt.Format("02.01.2006 15:04"),
-			IsPublic:        img.IsPublic,
-			FileName:        img.FileName,
-			Width:           img.Width,
-			Height:          img.Height,
-			FileSize:        img.FileSize,
-			StorageTier:     storageTier,
-			StorageType:     storageType,
-			StoragePoolName: storagePoolName,
-			GroupLabel:      group,
-			RenderHeader:    renderHeader,
+			ID:               img.ID,
+			UUID:             img.UUID,
+			Title:            title,
+			ShareLink:        img.ShareLink,
+			PreviewPath:      previewPath,
+			OriginalPath:     originalPath,
+			CreatedAt:        img.CreatedAt.Format("02.01.2006 15:04"),
+			IsPublic:         img.IsPublic,
+			FileName:         img.FileName,
+			Width:            img.Width,
+			Height:           img.Height,
+			FileSize:         img.FileSize,
+			StorageTier:      storageTier,
+			StorageType:      storageType,
+			StoragePoolName:  storagePoolName,
+			BlurHash:         img.BlurHash,
+			PlaceholderColor: img.PlaceholderColor(),
+			GroupLabel:       group,
+			RenderHeader:     renderHeader,
 		})
 		prevGroup = group
 	}
@@ -424,7 +459,10 @@ func HandleUserImages(c *fiber.Ctx) error {
 		}
 	}
 
-	imagesGallery := user_views.ImagesGallery(username, groups, int(totalCount), years, selectedYear)
+	// Most used tags of the user for the quick filter
+	userTags, _ := repository.GetGlobalFactory().GetTagRepository().GetByUserID(userID, userImagesTagFilterLimit)
+
+	imagesGallery := user_views.ImagesGallery(username, groups, int(totalCount), years, selectedYear, userTags, selectedTag)
 	imagesPage := user_views.Images(
 		" | Meine Bilder", userCtx.IsLoggedIn, false, flash.Get(c), username, userCtx.Plan, imagesGallery, isAdmin,
 	)
@@ -454,11 +492,14 @@ func HandleLoadMoreImages(c *fiber.Ctx) error {
 		}
 	}
 
+	selectedTag := models.NormalizeTagName(c.Query("tag", ""))
+
 	var images []models.Image
 	query := database.DB.Preload("StoragePool").Where("user_id = ?", userID)
 	if selectedYear > 0 {
 		query = query.Where("YEAR(created_at) = ?", selectedYear)
 	}
+	query = applyImageTagFilter(query, selectedTag)
 	result := query.Order("created_at DESC").Offset(offset).Limit(imagesPerPage).Find(&images)
 	if result.Error != nil {
 		return c.Status(fiber.StatusInternalServerError).SendString("Fehler beim Laden der Bilder")
@@ -490,23 +531,25 @@ func HandleLoadMoreImages(c *fiber.Ctx) error {
 		renderHeader := (i == 0 && group != lastGroup) || (i > 0 && group != prevGroup)
 
 		galleryImages = append(galleryImages, user_views.GalleryImage{
-			ID:              img.ID,
-			UUID:            img.UUID,
-			Title:           title,
-			ShareLink:       img.ShareLink,
-			PreviewPath:     previewPath,
-			OriginalPath:    originalPath,
-			CreatedAt:       img.CreatedAt.Format("02.01.2006 15:04"),
-			IsPublic:        img.IsPublic,
-			FileName:        img.FileName,
-			Width:           img.Width,
-			Height:          img.Height,
-			FileSize:        img.FileSize,
-			StorageTier:     storageTier,
-			StorageType:     storageType,
-			StoragePoolName: storagePoolName,
-			GroupLabel:      group,
-			RenderHeader:    renderHeader,
+			ID:               img.ID,
+			UUID:             img.UUID,
+			Title:            title,
+			ShareLink:        img.ShareLink,
+			PreviewPath:      previewPath,
+			OriginalPath:     originalPath,
+			CreatedAt:        img.CreatedAt.Format("02.01.2006 15:04"),
+			IsPublic:         img.IsPublic,
+			FileName:         img.FileName,
+			Width:            img.Width,
+			Height:           img.Height,
+			FileSize:         img.FileSize,
+			StorageTier:      storageTier,
+			StorageType:      storageType,
+			StoragePoolName:  storagePoolName,
+			BlurHash:         img.BlurHash,
+			PlaceholderColor: img.PlaceholderColor(),
+			GroupLabel:       group,
+			RenderHeader:     renderHeader,
 		})
 		prevGroup = group
 	}
@@ -526,7 +569,7 @@ func HandleLoadMoreImages(c *fiber.Ctx) error {
 		}
 	}
 
-	return user_views.GalleryGroups(groups, page, lastGroup, selectedYear).Render(c.Context(), c.Response().BodyWriter())
+	return user_views.GalleryGroups(groups, page, lastGroup, selectedYear, selectedTag).Render(c.Context(), c.Response().BodyWriter())
 }
 
 // HandleUserImageEdit allows users to edit their own images
@@ -543,10 +586,20 @@ func HandleUserImageEdit(c *fiber.Ctx) error {
 		flash.WithError(c, fiber.Map{"type": "error", "message": "Bild nicht gefunden"})
 		return c.Redirect("/user/images")
 	}
+	return renderUserImageEdit(c, image, flash.Get(c), fiber.StatusOK)
+}
+
+// renderUserImageEdit renders the edit page of an image with the given messages and status
+func renderUserImageEdit(c *fiber.Ctx, image *models.Image, messages fiber.Map, status int) error {
+	userCtx := usercontext.GetUserContext(c)
+	if tags, err := repository.GetGlobalFactory().GetTagRepository().GetByImageID(image.ID); err == nil {
+		image.Tags = tags
+	}
+	similar := findSimilarImages(image, 12)
 	csrfToken := c.Locals("csrf").(string)
-	userEdit := user_views.UserImageEdit(*image, csrfToken)
-	page := views.HomeCtx(c, fmt.Sprintf("| Bild %s bearbeiten", image.Title), userCtx.IsLoggedIn, false, flash.Get(c), userEdit, userCtx.IsAdmin, nil)
-	handler := adaptor.HTTPHandler(templ.Handler(page))
+	userEdit := user_views.UserImageEdit(*image, similar, csrfToken)
+	page := views.HomeCtx(c, fmt.Sprintf("| Bild %s bearbeiten", image.Title), userCtx.IsLoggedIn, false, messages, userEdit, userCtx.IsAdmin, nil)
+	handler := adaptor.HTTPHandler(templ.Handler(page, templ.WithStatus(status)))
 	return handler(c)
 }
 
@@ -567,16 +620,147 @@ func HandleUserImageUpdate(c *fiber.Ctx) error {
 	title := c.FormValue("title")
 	description := c.FormValue("description")
 	isPublic := c.FormValue("is_public") == "on"
+	commentsDisabled := c.FormValue("comments_disabled") == "on"
 
 	image.Title = title
 	image.Description = description
 	image.IsPublic = isPublic
+	image.CommentsDisabled = commentsDisabled
 
 	db.Save(image)
+	if _, err := repository.GetGlobalFactory().GetTagRepository().SetImageTags(image.ID, models.ParseTagList(c.FormValue("tags"))); err != nil {
+		log.Printf("failed to update tags of image %d: %v", image.ID, err)
+		flash.WithError(c, fiber.Map{"type": "error", "message": "Tags konnten nicht gespeichert werden"})
+		return c.Redirect("/user/images/edit/" + uuid)
+	}
 	flash.WithSuccess(c, fiber.Map{"type": "success", "message": "Bild aktualisiert"})
 	return c.Redirect("/user/images")
 }
 
+// parseEditOperations reads the rotate, flip and crop fields of the image edit form.
+// The crop rectangle is optional but has to be given completely.
+func parseEditOperations(c *fiber.Ctx) (imageprocessor.EditOperations, error) {
+	var ops imageprocessor.EditOperations
+	if v := c.FormValue("rotate"); v != "" {
+		rotate, err := strconv.Atoi(v)
+		if err != nil {
+			return ops, fmt.Errorf("ungültige Drehung")
+		}
+		ops.Rotate = rotate
+	}
+	ops.FlipH = c.FormValue("flip_h") == "on"
+	ops.FlipV = c.FormValue("flip_v") == "on"
+
+	fields := []string{"crop_x", "crop_y", "crop_width", "crop_height"}
+	values := make([]int, len(fields))
+	filled := 0
+	for i, field := range fields {
+		v := c.FormValue(field)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return ops, fmt.Errorf("ungültiger Zuschnitt")
+		}
+		values[i] = n
+		filled++
+	}
+	if filled == 0 {
+		return ops, nil
+	}
+	if filled != len(fields) || values[2] == 0 || values[3] == 0 {
+		return ops, fmt.Errorf("für den Zuschnitt werden X, Y, Breite und Höhe benötigt")
+	}
+	ops.Crop = image.Rect(values[0], values[1], values[0]+values[2], values[1]+values[3])
+	return ops, nil
+}
+
+// editErrorMessage turns an error of an image edit into a message for the owner
+func editErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, imageprocessor.ErrEditAnimated):
+		return "Animierte Bilder können nicht bearbeitet werden"
+	case errors.Is(err, imageprocessor.ErrEditUnsupported):
+		return "Dieses Bild kann derzeit nicht bearbeitet werden"
+	case errors.Is(err, imageprocessor.ErrNothingToUndo):
+		return "Es gibt keine Bearbeitung zum Rückgängigmachen"
+	case errors.Is(err, imageprocessor.ErrEditBusy):
+		return "Das Bild wird gerade verarbeitet oder bearbeitet, bitte versuche es gleich noch einmal"
+	default:
+		return "Bearbeitung fehlgeschlagen: " + err.Error()
+	}
+}
+
+// HandleUserImageRevise applies rotate, flip and crop to the original as a new revision
+// and regenerates all variants. UUID, share link, albums and counters stay the same.
+func HandleUserImageRevise(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return c.Redirect("/user/images")
+	}
+	db := database.GetDB()
+	image, err := models.FindImageByUUID(db, uuid)
+	if err != nil || image.UserID != userCtx.UserID {
+		flash.WithError(c, fiber.Map{"type": "error", "message": "Bild nicht gefunden"})
+		return c.Redirect("/user/images")
+	}
+	ops, err := parseEditOperations(c)
+	if err != nil {
+		flash.WithError(c, fiber.Map{"type": "error", "message": err.Error()})
+		return c.Redirect("/user/images/edit/" + uuid)
+	}
+	if ops.IsEmpty() {
+		flash.WithError(c, fiber.Map{"type": "error", "message": "Keine Bearbeitung ausgewählt"})
+		return c.Redirect("/user/images/edit/" + uuid)
+	}
+	if err := imageprocessor.EditImage(image, ops); err != nil {
+		if errors.Is(err, imageprocessor.ErrEditBusy) {
+			return renderUserImageEdit(c, image, fiber.Map{"type": "error", "message": editErrorMessage(err)}, fiber.StatusConflict)
+		}
+		log.Printf("failed to edit image %s: %v", image.UUID, err)
+		flash.WithError(c, fiber.Map{"type": "error", "message": editErrorMessage(err)})
+		return c.Redirect("/user/images/edit/" + uuid)
+	}
+	return reprocessEditedImage(c, image, "Bild bearbeitet, Varianten werden neu erzeugt")
+}
+
+// HandleUserImageRevert restores the original from before the last edit
+func HandleUserImageRevert(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return c.Redirect("/user/images")
+	}
+	db := database.GetDB()
+	image, err := models.FindImageByUUID(db, uuid)
+	if err != nil || image.UserID != userCtx.UserID {
+		flash.WithError(c, fiber.Map{"type": "error", "message": "Bild nicht gefunden"})
+		return c.Redirect("/user/images")
+	}
+	if err := imageprocessor.UndoImageEdit(image); err != nil {
+		if errors.Is(err, imageprocessor.ErrEditBusy) {
+			return renderUserImageEdit(c, image, fiber.Map{"type": "error", "message": editErrorMessage(err)}, fiber.StatusConflict)
+		}
+		log.Printf("failed to undo edit of image %s: %v", image.UUID, err)
+		flash.WithError(c, fiber.Map{"type": "error", "message": editErrorMessage(err)})
+		return c.Redirect("/user/images/edit/" + uuid)
+	}
+	return reprocessEditedImage(c, image, "Letzte Bearbeitung rückgängig gemacht, Varianten werden neu erzeugt")
+}
+
+// reprocessEditedImage re-enqueues the image processing after the original was replaced
+func reprocessEditedImage(c *fiber.Ctx, image *models.Image, message string) error {
+	if err := jobqueue.ProcessImageUnified(image); err != nil {
+		log.Printf("failed to enqueue processing of edited image %s: %v", image.UUID, err)
+		flash.WithError(c, fiber.Map{"type": "error", "message": "Varianten konnten nicht neu erzeugt werden"})
+		return c.Redirect("/user/images/edit/" + image.UUID)
+	}
+	flash.WithSuccess(c, fiber.Map{"type": "success", "message": message})
+	return c.Redirect("/user/images/edit/" + image.UUID)
+}
+
 // HandleUserImageDelete removes user's image and all variants
 func HandleUserImageDelete(c *fiber.Ctx) error {
 	if c.Method() != fiber.MethodPost {
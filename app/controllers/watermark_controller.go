@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sujit-baniya/flash"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/entitlements"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/jobqueue"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+)
+
+// errWatermarkImage is the user-facing error for an invalid watermark upload
+var errWatermarkImage = errors.New("nur PNG-Dateien bis 512 KB und 4096×4096 Pixel")
+
+// parseWatermarkForm reads the watermark inputs of a settings or album form into cfg.
+// A newly uploaded PNG replaces the stored one; without upload the stored image is kept unless removed.
+func parseWatermarkForm(c *fiber.Ctx, cfg *models.WatermarkConfig) error {
+	cfg.Enabled = c.FormValue("watermark_enabled") == "on"
+	cfg.Text = c.FormValue("watermark_text")
+	cfg.Position = c.FormValue("watermark_position")
+	cfg.Opacity, _ = strconv.Atoi(c.FormValue("watermark_opacity"))
+	cfg.Scale, _ = strconv.Atoi(c.FormValue("watermark_scale"))
+	if c.FormValue("watermark_remove_image") == "on" {
+		cfg.Image = nil
+	}
+
+	if fh, err := c.FormFile("watermark_image"); err == nil && fh.Size > 0 {
+		if fh.Size > models.MaxWatermarkImageBytes {
+			return errWatermarkImage
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return errWatermarkImage
+		}
+		defer f.Close()
+		data, err := io.ReadAll(io.LimitReader(f, models.MaxWatermarkImageBytes+1))
+		if err != nil || imageprocessor.ValidateWatermarkImage(data) != nil {
+			return errWatermarkImage
+		}
+		cfg.Image = data
+	}
+
+	cfg.Normalize()
+	if cfg.Enabled && !cfg.HasMark() {
+		return errors.New("bitte gib einen Text ein oder lade ein PNG hoch")
+	}
+	return nil
+}
+
+// watermarkColumns maps a config to the columns of its embedding, zero values included
+func watermarkColumns(prefix string, cfg models.WatermarkConfig) map[string]interface{} {
+	return map[string]interface{}{
+		prefix + "enabled":  cfg.Enabled,
+		prefix + "text":     cfg.Text,
+		prefix + "image":    cfg.Image,
+		prefix + "position": cfg.Position,
+		prefix + "opacity":  cfg.Opacity,
+		prefix + "scale":    cfg.Scale,
+	}
+}
+
+// canUserWatermark reports whether the plan of a user includes watermarks
+func canUserWatermark(us *models.UserSettings) bool {
+	return us != nil && entitlements.CanWatermark(entitlements.Plan(strings.ToLower(us.Plan)))
+}
+
+// HandleUserWatermarkSettings stores the default watermark of the user and re-renders all images
+func HandleUserWatermarkSettings(c *fiber.Ctx) error {
+	userCtx := usercontext.GetUserContext(c)
+	if !userCtx.IsLoggedIn {
+		return c.Redirect("/login")
+	}
+	db := database.GetDB()
+	us, err := models.GetOrCreateUserSettings(db, userCtx.UserID)
+	if err != nil {
+		flash.WithError(c, fiber.Map{"message": "Einstellungen konnten nicht geladen werden"})
+		return c.Redirect("/user/settings")
+	}
+	if !canUserWatermark(us) {
+		flash.WithError(c, fiber.Map{"message": "Wasserzeichen sind in deinem Paket nicht enthalten."})
+		return c.Redirect("/user/settings")
+	}
+
+	before := us.Watermark
+	if err := parseWatermarkForm(c, &us.Watermark); err != nil {
+		flash.WithError(c, fiber.Map{"message": "Wasserzeichen ungültig: " + err.Error()})
+		return c.Redirect("/user/settings")
+	}
+	if err := db.Model(us).Updates(watermarkColumns("watermark_", us.Watermark)).Error; err != nil {
+		log.Printf("failed to save watermark of user %d: %v", userCtx.UserID, err)
+		flash.WithError(c, fiber.Map{"message": "Wasserzeichen speichern fehlgeschlagen"})
+		return c.Redirect("/user/settings")
+	}
+
+	if !before.Equal(us.Watermark) {
+		if _, err := jobqueue.GetManager().GetQueue().EnqueueWatermarkRerender(userCtx.UserID, 0); err != nil {
+			log.Printf("failed to enqueue watermark rerender for user %d: %v", userCtx.UserID, err)
+		}
+		flash.WithSuccess(c, fiber.Map{"message": "Wasserzeichen gespeichert. Deine Bilder werden im Hintergrund neu erstellt."})
+		return c.Redirect("/user/settings")
+	}
+	flash.WithSuccess(c, fiber.Map{"message": "Wasserzeichen gespeichert"})
+	return c.Redirect("/user/settings")
+}
+
+// rerenderAlbumWatermarks re-renders images that joined or left an album with a watermark override
+func rerenderAlbumWatermarks(album *models.Album, imageIDs []uint) {
+	if album == nil || !album.WatermarkOverride || len(imageIDs) == 0 {
+		return
+	}
+	jobqueue.GetManager().GetQueue().EnqueueImagesWatermarkRerender(imageIDs)
+}
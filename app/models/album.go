@@ -8,19 +8,21 @@ import (
 )
 
 type Album struct {
-	ID           uint           `gorm:"primaryKey" json:"id"`
-	UserID       uint           `gorm:"index" json:"user_id"`
-	User         User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Title        string         `gorm:"type:varchar(255);not null" json:"title" validate:"required,min=3,max=255"`
-	Description  string         `gorm:"type:text" json:"description"`
-	CoverImageID uint           `json:"cover_image_id"`
-	IsPublic     bool           `gorm:"default:false" json:"is_public"`
-	ShareLink    string         `gorm:"type:char(36) CHARACTER SET utf8 COLLATE utf8_bin;uniqueIndex;not null" json:"share_link"`
-	ViewCount    int            `gorm:"default:0" json:"view_count"`
-	Images       []Image        `gorm:"many2many:album_images;" json:"images,omitempty"`
-	CreatedAt    time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt    time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                uint            `gorm:"primaryKey" json:"id"`
+	UserID            uint            `gorm:"index" json:"user_id"`
+	User              User            `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Title             string          `gorm:"type:varchar(255);not null" json:"title" validate:"required,min=3,max=255"`
+	Description       string          `gorm:"type:text" json:"description"`
+	CoverImageID      uint            `json:"cover_image_id"`
+	IsPublic          bool            `gorm:"default:false" json:"is_public"`
+	ShareLink         string          `gorm:"type:char(36) CHARACTER SET utf8 COLLATE utf8_bin;uniqueIndex;not null" json:"share_link"`
+	ViewCount         int             `gorm:"default:0" json:"view_count"`
+	WatermarkOverride bool            `gorm:"not null;default:false" json:"watermark_override"` // use Watermark instead of the owner's setting
+	Watermark         WatermarkConfig `gorm:"embedded;embeddedPrefix:watermark_" json:"watermark"`
+	Images            []Image         `gorm:"many2many:album_images;" json:"images,omitempty"`
+	CreatedAt         time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt         gorm.DeletedAt  `gorm:"index" json:"-"`
 }
 
 // IncrementViewCount erhöht den Zähler für Aufrufe
@@ -36,7 +38,7 @@ func (a *Album) TogglePublic(db *gorm.DB) error {
 
 // AddImage fügt ein Bild zum Album hinzu
 func (a *Album) AddImage(db *gorm.DB, imageID uint) error {
-	return db.Exec("INSERT INTO album_images (album_id, image_id) VALUES (?, ?)", a.ID, imageID).Error
+	return db.Create(&AlbumImage{AlbumID: a.ID, ImageID: imageID}).Error
 }
 
 // RemoveImage entfernt ein Bild aus dem Album
@@ -1,9 +1,65 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type AlbumImage struct {
 	AlbumID   uint      `gorm:"primaryKey;autoIncrement:false" json:"album_id"`
 	ImageID   uint      `gorm:"primaryKey;autoIncrement:false" json:"image_id"`
+	Position  int       `gorm:"not null;default:0" json:"position"` // Sortierung innerhalb des Albums (aufsteigend)
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 }
+
+// BeforeCreate hängt neue Bilder ans Ende des Albums an, wenn keine Position gesetzt ist
+func (ai *AlbumImage) BeforeCreate(tx *gorm.DB) error {
+	if ai.Position != 0 {
+		return nil
+	}
+	maxPosition, err := MaxAlbumImagePosition(tx, ai.AlbumID)
+	if err != nil {
+		return err
+	}
+	ai.Position = maxPosition + 1
+	return nil
+}
+
+// MaxAlbumImagePosition liefert die höchste Position im Album (0 bei einem leeren Album).
+// Beim Hinzufügen mehrerer Bilder wird sie einmal gelesen und die Positionen werden fortgezählt.
+func MaxAlbumImagePosition(tx *gorm.DB, albumID uint) (int, error) {
+	var maxPosition int
+	err := tx.Model(&AlbumImage{}).Where("album_id = ?", albumID).
+		Select("COALESCE(MAX(position), 0)").Scan(&maxPosition).Error
+	return maxPosition, err
+}
+
+// BackfillAlbumImagePositions nummeriert Alben durch, die noch Einträge ohne Position (0) aus der
+// Zeit vor der Sortierung enthalten. Die bisherige Reihenfolge (Position, Hinzufügedatum) bleibt erhalten.
+func BackfillAlbumImagePositions(db *gorm.DB) error {
+	var albumIDs []uint
+	if err := db.Model(&AlbumImage{}).Where("position = 0").Distinct().Pluck("album_id", &albumIDs).Error; err != nil {
+		return err
+	}
+	for _, albumID := range albumIDs {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var imageIDs []uint
+			if err := tx.Model(&AlbumImage{}).Where("album_id = ?", albumID).
+				Order("position ASC, created_at ASC, image_id ASC").Pluck("image_id", &imageIDs).Error; err != nil {
+				return err
+			}
+			for i, imageID := range imageIDs {
+				if err := tx.Model(&AlbumImage{}).Where("album_id = ? AND image_id = ?", albumID, imageID).
+					Update("position", i+1).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
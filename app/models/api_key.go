@@ -0,0 +1,184 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// API key scopes limit what an integration may do with a key
+const (
+	APIScopeImagesRead  = "images:read"
+	APIScopeImagesWrite = "images:write"
+	APIScopeAlbumsWrite = "albums:write"
+	APIScopeUpload      = "upload"
+)
+
+// AllAPIKeyScopes lists every scope in display order
+var AllAPIKeyScopes = []string{APIScopeImagesRead, APIScopeImagesWrite, APIScopeAlbumsWrite, APIScopeUpload}
+
+// MaxAPIKeysPerUser caps the number of active keys per account
+const MaxAPIKeysPerUser = 25
+
+var apiKeyEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+const apiKeyPrefix = "pxl_"
+
+// APIKey is a named, scoped API key of a user. Only the SHA-256 hash of the secret is stored.
+type APIKey struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"index;not null" json:"user_id"`
+	Name       string     `gorm:"type:varchar(100);not null" json:"name"`
+	Prefix     string     `gorm:"type:varchar(20);not null" json:"prefix"`
+	KeyHash    string     `gorm:"type:char(64);uniqueIndex;not null" json:"-"`
+	Scopes     string     `gorm:"type:varchar(255);not null" json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP string     `gorm:"type:varchar(45);default:''" json:"last_used_ip,omitempty"`
+	RevokedAt  *time.Time `gorm:"index" json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// NewAPIKey generates a key for the user and returns it together with the raw secret.
+// The raw secret is only available here; callers must persist the returned record.
+func NewAPIKey(userID uint, name string, scopes []string, expiresAt *time.Time) (*APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", fmt.Errorf("api key name is required")
+	}
+	if len([]rune(name)) > 100 {
+		return nil, "", fmt.Errorf("api key name is too long")
+	}
+	normalized := NormalizeAPIKeyScopes(scopes)
+	if len(normalized) == 0 {
+		return nil, "", fmt.Errorf("api key needs at least one scope")
+	}
+	rawKey, prefix, hash, err := generateAPIKeyMaterial()
+	if err != nil {
+		return nil, "", err
+	}
+	key := &APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    strings.Join(normalized, ","),
+		ExpiresAt: expiresAt,
+	}
+	return key, rawKey, nil
+}
+
+// NormalizeAPIKeyScopes drops unknown and duplicate scopes and returns them in display order
+func NormalizeAPIKeyScopes(scopes []string) []string {
+	wanted := make(map[string]bool, len(scopes))
+	for _, s := range scopes {
+		for _, part := range strings.Split(s, ",") {
+			wanted[strings.ToLower(strings.TrimSpace(part))] = true
+		}
+	}
+	out := make([]string, 0, len(AllAPIKeyScopes))
+	for _, s := range AllAPIKeyScopes {
+		if wanted[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// ScopeList returns the scopes granted to the key
+func (k *APIKey) ScopeList() []string {
+	return NormalizeAPIKeyScopes([]string{k.Scopes})
+}
+
+// HasScope reports whether the key grants the given scope
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired reports whether the key has passed its expiry date
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// IsActive reports whether the key may be used for authentication
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && !k.IsExpired(now)
+}
+
+// MaskedKey returns the public prefix followed by a mask for display
+func (k *APIKey) MaskedKey() string {
+	return k.Prefix + "********"
+}
+
+// MigrateLegacyAPIKeys moves the single API key formerly stored in user settings into the
+// api_keys table with all scopes, so existing integrations keep working.
+func MigrateLegacyAPIKeys(db *gorm.DB) error {
+	var legacy []UserSettings
+	if err := db.Where("api_key_hash <> '' AND api_key_revoked_at IS NULL").Find(&legacy).Error; err != nil {
+		return err
+	}
+	for _, us := range legacy {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var exists int64
+			if err := tx.Model(&APIKey{}).Where("key_hash = ?", us.APIKeyHash).Count(&exists).Error; err != nil {
+				return err
+			}
+			if exists == 0 {
+				key := APIKey{
+					UserID:     us.UserID,
+					Name:       "Standard",
+					Prefix:     us.APIKeyPrefix,
+					KeyHash:    us.APIKeyHash,
+					Scopes:     strings.Join(AllAPIKeyScopes, ","),
+					LastUsedAt: us.APIKeyLastUsedAt,
+				}
+				if us.APIKeyCreatedAt != nil {
+					key.CreatedAt = *us.APIKeyCreatedAt
+				}
+				if err := tx.Create(&key).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Model(&UserSettings{}).Where("id = ?", us.ID).
+				Updates(map[string]any{"api_key_hash": "", "api_key_prefix": ""}).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HashAPIKey returns the SHA-256 hash for the provided API key.
+func HashAPIKey(raw string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(raw)))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateAPIKeyMaterial() (string, string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	encoded := apiKeyEncoding.EncodeToString(b)
+	encoded = strings.ToLower(encoded)
+	rawKey := apiKeyPrefix + encoded
+	if len(rawKey) < 12 {
+		return "", "", "", fmt.Errorf("api key generation failed: key too short")
+	}
+	prefix := rawKey[:min(len(rawKey), 16)]
+	hash := HashAPIKey(rawKey)
+	return rawKey, prefix, hash, nil
+}
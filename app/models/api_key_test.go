@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	key, raw, err := NewAPIKey(1, "  CI Upload ", []string{"upload", "images:read,unknown", "upload"}, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, raw)
+
+	assert.Equal(t, "CI Upload", key.Name)
+	assert.Equal(t, HashAPIKey(raw), key.KeyHash)
+	assert.Equal(t, raw[:16], key.Prefix)
+	assert.Equal(t, "images:read,upload", key.Scopes)
+	assert.True(t, key.HasScope(APIScopeUpload))
+	assert.False(t, key.HasScope(APIScopeAlbumsWrite))
+	assert.True(t, key.IsActive(time.Now()))
+
+	_, _, err = NewAPIKey(1, "", []string{APIScopeUpload}, nil)
+	assert.Error(t, err)
+	_, _, err = NewAPIKey(1, "empty", []string{"admin"}, nil)
+	assert.Error(t, err)
+}
+
+func TestAPIKeyIsActive(t *testing.T) {
+	now := time.Now()
+	expires := now.Add(time.Hour)
+	key := &APIKey{ExpiresAt: &expires}
+
+	assert.True(t, key.IsActive(now))
+	assert.False(t, key.IsActive(expires))
+
+	key.ExpiresAt = nil
+	key.RevokedAt = &now
+	assert.False(t, key.IsActive(now))
+}
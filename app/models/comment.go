@@ -1,11 +1,22 @@
 package models
 
 import (
+	"errors"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"gorm.io/gorm"
 )
 
+// CommentMaxLength is the maximum number of characters allowed in a comment
+const CommentMaxLength = 1000
+
+var (
+	ErrCommentEmpty   = errors.New("comment is empty")
+	ErrCommentTooLong = errors.New("comment is too long")
+)
+
 type Comment struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
 	UserID    uint           `gorm:"index" json:"user_id"`
@@ -17,3 +28,24 @@ type Comment struct {
 	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
+
+// NormalizeCommentContent trims the content and validates its length
+func NormalizeCommentContent(content string) (string, error) {
+	content = strings.TrimSpace(strings.ReplaceAll(content, "\r\n", "\n"))
+	if content == "" {
+		return "", ErrCommentEmpty
+	}
+	if utf8.RuneCountInString(content) > CommentMaxLength {
+		return "", ErrCommentTooLong
+	}
+	return content, nil
+}
+
+// CanBeDeletedBy reports whether the given user may delete the comment.
+// Authors can delete their own comments, image owners every comment on their image.
+func (c *Comment) CanBeDeletedBy(userID, imageOwnerID uint, isAdmin bool) bool {
+	if userID == 0 {
+		return false
+	}
+	return isAdmin || c.UserID == userID || imageOwnerID == userID
+}
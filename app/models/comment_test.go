@@ -0,0 +1,32 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeCommentContent(t *testing.T) {
+	content, err := NormalizeCommentContent("  Schönes Bild!\r\n ")
+	assert.NoError(t, err)
+	assert.Equal(t, "Schönes Bild!", content)
+
+	_, err = NormalizeCommentContent("   ")
+	assert.ErrorIs(t, err, ErrCommentEmpty)
+
+	_, err = NormalizeCommentContent(strings.Repeat("ä", CommentMaxLength))
+	assert.NoError(t, err)
+
+	_, err = NormalizeCommentContent(strings.Repeat("a", CommentMaxLength+1))
+	assert.ErrorIs(t, err, ErrCommentTooLong)
+}
+
+func TestCommentCanBeDeletedBy(t *testing.T) {
+	c := &Comment{UserID: 2}
+	assert.True(t, c.CanBeDeletedBy(2, 5, false))
+	assert.True(t, c.CanBeDeletedBy(5, 5, false))
+	assert.True(t, c.CanBeDeletedBy(9, 5, true))
+	assert.False(t, c.CanBeDeletedBy(9, 5, false))
+	assert.False(t, c.CanBeDeletedBy(0, 0, false))
+}
@@ -12,29 +12,46 @@ import (
 )
 
 type Image struct {
-	ID             uint         `gorm:"primaryKey" json:"id"`
-	UUID           string       `gorm:"type:char(36) CHARACTER SET utf8 COLLATE utf8_bin;uniqueIndex;not null" json:"uuid"`
-	UserID         uint         `gorm:"index;index:idx_user_file_hash,priority:1;uniqueIndex:ux_images_user_active_file_hash,priority:1" json:"user_id"`
-	User           User         `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Title          string       `gorm:"type:varchar(255)" json:"title"`
-	Description    string       `gorm:"type:text" json:"description"`
-	FilePath       string       `gorm:"type:varchar(255);not null" json:"file_path"`
-	FileName       string       `gorm:"type:varchar(255);not null" json:"file_name"`
-	FileSize       int64        `gorm:"type:bigint" json:"file_size"`
-	FileType       string       `gorm:"type:varchar(50)" json:"file_type"`
-	Width          int          `gorm:"type:int" json:"width"`
-	Height         int          `gorm:"type:int" json:"height"`
-	ShareLink      string       `gorm:"type:varchar(16) CHARACTER SET utf8 COLLATE utf8_bin;uniqueIndex" json:"share_link"`
-	IsPublic       bool         `gorm:"default:false" json:"is_public"`
-	ViewCount      int          `gorm:"default:0" json:"view_count"`
-	DownloadCount  int          `gorm:"default:0" json:"download_count"`
-	LastViewedAt   *time.Time   `gorm:"index" json:"last_viewed_at,omitempty"`
-	IPv4           string       `gorm:"type:varchar(15);default:null" json:"-"`                                                    // IPv4 address of the uploader
-	IPv6           string       `gorm:"type:varchar(45);default:null" json:"-"`                                                    // IPv6 address of the uploader
-	FileHash       string       `gorm:"type:varchar(64);not null;default:'';index:idx_user_file_hash,priority:2" json:"file_hash"` // SHA-256 hash for duplicate detection
-	ActiveFileHash string       `gorm:"->;type:varchar(64) GENERATED ALWAYS AS (CASE WHEN deleted_at IS NULL THEN file_hash ELSE NULL END) STORED;default:(-);uniqueIndex:ux_images_user_active_file_hash,priority:2" json:"-"`
-	StoragePoolID  uint         `gorm:"index;default:null" json:"storage_pool_id"` // Reference to storage pool
-	StoragePool    *StoragePool `gorm:"foreignKey:StoragePoolID" json:"storage_pool,omitempty"`
+	ID                  uint         `gorm:"primaryKey" json:"id"`
+	UUID                string       `gorm:"type:char(36) CHARACTER SET utf8 COLLATE utf8_bin;uniqueIndex;not null" json:"uuid"`
+	UserID              uint         `gorm:"index;index:idx_user_file_hash,priority:1;uniqueIndex:ux_images_user_active_file_hash,priority:1" json:"user_id"`
+	User                User         `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Title               string       `gorm:"type:varchar(255)" json:"title"`
+	Description         string       `gorm:"type:text" json:"description"`
+	FilePath            string       `gorm:"type:varchar(255);not null" json:"file_path"`
+	FileName            string       `gorm:"type:varchar(255);not null" json:"file_name"`
+	FileSize            int64        `gorm:"type:bigint" json:"file_size"`
+	FileType            string       `gorm:"type:varchar(50)" json:"file_type"`
+	Width               int          `gorm:"type:int" json:"width"`
+	Height              int          `gorm:"type:int" json:"height"`
+	FrameCount          int          `gorm:"type:int;not null;default:1" json:"frame_count"`              // > 1 for animated GIF/WebP
+	DurationMs          int          `gorm:"type:int;not null;default:0" json:"duration_ms"`              // total animation duration
+	MetadataPolicy      string       `gorm:"type:varchar(20);not null;default:''" json:"metadata_policy"` // chosen at upload, empty = owner's setting
+	Revision            int          `gorm:"type:int;not null;default:0" json:"revision"`                 // incremented by every server-side edit
+	PreviousFileName    string       `gorm:"type:varchar(255);not null;default:''" json:"-"`              // original before the last edit, kept for undo
+	PreviousFileType    string       `gorm:"type:varchar(50);not null;default:''" json:"-"`
+	PreviousFileSize    int64        `gorm:"type:bigint;not null;default:0" json:"-"`
+	ShareLink           string       `gorm:"type:varchar(16) CHARACTER SET utf8 COLLATE utf8_bin;uniqueIndex" json:"share_link"`
+	IsPublic            bool         `gorm:"default:false" json:"is_public"`
+	CommentsDisabled    bool         `gorm:"default:false" json:"comments_disabled"` // Owner switch to turn comments off for this image
+	ViewCount           int          `gorm:"default:0" json:"view_count"`
+	DownloadCount       int          `gorm:"default:0" json:"download_count"`
+	LikeCount           int          `gorm:"default:0" json:"like_count"`
+	LastViewedAt        *time.Time   `gorm:"index" json:"last_viewed_at,omitempty"`
+	IPv4                string       `gorm:"type:varchar(15);default:null" json:"-"`                                                    // IPv4 address of the uploader
+	IPv6                string       `gorm:"type:varchar(45);default:null" json:"-"`                                                    // IPv6 address of the uploader
+	FileHash            string       `gorm:"type:varchar(64);not null;default:'';index:idx_user_file_hash,priority:2" json:"file_hash"` // SHA-256 hash for duplicate detection
+	ContentHash         string       `gorm:"type:varchar(64);not null;default:''" json:"-"`                                             // SHA-256 of the stored original once PixelFox rewrote it, empty = FileHash
+	PerceptualHash      *uint64      `gorm:"type:bigint unsigned;default:null" json:"perceptual_hash,omitempty"`                        // dHash for near-duplicate detection, nil = not computed yet
+	BlurHash            string       `gorm:"type:varchar(64);not null;default:''" json:"blur_hash"`                                     // placeholder while the image loads, empty = not computed yet
+	DominantColor       string       `gorm:"type:varchar(7);not null;default:''" json:"dominant_color"`                                 // #rrggbb
+	AverageColor        string       `gorm:"type:varchar(7);not null;default:''" json:"average_color"`                                  // #rrggbb
+	IsMostlyTransparent bool         `gorm:"not null;default:false" json:"is_mostly_transparent"`
+	IsWatermarked       bool         `gorm:"not null;default:false" json:"is_watermarked"` // served variants carry a watermark, the original is owner-only
+	BytesSaved          int64        `gorm:"not null;default:0" json:"bytes_saved"`        // saved by the optimisation pass on the original and its variants
+	ActiveFileHash      string       `gorm:"->;type:varchar(64) GENERATED ALWAYS AS (CASE WHEN deleted_at IS NULL THEN file_hash ELSE NULL END) STORED;default:(-);uniqueIndex:ux_images_user_active_file_hash,priority:2" json:"-"`
+	StoragePoolID       uint         `gorm:"index;default:null" json:"storage_pool_id"` // Reference to storage pool
+	StoragePool         *StoragePool `gorm:"foreignKey:StoragePoolID" json:"storage_pool,omitempty"`
 	// relations
 	Metadata  *ImageMetadata `gorm:"foreignKey:ImageID" json:"metadata,omitempty"`
 	Tags      []Tag          `gorm:"many2many:image_tags;" json:"tags,omitempty"`
@@ -46,6 +63,41 @@ type Image struct {
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
+// PlaceholderColor returns the color shown behind the image while it loads.
+// Mostly transparent images get none so the page background shows through.
+func (i *Image) PlaceholderColor() string {
+	if i.IsMostlyTransparent {
+		return ""
+	}
+	if i.DominantColor != "" {
+		return i.DominantColor
+	}
+	return i.AverageColor
+}
+
+// VariantBaseName returns the file name prefix of the image's variants. Edited images carry their
+// revision in it so CDNs and browsers fetch the new renditions instead of serving cached ones.
+func (i *Image) VariantBaseName() string {
+	if i.Revision <= 0 {
+		return i.UUID
+	}
+	return fmt.Sprintf("%s_r%d", i.UUID, i.Revision)
+}
+
+// StoredFileHash returns the SHA-256 the stored original is expected to have. Metadata scrubs,
+// optimisation and edits rewrite the original, so FileHash only matches until then.
+func (i *Image) StoredFileHash() string {
+	if i.ContentHash != "" {
+		return i.ContentHash
+	}
+	return i.FileHash
+}
+
+// CanUndoEdit reports whether the original before the last edit is still available
+func (i *Image) CanUndoEdit() bool {
+	return i.PreviousFileName != ""
+}
+
 const (
 	imageShareLinkLength           = 10
 	imageShareLinkGenerateMaxTries = 5
@@ -114,9 +166,37 @@ func FindImageByUUID(db *gorm.DB, uuid string) (*Image, error) {
 	return &image, result.Error
 }
 
+// AllowsComments reports whether new comments can be written for the image
+func (i *Image) AllowsComments() bool {
+	return i.IsPublic && !i.CommentsDisabled
+}
+
+// DisplayName returns the title of the image or its file name as fallback
+func (i *Image) DisplayName() string {
+	if i.Title != "" {
+		return i.Title
+	}
+	return i.FileName
+}
+
 // FindByFilename findet ein Bild anhand seines Dateinamens
 func FindImageByFilename(db *gorm.DB, filename string) (*Image, error) {
 	var image Image
 	result := db.Where("file_name = ?", filename).First(&image)
 	return &image, result.Error
 }
+
+// OptimizationSavings aggregates the bytes saved by the optimisation pass
+type OptimizationSavings struct {
+	BytesSaved int64
+	ImageCount int64 // images with savings
+}
+
+// GetOptimizationSavings sums the optimisation savings of all images
+func GetOptimizationSavings(db *gorm.DB) (OptimizationSavings, error) {
+	var savings OptimizationSavings
+	err := db.Model(&Image{}).Where("bytes_saved > 0").
+		Select("COALESCE(SUM(bytes_saved), 0) AS bytes_saved, COUNT(*) AS image_count").
+		Scan(&savings).Error
+	return savings, err
+}
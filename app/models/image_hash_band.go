@@ -0,0 +1,75 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// PerceptualHashBandCount is the number of one byte bands a perceptual hash is split into.
+// Two hashes that differ in fewer bits than there are bands share at least one band, so
+// near-duplicate candidates up to PerceptualHashBandCount-1 bits apart are found with an
+// indexed equality join instead of comparing every pair of images. Hashes further apart
+// may share no band at all.
+const PerceptualHashBandCount = 8
+
+// ImageHashBand stores one byte of an image's perceptual hash for the near-duplicate lookup
+type ImageHashBand struct {
+	ImageID uint  `gorm:"primaryKey;autoIncrement:false" json:"image_id"`
+	Band    uint8 `gorm:"primaryKey;autoIncrement:false;index:idx_image_hash_bands_bucket,priority:2" json:"band"`
+	UserID  uint  `gorm:"not null;index:idx_image_hash_bands_bucket,priority:1" json:"user_id"`
+	Value   uint8 `gorm:"not null;index:idx_image_hash_bands_bucket,priority:3" json:"value"`
+}
+
+// TableName returns the table name for the ImageHashBand model
+func (ImageHashBand) TableName() string {
+	return "image_hash_bands"
+}
+
+// PerceptualHashBands splits a perceptual hash into its bands, lowest byte first
+func PerceptualHashBands(hash uint64) [PerceptualHashBandCount]uint8 {
+	var bands [PerceptualHashBandCount]uint8
+	for i := range bands {
+		bands[i] = uint8(hash >> (8 * i))
+	}
+	return bands
+}
+
+// SaveImageHashBands replaces the bands of an image; a nil hash only removes them
+func SaveImageHashBands(db *gorm.DB, imageID, userID uint, hash *uint64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("image_id = ?", imageID).Delete(&ImageHashBand{}).Error; err != nil {
+			return err
+		}
+		if hash == nil {
+			return nil
+		}
+		rows := make([]ImageHashBand, 0, PerceptualHashBandCount)
+		for i, value := range PerceptualHashBands(*hash) {
+			rows = append(rows, ImageHashBand{ImageID: imageID, Band: uint8(i), UserID: userID, Value: value})
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+// BackfillImageHashBands stores the bands of images hashed before the bands existed
+func BackfillImageHashBands(db *gorm.DB) error {
+	const batchSize = 500
+	var afterID uint
+	for {
+		var images []Image
+		if err := db.Select("id", "user_id", "perceptual_hash").
+			Where("id > ? AND perceptual_hash IS NOT NULL", afterID).
+			Where("NOT EXISTS (SELECT 1 FROM image_hash_bands WHERE image_hash_bands.image_id = images.id)").
+			Order("id ASC").Limit(batchSize).Find(&images).Error; err != nil {
+			return err
+		}
+		for _, img := range images {
+			if err := SaveImageHashBands(db, img.ID, img.UserID, img.PerceptualHash); err != nil {
+				return err
+			}
+		}
+		if len(images) < batchSize {
+			return nil
+		}
+		afterID = images[len(images)-1].ID
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerceptualHashBands(t *testing.T) {
+	bands := PerceptualHashBands(0x0807060504030201)
+	assert.Equal(t, [PerceptualHashBandCount]uint8{1, 2, 3, 4, 5, 6, 7, 8}, bands)
+}
+
+func TestPerceptualHashBandsShareABandBelowBandCount(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		hash := rng.Uint64()
+		other := hash
+		// Flip up to PerceptualHashBandCount-1 distinct bits
+		for _, bit := range rng.Perm(64)[:rng.Intn(PerceptualHashBandCount)] {
+			other ^= 1 << bit
+		}
+		a, b := PerceptualHashBands(hash), PerceptualHashBands(other)
+		shared := false
+		for band := range a {
+			shared = shared || a[band] == b[band]
+		}
+		assert.True(t, shared, "%016x and %016x share no band", hash, other)
+	}
+}
+
+func TestPerceptualHashBandsMissPairsAtBandCount(t *testing.T) {
+	// One flipped bit per band: PerceptualHashBandCount bits apart and no shared band
+	a, b := PerceptualHashBands(0), PerceptualHashBands(0x0101010101010101)
+	for band := range a {
+		assert.NotEqual(t, a[band], b[band])
+	}
+}
@@ -63,20 +63,21 @@ func (j *JSON) UnmarshalJSON(data []byte) error {
 
 // ImageMetadata contains the metadata information of an image
 type ImageMetadata struct {
-	ID           uint           `gorm:"primaryKey" json:"id"`
-	ImageID      uint           `gorm:"index;not null" json:"image_id"`
-	CameraModel  *string        `gorm:"type:varchar(255)" json:"camera_model"`
-	TakenAt      *time.Time     `gorm:"type:datetime" json:"taken_at"`
-	Latitude     *float64       `gorm:"type:decimal(10,8)" json:"latitude"`
-	Longitude    *float64       `gorm:"type:decimal(11,8)" json:"longitude"`
-	ExposureTime *string        `gorm:"type:varchar(50)" json:"exposure_time"`
-	Aperture     *string        `gorm:"type:varchar(20)" json:"aperture"`
-	ISO          *int           `gorm:"type:int" json:"iso"`
-	FocalLength  *string        `gorm:"type:varchar(20)" json:"focal_length"`
-	Metadata     *JSON          `gorm:"type:json" json:"metadata"`
-	CreatedAt    time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt    time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	ImageID        uint           `gorm:"index;not null" json:"image_id"`
+	CameraModel    *string        `gorm:"type:varchar(255)" json:"camera_model"`
+	TakenAt        *time.Time     `gorm:"type:datetime" json:"taken_at"`
+	Latitude       *float64       `gorm:"type:decimal(10,8)" json:"latitude"`
+	Longitude      *float64       `gorm:"type:decimal(11,8)" json:"longitude"`
+	ExposureTime   *string        `gorm:"type:varchar(50)" json:"exposure_time"`
+	Aperture       *string        `gorm:"type:varchar(20)" json:"aperture"`
+	ISO            *int           `gorm:"type:int" json:"iso"`
+	FocalLength    *string        `gorm:"type:varchar(20)" json:"focal_length"`
+	LocationHidden bool           `gorm:"not null;default:false" json:"location_hidden"` // GPS data was removed by the metadata policy
+	Metadata       *JSON          `gorm:"type:json" json:"metadata"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // FindMetadataByImageID finds metadata for an image by its ID
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ImageReplica is a copy of an image's original and variants in another pool than its primary
+// Image.StoragePoolID. The copy is current while Checksum matches Image.StoredFileHash.
+type ImageReplica struct {
+	ID            uint         `gorm:"primaryKey" json:"id"`
+	ImageID       uint         `gorm:"not null;uniqueIndex:idx_image_replica_pool,priority:1" json:"image_id"`
+	StoragePoolID uint         `gorm:"not null;index;uniqueIndex:idx_image_replica_pool,priority:2" json:"storage_pool_id"`
+	StoragePool   *StoragePool `gorm:"foreignKey:StoragePoolID" json:"storage_pool,omitempty"`
+	Checksum      string       `gorm:"type:varchar(64);not null;default:''" json:"checksum"` // SHA-256 of the copied original
+	FileSize      int64        `gorm:"type:bigint;not null;default:0" json:"file_size"`      // original and variants
+	CreatedAt     time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time    `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName returns the table name for the ImageReplica model
+func (ImageReplica) TableName() string {
+	return "image_replicas"
+}
+
+// IsCurrent reports whether the replica holds the image's current original
+func (r *ImageReplica) IsCurrent(image *Image) bool {
+	return r.Checksum == image.StoredFileHash()
+}
+
+// storedHashSQL is Image.StoredFileHash as an SQL expression
+const storedHashSQL = "CASE WHEN images.content_hash <> '' THEN images.content_hash ELSE images.file_hash END"
+
+// FindImageReplicas returns the replicas of an image with their pools
+func FindImageReplicas(db *gorm.DB, imageID uint) ([]ImageReplica, error) {
+	var replicas []ImageReplica
+	err := db.Preload("StoragePool").Where("image_id = ?", imageID).Order("id ASC").Find(&replicas).Error
+	return replicas, err
+}
+
+// SaveImageReplica records a copy, replacing an earlier copy of the image in the same pool
+func SaveImageReplica(db *gorm.DB, replica *ImageReplica) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "image_id"}, {Name: "storage_pool_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"checksum", "file_size", "updated_at"}),
+	}).Create(replica).Error
+}
+
+// DeleteImageReplica forgets the copy of an image in a pool
+func DeleteImageReplica(db *gorm.DB, imageID, poolID uint) error {
+	return db.Where("image_id = ? AND storage_pool_id = ?", imageID, poolID).Delete(&ImageReplica{}).Error
+}
+
+// underReplicatedImages selects images of a tier with fewer than copies-1 current replicas
+func underReplicatedImages(db *gorm.DB, tier string, copies int) *gorm.DB {
+	current := db.Model(&ImageReplica{}).Select("COUNT(*)").
+		Where("image_replicas.image_id = images.id AND image_replicas.storage_pool_id <> images.storage_pool_id").
+		Where("image_replicas.checksum = " + storedHashSQL)
+	return db.Model(&Image{}).
+		Joins("JOIN storage_pools ON storage_pools.id = images.storage_pool_id").
+		Where("storage_pools.storage_tier = ?", tier).
+		Where("(?) < ?", current, copies-1)
+}
+
+// FindUnderReplicatedImageIDs returns IDs of images of a tier that lack current replicas, ordered by ID
+func FindUnderReplicatedImageIDs(db *gorm.DB, tier string, copies int, afterID uint, limit int) ([]uint, error) {
+	var ids []uint
+	if copies <= 1 {
+		return ids, nil
+	}
+	err := underReplicatedImages(db, tier, copies).Where("images.id > ?", afterID).
+		Order("images.id ASC").Limit(limit).Pluck("images.id", &ids).Error
+	return ids, err
+}
+
+// CountUnderReplicatedImages returns the number of images of a tier that lack current replicas
+func CountUnderReplicatedImages(db *gorm.DB, tier string, copies int) (int64, error) {
+	var count int64
+	if copies <= 1 {
+		return 0, nil
+	}
+	err := underReplicatedImages(db, tier, copies).Count(&count).Error
+	return count, err
+}
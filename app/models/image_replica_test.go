@@ -0,0 +1,28 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageReplicaIsCurrent(t *testing.T) {
+	image := &Image{FileHash: "upload"}
+	replica := ImageReplica{Checksum: "upload"}
+	assert.True(t, replica.IsCurrent(image))
+
+	// Once the stored original was rewritten, only copies of the rewrite are current
+	image.ContentHash = "rewritten"
+	assert.False(t, replica.IsCurrent(image))
+	replica.Checksum = "rewritten"
+	assert.True(t, replica.IsCurrent(image))
+}
+
+func TestGetReplicaCount(t *testing.T) {
+	s := &AppSettings{ReplicasHot: 2, ReplicasWarm: 3, ReplicasCold: 0}
+	assert.Equal(t, 2, s.GetReplicaCount(StorageTierHot))
+	assert.Equal(t, 3, s.GetReplicaCount(StorageTierWarm))
+	assert.Equal(t, 1, s.GetReplicaCount(StorageTierCold))
+	assert.Equal(t, 1, s.GetReplicaCount(StorageTierArchive))
+	assert.Equal(t, 1, s.GetReplicaCount("unknown"))
+}
@@ -6,7 +6,7 @@ import (
 	"gorm.io/gorm"
 )
 
-// Image variant type constants
+// Image variant types of the default variant profiles
 const (
 	VariantTypeWebP                = "webp"
 	VariantTypeAVIF                = "avif"
@@ -16,14 +16,20 @@ const (
 	VariantTypeThumbnailMediumWebP = "thumbnail_medium_webp"
 	VariantTypeThumbnailMediumAVIF = "thumbnail_medium_avif"
 	VariantTypeThumbnailMediumOrig = "thumbnail_medium_original"
+	VariantTypeVideoMP4            = "video_mp4"
+	VariantTypeVideoWebM           = "video_webm"
 	VariantTypeOriginal            = "original"
 )
 
+// VariantTypeWeb is the built-in full-size rendition of originals browsers cannot display
+// (HEIC/HEIF, TIFF, JPEG XL). It is not backed by a variant profile.
+const VariantTypeWeb = "web"
+
 type ImageVariant struct {
 	ID            uint           `gorm:"primaryKey" json:"id"`
 	ImageID       uint           `gorm:"index;not null" json:"image_id"`
 	Image         Image          `gorm:"foreignKey:ImageID" json:"image,omitempty"`
-	VariantType   string         `gorm:"type:varchar(50);not null" json:"variant_type"` // thumbnail_small_webp, thumbnail_medium_webp, thumbnail_small_avif, thumbnail_medium_avif, webp, avif
+	VariantType   string         `gorm:"type:varchar(50);not null" json:"variant_type"` // name of the VariantProfile
 	FilePath      string         `gorm:"type:varchar(255);not null" json:"file_path"`
 	FileName      string         `gorm:"type:varchar(255);not null" json:"file_name"`
 	FileType      string         `gorm:"type:varchar(50);not null" json:"file_type"`
@@ -45,16 +51,18 @@ func (ImageVariant) TableName() string {
 
 // BeforeCreate is called before creating a new record
 func (iv *ImageVariant) BeforeCreate(tx *gorm.DB) error {
-	// Validate variant type - "original" is NO LONGER valid, original data is stored in images table
-	validTypes := []string{VariantTypeThumbnailSmallWebP, VariantTypeThumbnailSmallAVIF, VariantTypeThumbnailSmallOrig, VariantTypeThumbnailMediumWebP, VariantTypeThumbnailMediumAVIF, VariantTypeThumbnailMediumOrig, VariantTypeWebP, VariantTypeAVIF}
-	isValid := false
-	for _, validType := range validTypes {
-		if iv.VariantType == validType {
-			isValid = true
-			break
-		}
+	// The variant type must name a variant profile - "original" is NO LONGER valid, original data is stored in images table
+	if !IsValidVariantProfileName(iv.VariantType) || iv.VariantType == VariantTypeOriginal {
+		return gorm.ErrInvalidValue
+	}
+	if iv.VariantType == VariantTypeWeb {
+		return nil
+	}
+	var count int64
+	if err := tx.Session(&gorm.Session{NewDB: true}).Model(&VariantProfile{}).Where("name = ?", iv.VariantType).Count(&count).Error; err != nil {
+		return err
 	}
-	if !isValid {
+	if count == 0 {
 		return gorm.ErrInvalidValue
 	}
 
@@ -4,35 +4,88 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// Like marks an image as favorite of a user. The unique index makes duplicate likes impossible.
 type Like struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	UserID    uint           `gorm:"index" json:"user_id"`
-	User      User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	ImageID   uint           `gorm:"index" json:"image_id"`
-	Image     Image          `gorm:"foreignKey:ImageID" json:"image,omitempty"`
-	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"index;uniqueIndex:ux_likes_user_image,priority:1" json:"user_id"`
+	User      User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	ImageID   uint      `gorm:"index;uniqueIndex:ux_likes_user_image,priority:2" json:"image_id"`
+	Image     Image     `gorm:"foreignKey:ImageID" json:"image,omitempty"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 }
 
-// ToggleLike erstellt oder entfernt einen Like
-func ToggleLike(db *gorm.DB, userID, imageID uint) error {
-	var like Like
-	result := db.Where("user_id = ? AND image_id = ?", userID, imageID).First(&like)
+// likesUniqueIndex is the index that allows only one like per user and image
+const likesUniqueIndex = "ux_likes_user_image"
 
-	if result.Error != nil {
-		if result.Error == gorm.ErrRecordNotFound {
-			// Like existiert nicht, erstelle ihn
-			newLike := Like{
-				UserID:  userID,
-				ImageID: imageID,
+// PrepareLikesUniqueIndex entfernt vor dem Anlegen des Unique-Index die Likes, die ihn verletzen
+// würden: soft-gelöschte Likes aus der Zeit vor dem Entfernen von DeletedAt und Duplikate
+// (der älteste Like bleibt erhalten). Muss vor AutoMigrate laufen.
+func PrepareLikesUniqueIndex(db *gorm.DB) error {
+	m := db.Migrator()
+	if !m.HasTable(&Like{}) || m.HasIndex(&Like{}, likesUniqueIndex) {
+		return nil
+	}
+	return purgeConflictingLikes(db, "likes", m.HasColumn(&Like{}, "deleted_at"))
+}
+
+// purgeConflictingLikes löscht soft-gelöschte (falls die Spalte existiert) und doppelte Likes der Tabelle
+func purgeConflictingLikes(db *gorm.DB, table string, softDeletes bool) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if softDeletes {
+			if err := tx.Exec("DELETE FROM " + table + " WHERE deleted_at IS NOT NULL").Error; err != nil {
+				return err
 			}
-			return db.Create(&newLike).Error
 		}
-		return result.Error
+		return tx.Exec("DELETE dup FROM " + table + " dup JOIN " + table + " keep" +
+			" ON keep.user_id = dup.user_id AND keep.image_id = dup.image_id AND keep.id < dup.id").Error
+	})
+}
+
+// LikeImage erstellt einen Like; liefert false, wenn das Bild bereits geliked war
+func LikeImage(db *gorm.DB, userID, imageID uint) (bool, error) {
+	like := Like{
+		UserID:  userID,
+		ImageID: imageID,
+	}
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&like)
+	if result.Error != nil {
+		return false, result.Error
 	}
+	return result.RowsAffected > 0, nil
+}
 
-	// Like existiert, entferne ihn
-	return db.Delete(&like).Error
+// UnlikeImage entfernt einen Like; liefert false, wenn kein Like existierte
+func UnlikeImage(db *gorm.DB, userID, imageID uint) (bool, error) {
+	result := db.Where("user_id = ? AND image_id = ?", userID, imageID).Delete(&Like{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// HasLikedImage prüft, ob der Benutzer das Bild geliked hat
+func HasLikedImage(db *gorm.DB, userID, imageID uint) (bool, error) {
+	var count int64
+	if err := db.Model(&Like{}).Where("user_id = ? AND image_id = ?", userID, imageID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ToggleLike erstellt oder entfernt einen Like und liefert den neuen Zustand
+func ToggleLike(db *gorm.DB, userID, imageID uint) (bool, error) {
+	removed, err := UnlikeImage(db, userID, imageID)
+	if err != nil {
+		return false, err
+	}
+	if removed {
+		return false, nil
+	}
+	if _, err := LikeImage(db, userID, imageID); err != nil {
+		return false, err
+	}
+	return true, nil
 }
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openLikeTestDB connects to the MySQL database configured with DB_* and skips without one
+func openLikeTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	if os.Getenv("DB_USER") == "" || os.Getenv("DB_NAME") == "" {
+		t.Skip("Skipping MySQL-dependent test: DB_USER and DB_NAME are not set")
+	}
+	host, port := os.Getenv("DB_HOST"), os.Getenv("DB_PORT")
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	if port == "" {
+		port = "3306"
+	}
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local&timeout=2s",
+		os.Getenv("DB_USER"), os.Getenv("DB_PASSWORD"), host, port, os.Getenv("DB_NAME"))
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+		DisableForeignKeyConstraintWhenMigrating: true,
+		Logger:                                   logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Skipf("Skipping MySQL-dependent test: %v", err)
+	}
+	require.NoError(t, db.AutoMigrate(&Like{}))
+	return db
+}
+
+// likeTestIDs returns user and image IDs far above real data and removes their likes afterwards
+func likeTestIDs(t *testing.T, db *gorm.DB) (uint, uint) {
+	userID := uint(900000000 + time.Now().UnixNano()%1000000)
+	imageID := userID + 1
+	t.Cleanup(func() {
+		db.Where("user_id = ?", userID).Delete(&Like{})
+	})
+	return userID, imageID
+}
+
+func TestLikeImageAndUnlikeImage(t *testing.T) {
+	db := openLikeTestDB(t)
+	userID, imageID := likeTestIDs(t, db)
+
+	liked, err := LikeImage(db, userID, imageID)
+	require.NoError(t, err)
+	assert.True(t, liked)
+
+	// A second like is ignored instead of creating a duplicate
+	liked, err = LikeImage(db, userID, imageID)
+	require.NoError(t, err)
+	assert.False(t, liked)
+
+	var count int64
+	require.NoError(t, db.Model(&Like{}).Where("user_id = ? AND image_id = ?", userID, imageID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	has, err := HasLikedImage(db, userID, imageID)
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	removed, err := UnlikeImage(db, userID, imageID)
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	removed, err = UnlikeImage(db, userID, imageID)
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	has, err = HasLikedImage(db, userID, imageID)
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestToggleLike(t *testing.T) {
+	db := openLikeTestDB(t)
+	userID, imageID := likeTestIDs(t, db)
+
+	liked, err := ToggleLike(db, userID, imageID)
+	require.NoError(t, err)
+	assert.True(t, liked)
+
+	liked, err = ToggleLike(db, userID, imageID)
+	require.NoError(t, err)
+	assert.False(t, liked)
+
+	liked, err = ToggleLike(db, userID, imageID)
+	require.NoError(t, err)
+	assert.True(t, liked)
+}
+
+func TestPurgeConflictingLikes(t *testing.T) {
+	db := openLikeTestDB(t)
+	table := fmt.Sprintf("likes_purge_test_%d", time.Now().UnixNano())
+	require.NoError(t, db.Exec("CREATE TABLE "+table+" (id INT UNSIGNED AUTO_INCREMENT PRIMARY KEY, user_id INT UNSIGNED NOT NULL, image_id INT UNSIGNED NOT NULL, created_at DATETIME NULL, deleted_at DATETIME NULL)").Error)
+	t.Cleanup(func() { db.Exec("DROP TABLE " + table) })
+
+	require.NoError(t, db.Exec("INSERT INTO "+table+" (id, user_id, image_id, deleted_at) VALUES "+
+		"(1, 1, 10, NULL), (2, 1, 10, NULL), (3, 1, 10, NULL), "+ // duplicates, the oldest stays
+		"(4, 1, 11, NOW()), (5, 1, 11, NULL), "+ // soft-deleted like followed by a new one
+		"(6, 2, 10, NOW()), "+ // only soft-deleted
+		"(7, 2, 11, NULL)").Error)
+
+	require.NoError(t, purgeConflictingLikes(db, table, true))
+
+	var ids []uint
+	require.NoError(t, db.Table(table).Order("id ASC").Pluck("id", &ids).Error)
+	assert.Equal(t, []uint{1, 5, 7}, ids)
+
+	// The unique index can be created afterwards
+	require.NoError(t, db.Exec("CREATE UNIQUE INDEX ux_purge_test ON "+table+" (user_id, image_id)").Error)
+}
@@ -0,0 +1,75 @@
+package models
+
+import "sort"
+
+// NearDuplicatePair links two images of the same user whose perceptual hashes are within the configured distance
+type NearDuplicatePair struct {
+	UserID       uint
+	ImageID      uint
+	OtherImageID uint
+	Distance     int
+}
+
+// NearDuplicateCluster is a group of a user's images that are connected by near-duplicate pairs
+type NearDuplicateCluster struct {
+	UserID   uint
+	ImageIDs []uint  // ascending
+	Images   []Image // filled by the caller, same order as ImageIDs where found
+}
+
+// ReclaimableBytes returns the storage freed by keeping only the largest image of the cluster
+func (c NearDuplicateCluster) ReclaimableBytes() int64 {
+	var total, largest int64
+	for _, img := range c.Images {
+		total += img.FileSize
+		largest = max(largest, img.FileSize)
+	}
+	return total - largest
+}
+
+// GroupNearDuplicatePairs merges pairs transitively into clusters of image IDs.
+// Clusters keep the order in which the pairs first mention them.
+func GroupNearDuplicatePairs(pairs []NearDuplicatePair) []NearDuplicateCluster {
+	parent := make(map[uint]uint)
+	var find func(id uint) uint
+	find = func(id uint) uint {
+		p, ok := parent[id]
+		if !ok || p == id {
+			parent[id] = id
+			return id
+		}
+		root := find(p)
+		parent[id] = root
+		return root
+	}
+	for _, p := range pairs {
+		a, b := find(p.ImageID), find(p.OtherImageID)
+		if a != b {
+			parent[b] = a
+		}
+	}
+
+	index := make(map[uint]int)
+	seen := make(map[uint]bool)
+	var clusters []NearDuplicateCluster
+	for _, p := range pairs {
+		root := find(p.ImageID)
+		i, ok := index[root]
+		if !ok {
+			i = len(clusters)
+			index[root] = i
+			clusters = append(clusters, NearDuplicateCluster{UserID: p.UserID})
+		}
+		for _, id := range []uint{p.ImageID, p.OtherImageID} {
+			if !seen[id] {
+				seen[id] = true
+				clusters[i].ImageIDs = append(clusters[i].ImageIDs, id)
+			}
+		}
+	}
+	for i := range clusters {
+		ids := clusters[i].ImageIDs
+		sort.Slice(ids, func(a, b int) bool { return ids[a] < ids[b] })
+	}
+	return clusters
+}
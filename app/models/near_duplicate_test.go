@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupNearDuplicatePairs(t *testing.T) {
+	pairs := []NearDuplicatePair{
+		{UserID: 1, ImageID: 3, OtherImageID: 7, Distance: 2},
+		{UserID: 2, ImageID: 10, OtherImageID: 11, Distance: 0},
+		{UserID: 1, ImageID: 5, OtherImageID: 7, Distance: 4},
+		{UserID: 1, ImageID: 8, OtherImageID: 9, Distance: 1},
+	}
+
+	clusters := GroupNearDuplicatePairs(pairs)
+	if assert.Len(t, clusters, 3) {
+		assert.Equal(t, uint(1), clusters[0].UserID)
+		assert.Equal(t, []uint{3, 5, 7}, clusters[0].ImageIDs)
+		assert.Equal(t, uint(2), clusters[1].UserID)
+		assert.Equal(t, []uint{10, 11}, clusters[1].ImageIDs)
+		assert.Equal(t, []uint{8, 9}, clusters[2].ImageIDs)
+	}
+
+	assert.Empty(t, GroupNearDuplicatePairs(nil))
+}
+
+func TestNearDuplicateClusterReclaimableBytes(t *testing.T) {
+	cluster := NearDuplicateCluster{Images: []Image{{FileSize: 300}, {FileSize: 1000}, {FileSize: 200}}}
+	assert.Equal(t, int64(500), cluster.ReclaimableBytes())
+	assert.Equal(t, int64(0), NearDuplicateCluster{}.ReclaimableBytes())
+}
@@ -6,13 +6,26 @@ import (
 	"gorm.io/gorm"
 )
 
+// Notification types
+const (
+	NotificationTypeLike             = "like"
+	NotificationTypeComment          = "comment"
+	NotificationTypeFollow           = "follow"
+	NotificationTypeSystem           = "system"
+	NotificationTypeReportResolved   = "report_resolved"
+	NotificationTypeUploadBatch      = "upload_batch"
+	NotificationTypePlanChanged      = "plan_changed"
+	NotificationTypeProcessingFailed = "processing_failed"
+)
+
 type Notification struct {
 	ID          uint           `gorm:"primaryKey" json:"id"`
-	UserID      uint           `gorm:"index" json:"user_id"`
+	UserID      uint           `gorm:"index;index:idx_notifications_user_read,priority:1" json:"user_id"`
 	User        User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Type        string         `gorm:"type:varchar(50)" json:"type" validate:"oneof=like comment follow system"`
+	Type        string         `gorm:"type:varchar(50)" json:"type" validate:"oneof=like comment follow system report_resolved upload_batch plan_changed processing_failed"`
 	Content     string         `gorm:"type:text" json:"content"`
-	IsRead      bool           `gorm:"default:false" json:"is_read"`
+	Link        string         `gorm:"type:varchar(255);default:''" json:"link"` // relativer Pfad, auf den die Benachrichtigung verweist
+	IsRead      bool           `gorm:"default:false;index:idx_notifications_user_read,priority:2" json:"is_read"`
 	ReferenceID uint           `json:"reference_id"` // ID des Objekts, auf das sich die Benachrichtigung bezieht
 	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
@@ -26,11 +39,15 @@ func (n *Notification) MarkAsRead(db *gorm.DB) error {
 }
 
 // CreateNotification erstellt eine neue Benachrichtigung
-func CreateNotification(db *gorm.DB, userID uint, notificationType string, content string, referenceID uint) error {
+func CreateNotification(db *gorm.DB, userID uint, notificationType string, content string, link string, referenceID uint) error {
+	if userID == 0 {
+		return nil
+	}
 	notification := Notification{
 		UserID:      userID,
 		Type:        notificationType,
 		Content:     content,
+		Link:        link,
 		ReferenceID: referenceID,
 		IsRead:      false,
 	}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RecoveryCodeCount is the number of one-time recovery codes issued per user
+const RecoveryCodeCount = 10
+
+// RecoveryCode is a hashed one-time code that can replace a TOTP code during login
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"index" json:"user_id"`
+	CodeHash  string     `gorm:"type:char(64);index" json:"-"`
+	UsedAt    *time.Time `gorm:"type:timestamp;default:null" json:"used_at,omitempty"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// HashRecoveryCode normalizes and hashes a recovery code (case and dashes are ignored)
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of the user and returns the new raw codes.
+// The raw codes are only available here; the database keeps hashes only.
+func RegenerateRecoveryCodes(db *gorm.DB, userID uint) ([]string, error) {
+	codes := make([]string, 0, RecoveryCodeCount)
+	rows := make([]RecoveryCode, 0, RecoveryCodeCount)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))[:10]
+		code := raw[:5] + "-" + raw[5:]
+		codes = append(codes, code)
+		rows = append(rows, RecoveryCode{UserID: userID, CodeHash: HashRecoveryCode(code)})
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// UseRecoveryCode consumes a matching unused recovery code. Returns false if none matched.
+func UseRecoveryCode(db *gorm.DB, userID uint, code string) (bool, error) {
+	res := db.Model(&RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, HashRecoveryCode(code)).
+		Limit(1).
+		Update("used_at", time.Now())
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// CountUnusedRecoveryCodes returns how many recovery codes the user can still use
+func CountUnusedRecoveryCodes(db *gorm.DB, userID uint) (int64, error) {
+	var n int64
+	err := db.Model(&RecoveryCode{}).Where("user_id = ? AND used_at IS NULL", userID).Count(&n).Error
+	return n, err
+}
+
+// DeleteRecoveryCodes removes all recovery codes of the user
+func DeleteRecoveryCodes(db *gorm.DB, userID uint) error {
+	return db.Where("user_id = ?", userID).Delete(&RecoveryCode{}).Error
+}
@@ -0,0 +1,237 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Status of an integrity scrub run
+const (
+	ScrubReportRunning   = "running"
+	ScrubReportCompleted = "completed"
+	ScrubReportFailed    = "failed"
+)
+
+// Kinds of integrity scrub findings
+const (
+	ScrubFindingMissingOriginal = "missing_original"
+	ScrubFindingCorruptOriginal = "corrupt_original" // hash differs from Image.StoredFileHash
+	ScrubFindingMissingVariant  = "missing_variant"
+	ScrubFindingOrphan          = "orphan" // file without image or variant row
+)
+
+// Status of a finding
+const (
+	ScrubFindingOpen        = "open"
+	ScrubFindingRepairing   = "repairing"
+	ScrubFindingResolved    = "resolved"
+	ScrubFindingQuarantined = "quarantined"
+	ScrubFindingFailed      = "failed" // repair failed, Detail says why
+)
+
+// Repair actions for findings
+const (
+	ScrubRepairRegenerate = "regenerate" // render missing variants again
+	ScrubRepairRestore    = "restore"    // copy the file back from an S3 pool
+	ScrubRepairQuarantine = "quarantine" // move an orphan below quarantine/
+)
+
+// ScrubReportStaleAfter is how long a running scrub may go without progress before a new one may start
+const ScrubReportStaleAfter = time.Hour
+
+// ScrubReport summarises one integrity scrub of a storage pool
+type ScrubReport struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	StoragePoolID   uint       `gorm:"index;not null" json:"storage_pool_id"`
+	Status          string     `gorm:"type:varchar(20);not null;default:'running'" json:"status"`
+	ImagesChecked   int64      `gorm:"not null;default:0" json:"images_checked"`
+	VariantsChecked int64      `gorm:"not null;default:0" json:"variants_checked"`
+	FilesListed     int64      `gorm:"not null;default:0" json:"files_listed"` // files looked at for orphans
+	BytesHashed     int64      `gorm:"not null;default:0" json:"bytes_hashed"`
+	MissingCount    int64      `gorm:"not null;default:0" json:"missing_count"` // originals and variants
+	CorruptCount    int64      `gorm:"not null;default:0" json:"corrupt_count"`
+	OrphanCount     int64      `gorm:"not null;default:0" json:"orphan_count"`
+	Error           string     `gorm:"type:text" json:"error,omitempty"`
+	StartedAt       time.Time  `json:"started_at"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// ScrubProgress holds the counters a scrub batch adds to its report
+type ScrubProgress struct {
+	ImagesChecked   int64
+	VariantsChecked int64
+	FilesListed     int64
+	BytesHashed     int64
+	MissingCount    int64
+	CorruptCount    int64
+	OrphanCount     int64
+}
+
+// ScrubFinding is a problem found by an integrity scrub
+type ScrubFinding struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	ReportID      uint       `gorm:"index;not null" json:"report_id"`
+	StoragePoolID uint       `gorm:"not null;index:idx_scrub_findings_pool_status,priority:1" json:"storage_pool_id"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'open';index:idx_scrub_findings_pool_status,priority:2" json:"status"`
+	Kind          string     `gorm:"type:varchar(30);not null" json:"kind"`
+	ImageID       uint       `gorm:"index;not null;default:0" json:"image_id"` // 0 for orphans
+	VariantID     uint       `gorm:"not null;default:0" json:"variant_id"`
+	FilePath      string     `gorm:"type:varchar(500);not null" json:"file_path"` // relative to the pool root
+	FileSize      int64      `gorm:"not null;default:0" json:"file_size"`
+	ExpectedHash  string     `gorm:"type:varchar(64);not null;default:''" json:"expected_hash,omitempty"`
+	ActualHash    string     `gorm:"type:varchar(64);not null;default:''" json:"actual_hash,omitempty"`
+	Detail        string     `gorm:"type:text" json:"detail,omitempty"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty"`
+}
+
+// FindingCount returns the number of problems the scrub found
+func (r *ScrubReport) FindingCount() int64 {
+	return r.MissingCount + r.CorruptCount + r.OrphanCount
+}
+
+// IsStale reports whether a running scrub stopped making progress, e.g. because its job was lost
+func (r *ScrubReport) IsStale(now time.Time) bool {
+	return r.Status == ScrubReportRunning && now.Sub(r.UpdatedAt) > ScrubReportStaleAfter
+}
+
+// RepairActions returns the repairs an admin can start for the finding
+func (f *ScrubFinding) RepairActions() []string {
+	if f.Status != ScrubFindingOpen && f.Status != ScrubFindingFailed {
+		return nil
+	}
+	switch f.Kind {
+	case ScrubFindingMissingVariant:
+		return []string{ScrubRepairRegenerate, ScrubRepairRestore}
+	case ScrubFindingMissingOriginal, ScrubFindingCorruptOriginal:
+		return []string{ScrubRepairRestore}
+	case ScrubFindingOrphan:
+		return []string{ScrubRepairQuarantine}
+	}
+	return nil
+}
+
+// CanRepair reports whether the action applies to the finding
+func (f *ScrubFinding) CanRepair(action string) bool {
+	for _, a := range f.RepairActions() {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// StartScrubReport creates the report of a new scrub. Unrepaired findings of earlier scrubs of the
+// pool are dropped; the new scrub finds them again if they still exist.
+func StartScrubReport(db *gorm.DB, poolID uint, now time.Time) (*ScrubReport, error) {
+	report := &ScrubReport{StoragePoolID: poolID, Status: ScrubReportRunning, StartedAt: now}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("storage_pool_id = ? AND status IN ?", poolID, []string{ScrubFindingOpen, ScrubFindingFailed}).
+			Delete(&ScrubFinding{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(report).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// AddScrubProgress adds the counters of a scrub batch to its report
+func AddScrubProgress(db *gorm.DB, reportID uint, p ScrubProgress) error {
+	return db.Model(&ScrubReport{}).Where("id = ?", reportID).Updates(map[string]interface{}{
+		"images_checked":   gorm.Expr("images_checked + ?", p.ImagesChecked),
+		"variants_checked": gorm.Expr("variants_checked + ?", p.VariantsChecked),
+		"files_listed":     gorm.Expr("files_listed + ?", p.FilesListed),
+		"bytes_hashed":     gorm.Expr("bytes_hashed + ?", p.BytesHashed),
+		"missing_count":    gorm.Expr("missing_count + ?", p.MissingCount),
+		"corrupt_count":    gorm.Expr("corrupt_count + ?", p.CorruptCount),
+		"orphan_count":     gorm.Expr("orphan_count + ?", p.OrphanCount),
+	}).Error
+}
+
+// FinishScrubReport marks a scrub as completed, or as failed if errMsg is set
+func FinishScrubReport(db *gorm.DB, reportID uint, errMsg string, now time.Time) error {
+	status := ScrubReportCompleted
+	if errMsg != "" {
+		status = ScrubReportFailed
+	}
+	return db.Model(&ScrubReport{}).Where("id = ?", reportID).Updates(map[string]interface{}{
+		"status":      status,
+		"error":       errMsg,
+		"finished_at": now,
+	}).Error
+}
+
+// FindScrubReportByID returns a scrub report
+func FindScrubReportByID(db *gorm.DB, id uint) (*ScrubReport, error) {
+	var report ScrubReport
+	if err := db.First(&report, id).Error; err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// FindLatestScrubReport returns the most recent scrub report of a pool
+func FindLatestScrubReport(db *gorm.DB, poolID uint) (*ScrubReport, error) {
+	var report ScrubReport
+	if err := db.Where("storage_pool_id = ?", poolID).Order("id DESC").First(&report).Error; err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// FindLatestScrubReports returns the most recent scrub report of every pool, keyed by pool ID
+func FindLatestScrubReports(db *gorm.DB) (map[uint]ScrubReport, error) {
+	var reports []ScrubReport
+	latest := db.Model(&ScrubReport{}).Select("MAX(id)").Group("storage_pool_id")
+	if err := db.Where("id IN (?)", latest).Find(&reports).Error; err != nil {
+		return nil, err
+	}
+	byPool := make(map[uint]ScrubReport, len(reports))
+	for _, r := range reports {
+		byPool[r.StoragePoolID] = r
+	}
+	return byPool, nil
+}
+
+// FindScrubFindings returns findings newest first, optionally filtered by pool and status, and their total count
+func FindScrubFindings(db *gorm.DB, poolID uint, status string, limit, offset int) ([]ScrubFinding, int64, error) {
+	q := db.Model(&ScrubFinding{})
+	if poolID > 0 {
+		q = q.Where("storage_pool_id = ?", poolID)
+	}
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var findings []ScrubFinding
+	if err := q.Order("id DESC").Limit(limit).Offset(offset).Find(&findings).Error; err != nil {
+		return nil, 0, err
+	}
+	return findings, total, nil
+}
+
+// FindScrubFindingByID returns a finding
+func FindScrubFindingByID(db *gorm.DB, id uint) (*ScrubFinding, error) {
+	var finding ScrubFinding
+	if err := db.First(&finding, id).Error; err != nil {
+		return nil, err
+	}
+	return &finding, nil
+}
+
+// UpdateScrubFindingStatus records the outcome of a repair
+func UpdateScrubFindingStatus(db *gorm.DB, id uint, status, detail string, now time.Time) error {
+	updates := map[string]interface{}{"status": status, "detail": detail}
+	if status == ScrubFindingResolved || status == ScrubFindingQuarantined {
+		updates["resolved_at"] = now
+	}
+	return db.Model(&ScrubFinding{}).Where("id = ?", id).Updates(updates).Error
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScrubFindingRepairActions(t *testing.T) {
+	variant := ScrubFinding{Kind: ScrubFindingMissingVariant, Status: ScrubFindingOpen}
+	assert.Equal(t, []string{ScrubRepairRegenerate, ScrubRepairRestore}, variant.RepairActions())
+	assert.True(t, variant.CanRepair(ScrubRepairRegenerate))
+	assert.False(t, variant.CanRepair(ScrubRepairQuarantine))
+
+	corrupt := ScrubFinding{Kind: ScrubFindingCorruptOriginal, Status: ScrubFindingFailed}
+	assert.Equal(t, []string{ScrubRepairRestore}, corrupt.RepairActions())
+
+	orphan := ScrubFinding{Kind: ScrubFindingOrphan, Status: ScrubFindingOpen}
+	assert.True(t, orphan.CanRepair(ScrubRepairQuarantine))
+	assert.False(t, orphan.CanRepair(ScrubRepairRestore))
+
+	// Findings being repaired or already handled offer no actions
+	for _, status := range []string{ScrubFindingRepairing, ScrubFindingResolved, ScrubFindingQuarantined} {
+		orphan.Status = status
+		assert.Empty(t, orphan.RepairActions(), status)
+		assert.False(t, orphan.CanRepair(ScrubRepairQuarantine), status)
+	}
+}
+
+func TestScrubReportIsStale(t *testing.T) {
+	now := time.Now()
+	report := ScrubReport{Status: ScrubReportRunning, UpdatedAt: now.Add(-30 * time.Minute)}
+	assert.False(t, report.IsStale(now))
+	report.UpdatedAt = now.Add(-2 * time.Hour)
+	assert.True(t, report.IsStale(now))
+	report.Status = ScrubReportCompleted
+	assert.False(t, report.IsStale(now))
+
+	report.MissingCount, report.CorruptCount, report.OrphanCount = 2, 1, 4
+	assert.Equal(t, int64(7), report.FindingCount())
+}
+
+func TestImageStoredFileHash(t *testing.T) {
+	image := Image{FileHash: "upload"}
+	assert.Equal(t, "upload", image.StoredFileHash())
+	image.ContentHash = "rewritten"
+	assert.Equal(t, "rewritten", image.StoredFileHash())
+}
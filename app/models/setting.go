@@ -38,6 +38,10 @@ type AppSettings struct {
 	APIRateLimitPerMinute int `json:"api_rate_limit_per_minute" validate:"min=0,max=100000"` // Global API limiter for /api routes (0 = unlimited)
 	// Replication/Storage settings
 	ReplicationRequireChecksum bool `json:"replication_require_checksum"`
+	// Copies kept of every image per tier, the primary included; 1 = no replicas
+	ReplicasHot  int `json:"replicas_hot" validate:"min=1,max=5"`
+	ReplicasWarm int `json:"replicas_warm" validate:"min=1,max=5"`
+	ReplicasCold int `json:"replicas_cold" validate:"min=1,max=5"`
 	// Tiering (Phase A)
 	TieringEnabled               bool `json:"tiering_enabled"`
 	HotKeepDaysAfterUpload       int  `json:"hot_keep_days_after_upload" validate:"min=0,max=3650"`
@@ -47,6 +51,20 @@ type AppSettings struct {
 	HotWatermarkLow              int  `json:"hot_watermark_low" validate:"min=0,max=100"`
 	MaxTieringCandidatesPerSweep int  `json:"max_tiering_candidates_per_sweep" validate:"min=1,max=100000"`
 	TieringSweepIntervalMinutes  int  `json:"tiering_sweep_interval_minutes" validate:"min=1,max=1440"`
+	// Security
+	RequireAdmin2FA bool `json:"require_admin_2fa"`
+	// On-the-fly transformations
+	TransformCacheMaxMBPerPool int `json:"transform_cache_max_mb_per_pool" validate:"min=0,max=10000000"` // LRU limit for derived variants per pool (0 = unlimited)
+	// Near-duplicate detection
+	SimilarImageMaxDistance    int  `json:"similar_image_max_distance" validate:"min=0,max=32"` // Max. Hamming distance of perceptual hashes to count as near-duplicate (below PerceptualHashBandCount the report uses the hash band index)
+	NearDuplicateUploadWarning bool `json:"near_duplicate_upload_warning"`
+	// Optimisation pass (pngquant/jpegoptim or built-in fallback)
+	OptimizationEnabled bool `json:"optimization_enabled"` // original-format variants
+	OptimizeOriginals   bool `json:"optimize_originals"`   // stored originals, lossless only
+	// Integrity scrub of storage pools
+	IntegrityScrubEnabled        bool `json:"integrity_scrub_enabled"`
+	IntegrityScrubIntervalHours  int  `json:"integrity_scrub_interval_hours" validate:"min=1,max=8760"`
+	IntegrityScrubFilesPerSecond int  `json:"integrity_scrub_files_per_second" validate:"min=1,max=10000"` // throttle per pool
 	mu                           sync.RWMutex
 }
 
@@ -82,6 +100,9 @@ func LoadSettings(db *gorm.DB) error {
 		JobQueueWorkerCount:          5,    // Default: 5 workers
 		APIRateLimitPerMinute:        120,  // Default: 120 requests / minute for /api routes
 		ReplicationRequireChecksum:   true, // Default: enforce checksum for replication
+		ReplicasHot:                  1,
+		ReplicasWarm:                 1,
+		ReplicasCold:                 1,
 		// Tiering defaults (Phase A)
 		TieringEnabled:               true,
 		HotKeepDaysAfterUpload:       7,
@@ -91,6 +112,15 @@ func LoadSettings(db *gorm.DB) error {
 		HotWatermarkLow:              65,
 		MaxTieringCandidatesPerSweep: 200,
 		TieringSweepIntervalMinutes:  15,
+		RequireAdmin2FA:              false,
+		TransformCacheMaxMBPerPool:   1024,
+		SimilarImageMaxDistance:      7,
+		NearDuplicateUploadWarning:   true,
+		OptimizationEnabled:          true,
+		OptimizeOriginals:            false,
+		IntegrityScrubEnabled:        true,
+		IntegrityScrubIntervalHours:  168,
+		IntegrityScrubFilesPerSecond: 20,
 	}
 
 	// Load settings from database
@@ -134,6 +164,18 @@ func LoadSettings(db *gorm.DB) error {
 			}
 		case "replication_require_checksum":
 			appSettings.ReplicationRequireChecksum = setting.Value == "true"
+		case "replicas_hot":
+			if v, err := strconv.Atoi(setting.Value); err == nil {
+				appSettings.ReplicasHot = v
+			}
+		case "replicas_warm":
+			if v, err := strconv.Atoi(setting.Value); err == nil {
+				appSettings.ReplicasWarm = v
+			}
+		case "replicas_cold":
+			if v, err := strconv.Atoi(setting.Value); err == nil {
+				appSettings.ReplicasCold = v
+			}
 		case "tiering_enabled":
 			appSettings.TieringEnabled = setting.Value == "true"
 		case "hot_keep_days_after_upload":
@@ -164,6 +206,32 @@ func LoadSettings(db *gorm.DB) error {
 			if v, err := strconv.Atoi(setting.Value); err == nil {
 				appSettings.TieringSweepIntervalMinutes = v
 			}
+		case "require_admin_2fa":
+			appSettings.RequireAdmin2FA = setting.Value == "true"
+		case "transform_cache_max_mb_per_pool":
+			if v, err := strconv.Atoi(setting.Value); err == nil {
+				appSettings.TransformCacheMaxMBPerPool = v
+			}
+		case "similar_image_max_distance":
+			if v, err := strconv.Atoi(setting.Value); err == nil {
+				appSettings.SimilarImageMaxDistance = v
+			}
+		case "near_duplicate_upload_warning":
+			appSettings.NearDuplicateUploadWarning = setting.Value == "true"
+		case "optimization_enabled":
+			appSettings.OptimizationEnabled = setting.Value == "true"
+		case "optimize_originals":
+			appSettings.OptimizeOriginals = setting.Value == "true"
+		case "integrity_scrub_enabled":
+			appSettings.IntegrityScrubEnabled = setting.Value == "true"
+		case "integrity_scrub_interval_hours":
+			if v, err := strconv.Atoi(setting.Value); err == nil {
+				appSettings.IntegrityScrubIntervalHours = v
+			}
+		case "integrity_scrub_files_per_second":
+			if v, err := strconv.Atoi(setting.Value); err == nil {
+				appSettings.IntegrityScrubFilesPerSecond = v
+			}
 		}
 	}
 
@@ -194,6 +262,9 @@ func SaveSettings(db *gorm.DB, settings *AppSettings) error {
 		"job_queue_worker_count":            fmt.Sprintf("%d", settings.JobQueueWorkerCount),
 		"api_rate_limit_per_minute":         fmt.Sprintf("%d", settings.APIRateLimitPerMinute),
 		"replication_require_checksum":      fmt.Sprintf("%t", settings.ReplicationRequireChecksum),
+		"replicas_hot":                      fmt.Sprintf("%d", settings.ReplicasHot),
+		"replicas_warm":                     fmt.Sprintf("%d", settings.ReplicasWarm),
+		"replicas_cold":                     fmt.Sprintf("%d", settings.ReplicasCold),
 		// Tiering
 		"tiering_enabled":                  fmt.Sprintf("%t", settings.TieringEnabled),
 		"hot_keep_days_after_upload":       fmt.Sprintf("%d", settings.HotKeepDaysAfterUpload),
@@ -203,6 +274,20 @@ func SaveSettings(db *gorm.DB, settings *AppSettings) error {
 		"hot_watermark_low":                fmt.Sprintf("%d", settings.HotWatermarkLow),
 		"max_tiering_candidates_per_sweep": fmt.Sprintf("%d", settings.MaxTieringCandidatesPerSweep),
 		"tiering_sweep_interval_minutes":   fmt.Sprintf("%d", settings.TieringSweepIntervalMinutes),
+		// Security
+		"require_admin_2fa": fmt.Sprintf("%t", settings.RequireAdmin2FA),
+		// Transformations
+		"transform_cache_max_mb_per_pool": fmt.Sprintf("%d", settings.TransformCacheMaxMBPerPool),
+		// Near-duplicate detection
+		"similar_image_max_distance":    fmt.Sprintf("%d", settings.SimilarImageMaxDistance),
+		"near_duplicate_upload_warning": fmt.Sprintf("%t", settings.NearDuplicateUploadWarning),
+		// Optimisation
+		"optimization_enabled": fmt.Sprintf("%t", settings.OptimizationEnabled),
+		"optimize_originals":   fmt.Sprintf("%t", settings.OptimizeOriginals),
+		// Integrity scrub
+		"integrity_scrub_enabled":          fmt.Sprintf("%t", settings.IntegrityScrubEnabled),
+		"integrity_scrub_interval_hours":   fmt.Sprintf("%d", settings.IntegrityScrubIntervalHours),
+		"integrity_scrub_files_per_second": fmt.Sprintf("%d", settings.IntegrityScrubFilesPerSecond),
 	}
 
 	// Save each setting
@@ -243,9 +328,9 @@ func getSettingType(key string) string {
 	switch key {
 	case "site_title", "site_description":
 		return "string"
-	case "image_upload_enabled", "direct_upload_enabled", "thumbnail_original_enabled", "thumbnail_webp_enabled", "thumbnail_avif_enabled", "replication_require_checksum", "tiering_enabled":
+	case "image_upload_enabled", "direct_upload_enabled", "thumbnail_original_enabled", "thumbnail_webp_enabled", "thumbnail_avif_enabled", "replication_require_checksum", "tiering_enabled", "require_admin_2fa", "near_duplicate_upload_warning", "optimization_enabled", "optimize_originals", "integrity_scrub_enabled":
 		return "boolean"
-	case "job_queue_worker_count", "upload_rate_limit_per_minute", "upload_user_rate_limit_per_minute", "hot_keep_days_after_upload", "demote_if_no_views_days", "min_dwell_days_per_tier", "hot_watermark_high", "hot_watermark_low", "max_tiering_candidates_per_sweep", "tiering_sweep_interval_minutes", "api_rate_limit_per_minute":
+	case "job_queue_worker_count", "upload_rate_limit_per_minute", "upload_user_rate_limit_per_minute", "hot_keep_days_after_upload", "demote_if_no_views_days", "min_dwell_days_per_tier", "hot_watermark_high", "hot_watermark_low", "max_tiering_candidates_per_sweep", "tiering_sweep_interval_minutes", "api_rate_limit_per_minute", "transform_cache_max_mb_per_pool", "similar_image_max_distance", "integrity_scrub_interval_hours", "integrity_scrub_files_per_second", "replicas_hot", "replicas_warm", "replicas_cold":
 		return "integer"
 	default:
 		return "string"
@@ -356,6 +441,26 @@ func (s *AppSettings) IsReplicationChecksumRequired() bool {
 	return s.ReplicationRequireChecksum
 }
 
+// GetReplicaCount returns how many copies of an image the tier keeps, the primary included.
+// Archive pools and unknown tiers keep a single copy.
+func (s *AppSettings) GetReplicaCount(tier string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	count := 1
+	switch tier {
+	case StorageTierHot:
+		count = s.ReplicasHot
+	case StorageTierWarm:
+		count = s.ReplicasWarm
+	case StorageTierCold:
+		count = s.ReplicasCold
+	}
+	if count < 1 {
+		return 1
+	}
+	return count
+}
+
 // Tiering getters
 func (s *AppSettings) IsTieringEnabled() bool {
 	s.mu.RLock()
@@ -404,3 +509,66 @@ func (s *AppSettings) GetTieringSweepIntervalMinutes() int {
 	defer s.mu.RUnlock()
 	return s.TieringSweepIntervalMinutes
 }
+
+// IsAdmin2FARequired returns whether admin accounts must use two-factor authentication
+func (s *AppSettings) IsAdmin2FARequired() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.RequireAdmin2FA
+}
+
+// GetTransformCacheMaxMBPerPool returns the size limit of the transformation cache per storage pool (0 = unlimited)
+func (s *AppSettings) GetTransformCacheMaxMBPerPool() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.TransformCacheMaxMBPerPool
+}
+
+// GetSimilarImageMaxDistance returns the maximum Hamming distance between perceptual hashes of near-duplicate images
+func (s *AppSettings) GetSimilarImageMaxDistance() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.SimilarImageMaxDistance
+}
+
+// IsNearDuplicateUploadWarningEnabled returns whether uploaders are warned about near-duplicates in their library
+func (s *AppSettings) IsNearDuplicateUploadWarningEnabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.NearDuplicateUploadWarning
+}
+
+// IsOptimizationEnabled returns whether original-format variants are optimised after encoding
+func (s *AppSettings) IsOptimizationEnabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.OptimizationEnabled
+}
+
+// IsOriginalOptimizationEnabled returns whether stored originals are optimised losslessly as well
+func (s *AppSettings) IsOriginalOptimizationEnabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.OptimizationEnabled && s.OptimizeOriginals
+}
+
+// IsIntegrityScrubEnabled returns whether storage pools are scrubbed on a schedule
+func (s *AppSettings) IsIntegrityScrubEnabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.IntegrityScrubEnabled
+}
+
+// GetIntegrityScrubIntervalHours returns the hours between two scheduled scrubs of a pool
+func (s *AppSettings) GetIntegrityScrubIntervalHours() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.IntegrityScrubIntervalHours
+}
+
+// GetIntegrityScrubFilesPerSecond returns how many files a scrub checks per second and pool
+func (s *AppSettings) GetIntegrityScrubFilesPerSecond() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.IntegrityScrubFilesPerSecond
+}
@@ -27,6 +27,19 @@ const (
 	StorageTypeS3    = "s3"    // S3-compatible storage (AWS S3, Backblaze B2, MinIO, etc.)
 )
 
+// S3 delivery mode constants
+const (
+	S3DeliveryProxy   = "proxy"   // Objects are streamed through the application
+	S3DeliveryPresign = "presign" // Clients are redirected to a short-lived presigned URL
+)
+
+// Bounds of the presigned URL lifetime in seconds; S3 allows at most 7 days
+const (
+	DefaultS3PresignTTL = 300
+	MinS3PresignTTL     = 30
+	MaxS3PresignTTL     = 7 * 24 * 60 * 60
+)
+
 // StoragePool represents a storage location for images and variants
 type StoragePool struct {
 	ID          uint   `gorm:"primaryKey" json:"id"`
@@ -42,13 +55,15 @@ type StoragePool struct {
 	Description string `gorm:"type:text" json:"description"`                                                                                                     // Optional description
 
 	// S3-specific configuration fields (only used when StorageType = 's3')
-	// Note: These credentials should be encrypted at rest in production
+	// Access key ID and secret are encrypted at rest with STORAGE_CREDENTIAL_KEYS, see storage_pool_credentials.go
 	S3AccessKeyID     *string `gorm:"type:varchar(255)" json:"s3_access_key_id,omitempty"`          // S3 Access Key ID (nullable for security)
 	S3SecretAccessKey *string `gorm:"type:varchar(500)" json:"-"`                                   // S3 Secret Key (excluded from JSON for security)
 	S3Region          *string `gorm:"type:varchar(100)" json:"s3_region,omitempty"`                 // S3 Region (e.g., us-west-2, us-west-001 for Backblaze B2)
 	S3BucketName      *string `gorm:"type:varchar(255)" json:"s3_bucket_name,omitempty"`            // S3 Bucket name
 	S3EndpointURL     *string `gorm:"type:varchar(500)" json:"s3_endpoint_url,omitempty"`           // S3 Endpoint URL (for S3-compatible services like Backblaze B2, MinIO)
 	S3PathPrefix      *string `gorm:"type:varchar(500);default:''" json:"s3_path_prefix,omitempty"` // Optional path prefix within bucket for organizing files
+	S3DeliveryMode    string  `gorm:"type:varchar(20);default:'proxy'" json:"s3_delivery_mode"`     // proxy or presign
+	S3PresignTTL      int     `gorm:"default:300" json:"s3_presign_ttl"`                            // Lifetime of presigned URLs in seconds
 
 	// Node-aware multi-VPS fields
 	PublicBaseURL string `gorm:"type:varchar(500);default:''" json:"public_base_url,omitempty"` // Public base URL for serving files, e.g. https://s01.pixelfox.cc
@@ -278,6 +293,28 @@ func (sp *StoragePool) GetS3PathPrefix() string {
 	return ""
 }
 
+// GetS3DeliveryMode returns how objects of this pool are delivered, proxy by default
+func (sp *StoragePool) GetS3DeliveryMode() string {
+	if sp.S3DeliveryMode == S3DeliveryPresign {
+		return S3DeliveryPresign
+	}
+	return S3DeliveryProxy
+}
+
+// GetS3PresignTTL returns the lifetime of presigned URLs, clamped to what S3 accepts
+func (sp *StoragePool) GetS3PresignTTL() time.Duration {
+	ttl := sp.S3PresignTTL
+	switch {
+	case ttl <= 0:
+		ttl = DefaultS3PresignTTL
+	case ttl < MinS3PresignTTL:
+		ttl = MinS3PresignTTL
+	case ttl > MaxS3PresignTTL:
+		ttl = MaxS3PresignTTL
+	}
+	return time.Duration(ttl) * time.Second
+}
+
 // SetS3Credentials sets S3 credentials (helper method for safe credential handling)
 func (sp *StoragePool) SetS3Credentials(accessKeyID, secretAccessKey string) {
 	accessKey := strings.TrimSpace(accessKeyID)
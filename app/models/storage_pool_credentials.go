@@ -0,0 +1,206 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/security"
+)
+
+// StorageCredentialKeysEnv names the key list S3 credentials are encrypted with, "id:base64key,..."
+// with 32 byte keys. The first key encrypts, the others are kept to read values not rotated yet.
+const StorageCredentialKeysEnv = "STORAGE_CREDENTIAL_KEYS"
+
+// Purposes authenticated with each encrypted column
+const (
+	s3AccessKeyIDPurpose     = "storage_pools.s3_access_key_id"
+	s3SecretAccessKeyPurpose = "storage_pools.s3_secret_access_key"
+)
+
+var (
+	credentialCipherOnce sync.Once
+	credentialCipher     *security.CredentialCipher
+	credentialCipherErr  error
+)
+
+// storageCredentialCipher returns the cipher from STORAGE_CREDENTIAL_KEYS; nil keeps credentials in plaintext
+func storageCredentialCipher() (*security.CredentialCipher, error) {
+	credentialCipherOnce.Do(func() {
+		credentialCipher, credentialCipherErr = security.NewCredentialCipher(env.GetEnv(StorageCredentialKeysEnv, ""))
+		if credentialCipherErr != nil {
+			log.Errorf("[StoragePool] Invalid %s, S3 credentials cannot be saved: %v", StorageCredentialKeysEnv, credentialCipherErr)
+		} else if credentialCipher == nil {
+			log.Warnf("[StoragePool] %s is not set, S3 credentials are stored in plaintext", StorageCredentialKeysEnv)
+		}
+	})
+	return credentialCipher, credentialCipherErr
+}
+
+// SetStorageCredentialCipher replaces the cipher loaded from the environment, e.g. in tests
+func SetStorageCredentialCipher(c *security.CredentialCipher) {
+	credentialCipherOnce.Do(func() {})
+	credentialCipher, credentialCipherErr = c, nil
+}
+
+// BeforeSave encrypts the S3 credentials with the current key
+func (sp *StoragePool) BeforeSave(tx *gorm.DB) error {
+	return sp.EncryptCredentials()
+}
+
+// AfterSave restores the plaintext credentials on the saved struct
+func (sp *StoragePool) AfterSave(tx *gorm.DB) error {
+	sp.decryptCredentialsOrWarn()
+	return nil
+}
+
+// AfterFind decrypts the S3 credentials
+func (sp *StoragePool) AfterFind(tx *gorm.DB) error {
+	sp.decryptCredentialsOrWarn()
+	return nil
+}
+
+// EncryptCredentials encrypts plaintext credentials and re-encrypts those of older keys with the current key
+func (sp *StoragePool) EncryptCredentials() error {
+	c, err := storageCredentialCipher()
+	if err != nil {
+		if sp.S3AccessKeyID != nil || sp.S3SecretAccessKey != nil {
+			return fmt.Errorf("cannot encrypt S3 credentials: %w", err)
+		}
+		return nil
+	}
+	if c == nil {
+		return nil
+	}
+	if err := encryptCredential(c, sp.S3AccessKeyID, s3AccessKeyIDPurpose); err != nil {
+		return fmt.Errorf("failed to encrypt S3 access key ID: %w", err)
+	}
+	if err := encryptCredential(c, sp.S3SecretAccessKey, s3SecretAccessKeyPurpose); err != nil {
+		return fmt.Errorf("failed to encrypt S3 secret access key: %w", err)
+	}
+	return nil
+}
+
+// DecryptCredentials replaces encrypted credentials with their plaintext
+func (sp *StoragePool) DecryptCredentials() error {
+	if err := decryptCredential(sp.S3AccessKeyID, s3AccessKeyIDPurpose); err != nil {
+		return fmt.Errorf("failed to decrypt S3 access key ID: %w", err)
+	}
+	if err := decryptCredential(sp.S3SecretAccessKey, s3SecretAccessKeyPurpose); err != nil {
+		return fmt.Errorf("failed to decrypt S3 secret access key: %w", err)
+	}
+	return nil
+}
+
+// decryptCredentialsOrWarn leaves credentials that cannot be decrypted encrypted, so listing pools keeps working
+func (sp *StoragePool) decryptCredentialsOrWarn() {
+	if err := sp.DecryptCredentials(); err != nil {
+		log.Warnf("[StoragePool] Pool %s: %v", sp.Name, err)
+	}
+}
+
+func encryptCredential(c *security.CredentialCipher, value *string, purpose string) error {
+	if value == nil || *value == "" {
+		return nil
+	}
+	if id, encrypted := security.CredentialKeyID(*value); encrypted {
+		if id == c.CurrentKeyID() {
+			return nil
+		}
+		plaintext, err := c.Decrypt(*value, purpose)
+		if err != nil {
+			return err
+		}
+		*value = plaintext
+	}
+	encrypted, err := c.Encrypt(*value, purpose)
+	if err != nil {
+		return err
+	}
+	*value = encrypted
+	return nil
+}
+
+func decryptCredential(value *string, purpose string) error {
+	if value == nil {
+		return nil
+	}
+	if _, encrypted := security.CredentialKeyID(*value); !encrypted {
+		return nil
+	}
+	c, err := storageCredentialCipher()
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		return fmt.Errorf("credential is encrypted but %s is not set", StorageCredentialKeysEnv)
+	}
+	plaintext, err := c.Decrypt(*value, purpose)
+	if err != nil {
+		return err
+	}
+	*value = plaintext
+	return nil
+}
+
+// storedCredentials are the credential columns as stored, read without the decrypting hooks
+type storedCredentials struct {
+	ID                uint
+	Name              string
+	S3AccessKeyID     *string
+	S3SecretAccessKey *string
+}
+
+// ReencryptStoragePoolCredentials encrypts all S3 credentials that are stored in plaintext or with
+// another than the current key. It returns the number of updated pools.
+func ReencryptStoragePoolCredentials(db *gorm.DB) (int, error) {
+	c, err := storageCredentialCipher()
+	if err != nil {
+		return 0, err
+	}
+	if c == nil {
+		return 0, fmt.Errorf("%s is not set", StorageCredentialKeysEnv)
+	}
+	var rows []storedCredentials
+	if err := db.Table("storage_pools").Select("id", "name", "s3_access_key_id", "s3_secret_access_key").
+		Order("id ASC").Scan(&rows).Error; err != nil {
+		return 0, fmt.Errorf("failed to load storage pools: %w", err)
+	}
+
+	updated := 0
+	var errs []error
+	for _, row := range rows {
+		if isCurrentCredential(c, row.S3AccessKeyID) && isCurrentCredential(c, row.S3SecretAccessKey) {
+			continue
+		}
+		if err := encryptCredential(c, row.S3AccessKeyID, s3AccessKeyIDPurpose); err != nil {
+			errs = append(errs, fmt.Errorf("pool %s: access key ID: %w", row.Name, err))
+			continue
+		}
+		if err := encryptCredential(c, row.S3SecretAccessKey, s3SecretAccessKeyPurpose); err != nil {
+			errs = append(errs, fmt.Errorf("pool %s: secret access key: %w", row.Name, err))
+			continue
+		}
+		if err := db.Model(&StoragePool{}).Where("id = ?", row.ID).UpdateColumns(map[string]interface{}{
+			"s3_access_key_id":     row.S3AccessKeyID,
+			"s3_secret_access_key": row.S3SecretAccessKey,
+		}).Error; err != nil {
+			errs = append(errs, fmt.Errorf("pool %s: %w", row.Name, err))
+			continue
+		}
+		updated++
+	}
+	return updated, errors.Join(errs...)
+}
+
+func isCurrentCredential(c *security.CredentialCipher, value *string) bool {
+	if value == nil || *value == "" {
+		return true
+	}
+	id, encrypted := security.CredentialKeyID(*value)
+	return encrypted && id == c.CurrentKeyID()
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ManuelReschke/PixelFox/internal/pkg/security"
+)
+
+func useCredentialKeys(t *testing.T, spec string) {
+	t.Helper()
+	c, err := security.NewCredentialCipher(spec)
+	require.NoError(t, err)
+	SetStorageCredentialCipher(c)
+	t.Cleanup(func() { SetStorageCredentialCipher(nil) })
+}
+
+func credentialKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), 32)))
+}
+
+func TestStoragePoolCredentialEncryption(t *testing.T) {
+	useCredentialKeys(t, "k1:"+credentialKey('a'))
+	accessKey, secretKey := "AKIA123", "top-secret"
+	pool := StoragePool{S3AccessKeyID: &accessKey, S3SecretAccessKey: &secretKey}
+
+	require.NoError(t, pool.EncryptCredentials())
+	assert.True(t, strings.HasPrefix(*pool.S3AccessKeyID, "enc:k1:"))
+	assert.True(t, strings.HasPrefix(*pool.S3SecretAccessKey, "enc:k1:"))
+	stored := *pool.S3SecretAccessKey
+
+	// Encrypting again keeps values of the current key
+	require.NoError(t, pool.EncryptCredentials())
+	assert.Equal(t, stored, *pool.S3SecretAccessKey)
+
+	require.NoError(t, pool.DecryptCredentials())
+	assert.Equal(t, "AKIA123", pool.GetS3AccessKeyID())
+	assert.Equal(t, "top-secret", pool.GetS3SecretAccessKey())
+
+	// After a rotation values of the old key are re-encrypted with the new one
+	pool.S3SecretAccessKey = &stored
+	useCredentialKeys(t, "k2:"+credentialKey('b')+",k1:"+credentialKey('a'))
+	require.NoError(t, pool.EncryptCredentials())
+	assert.True(t, strings.HasPrefix(*pool.S3SecretAccessKey, "enc:k2:"))
+	require.NoError(t, pool.DecryptCredentials())
+	assert.Equal(t, "top-secret", pool.GetS3SecretAccessKey())
+}
+
+func TestStoragePoolCredentialsWithoutKeys(t *testing.T) {
+	SetStorageCredentialCipher(nil)
+	secretKey := "top-secret"
+	pool := StoragePool{S3SecretAccessKey: &secretKey}
+	require.NoError(t, pool.EncryptCredentials())
+	assert.Equal(t, "top-secret", pool.GetS3SecretAccessKey())
+
+	// Encrypted values cannot be read without the key
+	encrypted := "enc:k1:AAAA"
+	pool.S3SecretAccessKey = &encrypted
+	assert.Error(t, pool.DecryptCredentials())
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoragePoolS3Delivery(t *testing.T) {
+	pool := StoragePool{}
+	assert.Equal(t, S3DeliveryProxy, pool.GetS3DeliveryMode())
+	assert.Equal(t, DefaultS3PresignTTL*time.Second, pool.GetS3PresignTTL())
+
+	pool.S3DeliveryMode = "cdn"
+	assert.Equal(t, S3DeliveryProxy, pool.GetS3DeliveryMode())
+	pool.S3DeliveryMode = S3DeliveryPresign
+	assert.Equal(t, S3DeliveryPresign, pool.GetS3DeliveryMode())
+
+	pool.S3PresignTTL = 5
+	assert.Equal(t, MinS3PresignTTL*time.Second, pool.GetS3PresignTTL())
+	pool.S3PresignTTL = 30 * 24 * 60 * 60
+	assert.Equal(t, MaxS3PresignTTL*time.Second, pool.GetS3PresignTTL())
+	pool.S3PresignTTL = 900
+	assert.Equal(t, 15*time.Minute, pool.GetS3PresignTTL())
+}
@@ -1,18 +1,30 @@
 package models
 
 import (
+	"strings"
 	"time"
+	"unicode"
 
 	"gorm.io/gorm"
 )
 
+const (
+	// TagMinLength is the minimum number of characters of a normalized tag
+	TagMinLength = 2
+	// TagMaxLength is the maximum number of characters of a normalized tag
+	TagMaxLength = 32
+	// MaxTagsPerImage caps the number of tags an image can have
+	MaxTagsPerImage = 15
+)
+
 type Tag struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Name      string         `gorm:"type:varchar(100) CHARACTER SET utf8 COLLATE utf8_bin;uniqueIndex" json:"name" validate:"required,min=2,max=100"`
-	Images    []Image        `gorm:"many2many:image_tags;" json:"images,omitempty"`
-	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	Name       string         `gorm:"type:varchar(100) CHARACTER SET utf8 COLLATE utf8_bin;uniqueIndex" json:"name" validate:"required,min=2,max=100"`
+	ImageCount int            `gorm:"default:0;index" json:"image_count"` // zwischengespeicherte Anzahl der Bilder mit diesem Tag
+	Images     []Image        `gorm:"many2many:image_tags;" json:"images,omitempty"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // FindOrCreate findet einen Tag anhand des Namens oder erstellt ihn, wenn er nicht existiert
@@ -26,3 +38,95 @@ func (t *Tag) FindOrCreate(db *gorm.DB) error {
 	}
 	return nil
 }
+
+// NormalizeTagName normalisiert einen Tag: Kleinbuchstaben, ohne führendes #,
+// Leerzeichen werden zu Bindestrichen, nur Buchstaben, Ziffern, - und _.
+// Liefert einen leeren String, wenn der Tag danach zu kurz ist.
+func NormalizeTagName(name string) string {
+	name = strings.TrimLeft(strings.TrimSpace(strings.ToLower(name)), "#")
+
+	var b strings.Builder
+	lastDash := false
+	count := 0
+	for _, r := range name {
+		if count >= TagMaxLength {
+			break
+		}
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			b.WriteRune(r)
+			lastDash = false
+		case r == '-' || unicode.IsSpace(r):
+			if lastDash || b.Len() == 0 {
+				continue
+			}
+			b.WriteRune('-')
+			lastDash = true
+		default:
+			continue
+		}
+		count++
+	}
+
+	normalized := strings.TrimRight(b.String(), "-")
+	if len([]rune(normalized)) < TagMinLength {
+		return ""
+	}
+	return normalized
+}
+
+// ParseTagList zerlegt eine komma-separierte Eingabe in normalisierte, eindeutige Tags
+// und begrenzt sie auf MaxTagsPerImage
+func ParseTagList(input string) []string {
+	parts := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n'
+	})
+	seen := make(map[string]struct{}, len(parts))
+	tags := make([]string, 0, len(parts))
+	for _, part := range parts {
+		tag := NormalizeTagName(part)
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		tags = append(tags, tag)
+		if len(tags) == MaxTagsPerImage {
+			break
+		}
+	}
+	return tags
+}
+
+// TagNames liefert die Namen der Tags
+func TagNames(tags []Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// RefreshTagCounts berechnet die zwischengespeicherte Bildanzahl der angegebenen Tags neu
+func RefreshTagCounts(db *gorm.DB, tagIDs []uint) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	return db.Exec(`UPDATE tags SET image_count = (
+		SELECT COUNT(*) FROM image_tags
+		JOIN images ON images.id = image_tags.image_id AND images.deleted_at IS NULL
+		WHERE image_tags.tag_id = tags.id
+	) WHERE id IN ?`, tagIDs).Error
+}
+
+// PruneUnusedTags löscht Tags ohne Bilder endgültig; ohne IDs werden alle Tags geprüft
+func PruneUnusedTags(db *gorm.DB, tagIDs []uint) (int64, error) {
+	query := db.Unscoped().Where("NOT EXISTS (SELECT 1 FROM image_tags WHERE image_tags.tag_id = tags.id)")
+	if len(tagIDs) > 0 {
+		query = query.Where("id IN ?", tagIDs)
+	}
+	result := query.Delete(&Tag{})
+	return result.RowsAffected, result.Error
+}
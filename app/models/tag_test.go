@@ -0,0 +1,28 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTagName(t *testing.T) {
+	assert.Equal(t, "sunset", NormalizeTagName("  #Sunset "))
+	assert.Equal(t, "new-york", NormalizeTagName("New   York"))
+	assert.Equal(t, "straße", NormalizeTagName("Straße!"))
+	assert.Equal(t, "a-b", NormalizeTagName("-a - b-"))
+	assert.Equal(t, "", NormalizeTagName("x"))
+	assert.Equal(t, "", NormalizeTagName("!!"))
+	assert.Len(t, []rune(NormalizeTagName(strings.Repeat("ä", 50))), TagMaxLength)
+}
+
+func TestParseTagList(t *testing.T) {
+	assert.Equal(t, []string{"cat", "dog"}, ParseTagList("Cat, dog; CAT,, x"))
+
+	var many []string
+	for i := 0; i < MaxTagsPerImage+5; i++ {
+		many = append(many, "tag"+strings.Repeat("a", i))
+	}
+	assert.Len(t, ParseTagList(strings.Join(many, ",")), MaxTagsPerImage)
+}
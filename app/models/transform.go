@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TransformPreset is an admin-approved transformation spec. Only specs on this allow-list
+// are rendered by the signed transformation endpoint.
+type TransformPreset struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"name"`
+	Spec      string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"spec"` // canonical spec, e.g. 800x600,fit=cover,fmt=webp,q=80
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DerivedVariant is a cached on-the-fly transformation of an image stored in the image's pool.
+// Entries are evicted least-recently-used first when the pool's cache limit is exceeded.
+type DerivedVariant struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	ImageID        uint      `gorm:"uniqueIndex:idx_derived_image_spec;not null" json:"image_id"`
+	StoragePoolID  uint      `gorm:"index;not null" json:"storage_pool_id"`
+	Spec           string    `gorm:"type:varchar(100);uniqueIndex:idx_derived_image_spec;not null" json:"spec"`
+	FilePath       string    `gorm:"type:varchar(500);not null" json:"file_path"` // relative to the pool base path
+	FileSize       int64     `gorm:"type:bigint;not null" json:"file_size"`
+	Width          int       `json:"width"`
+	Height         int       `json:"height"`
+	ContentType    string    `gorm:"type:varchar(50);not null" json:"content_type"`
+	HitCount       int64     `gorm:"default:0" json:"hit_count"`
+	LastAccessedAt time.Time `gorm:"index" json:"last_accessed_at"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// FindDerivedVariantsByImageID returns all cached transformations of an image
+func FindDerivedVariantsByImageID(db *gorm.DB, imageID uint) ([]DerivedVariant, error) {
+	var variants []DerivedVariant
+	err := db.Where("image_id = ?", imageID).Find(&variants).Error
+	return variants, err
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
 	"time"
 
@@ -10,6 +11,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// PasswordResetTokenTTL defines how long a password reset link stays valid
+const PasswordResetTokenTTL = 1 * time.Hour
+
 const (
 	ROLE_USER       = "user"
 	ROLE_ADMIN      = "admin"
@@ -19,26 +23,31 @@ const (
 )
 
 type User struct {
-	ID                uint              `gorm:"primaryKey" json:"id"`
-	Name              string            `gorm:"type:varchar(150)" json:"name" validate:"required,min=3,max=150"`
-	Email             string            `gorm:"uniqueIndex;type:varchar(200) CHARACTER SET utf8 COLLATE utf8_bin" json:"email" validate:"required,email,min=5,max=200"`
-	Password          string            `gorm:"type:text" json:"-" validate:"required,min=6"`
-	Role              string            `gorm:"type:varchar(50);default:'user'" json:"role" validate:"oneof=user admin"`
-	Status            string            `gorm:"type:varchar(50);default:'active'" json:"status" validate:"oneof=active inactive disabled"`
-	Bio               string            `gorm:"type:text;default:null" json:"bio" validate:"max=1000"`
-	AvatarURL         string            `gorm:"type:varchar(255);default:null" json:"avatar_url" validate:"max=255"`
-	IPv4              string            `gorm:"type:varchar(15);default:null" json:"-"`
-	IPv6              string            `gorm:"type:varchar(45);default:null" json:"-"`
-	ActivationToken   string            `gorm:"type:varchar(100);index" json:"-"`
-	ActivationSentAt  *time.Time        `gorm:"type:timestamp;default:null" json:"-"`
-	PendingEmail      string            `gorm:"type:varchar(200);default:null" json:"-"`       // New email waiting for verification
-	EmailChangeToken  string            `gorm:"type:varchar(100);default:null;index" json:"-"` // Token for email change verification
-	EmailChangeSentAt *time.Time        `gorm:"type:timestamp;default:null" json:"-"`          // When email change token was sent
-	LastLoginAt       *time.Time        `gorm:"type:timestamp;default:null" json:"last_login_at"`
-	CreatedAt         time.Time         `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt         time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt         gorm.DeletedAt    `gorm:"index" json:"-"`
-	Accounts          []ProviderAccount `gorm:"foreignKey:UserID" json:"accounts,omitempty"`
+	ID                  uint              `gorm:"primaryKey" json:"id"`
+	Name                string            `gorm:"type:varchar(150)" json:"name" validate:"required,min=3,max=150"`
+	Email               string            `gorm:"uniqueIndex;type:varchar(200) CHARACTER SET utf8 COLLATE utf8_bin" json:"email" validate:"required,email,min=5,max=200"`
+	Password            string            `gorm:"type:text" json:"-" validate:"required,min=6"`
+	Role                string            `gorm:"type:varchar(50);default:'user'" json:"role" validate:"oneof=user admin"`
+	Status              string            `gorm:"type:varchar(50);default:'active'" json:"status" validate:"oneof=active inactive disabled"`
+	Bio                 string            `gorm:"type:text;default:null" json:"bio" validate:"max=1000"`
+	AvatarURL           string            `gorm:"type:varchar(255);default:null" json:"avatar_url" validate:"max=255"`
+	IPv4                string            `gorm:"type:varchar(15);default:null" json:"-"`
+	IPv6                string            `gorm:"type:varchar(45);default:null" json:"-"`
+	ActivationToken     string            `gorm:"type:varchar(100);index" json:"-"`
+	ActivationSentAt    *time.Time        `gorm:"type:timestamp;default:null" json:"-"`
+	PendingEmail        string            `gorm:"type:varchar(200);default:null" json:"-"`       // New email waiting for verification
+	EmailChangeToken    string            `gorm:"type:varchar(100);default:null;index" json:"-"` // Token for email change verification
+	EmailChangeSentAt   *time.Time        `gorm:"type:timestamp;default:null" json:"-"`          // When email change token was sent
+	PasswordResetToken  string            `gorm:"type:varchar(100);default:null;index" json:"-"` // Single-use token for password reset
+	PasswordResetSentAt *time.Time        `gorm:"type:timestamp;default:null" json:"-"`          // When password reset token was sent
+	TwoFactorSecret     string            `gorm:"type:varchar(64);default:null" json:"-"`        // Base32 TOTP secret, set once 2FA is confirmed
+	TwoFactorEnabledAt  *time.Time        `gorm:"type:timestamp;default:null" json:"-"`          // When 2FA was enabled
+	TwoFactorLastStep   int64             `gorm:"default:0" json:"-"`                            // Last accepted TOTP time step (replay protection)
+	LastLoginAt         *time.Time        `gorm:"type:timestamp;default:null" json:"last_login_at"`
+	CreatedAt           time.Time         `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt           time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt           gorm.DeletedAt    `gorm:"index" json:"-"`
+	Accounts            []ProviderAccount `gorm:"foreignKey:UserID" json:"accounts,omitempty"`
 }
 
 func (u *User) Validate() error {
@@ -149,3 +158,52 @@ func (u *User) ClearEmailChangeRequest() {
 	u.EmailChangeToken = ""
 	u.EmailChangeSentAt = nil
 }
+
+// GeneratePasswordResetToken creates a random single-use token for resetting the password
+func (u *User) GeneratePasswordResetToken() error {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	u.PasswordResetToken = hex.EncodeToString(b)
+	now := time.Now()
+	u.PasswordResetSentAt = &now
+	return nil
+}
+
+// IsPasswordResetTokenValid checks if the password reset token matches and is not expired
+func (u *User) IsPasswordResetTokenValid(token string) bool {
+	if u.PasswordResetToken == "" || u.PasswordResetSentAt == nil || token == "" {
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(u.PasswordResetToken), []byte(token)) != 1 {
+		return false
+	}
+	return time.Since(*u.PasswordResetSentAt) < PasswordResetTokenTTL
+}
+
+// ClearPasswordResetRequest invalidates any pending password reset token
+func (u *User) ClearPasswordResetRequest() {
+	u.PasswordResetToken = ""
+	u.PasswordResetSentAt = nil
+}
+
+// HasTwoFactor reports whether TOTP two-factor authentication is enabled for the user
+func (u *User) HasTwoFactor() bool {
+	return u.TwoFactorSecret != "" && u.TwoFactorEnabledAt != nil
+}
+
+// EnableTwoFactor stores a confirmed TOTP secret and the time step used for confirmation
+func (u *User) EnableTwoFactor(secret string, step int64) {
+	now := time.Now()
+	u.TwoFactorSecret = secret
+	u.TwoFactorEnabledAt = &now
+	u.TwoFactorLastStep = step
+}
+
+// DisableTwoFactor removes the TOTP secret
+func (u *User) DisableTwoFactor() {
+	u.TwoFactorSecret = ""
+	u.TwoFactorEnabledAt = nil
+	u.TwoFactorLastStep = 0
+}
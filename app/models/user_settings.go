@@ -1,11 +1,6 @@
 package models
 
 import (
-	"crypto/rand"
-	"crypto/sha256"
-	"encoding/base32"
-	"encoding/hex"
-	"fmt"
 	"strings"
 	"time"
 
@@ -14,32 +9,46 @@ import (
 
 // UserSettings stores per-user preferences and plan info
 type UserSettings struct {
-	ID                uint           `gorm:"primaryKey" json:"id"`
-	UserID            uint           `gorm:"uniqueIndex" json:"user_id"`
-	Plan              string         `gorm:"type:varchar(50);default:'free'" json:"plan"`
-	PrefThumbOriginal bool           `gorm:"default:true" json:"pref_thumb_original"`
-	PrefThumbWebP     bool           `gorm:"default:false" json:"pref_thumb_webp"`
-	PrefThumbAVIF     bool           `gorm:"default:false" json:"pref_thumb_avif"`
-	APIKeyHash        string         `gorm:"type:char(64);default:''" json:"-"`
-	APIKeyPrefix      string         `gorm:"type:varchar(20);default:''" json:"api_key_prefix"`
-	APIKeyCreatedAt   *time.Time     `json:"api_key_created_at"`
-	APIKeyLastUsedAt  *time.Time     `json:"api_key_last_used_at"`
-	APIKeyRevokedAt   *time.Time     `json:"api_key_revoked_at"`
-	CreatedAt         time.Time      `json:"created_at"`
-	UpdatedAt         time.Time      `json:"updated_at"`
-	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                uint            `gorm:"primaryKey" json:"id"`
+	UserID            uint            `gorm:"uniqueIndex" json:"user_id"`
+	Plan              string          `gorm:"type:varchar(50);default:'free'" json:"plan"`
+	PrefThumbOriginal bool            `gorm:"default:true" json:"pref_thumb_original"`
+	PrefThumbWebP     bool            `gorm:"default:false" json:"pref_thumb_webp"`
+	PrefThumbAVIF     bool            `gorm:"default:false" json:"pref_thumb_avif"`
+	APIKeyHash        string          `gorm:"type:char(64);default:''" json:"-"` // legacy single key, see MigrateLegacyAPIKeys
+	APIKeyPrefix      string          `gorm:"type:varchar(20);default:''" json:"api_key_prefix"`
+	APIKeyCreatedAt   *time.Time      `json:"api_key_created_at"`
+	APIKeyLastUsedAt  *time.Time      `json:"api_key_last_used_at"`
+	APIKeyRevokedAt   *time.Time      `json:"api_key_revoked_at"`
+	EmailDigest       string          `gorm:"type:varchar(10);default:'off'" json:"email_digest"`
+	EmailDigestSentAt *time.Time      `json:"-"`
+	MetadataPolicy    string          `gorm:"type:varchar(20);default:'strip_location'" json:"metadata_policy"` // default for new uploads, see NormalizeMetadataPolicy
+	Watermark         WatermarkConfig `gorm:"embedded;embeddedPrefix:watermark_" json:"watermark"`              // applied to served variants on paid plans
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt  `gorm:"index" json:"-"`
 }
 
-var apiKeyEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+// Email digest frequencies for unread notifications
+const (
+	EmailDigestOff    = "off"
+	EmailDigestDaily  = "daily"
+	EmailDigestWeekly = "weekly"
+)
 
-const apiKeyPrefix = "pxl_"
+// Metadata policies for uploaded originals. Variants never carry EXIF/GPS data.
+const (
+	MetadataPolicyKeep          = "keep"
+	MetadataPolicyStripLocation = "strip_location"
+	MetadataPolicyStripAll      = "strip_all"
+)
 
 // GetOrCreateUserSettings returns existing settings or creates defaults
 func GetOrCreateUserSettings(db *gorm.DB, userID uint) (*UserSettings, error) {
 	var us UserSettings
 	if err := db.Where("user_id = ?", userID).First(&us).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			us = UserSettings{UserID: userID, Plan: "free", PrefThumbOriginal: true}
+			us = UserSettings{UserID: userID, Plan: "free", PrefThumbOriginal: true, MetadataPolicy: MetadataPolicyStripLocation}
 			if err := db.Create(&us).Error; err != nil {
 				return nil, err
 			}
@@ -50,62 +59,55 @@ func GetOrCreateUserSettings(db *gorm.DB, userID uint) (*UserSettings, error) {
 	return &us, nil
 }
 
-// HasActiveAPIKey reports whether the user has an active API key configured
-func (us *UserSettings) HasActiveAPIKey() bool {
-	return us != nil && us.APIKeyHash != "" && us.APIKeyRevokedAt == nil
-}
-
-// IssueAPIKey generates a new API key, persists metadata on the struct, and returns the raw secret.
-// Callers must persist the struct via the database after invoking this method.
-func (us *UserSettings) IssueAPIKey() (string, error) {
-	rawKey, prefix, hash, err := generateAPIKeyMaterial()
-	if err != nil {
-		return "", err
+// NormalizeEmailDigest maps user input to a known digest frequency (defaults to off)
+func NormalizeEmailDigest(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case EmailDigestDaily:
+		return EmailDigestDaily
+	case EmailDigestWeekly:
+		return EmailDigestWeekly
+	default:
+		return EmailDigestOff
 	}
-	now := time.Now()
-	us.APIKeyHash = hash
-	us.APIKeyPrefix = prefix
-	us.APIKeyCreatedAt = &now
-	us.APIKeyRevokedAt = nil
-	us.APIKeyLastUsedAt = nil
-	return rawKey, nil
 }
 
-// RevokeAPIKey clears the stored API key metadata without deleting the record.
-func (us *UserSettings) RevokeAPIKey() {
-	us.APIKeyHash = ""
-	us.APIKeyPrefix = ""
-	now := time.Now()
-	us.APIKeyRevokedAt = &now
-	us.APIKeyLastUsedAt = nil
+// IsValidMetadataPolicy reports whether value names a known metadata policy
+func IsValidMetadataPolicy(value string) bool {
+	switch value {
+	case MetadataPolicyKeep, MetadataPolicyStripLocation, MetadataPolicyStripAll:
+		return true
+	}
+	return false
 }
 
-// TouchAPIKeyUsage updates the last-used timestamp metadata.
-func (us *UserSettings) TouchAPIKeyUsage() {
-	now := time.Now()
-	us.APIKeyLastUsedAt = &now
+// NormalizeMetadataPolicy maps user input to a known metadata policy (defaults to stripping the location)
+func NormalizeMetadataPolicy(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if IsValidMetadataPolicy(value) {
+		return value
+	}
+	return MetadataPolicyStripLocation
 }
 
-// HashAPIKey returns the SHA-256 hash for the provided API key.
-func HashAPIKey(raw string) string {
-	sum := sha256.Sum256([]byte(strings.TrimSpace(raw)))
-	return hex.EncodeToString(sum[:])
+// EmailDigestInterval returns the interval between two digests, 0 if digests are disabled
+func (us *UserSettings) EmailDigestInterval() time.Duration {
+	switch NormalizeEmailDigest(us.EmailDigest) {
+	case EmailDigestDaily:
+		return 24 * time.Hour
+	case EmailDigestWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
 }
 
-func generateAPIKeyMaterial() (string, string, string, error) {
-	b := make([]byte, 32)
-	if _, err := rand.Read(b); err != nil {
-		return "", "", "", err
-	}
-	encoded := apiKeyEncoding.EncodeToString(b)
-	encoded = strings.ToLower(encoded)
-	rawKey := apiKeyPrefix + encoded
-	if len(rawKey) < 12 {
-		return "", "", "", fmt.Errorf("api key generation failed: key too short")
+// IsEmailDigestDue reports whether the next digest should be sent at the given time
+func (us *UserSettings) IsEmailDigestDue(now time.Time) bool {
+	interval := us.EmailDigestInterval()
+	if interval == 0 {
+		return false
 	}
-	prefix := rawKey[:min(len(rawKey), 16)]
-	hash := HashAPIKey(rawKey)
-	return rawKey, prefix, hash, nil
+	return us.EmailDigestSentAt == nil || !now.Before(us.EmailDigestSentAt.Add(interval))
 }
 
 func min(a, b int) int {
@@ -2,35 +2,35 @@ package models
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 )
 
-func TestUserSettingsIssueAPIKey(t *testing.T) {
-	us := &UserSettings{UserID: 1}
+func TestUserSettingsEmailDigest(t *testing.T) {
+	assert.Equal(t, EmailDigestDaily, NormalizeEmailDigest(" Daily "))
+	assert.Equal(t, EmailDigestOff, NormalizeEmailDigest("hourly"))
 
-	key, err := us.IssueAPIKey()
-	require.NoError(t, err)
-	require.NotEmpty(t, key)
+	now := time.Now()
+	us := &UserSettings{EmailDigest: EmailDigestOff}
+	assert.False(t, us.IsEmailDigestDue(now))
 
-	assert.NotEmpty(t, us.APIKeyHash)
-	assert.NotEmpty(t, us.APIKeyPrefix)
-	assert.NotNil(t, us.APIKeyCreatedAt)
-	assert.Nil(t, us.APIKeyLastUsedAt)
-	assert.True(t, us.HasActiveAPIKey())
-	assert.Equal(t, HashAPIKey(key), us.APIKeyHash)
-}
+	us.EmailDigest = EmailDigestDaily
+	assert.True(t, us.IsEmailDigestDue(now))
 
-func TestUserSettingsRevokeAPIKey(t *testing.T) {
-	us := &UserSettings{UserID: 99}
-	_, err := us.IssueAPIKey()
-	require.NoError(t, err)
+	sent := now.Add(-2 * time.Hour)
+	us.EmailDigestSentAt = &sent
+	assert.False(t, us.IsEmailDigestDue(now))
+	assert.True(t, us.IsEmailDigestDue(now.Add(23*time.Hour)))
 
-	us.RevokeAPIKey()
+	us.EmailDigest = EmailDigestWeekly
+	assert.False(t, us.IsEmailDigestDue(now.Add(23*time.Hour)))
+}
 
-	assert.False(t, us.HasActiveAPIKey())
-	assert.Equal(t, "", us.APIKeyHash)
-	assert.Equal(t, "", us.APIKeyPrefix)
-	assert.NotNil(t, us.APIKeyRevokedAt)
+func TestNormalizeMetadataPolicy(t *testing.T) {
+	assert.Equal(t, MetadataPolicyStripAll, NormalizeMetadataPolicy(" Strip_All "))
+	assert.Equal(t, MetadataPolicyKeep, NormalizeMetadataPolicy("keep"))
+	assert.Equal(t, MetadataPolicyStripLocation, NormalizeMetadataPolicy(""))
+	assert.Equal(t, MetadataPolicyStripLocation, NormalizeMetadataPolicy("everything"))
+	assert.False(t, IsValidMetadataPolicy(""))
 }
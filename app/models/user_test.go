@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserPasswordResetToken(t *testing.T) {
+	u := &User{}
+	require.NoError(t, u.GeneratePasswordResetToken())
+	require.NotEmpty(t, u.PasswordResetToken)
+	require.NotNil(t, u.PasswordResetSentAt)
+
+	assert.True(t, u.IsPasswordResetTokenValid(u.PasswordResetToken))
+	assert.False(t, u.IsPasswordResetTokenValid("wrong"))
+	assert.False(t, u.IsPasswordResetTokenValid(""))
+
+	expired := time.Now().Add(-PasswordResetTokenTTL - time.Minute)
+	u.PasswordResetSentAt = &expired
+	assert.False(t, u.IsPasswordResetTokenValid(u.PasswordResetToken))
+}
+
+func TestUserClearPasswordResetRequest(t *testing.T) {
+	u := &User{}
+	require.NoError(t, u.GeneratePasswordResetToken())
+	token := u.PasswordResetToken
+
+	u.ClearPasswordResetRequest()
+
+	assert.Empty(t, u.PasswordResetToken)
+	assert.Nil(t, u.PasswordResetSentAt)
+	assert.False(t, u.IsPasswordResetTokenValid(token))
+}
@@ -0,0 +1,219 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Variant profile output formats
+const (
+	VariantFormatWebP     = "webp"
+	VariantFormatAVIF     = "avif"
+	VariantFormatOriginal = "original" // same format as the uploaded file
+	VariantFormatMP4      = "mp4"      // H.264 video, animated images only
+	VariantFormatWebM     = "webm"     // VP9 video, animated images only
+)
+
+// Variant profile limits
+const (
+	VariantProfileMaxDimension = 8192
+	VariantProfileMaxAVIFCRF   = 63
+	VariantProfileMaxMP4CRF    = 51
+	VariantProfileMaxWebMCRF   = 63
+)
+
+var variantProfileNamePattern = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+
+// VariantProfile describes a variant generated for every processed image.
+// Its name is stored as ImageVariant.VariantType.
+type VariantProfile struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"type:varchar(50);uniqueIndex;not null" json:"name"`
+	MaxWidth  int       `gorm:"not null" json:"max_width"`               // 0 = unbounded
+	MaxHeight int       `gorm:"not null" json:"max_height"`              // 0 = unbounded
+	Format    string    `gorm:"type:varchar(20);not null" json:"format"` // webp, avif, original, mp4 or webm
+	Quality   int       `gorm:"not null" json:"quality"`                 // 1-100 for WebP/JPEG, CRF for AVIF/MP4/WebM
+	Plans     string    `gorm:"type:varchar(100);not null" json:"plans"` // comma separated plans, empty = all plans
+	IsActive  bool      `gorm:"index;not null" json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DefaultVariantProfiles returns the profiles matching the formerly built-in variant types
+// and the optional, initially inactive video profiles for animated images
+func DefaultVariantProfiles() []VariantProfile {
+	return []VariantProfile{
+		{Name: VariantTypeWebP, Format: VariantFormatWebP, Quality: 85, IsActive: true},
+		{Name: VariantTypeAVIF, Format: VariantFormatAVIF, Quality: 35, IsActive: true},
+		{Name: VariantTypeThumbnailSmallWebP, MaxWidth: 200, Format: VariantFormatWebP, Quality: 85, IsActive: true},
+		{Name: VariantTypeThumbnailSmallAVIF, MaxWidth: 200, Format: VariantFormatAVIF, Quality: 35, IsActive: true},
+		{Name: VariantTypeThumbnailSmallOrig, MaxWidth: 200, Format: VariantFormatOriginal, Quality: 90, IsActive: true},
+		{Name: VariantTypeThumbnailMediumWebP, MaxWidth: 500, Format: VariantFormatWebP, Quality: 85, IsActive: true},
+		{Name: VariantTypeThumbnailMediumAVIF, MaxWidth: 500, Format: VariantFormatAVIF, Quality: 35, IsActive: true},
+		{Name: VariantTypeThumbnailMediumOrig, MaxWidth: 500, Format: VariantFormatOriginal, Quality: 90, IsActive: true},
+		{Name: VariantTypeVideoMP4, Format: VariantFormatMP4, Quality: 23, IsActive: false},
+		{Name: VariantTypeVideoWebM, Format: VariantFormatWebM, Quality: 33, IsActive: false},
+	}
+}
+
+// WebVariantProfile describes the built-in web variant of originals browsers cannot display.
+// Format "original" means the browser-safe format chosen by the image processor (JPEG or PNG).
+func WebVariantProfile() VariantProfile {
+	return VariantProfile{Name: VariantTypeWeb, Format: VariantFormatOriginal, Quality: 90, IsActive: true}
+}
+
+// SeedVariantProfiles creates the default profiles on a fresh installation
+func SeedVariantProfiles(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&VariantProfile{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	profiles := DefaultVariantProfiles()
+	return db.Create(&profiles).Error
+}
+
+// Validate normalizes and checks the profile fields
+func (p *VariantProfile) Validate() error {
+	p.Name = strings.ToLower(strings.TrimSpace(p.Name))
+	p.Format = strings.ToLower(strings.TrimSpace(p.Format))
+	p.Plans = strings.Join(p.PlanList(), ",")
+	if !IsValidVariantProfileName(p.Name) {
+		return fmt.Errorf("name may only contain a-z, 0-9 and _ (max. 50 characters)")
+	}
+	if p.Name == VariantTypeOriginal || p.Name == VariantTypeWeb {
+		return fmt.Errorf("name %q is reserved", p.Name)
+	}
+	if p.MaxWidth < 0 || p.MaxWidth > VariantProfileMaxDimension || p.MaxHeight < 0 || p.MaxHeight > VariantProfileMaxDimension {
+		return fmt.Errorf("dimensions must be between 0 and %d", VariantProfileMaxDimension)
+	}
+	switch p.Format {
+	case VariantFormatAVIF:
+		if p.Quality < 0 || p.Quality > VariantProfileMaxAVIFCRF {
+			return fmt.Errorf("AVIF quality (CRF) must be between 0 and %d", VariantProfileMaxAVIFCRF)
+		}
+	case VariantFormatMP4:
+		if p.Quality < 0 || p.Quality > VariantProfileMaxMP4CRF {
+			return fmt.Errorf("MP4 quality (CRF) must be between 0 and %d", VariantProfileMaxMP4CRF)
+		}
+	case VariantFormatWebM:
+		if p.Quality < 0 || p.Quality > VariantProfileMaxWebMCRF {
+			return fmt.Errorf("WebM quality (CRF) must be between 0 and %d", VariantProfileMaxWebMCRF)
+		}
+	case VariantFormatWebP, VariantFormatOriginal:
+		if p.Quality < 1 || p.Quality > 100 {
+			return fmt.Errorf("quality must be between 1 and 100")
+		}
+	default:
+		return fmt.Errorf("unsupported format %q", p.Format)
+	}
+	return nil
+}
+
+// IsValidVariantProfileName reports whether name can be used as profile name and variant type
+func IsValidVariantProfileName(name string) bool {
+	return variantProfileNamePattern.MatchString(name)
+}
+
+// IsFullSize reports whether the profile keeps the original dimensions
+func (p *VariantProfile) IsFullSize() bool {
+	return p.MaxWidth == 0 && p.MaxHeight == 0
+}
+
+// IsVideo reports whether the profile converts animated images into a video
+func (p *VariantProfile) IsVideo() bool {
+	return p.Format == VariantFormatMP4 || p.Format == VariantFormatWebM
+}
+
+// PlanList returns the plans the profile is generated for; empty means all plans
+func (p *VariantProfile) PlanList() []string {
+	var plans []string
+	for _, part := range strings.Split(p.Plans, ",") {
+		if part = strings.ToLower(strings.TrimSpace(part)); part != "" {
+			plans = append(plans, part)
+		}
+	}
+	return plans
+}
+
+// AvailableForPlan reports whether images of users with the given plan get this variant
+func (p *VariantProfile) AvailableForPlan(plan string) bool {
+	plans := p.PlanList()
+	if len(plans) == 0 {
+		return true
+	}
+	plan = strings.ToLower(strings.TrimSpace(plan))
+	if plan == "" {
+		plan = "free"
+	}
+	for _, allowed := range plans {
+		if allowed == plan {
+			return true
+		}
+	}
+	return false
+}
+
+// FileName returns the variant file name for an image. Names follow the historic scheme:
+// "webp" -> <uuid>.webp, "thumbnail_small_webp" -> <uuid>_small.webp, "hero_avif" -> <uuid>_hero.avif
+func (p *VariantProfile) FileName(uuid, originalExt string) string {
+	ext := "." + p.Format
+	if p.Format == VariantFormatOriginal {
+		ext = originalExt
+	}
+	suffix := strings.TrimPrefix(p.Name, "thumbnail_")
+	suffix = strings.TrimSuffix(suffix, "_"+p.Format)
+	if suffix == p.Format {
+		suffix = ""
+	}
+	if suffix == "" {
+		return uuid + ext
+	}
+	return uuid + "_" + suffix + ext
+}
+
+// fileNameKey identifies the variant files of the profile: the name suffix and the format.
+// Profiles with the same key would write the same file for every image.
+func (p *VariantProfile) fileNameKey() string {
+	return p.FileName("", "."+p.Format)
+}
+
+// CheckFileNameConflict rejects a profile whose file names equal those of another profile or the
+// built-in web variant, e.g. "small_webp" next to "thumbnail_small_webp" or "hero" next to "hero_webp".
+func (p *VariantProfile) CheckFileNameConflict(existing []VariantProfile) error {
+	key := p.fileNameKey()
+	web := WebVariantProfile()
+	if key == web.fileNameKey() {
+		return fmt.Errorf("name %q uses the file names of the built-in web variant", p.Name)
+	}
+	for i := range existing {
+		other := &existing[i]
+		if other.Name == p.Name || (p.ID != 0 && other.ID == p.ID) {
+			continue
+		}
+		if other.fileNameKey() == key {
+			return fmt.Errorf("name %q uses the same file names as profile %q", p.Name, other.Name)
+		}
+	}
+	return nil
+}
+
+// FindActiveVariantProfiles returns all active profiles ordered by size
+func FindActiveVariantProfiles(db *gorm.DB) ([]VariantProfile, error) {
+	var profiles []VariantProfile
+	err := db.Where("is_active = ?", true).Order("max_width ASC, max_height ASC, name ASC").Find(&profiles).Error
+	return profiles, err
+}
+
+// FindAllVariantProfileNames returns the names of all profiles, active or not
+func FindAllVariantProfileNames(db *gorm.DB) ([]string, error) {
+	var names []string
+	err := db.Model(&VariantProfile{}).Pluck("name", &names).Error
+	return names, err
+}
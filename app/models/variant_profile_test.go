@@ -0,0 +1,98 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariantProfileFileName(t *testing.T) {
+	profiles := map[string]VariantProfile{}
+	for _, p := range DefaultVariantProfiles() {
+		profiles[p.Name] = p
+	}
+
+	// Default profiles keep the historic file names
+	webp := profiles[VariantTypeWebP]
+	assert.Equal(t, "abc.webp", webp.FileName("abc", ".jpg"))
+	small := profiles[VariantTypeThumbnailSmallWebP]
+	assert.Equal(t, "abc_small.webp", small.FileName("abc", ".jpg"))
+	mediumOrig := profiles[VariantTypeThumbnailMediumOrig]
+	assert.Equal(t, "abc_medium.png", mediumOrig.FileName("abc", ".png"))
+
+	hero := VariantProfile{Name: "hero_avif", Format: VariantFormatAVIF}
+	assert.Equal(t, "abc_hero.avif", hero.FileName("abc", ".jpg"))
+
+	web := WebVariantProfile()
+	assert.Equal(t, "abc_web.jpg", web.FileName("abc", ".jpg"))
+}
+
+func TestVariantProfileValidate(t *testing.T) {
+	p := VariantProfile{Name: " Hero_WebP ", MaxWidth: 1200, Format: "WEBP", Quality: 80, Plans: "Premium, ,premium_max"}
+	assert.NoError(t, p.Validate())
+	assert.Equal(t, "hero_webp", p.Name)
+	assert.Equal(t, VariantFormatWebP, p.Format)
+	assert.Equal(t, "premium,premium_max", p.Plans)
+
+	assert.Error(t, (&VariantProfile{Name: "bad name", Format: VariantFormatWebP, Quality: 80}).Validate())
+	assert.Error(t, (&VariantProfile{Name: VariantTypeOriginal, Format: VariantFormatWebP, Quality: 80}).Validate())
+	assert.Error(t, (&VariantProfile{Name: VariantTypeWeb, Format: VariantFormatWebP, Quality: 80}).Validate())
+	assert.Error(t, (&VariantProfile{Name: "x", Format: "gif", Quality: 80}).Validate())
+	assert.Error(t, (&VariantProfile{Name: "x", Format: VariantFormatAVIF, Quality: 70}).Validate())
+	assert.Error(t, (&VariantProfile{Name: "x", MaxWidth: -1, Format: VariantFormatWebP, Quality: 80}).Validate())
+}
+
+func TestVariantProfileAvailableForPlan(t *testing.T) {
+	all := VariantProfile{}
+	assert.True(t, all.AvailableForPlan("premium"))
+
+	premium := VariantProfile{Plans: "premium,premium_max"}
+	assert.True(t, premium.AvailableForPlan("Premium"))
+	assert.False(t, premium.AvailableForPlan(""))
+	assert.False(t, premium.AvailableForPlan("free"))
+}
+
+func TestVariantProfileVideoFormats(t *testing.T) {
+	mp4 := VariantProfile{Name: "clip_mp4", Format: "MP4", Quality: 23}
+	assert.NoError(t, mp4.Validate())
+	assert.True(t, mp4.IsVideo())
+	assert.Equal(t, "abc_clip.mp4", mp4.FileName("abc", ".gif"))
+
+	assert.Error(t, (&VariantProfile{Name: "x", Format: VariantFormatMP4, Quality: 52}).Validate())
+	assert.NoError(t, (&VariantProfile{Name: "x", Format: VariantFormatWebM, Quality: 63}).Validate())
+	assert.False(t, (&VariantProfile{Format: VariantFormatWebP}).IsVideo())
+}
+
+func TestImageVariantBaseName(t *testing.T) {
+	image := Image{UUID: "abc"}
+	assert.Equal(t, "abc", image.VariantBaseName())
+
+	image.Revision = 2
+	assert.Equal(t, "abc_r2", image.VariantBaseName())
+
+	webp := VariantProfile{Name: "thumbnail_small_webp", Format: "webp"}
+	assert.Equal(t, "abc_r2_small.webp", webp.FileName(image.VariantBaseName(), ".jpg"))
+}
+
+func TestVariantProfileCheckFileNameConflict(t *testing.T) {
+	defaults := DefaultVariantProfiles()
+
+	for _, name := range []string{"small_webp", "thumbnail_small", "webp_webp"} {
+		p := VariantProfile{Name: name, Format: VariantFormatWebP}
+		assert.Error(t, p.CheckFileNameConflict(defaults), name)
+	}
+	web := VariantProfile{Name: "web_original", Format: VariantFormatOriginal}
+	assert.Error(t, web.CheckFileNameConflict(nil))
+
+	hero := VariantProfile{Name: "hero", Format: VariantFormatWebP}
+	heroWebP := VariantProfile{Name: "hero_webp", Format: VariantFormatWebP}
+	assert.Error(t, hero.CheckFileNameConflict([]VariantProfile{heroWebP}))
+	assert.NoError(t, hero.CheckFileNameConflict(defaults))
+
+	// Same suffix in another format and the profile itself are fine
+	heroAVIF := VariantProfile{Name: "hero_avif", Format: VariantFormatAVIF}
+	assert.NoError(t, heroAVIF.CheckFileNameConflict([]VariantProfile{hero, heroAVIF}))
+	for _, p := range defaults {
+		assert.NoError(t, p.CheckFileNameConflict(defaults), p.Name)
+	}
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"bytes"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Watermark positions on the image
+const (
+	WatermarkTopLeft     = "top_left"
+	WatermarkTopRight    = "top_right"
+	WatermarkBottomLeft  = "bottom_left"
+	WatermarkBottomRight = "bottom_right"
+	WatermarkCenter      = "center"
+)
+
+// WatermarkPositions lists the selectable positions in display order
+var WatermarkPositions = []string{WatermarkTopLeft, WatermarkTopRight, WatermarkCenter, WatermarkBottomLeft, WatermarkBottomRight}
+
+// Watermark limits
+const (
+	MaxWatermarkTextLength = 100
+	MaxWatermarkImageBytes = 512 * 1024
+	DefaultWatermarkScale  = 20
+	DefaultWatermarkAlpha  = 50
+)
+
+// WatermarkConfig describes the watermark drawn onto the served variants of an image.
+// It is embedded into UserSettings (default) and Album (override).
+type WatermarkConfig struct {
+	Enabled  bool   `gorm:"not null;default:false" json:"enabled"`
+	Text     string `gorm:"type:varchar(100);not null;default:''" json:"text"`
+	Image    []byte `gorm:"type:mediumblob" json:"-"` // PNG, takes precedence over Text
+	Position string `gorm:"type:varchar(20);not null;default:'bottom_right'" json:"position"`
+	Opacity  int    `gorm:"not null;default:50" json:"opacity"` // percent
+	Scale    int    `gorm:"not null;default:20" json:"scale"`   // watermark width in percent of the image width
+}
+
+// HasMark reports whether the config is enabled and has something to draw
+func (w *WatermarkConfig) HasMark() bool {
+	return w.Enabled && (len(w.Image) > 0 || strings.TrimSpace(w.Text) != "")
+}
+
+// Equal reports whether two configs render the same watermark
+func (w *WatermarkConfig) Equal(other WatermarkConfig) bool {
+	return w.Enabled == other.Enabled && w.Text == other.Text && bytes.Equal(w.Image, other.Image) &&
+		w.Position == other.Position && w.Opacity == other.Opacity && w.Scale == other.Scale
+}
+
+// Normalize clamps user input to valid values
+func (w *WatermarkConfig) Normalize() {
+	w.Text = strings.TrimSpace(w.Text)
+	if r := []rune(w.Text); len(r) > MaxWatermarkTextLength {
+		w.Text = string(r[:MaxWatermarkTextLength])
+	}
+	if !IsValidWatermarkPosition(w.Position) {
+		w.Position = WatermarkBottomRight
+	}
+	if w.Opacity <= 0 || w.Opacity > 100 {
+		w.Opacity = DefaultWatermarkAlpha
+	}
+	if w.Scale <= 0 {
+		w.Scale = DefaultWatermarkScale
+	}
+	w.Scale = min(max(w.Scale, 5), 100)
+}
+
+// IsValidWatermarkPosition reports whether value names a known position
+func IsValidWatermarkPosition(value string) bool {
+	for _, p := range WatermarkPositions {
+		if p == value {
+			return true
+		}
+	}
+	return false
+}
+
+// FindWatermarkOverrideAlbum returns the album whose watermark override applies to an image.
+// Images in several albums use the oldest album with an override; nil if there is none.
+func FindWatermarkOverrideAlbum(db *gorm.DB, imageID uint) (*Album, error) {
+	var albums []Album
+	err := db.Joins("JOIN album_images ON album_images.album_id = albums.id").
+		Where("album_images.image_id = ? AND albums.watermark_override = ?", imageID, true).
+		Order("albums.id ASC").Limit(1).Find(&albums).Error
+	if err != nil || len(albums) == 0 {
+		return nil, err
+	}
+	return &albums[0], nil
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatermarkConfigNormalize(t *testing.T) {
+	cfg := WatermarkConfig{Text: "  © Fox  ", Position: "somewhere", Opacity: 0, Scale: 500}
+	cfg.Normalize()
+	assert.Equal(t, "© Fox", cfg.Text)
+	assert.Equal(t, WatermarkBottomRight, cfg.Position)
+	assert.Equal(t, DefaultWatermarkAlpha, cfg.Opacity)
+	assert.Equal(t, 100, cfg.Scale)
+
+	cfg = WatermarkConfig{Position: WatermarkCenter, Opacity: 80, Scale: 1}
+	cfg.Normalize()
+	assert.Equal(t, WatermarkCenter, cfg.Position)
+	assert.Equal(t, 80, cfg.Opacity)
+	assert.Equal(t, 5, cfg.Scale)
+}
+
+func TestWatermarkConfigHasMark(t *testing.T) {
+	assert.False(t, (&WatermarkConfig{Text: "Fox"}).HasMark())
+	assert.False(t, (&WatermarkConfig{Enabled: true, Text: "   "}).HasMark())
+	assert.True(t, (&WatermarkConfig{Enabled: true, Text: "Fox"}).HasMark())
+	assert.True(t, (&WatermarkConfig{Enabled: true, Image: []byte{1}}).HasMark())
+
+	a := WatermarkConfig{Enabled: true, Image: []byte{1, 2}, Opacity: 50}
+	assert.True(t, a.Equal(WatermarkConfig{Enabled: true, Image: []byte{1, 2}, Opacity: 50}))
+	assert.False(t, a.Equal(WatermarkConfig{Enabled: true, Image: []byte{1, 3}, Opacity: 50}))
+}
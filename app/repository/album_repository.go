@@ -66,29 +66,120 @@ func (r *albumRepository) AddImage(albumID, imageID uint) error {
 		return err
 	}
 
-	// If association doesn't exist, create it
+	// If association doesn't exist, create it (appended at the end of the album)
 	if count == 0 {
-		return r.db.Exec("INSERT INTO album_images (album_id, image_id) VALUES (?, ?)",
-			albumID, imageID).Error
+		return r.db.Create(&models.AlbumImage{AlbumID: albumID, ImageID: imageID}).Error
 	}
 
 	return nil
 }
 
+// AddImages adds several images to an album, skipping images that are already part of it.
+// Returns the number of newly added images.
+func (r *albumRepository) AddImages(albumID uint, imageIDs []uint) (int, error) {
+	added := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existing []uint
+		if err := tx.Model(&models.AlbumImage{}).Where("album_id = ?", albumID).Pluck("image_id", &existing).Error; err != nil {
+			return err
+		}
+		present := make(map[uint]bool, len(existing))
+		for _, id := range existing {
+			present[id] = true
+		}
+		position, err := models.MaxAlbumImagePosition(tx, albumID)
+		if err != nil {
+			return err
+		}
+		var rows []models.AlbumImage
+		for _, id := range imageIDs {
+			if present[id] {
+				continue
+			}
+			position++
+			rows = append(rows, models.AlbumImage{AlbumID: albumID, ImageID: id, Position: position})
+			present[id] = true
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := tx.Create(&rows).Error; err != nil {
+			return err
+		}
+		added = len(rows)
+		return nil
+	})
+	return added, err
+}
+
+// RemoveImages removes several images from an album and clears the cover if it was removed.
+// Returns the number of removed images.
+func (r *albumRepository) RemoveImages(albumID uint, imageIDs []uint) (int64, error) {
+	if len(imageIDs) == 0 {
+		return 0, nil
+	}
+	var removed int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("album_id = ? AND image_id IN ?", albumID, imageIDs).Delete(&models.AlbumImage{})
+		if res.Error != nil {
+			return res.Error
+		}
+		removed = res.RowsAffected
+		return tx.Model(&models.Album{}).
+			Where("id = ? AND cover_image_id IN ?", albumID, imageIDs).
+			Update("cover_image_id", 0).Error
+	})
+	return removed, err
+}
+
+// ReorderImages stores the given image order. Images of the album that are not listed
+// keep their relative order and are placed after the listed ones.
+func (r *albumRepository) ReorderImages(albumID uint, imageIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current []uint
+		if err := tx.Model(&models.AlbumImage{}).Where("album_id = ?", albumID).
+			Order("position ASC, created_at ASC, image_id ASC").Pluck("image_id", &current).Error; err != nil {
+			return err
+		}
+		listed := make(map[uint]bool, len(imageIDs))
+		order := make([]uint, 0, len(current))
+		for _, id := range imageIDs {
+			if !listed[id] {
+				listed[id] = true
+				order = append(order, id)
+			}
+		}
+		for _, id := range current {
+			if !listed[id] {
+				order = append(order, id)
+			}
+		}
+		for i, id := range order {
+			if err := tx.Model(&models.AlbumImage{}).
+				Where("album_id = ? AND image_id = ?", albumID, id).
+				Update("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // RemoveImage removes an image from an album
 func (r *albumRepository) RemoveImage(albumID, imageID uint) error {
 	return r.db.Exec("DELETE FROM album_images WHERE album_id = ? AND image_id = ?",
 		albumID, imageID).Error
 }
 
-// GetImages retrieves all images in an album
+// GetImages retrieves all images in an album in album order
 func (r *albumRepository) GetImages(albumID uint) ([]models.Image, error) {
 	var images []models.Image
 	err := r.db.Table("images").
 		Joins("JOIN album_images ON images.id = album_images.image_id").
 		Where("album_images.album_id = ?", albumID).
 		Preload("StoragePool").
-		Order("images.created_at DESC").
+		Preload("Tags").
+		Order("album_images.position ASC, album_images.created_at ASC, images.id ASC").
 		Find(&images).Error
 	return images, err
 }
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"gorm.io/gorm"
+)
+
+// apiKeyRepository implements the APIKeyRepository interface
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository instance
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+// Create persists a new API key
+func (r *apiKeyRepository) Create(key *models.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+// ListByUser returns all keys of a user, active keys first and newest first
+func (r *apiKeyRepository) ListByUser(userID uint) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := r.db.Where("user_id = ?", userID).
+		Order("revoked_at IS NOT NULL ASC, created_at DESC, id DESC").
+		Find(&keys).Error
+	return keys, err
+}
+
+// CountActiveByUser counts the user's keys that are neither revoked nor expired
+func (r *apiKeyRepository) CountActiveByUser(userID uint) (int64, error) {
+	var n int64
+	err := r.db.Model(&models.APIKey{}).
+		Where("user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", userID, time.Now()).
+		Count(&n).Error
+	return n, err
+}
+
+// GetActiveByHash resolves a key hash to a key that is neither revoked nor expired, together with its user
+func (r *apiKeyRepository) GetActiveByHash(hash string) (*models.APIKey, *models.User, error) {
+	trimmed := strings.TrimSpace(hash)
+	if trimmed == "" {
+		return nil, nil, gorm.ErrRecordNotFound
+	}
+	var key models.APIKey
+	err := r.db.Where("key_hash = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", trimmed, time.Now()).
+		First(&key).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	var user models.User
+	if err := r.db.First(&user, key.UserID).Error; err != nil {
+		return nil, nil, err
+	}
+	return &key, &user, nil
+}
+
+// Revoke marks a key of the user as revoked. Returns gorm.ErrRecordNotFound if no active key matched.
+func (r *apiKeyRepository) Revoke(userID, keyID uint) error {
+	res := r.db.Model(&models.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", keyID, userID).
+		Update("revoked_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// TouchUsage records the time and client IP of the key's latest use
+func (r *apiKeyRepository) TouchUsage(keyID uint, ip string) error {
+	if len(ip) > 45 {
+		ip = ip[:45]
+	}
+	return r.db.Model(&models.APIKey{}).Where("id = ?", keyID).
+		UpdateColumns(map[string]any{"last_used_at": time.Now(), "last_used_ip": ip}).Error
+}
+
+// LastUsedAtByUser returns the most recent usage of any of the user's keys
+func (r *apiKeyRepository) LastUsedAtByUser(userID uint) (*time.Time, error) {
+	var key models.APIKey
+	err := r.db.Where("user_id = ? AND last_used_at IS NOT NULL", userID).
+		Order("last_used_at DESC").First(&key).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return key.LastUsedAt, nil
+}
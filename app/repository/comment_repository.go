@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"gorm.io/gorm"
+)
+
+// commentRepository implements the CommentRepository interface
+type commentRepository struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository creates a new comment repository instance
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+// Create creates a new comment in the database
+func (r *commentRepository) Create(comment *models.Comment) error {
+	return r.db.Create(comment).Error
+}
+
+// GetByID retrieves a comment by its ID including its author
+func (r *commentRepository) GetByID(id uint) (*models.Comment, error) {
+	var comment models.Comment
+	err := r.db.Preload("User").First(&comment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GetByImageID retrieves the comments of an image, newest first, with pagination
+func (r *commentRepository) GetByImageID(imageID uint, offset, limit int) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := r.db.Preload("User").Where("image_id = ?", imageID).
+		Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&comments).Error
+	return comments, err
+}
+
+// CountByImageID returns the number of comments of an image
+func (r *commentRepository) CountByImageID(imageID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Comment{}).Where("image_id = ?", imageID).Count(&count).Error
+	return count, err
+}
+
+// Delete soft deletes a comment by its ID
+func (r *commentRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Comment{}, id).Error
+}
+
+// DeleteByImageID soft deletes all comments of an image
+func (r *commentRepository) DeleteByImageID(imageID uint) error {
+	return r.db.Where("image_id = ?", imageID).Delete(&models.Comment{}).Error
+}
@@ -33,6 +33,11 @@ func (f *Factory) GetUserRepository() UserRepository {
 	return f.GetRepositories().User
 }
 
+// GetAPIKeyRepository returns the API key repository instance
+func (f *Factory) GetAPIKeyRepository() APIKeyRepository {
+	return f.GetRepositories().APIKey
+}
+
 // GetImageRepository returns the image repository instance
 func (f *Factory) GetImageRepository() ImageRepository {
 	return f.GetRepositories().Image
@@ -43,11 +48,46 @@ func (f *Factory) GetAlbumRepository() AlbumRepository {
 	return f.GetRepositories().Album
 }
 
+// GetCommentRepository returns the comment repository instance
+func (f *Factory) GetCommentRepository() CommentRepository {
+	return f.GetRepositories().Comment
+}
+
+// GetLikeRepository returns the like repository instance
+func (f *Factory) GetLikeRepository() LikeRepository {
+	return f.GetRepositories().Like
+}
+
+// GetNotificationRepository returns the notification repository instance
+func (f *Factory) GetNotificationRepository() NotificationRepository {
+	return f.GetRepositories().Notification
+}
+
+// GetTagRepository returns the tag repository instance
+func (f *Factory) GetTagRepository() TagRepository {
+	return f.GetRepositories().Tag
+}
+
+// GetSearchRepository returns the search repository instance
+func (f *Factory) GetSearchRepository() SearchRepository {
+	return f.GetRepositories().Search
+}
+
 // GetStoragePoolRepository returns the storage pool repository instance
 func (f *Factory) GetStoragePoolRepository() StoragePoolRepository {
 	return f.GetRepositories().StoragePool
 }
 
+// GetTransformRepository returns the transform repository instance
+func (f *Factory) GetTransformRepository() TransformRepository {
+	return f.GetRepositories().Transform
+}
+
+// GetVariantProfileRepository returns the variant profile repository instance
+func (f *Factory) GetVariantProfileRepository() VariantProfileRepository {
+	return f.GetRepositories().VariantProfile
+}
+
 // GetSettingRepository returns the setting repository instance
 func (f *Factory) GetSettingRepository() SettingRepository {
 	return f.GetRepositories().Setting
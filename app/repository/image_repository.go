@@ -8,6 +8,7 @@ import (
 	"github.com/ManuelReschke/PixelFox/app/models"
 	metrics "github.com/ManuelReschke/PixelFox/internal/pkg/metrics/counter"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // imageRepository implements the ImageRepository interface
@@ -76,6 +77,40 @@ func (r *imageRepository) GetByUserID(userID uint, offset, limit int) ([]models.
 	return images, err
 }
 
+// ImageListFilter describes a cursor based listing of a user's images (newest first)
+type ImageListFilter struct {
+	UserID        uint
+	AlbumID       uint       // optional: only images of this album
+	IsPublic      *bool      // optional: visibility
+	CreatedAfter  *time.Time // optional: created at or after
+	CreatedBefore *time.Time // optional: created before
+	BeforeID      uint       // cursor: only images with a lower ID
+	Limit         int
+}
+
+// ListByUser retrieves a user's images ordered by ID descending, starting after the cursor
+func (r *imageRepository) ListByUser(filter ImageListFilter) ([]models.Image, error) {
+	var images []models.Image
+	query := r.db.Preload("StoragePool").Preload("Tags").Where("images.user_id = ?", filter.UserID)
+	if filter.AlbumID > 0 {
+		query = query.Where("images.id IN (SELECT image_id FROM album_images WHERE album_id = ?)", filter.AlbumID)
+	}
+	if filter.IsPublic != nil {
+		query = query.Where("images.is_public = ?", *filter.IsPublic)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("images.created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("images.created_at < ?", *filter.CreatedBefore)
+	}
+	if filter.BeforeID > 0 {
+		query = query.Where("images.id < ?", filter.BeforeID)
+	}
+	err := query.Order("images.id DESC").Limit(filter.Limit).Find(&images).Error
+	return images, err
+}
+
 // Update updates an existing image in the database
 func (r *imageRepository) Update(image *models.Image) error {
 	return r.db.Save(image).Error
@@ -133,6 +168,20 @@ func (r *imageRepository) Search(query string) ([]models.Image, error) {
 	return images, err
 }
 
+// SearchWithTag searches images like Search but only returns images carrying the given tag.
+// An empty query matches all images of the tag.
+func (r *imageRepository) SearchWithTag(query, tag string) ([]models.Image, error) {
+	var images []models.Image
+	db := r.db.Preload("User").Preload("StoragePool").
+		Where("images.id IN (SELECT image_tags.image_id FROM image_tags JOIN tags ON tags.id = image_tags.tag_id WHERE tags.name = ?)", tag)
+	if q := strings.TrimSpace(query); q != "" {
+		searchPattern := "%" + q + "%"
+		db = db.Where("title LIKE ? OR description LIKE ? OR uuid LIKE ?", searchPattern, searchPattern, searchPattern)
+	}
+	err := db.Order("created_at DESC").Find(&images).Error
+	return images, err
+}
+
 // GetPublicImages retrieves public images with pagination
 func (r *imageRepository) GetPublicImages(offset, limit int) ([]models.Image, error) {
 	var images []models.Image
@@ -215,3 +264,58 @@ func (r *imageRepository) GetByUserIDAndFileHash(userID uint, fileHash string) (
 	}
 	return &image, nil
 }
+
+// GetByIDs retrieves images by their IDs including storage pool and owner
+func (r *imageRepository) GetByIDs(ids []uint) ([]models.Image, error) {
+	var images []models.Image
+	if len(ids) == 0 {
+		return images, nil
+	}
+	err := r.db.Preload("StoragePool").Preload("User").Where("id IN ?", ids).Find(&images).Error
+	return images, err
+}
+
+// FindSimilarByUserID retrieves a user's images whose perceptual hash is within maxDistance bits of hash, closest first
+func (r *imageRepository) FindSimilarByUserID(userID uint, hash uint64, maxDistance int, excludeID uint, limit int) ([]models.Image, error) {
+	var images []models.Image
+	err := r.db.Preload("StoragePool").
+		Where("user_id = ? AND id <> ? AND perceptual_hash IS NOT NULL", userID, excludeID).
+		Where("BIT_COUNT(perceptual_hash ^ ?) <= ?", hash, maxDistance).
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "BIT_COUNT(perceptual_hash ^ ?) ASC, id DESC", Vars: []interface{}{hash}, WithoutParentheses: true}}).
+		Limit(limit).
+		Find(&images).Error
+	return images, err
+}
+
+// FindNearDuplicatePairs lists pairs of images of the same user whose perceptual hashes differ in at most maxDistance bits.
+// userID 0 scans all users. Hashes closer than models.PerceptualHashBandCount bits always share a hash band,
+// so below that distance only images sharing a band are compared; larger distances compare all images of a user.
+func (r *imageRepository) FindNearDuplicatePairs(userID uint, maxDistance, limit int) ([]models.NearDuplicatePair, error) {
+	var pairs []models.NearDuplicatePair
+	var query *gorm.DB
+	if maxDistance < models.PerceptualHashBandCount {
+		candidates := r.db.Table("image_hash_bands AS x").
+			Select("DISTINCT x.image_id AS image_id, y.image_id AS other_image_id").
+			Joins("JOIN image_hash_bands AS y ON y.user_id = x.user_id AND y.band = x.band AND y.value = x.value AND y.image_id > x.image_id")
+		if userID > 0 {
+			candidates = candidates.Where("x.user_id = ?", userID)
+		}
+		query = r.db.Table("(?) AS c", candidates).
+			Joins("JOIN images AS a ON a.id = c.image_id AND a.perceptual_hash IS NOT NULL AND a.deleted_at IS NULL").
+			Joins("JOIN images AS b ON b.id = c.other_image_id AND b.user_id = a.user_id AND b.perceptual_hash IS NOT NULL AND b.deleted_at IS NULL")
+	} else {
+		query = r.db.Table("images AS a").
+			Joins("JOIN images AS b ON b.user_id = a.user_id AND b.id > a.id AND b.perceptual_hash IS NOT NULL AND b.deleted_at IS NULL").
+			Where("a.perceptual_hash IS NOT NULL AND a.deleted_at IS NULL")
+		if userID > 0 {
+			query = query.Where("a.user_id = ?", userID)
+		}
+	}
+	query = query.Select("a.user_id, a.id AS image_id, b.id AS other_image_id, BIT_COUNT(a.perceptual_hash ^ b.perceptual_hash) AS distance").
+		Where("BIT_COUNT(a.perceptual_hash ^ b.perceptual_hash) <= ?", maxDistance)
+	err := query.Order("a.user_id ASC, a.id ASC, b.id ASC").Limit(limit).Scan(&pairs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find near-duplicate pairs: %w", err)
+	}
+	return pairs, nil
+}
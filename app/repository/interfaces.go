@@ -13,7 +13,6 @@ type UserRepository interface {
 	GetByID(id uint) (*models.User, error)
 	GetByEmail(email string) (*models.User, error)
 	GetByActivationToken(token string) (*models.User, error)
-	GetByAPIKeyHash(hash string) (*models.User, *models.UserSettings, error)
 	GetStatsByUserID(userID uint) (*UserStats, error)
 	Update(user *models.User) error
 	Delete(id uint) error
@@ -25,6 +24,17 @@ type UserRepository interface {
 	GetDailyStats(startDate, endDate time.Time) ([]models.DailyStats, error)
 }
 
+// APIKeyRepository defines the interface for user API key operations
+type APIKeyRepository interface {
+	Create(key *models.APIKey) error
+	ListByUser(userID uint) ([]models.APIKey, error)
+	CountActiveByUser(userID uint) (int64, error)
+	GetActiveByHash(hash string) (*models.APIKey, *models.User, error)
+	Revoke(userID, keyID uint) error
+	TouchUsage(keyID uint, ip string) error
+	LastUsedAtByUser(userID uint) (*time.Time, error)
+}
+
 // ImageRepository defines the interface for image-related database operations
 type ImageRepository interface {
 	Create(image *models.Image) error
@@ -33,12 +43,14 @@ type ImageRepository interface {
 	GetByFilename(filename string) (*models.Image, error)
 	GetByShareLink(shareLink string) (*models.Image, error)
 	GetByUserID(userID uint, offset, limit int) ([]models.Image, error)
+	ListByUser(filter ImageListFilter) ([]models.Image, error)
 	Update(image *models.Image) error
 	Delete(id uint) error
 	List(offset, limit int) ([]models.Image, error)
 	Count() (int64, error)
 	CountByUserID(userID uint) (int64, error)
 	Search(query string) ([]models.Image, error)
+	SearchWithTag(query, tag string) ([]models.Image, error)
 	GetPublicImages(offset, limit int) ([]models.Image, error)
 	GetRecentImages(limit int) ([]models.Image, error)
 	UpdateViewCount(id uint) error
@@ -47,6 +59,9 @@ type ImageRepository interface {
 	DeleteVariants(imageID uint) error
 	GetDailyStats(startDate, endDate time.Time) ([]models.DailyStats, error)
 	GetByUserIDAndFileHash(userID uint, fileHash string) (*models.Image, error)
+	GetByIDs(ids []uint) ([]models.Image, error)
+	FindSimilarByUserID(userID uint, hash uint64, maxDistance int, excludeID uint, limit int) ([]models.Image, error)
+	FindNearDuplicatePairs(userID uint, maxDistance, limit int) ([]models.NearDuplicatePair, error)
 }
 
 // AlbumRepository defines the interface for album-related database operations
@@ -58,11 +73,65 @@ type AlbumRepository interface {
 	Delete(id uint) error
 	AddImage(albumID, imageID uint) error
 	RemoveImage(albumID, imageID uint) error
+	AddImages(albumID uint, imageIDs []uint) (int, error)
+	RemoveImages(albumID uint, imageIDs []uint) (int64, error)
+	ReorderImages(albumID uint, imageIDs []uint) error
 	GetImages(albumID uint) ([]models.Image, error)
 	Count() (int64, error)
 	CountByUserID(userID uint) (int64, error)
 }
 
+// CommentRepository defines the interface for image comment operations
+type CommentRepository interface {
+	Create(comment *models.Comment) error
+	GetByID(id uint) (*models.Comment, error)
+	GetByImageID(imageID uint, offset, limit int) ([]models.Comment, error)
+	CountByImageID(imageID uint) (int64, error)
+	Delete(id uint) error
+	DeleteByImageID(imageID uint) error
+}
+
+// LikeRepository defines the interface for image like operations
+type LikeRepository interface {
+	Like(userID, imageID uint) (bool, error)
+	Unlike(userID, imageID uint) (bool, error)
+	HasLiked(userID, imageID uint) (bool, error)
+	GetLikedImages(userID uint, offset, limit int) ([]models.Image, error)
+	CountByUserID(userID uint) (int64, error)
+	DeleteByImageID(imageID uint) error
+}
+
+// NotificationRepository defines the interface for user notification operations
+type NotificationRepository interface {
+	Create(notification *models.Notification) error
+	GetByID(userID, id uint) (*models.Notification, error)
+	GetByUserID(userID uint, offset, limit int) ([]models.Notification, error)
+	CountByUserID(userID uint) (int64, error)
+	CountUnread(userID uint) (int64, error)
+	GetUnreadSince(userID uint, since time.Time, limit int) ([]models.Notification, error)
+	MarkAsRead(userID, id uint) error
+	MarkAllAsRead(userID uint) error
+}
+
+// TagRepository defines the interface for image tag operations
+type TagRepository interface {
+	GetByName(name string) (*models.Tag, error)
+	GetByImageID(imageID uint) ([]models.Tag, error)
+	GetByUserID(userID uint, limit int) ([]models.Tag, error)
+	SetImageTags(imageID uint, names []string) ([]models.Tag, error)
+	Suggest(prefix string, limit int) ([]models.Tag, error)
+	GetPublicImages(tagID uint, offset, limit int) ([]models.Image, error)
+	CountPublicImages(tagID uint) (int64, error)
+	PruneUnused() (int64, error)
+}
+
+// SearchRepository defines the interface for the public full-text search
+type SearchRepository interface {
+	SearchImages(params SearchParams) ([]models.Image, int64, error)
+	SearchAlbums(params SearchParams) ([]AlbumSearchResult, int64, error)
+	SearchUsers(params SearchParams) ([]UserSearchResult, int64, error)
+}
+
 // StoragePoolRepository defines the interface for storage pool operations
 type StoragePoolRepository interface {
 	Create(pool *models.StoragePool) error
@@ -85,6 +154,7 @@ type StoragePoolRepository interface {
 	CountVariantsInPool(poolID uint) (int64, error)
 	RecalculatePoolUsage(poolID uint) (int64, error)
 	GetHealthSnapshots() (map[uint]HealthSnapshot, error)
+	GetOptimizationSavings() (models.OptimizationSavings, error)
 }
 
 // SettingRepository defines the interface for application settings
@@ -134,6 +204,25 @@ type QueueRepository interface {
 	DeleteKeys(keys []string) (int64, error)
 }
 
+// TransformRepository defines the interface for transformation presets and the derived variant cache
+type TransformRepository interface {
+	ListPresets() ([]models.TransformPreset, error)
+	GetPresetBySpec(spec string) (*models.TransformPreset, error)
+	CreatePreset(preset *models.TransformPreset) error
+	DeletePreset(id uint) error
+	CacheStatsByPool() ([]TransformCacheStats, error)
+}
+
+// VariantProfileRepository defines the interface for admin-configurable variant profiles
+type VariantProfileRepository interface {
+	List() ([]models.VariantProfile, error)
+	GetByID(id uint) (*models.VariantProfile, error)
+	Create(profile *models.VariantProfile) error
+	Update(profile *models.VariantProfile) error
+	Delete(id uint) error
+	CountVariantsByType() (map[string]int64, error)
+}
+
 // UserWithStats represents a user with additional statistics
 type UserWithStats struct {
 	User         models.User
@@ -151,26 +240,42 @@ type UserStats struct {
 
 // Repositories struct holds all repository instances
 type Repositories struct {
-	User        UserRepository
-	Image       ImageRepository
-	Album       AlbumRepository
-	StoragePool StoragePoolRepository
-	Setting     SettingRepository
-	Page        PageRepository
-	News        NewsRepository
-	Queue       QueueRepository
+	User           UserRepository
+	APIKey         APIKeyRepository
+	Image          ImageRepository
+	Album          AlbumRepository
+	Comment        CommentRepository
+	Like           LikeRepository
+	Notification   NotificationRepository
+	Tag            TagRepository
+	Search         SearchRepository
+	StoragePool    StoragePoolRepository
+	Transform      TransformRepository
+	VariantProfile VariantProfileRepository
+	Setting        SettingRepository
+	Page           PageRepository
+	News           NewsRepository
+	Queue          QueueRepository
 }
 
 // NewRepositories creates a new instance of all repositories
 func NewRepositories(db *gorm.DB) *Repositories {
 	return &Repositories{
-		User:        NewUserRepository(db),
-		Image:       NewImageRepository(db),
-		Album:       NewAlbumRepository(db),
-		StoragePool: NewStoragePoolRepository(db),
-		Setting:     NewSettingRepository(db),
-		Page:        NewPageRepository(db),
-		News:        NewNewsRepository(db),
-		Queue:       NewQueueRepository(),
+		User:           NewUserRepository(db),
+		APIKey:         NewAPIKeyRepository(db),
+		Image:          NewImageRepository(db),
+		Album:          NewAlbumRepository(db),
+		Comment:        NewCommentRepository(db),
+		Like:           NewLikeRepository(db),
+		Notification:   NewNotificationRepository(db),
+		Tag:            NewTagRepository(db),
+		Search:         NewSearchRepository(db),
+		StoragePool:    NewStoragePoolRepository(db),
+		Transform:      NewTransformRepository(db),
+		VariantProfile: NewVariantProfileRepository(db),
+		Setting:        NewSettingRepository(db),
+		Page:           NewPageRepository(db),
+		News:           NewNewsRepository(db),
+		Queue:          NewQueueRepository(),
 	}
 }
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"gorm.io/gorm"
+)
+
+// likeRepository implements the LikeRepository interface
+type likeRepository struct {
+	db *gorm.DB
+}
+
+// NewLikeRepository creates a new like repository instance
+func NewLikeRepository(db *gorm.DB) LikeRepository {
+	return &likeRepository{db: db}
+}
+
+// Like stores a like; returns false if the user already liked the image
+func (r *likeRepository) Like(userID, imageID uint) (bool, error) {
+	return models.LikeImage(r.db, userID, imageID)
+}
+
+// Unlike removes a like; returns false if there was nothing to remove
+func (r *likeRepository) Unlike(userID, imageID uint) (bool, error) {
+	return models.UnlikeImage(r.db, userID, imageID)
+}
+
+// HasLiked checks whether a user liked an image
+func (r *likeRepository) HasLiked(userID, imageID uint) (bool, error) {
+	return models.HasLikedImage(r.db, userID, imageID)
+}
+
+// GetLikedImages retrieves the images a user liked, most recently liked first, with pagination
+func (r *likeRepository) GetLikedImages(userID uint, offset, limit int) ([]models.Image, error) {
+	var images []models.Image
+	err := r.db.Preload("StoragePool").
+		Joins("JOIN likes ON likes.image_id = images.id").
+		Where("likes.user_id = ?", userID).
+		Where("images.is_public = ? OR images.user_id = ?", true, userID).
+		Order("likes.created_at DESC, likes.id DESC").
+		Offset(offset).Limit(limit).
+		Find(&images).Error
+	return images, err
+}
+
+// CountByUserID returns the number of visible images a user liked
+func (r *likeRepository) CountByUserID(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Image{}).
+		Joins("JOIN likes ON likes.image_id = images.id").
+		Where("likes.user_id = ?", userID).
+		Where("images.is_public = ? OR images.user_id = ?", true, userID).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteByImageID removes all likes of an image
+func (r *likeRepository) DeleteByImageID(imageID uint) error {
+	return r.db.Where("image_id = ?", imageID).Delete(&models.Like{}).Error
+}
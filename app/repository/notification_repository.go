@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"gorm.io/gorm"
+)
+
+// notificationRepository implements the NotificationRepository interface
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new notification repository instance
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// Create creates a new notification in the database
+func (r *notificationRepository) Create(notification *models.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+// GetByID retrieves a notification of a user by its ID
+func (r *notificationRepository) GetByID(userID, id uint) (*models.Notification, error) {
+	var notification models.Notification
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&notification).Error
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// GetByUserID retrieves the notifications of a user, newest first, with pagination
+func (r *notificationRepository) GetByUserID(userID uint, offset, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&notifications).Error
+	return notifications, err
+}
+
+// CountByUserID returns the number of notifications of a user
+func (r *notificationRepository) CountByUserID(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// CountUnread returns the number of unread notifications of a user
+func (r *notificationRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).Where("user_id = ? AND is_read = ?", userID, false).Count(&count).Error
+	return count, err
+}
+
+// GetUnreadSince retrieves unread notifications of a user created after the given time, newest first
+func (r *notificationRepository) GetUnreadSince(userID uint, since time.Time, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	err := r.db.Where("user_id = ? AND is_read = ? AND created_at > ?", userID, false, since).
+		Order("created_at DESC, id DESC").Limit(limit).Find(&notifications).Error
+	return notifications, err
+}
+
+// MarkAsRead marks a single notification of a user as read
+func (r *notificationRepository) MarkAsRead(userID, id uint) error {
+	return r.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("is_read", true).Error
+}
+
+// MarkAllAsRead marks all notifications of a user as read
+func (r *notificationRepository) MarkAllAsRead(userID uint) error {
+	return r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Update("is_read", true).Error
+}
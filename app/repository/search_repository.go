@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// SearchMaxTerms caps the number of words taken from a search query
+	SearchMaxTerms = 10
+
+	OrientationLandscape = "landscape"
+	OrientationPortrait  = "portrait"
+	OrientationSquare    = "square"
+)
+
+// SearchParams describes a full-text search request including its filters
+type SearchParams struct {
+	Query       string     // raw user input, converted with FulltextBooleanQuery
+	ViewerID    uint       // private content of this user is included (0 = anonymous)
+	OwnerID     uint       // optional: restrict results to content of this user
+	From        *time.Time // optional: created at or after
+	To          *time.Time // optional: created before
+	Formats     []string   // optional: file extensions including the dot (".jpg")
+	MinWidth    int
+	MinHeight   int
+	Orientation string // optional: landscape, portrait or square
+	Offset      int
+	Limit       int
+}
+
+// AlbumSearchResult represents an album hit with its number of images
+type AlbumSearchResult struct {
+	Album      models.Album
+	ImageCount int64
+}
+
+// UserSearchResult represents a user hit with the number of public images
+type UserSearchResult struct {
+	User             models.User
+	PublicImageCount int64
+}
+
+// searchRepository implements the SearchRepository interface
+type searchRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchRepository creates a new search repository instance
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// FulltextBooleanQuery converts user input into a MySQL boolean mode query.
+// Operators are stripped and every term becomes a prefix match, so "sun beach"
+// turns into "sun* beach*". Returns an empty string if no usable term is left.
+func FulltextBooleanQuery(input string) string {
+	terms := strings.FieldsFunc(strings.ToLower(input), func(r rune) bool {
+		switch r {
+		case '+', '-', '<', '>', '(', ')', '~', '*', '"', '@', '\'', ',', ';', '#':
+			return true
+		}
+		return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+	var parts []string
+	for _, term := range terms {
+		if term == "" {
+			continue
+		}
+		parts = append(parts, term+"*")
+		if len(parts) == SearchMaxTerms {
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// imageRelevanceExpr ranks an image by title/description, tags, visible album titles and camera model
+const imageRelevanceExpr = `(MATCH(images.title, images.description) AGAINST (? IN BOOLEAN MODE) * 3
+	+ COALESCE((SELECT MAX(MATCH(tags.name) AGAINST (? IN BOOLEAN MODE)) FROM image_tags JOIN tags ON tags.id = image_tags.tag_id WHERE image_tags.image_id = images.id), 0) * 2
+	+ COALESCE((SELECT MAX(MATCH(albums.title, albums.description) AGAINST (? IN BOOLEAN MODE)) FROM album_images JOIN albums ON albums.id = album_images.album_id WHERE album_images.image_id = images.id AND albums.deleted_at IS NULL AND (albums.is_public = ? OR albums.user_id = ?)), 0)
+	+ COALESCE((SELECT MAX(MATCH(image_metadata.camera_model) AGAINST (? IN BOOLEAN MODE)) FROM image_metadata WHERE image_metadata.image_id = images.id AND image_metadata.deleted_at IS NULL), 0))`
+
+// imageCandidatesSQL collects the IDs of images with at least one match. Every branch is answered
+// by a full-text index, so only these candidates are ranked with imageRelevanceExpr.
+const imageCandidatesSQL = `SELECT images.id AS image_id FROM images WHERE MATCH(images.title, images.description) AGAINST (? IN BOOLEAN MODE)
+	UNION SELECT image_tags.image_id FROM tags JOIN image_tags ON image_tags.tag_id = tags.id WHERE MATCH(tags.name) AGAINST (? IN BOOLEAN MODE)
+	UNION SELECT album_images.image_id FROM albums JOIN album_images ON album_images.album_id = albums.id WHERE MATCH(albums.title, albums.description) AGAINST (? IN BOOLEAN MODE) AND albums.deleted_at IS NULL AND (albums.is_public = ? OR albums.user_id = ?)
+	UNION SELECT image_metadata.image_id FROM image_metadata WHERE MATCH(image_metadata.camera_model) AGAINST (? IN BOOLEAN MODE) AND image_metadata.deleted_at IS NULL`
+
+// imageFilters applies visibility and the optional filters to an image query
+func imageFilters(db *gorm.DB, p SearchParams) *gorm.DB {
+	db = db.Where("(images.is_public = ? OR images.user_id = ?)", true, p.ViewerID)
+	if p.OwnerID > 0 {
+		db = db.Where("images.user_id = ?", p.OwnerID)
+	}
+	if p.From != nil {
+		db = db.Where("images.created_at >= ?", *p.From)
+	}
+	if p.To != nil {
+		db = db.Where("images.created_at < ?", *p.To)
+	}
+	if len(p.Formats) > 0 {
+		db = db.Where("images.file_type IN ?", p.Formats)
+	}
+	if p.MinWidth > 0 {
+		db = db.Where("images.width >= ?", p.MinWidth)
+	}
+	if p.MinHeight > 0 {
+		db = db.Where("images.height >= ?", p.MinHeight)
+	}
+	switch p.Orientation {
+	case OrientationLandscape:
+		db = db.Where("images.width > images.height")
+	case OrientationPortrait:
+		db = db.Where("images.width < images.height")
+	case OrientationSquare:
+		db = db.Where("images.width = images.height AND images.width > 0")
+	}
+	return db
+}
+
+// SearchImages returns visible images ranked by relevance. Without a query
+// the filtered images are returned newest first.
+func (r *searchRepository) SearchImages(p SearchParams) ([]models.Image, int64, error) {
+	var (
+		images []models.Image
+		total  int64
+	)
+	ft := FulltextBooleanQuery(p.Query)
+	base := imageFilters(r.db.Model(&models.Image{}), p)
+
+	if ft == "" {
+		if err := base.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+		err := imageFilters(r.db.Preload("StoragePool").Preload("User").Preload("Tags"), p).
+			Order("images.created_at DESC").Offset(p.Offset).Limit(p.Limit).Find(&images).Error
+		return images, total, err
+	}
+
+	candidates := gorm.Expr(imageCandidatesSQL, ft, ft, ft, true, p.ViewerID, ft)
+	relevance := gorm.Expr(imageRelevanceExpr, ft, ft, ft, true, p.ViewerID, ft)
+	ranked := base.Joins("JOIN (?) AS candidates ON candidates.image_id = images.id", candidates).
+		Select("images.id, ? AS relevance", relevance).Having("relevance > 0").
+		Session(&gorm.Session{})
+	if err := r.db.Table("(?) AS hits", ranked).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var hits []struct {
+		ID        uint
+		Relevance float64
+	}
+	if err := ranked.Order("relevance DESC, images.created_at DESC").
+		Offset(p.Offset).Limit(p.Limit).Scan(&hits).Error; err != nil {
+		return nil, 0, err
+	}
+	if len(hits) == 0 {
+		return images, total, nil
+	}
+
+	ids := make([]uint, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+	var found []models.Image
+	if err := r.db.Preload("StoragePool").Preload("User").Preload("Tags").Where("id IN ?", ids).Find(&found).Error; err != nil {
+		return nil, 0, err
+	}
+	// Restore the ranking order
+	byID := make(map[uint]models.Image, len(found))
+	for _, img := range found {
+		byID[img.ID] = img
+	}
+	for _, id := range ids {
+		if img, ok := byID[id]; ok {
+			images = append(images, img)
+		}
+	}
+	return images, total, nil
+}
+
+// SearchAlbums returns visible albums ranked by title and description
+func (r *searchRepository) SearchAlbums(p SearchParams) ([]AlbumSearchResult, int64, error) {
+	var (
+		albums []models.Album
+		total  int64
+	)
+	ft := FulltextBooleanQuery(p.Query)
+	if ft == "" {
+		return nil, 0, nil
+	}
+
+	query := r.db.Model(&models.Album{}).
+		Where("(albums.is_public = ? OR albums.user_id = ?)", true, p.ViewerID).
+		Where("MATCH(albums.title, albums.description) AGAINST (? IN BOOLEAN MODE)", ft)
+	if p.OwnerID > 0 {
+		query = query.Where("albums.user_id = ?", p.OwnerID)
+	}
+	if p.From != nil {
+		query = query.Where("albums.created_at >= ?", *p.From)
+	}
+	if p.To != nil {
+		query = query.Where("albums.created_at < ?", *p.To)
+	}
+	query = query.Session(&gorm.Session{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Preload("User").
+		Order(gorm.Expr("MATCH(albums.title, albums.description) AGAINST (? IN BOOLEAN MODE) DESC, albums.created_at DESC", ft)).
+		Offset(p.Offset).Limit(p.Limit).Find(&albums).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]AlbumSearchResult, 0, len(albums))
+	for _, album := range albums {
+		var count int64
+		if err := r.db.Table("album_images").
+			Joins("JOIN images ON images.id = album_images.image_id AND images.deleted_at IS NULL").
+			Where("album_images.album_id = ?", album.ID).
+			Where("(images.is_public = ? OR images.user_id = ?)", true, p.ViewerID).
+			Count(&count).Error; err != nil {
+			return nil, 0, err
+		}
+		results = append(results, AlbumSearchResult{Album: album, ImageCount: count})
+	}
+	return results, total, nil
+}
+
+// SearchUsers returns active users by name. Only users with public images
+// (or the viewer) are returned; emails are never searched.
+func (r *searchRepository) SearchUsers(p SearchParams) ([]UserSearchResult, int64, error) {
+	var (
+		users []models.User
+		total int64
+	)
+	ft := FulltextBooleanQuery(p.Query)
+	if ft == "" {
+		return nil, 0, nil
+	}
+
+	query := r.db.Model(&models.User{}).
+		Where("users.status = ?", models.STATUS_ACTIVE).
+		Where("MATCH(users.name) AGAINST (? IN BOOLEAN MODE)", ft).
+		Where("(users.id = ? OR EXISTS (SELECT 1 FROM images WHERE images.user_id = users.id AND images.is_public = ? AND images.deleted_at IS NULL))", p.ViewerID, true).
+		Session(&gorm.Session{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.
+		Order(gorm.Expr("MATCH(users.name) AGAINST (? IN BOOLEAN MODE) DESC, users.name ASC", ft)).
+		Offset(p.Offset).Limit(p.Limit).Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]UserSearchResult, 0, len(users))
+	for _, user := range users {
+		var count int64
+		if err := r.db.Model(&models.Image{}).
+			Where("user_id = ? AND is_public = ?", user.ID, true).
+			Count(&count).Error; err != nil {
+			return nil, 0, err
+		}
+		results = append(results, UserSearchResult{User: user, PublicImageCount: count})
+	}
+	return results, total, nil
+}
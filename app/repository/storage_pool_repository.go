@@ -207,6 +207,11 @@ func (r *storagePoolRepository) CountVariantsInPool(poolID uint) (int64, error)
 	return count, err
 }
 
+// GetOptimizationSavings returns the bytes saved by the optimisation pass across all pools
+func (r *storagePoolRepository) GetOptimizationSavings() (models.OptimizationSavings, error) {
+	return models.GetOptimizationSavings(r.db)
+}
+
 // RecalculatePoolUsage recalculates the actual usage of a storage pool
 func (r *storagePoolRepository) RecalculatePoolUsage(poolID uint) (int64, error) {
 	// Sum image file sizes
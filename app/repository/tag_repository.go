@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tagRepository implements the TagRepository interface
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates a new tag repository instance
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+// GetByName retrieves a tag by its normalized name
+func (r *tagRepository) GetByName(name string) (*models.Tag, error) {
+	var tag models.Tag
+	if err := r.db.Where("name = ?", name).First(&tag).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// GetByImageID retrieves the tags of an image ordered by name
+func (r *tagRepository) GetByImageID(imageID uint) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := r.db.Joins("JOIN image_tags ON image_tags.tag_id = tags.id").
+		Where("image_tags.image_id = ?", imageID).
+		Order("tags.name ASC").Find(&tags).Error
+	return tags, err
+}
+
+// GetByUserID retrieves the tags used on a user's images, most used first
+func (r *tagRepository) GetByUserID(userID uint, limit int) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := r.db.Select("tags.*").
+		Joins("JOIN image_tags ON image_tags.tag_id = tags.id").
+		Joins("JOIN images ON images.id = image_tags.image_id AND images.deleted_at IS NULL").
+		Where("images.user_id = ?", userID).
+		Group("tags.id").
+		Order("COUNT(*) DESC, tags.name ASC").
+		Limit(limit).Find(&tags).Error
+	return tags, err
+}
+
+// SetImageTags replaces the tags of an image with the given normalized names,
+// refreshes the cached counts and prunes tags that are no longer used
+func (r *tagRepository) SetImageTags(imageID uint, names []string) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var previousIDs []uint
+		if err := tx.Model(&models.ImageTag{}).Where("image_id = ?", imageID).Pluck("tag_id", &previousIDs).Error; err != nil {
+			return err
+		}
+
+		if len(names) > 0 {
+			newTags := make([]models.Tag, 0, len(names))
+			for _, name := range names {
+				newTags = append(newTags, models.Tag{Name: name})
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&newTags).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("name IN ?", names).Order("name ASC").Find(&tags).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("image_id = ?", imageID).Delete(&models.ImageTag{}).Error; err != nil {
+			return err
+		}
+		affected := append([]uint{}, previousIDs...)
+		if len(tags) > 0 {
+			links := make([]models.ImageTag, 0, len(tags))
+			for _, tag := range tags {
+				links = append(links, models.ImageTag{ImageID: imageID, TagID: tag.ID})
+				affected = append(affected, tag.ID)
+			}
+			if err := tx.Create(&links).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := models.RefreshTagCounts(tx, affected); err != nil {
+			return err
+		}
+		_, err := models.PruneUnusedTags(tx, previousIDs)
+		return err
+	})
+	return tags, err
+}
+
+// Suggest returns existing tags starting with the given prefix, most used first
+func (r *tagRepository) Suggest(prefix string, limit int) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := r.db.Where("name LIKE ? AND image_count > 0", escapeLike(prefix)+"%").
+		Order("image_count DESC, name ASC").Limit(limit).Find(&tags).Error
+	return tags, err
+}
+
+// GetPublicImages retrieves the public images of a tag, newest first, with pagination
+func (r *tagRepository) GetPublicImages(tagID uint, offset, limit int) ([]models.Image, error) {
+	var images []models.Image
+	err := r.db.Preload("StoragePool").
+		Joins("JOIN image_tags ON image_tags.image_id = images.id").
+		Where("image_tags.tag_id = ? AND images.is_public = ?", tagID, true).
+		Order("images.created_at DESC").Offset(offset).Limit(limit).Find(&images).Error
+	return images, err
+}
+
+// CountPublicImages returns the number of public images of a tag
+func (r *tagRepository) CountPublicImages(tagID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Image{}).
+		Joins("JOIN image_tags ON image_tags.image_id = images.id").
+		Where("image_tags.tag_id = ? AND images.is_public = ?", tagID, true).
+		Count(&count).Error
+	return count, err
+}
+
+// PruneUnused deletes all tags that are not attached to any image
+func (r *tagRepository) PruneUnused() (int64, error) {
+	return models.PruneUnusedTags(r.db, nil)
+}
+
+// escapeLike escapes the LIKE wildcards of a user supplied value
+func escapeLike(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(value)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"gorm.io/gorm"
+)
+
+// TransformCacheStats summarizes the cached transformations of a storage pool
+type TransformCacheStats struct {
+	StoragePoolID uint
+	PoolName      string
+	Entries       int64
+	TotalBytes    int64
+}
+
+// transformRepository implements the TransformRepository interface
+type transformRepository struct {
+	db *gorm.DB
+}
+
+// NewTransformRepository creates a new transform repository instance
+func NewTransformRepository(db *gorm.DB) TransformRepository {
+	return &transformRepository{db: db}
+}
+
+// ListPresets returns all allowed transformation presets ordered by name
+func (r *transformRepository) ListPresets() ([]models.TransformPreset, error) {
+	var presets []models.TransformPreset
+	err := r.db.Order("name ASC").Find(&presets).Error
+	return presets, err
+}
+
+// GetPresetBySpec retrieves the preset allowing the given canonical spec
+func (r *transformRepository) GetPresetBySpec(spec string) (*models.TransformPreset, error) {
+	var preset models.TransformPreset
+	if err := r.db.Where("spec = ?", spec).First(&preset).Error; err != nil {
+		return nil, err
+	}
+	return &preset, nil
+}
+
+// CreatePreset adds a spec to the allow-list
+func (r *transformRepository) CreatePreset(preset *models.TransformPreset) error {
+	return r.db.Create(preset).Error
+}
+
+// DeletePreset removes a spec from the allow-list. Cached renderings stay until evicted.
+func (r *transformRepository) DeletePreset(id uint) error {
+	return r.db.Delete(&models.TransformPreset{}, id).Error
+}
+
+// CacheStatsByPool returns entry count and size of the transformation cache per storage pool
+func (r *transformRepository) CacheStatsByPool() ([]TransformCacheStats, error) {
+	var stats []TransformCacheStats
+	err := r.db.Table("derived_variants").
+		Select("derived_variants.storage_pool_id, storage_pools.name AS pool_name, COUNT(*) AS entries, COALESCE(SUM(derived_variants.file_size), 0) AS total_bytes").
+		Joins("LEFT JOIN storage_pools ON storage_pools.id = derived_variants.storage_pool_id").
+		Group("derived_variants.storage_pool_id, storage_pools.name").
+		Order("derived_variants.storage_pool_id ASC").
+		Scan(&stats).Error
+	return stats, err
+}
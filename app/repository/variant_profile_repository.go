@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"gorm.io/gorm"
+)
+
+// variantProfileRepository implements the VariantProfileRepository interface
+type variantProfileRepository struct {
+	db *gorm.DB
+}
+
+// NewVariantProfileRepository creates a new variant profile repository instance
+func NewVariantProfileRepository(db *gorm.DB) VariantProfileRepository {
+	return &variantProfileRepository{db: db}
+}
+
+// List returns all variant profiles ordered by size
+func (r *variantProfileRepository) List() ([]models.VariantProfile, error) {
+	var profiles []models.VariantProfile
+	err := r.db.Order("max_width ASC, max_height ASC, name ASC").Find(&profiles).Error
+	return profiles, err
+}
+
+// GetByID retrieves a variant profile by its ID
+func (r *variantProfileRepository) GetByID(id uint) (*models.VariantProfile, error) {
+	var profile models.VariantProfile
+	if err := r.db.First(&profile, id).Error; err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// Create stores a new variant profile
+func (r *variantProfileRepository) Create(profile *models.VariantProfile) error {
+	return r.db.Create(profile).Error
+}
+
+// Update saves all fields of a variant profile
+func (r *variantProfileRepository) Update(profile *models.VariantProfile) error {
+	return r.db.Save(profile).Error
+}
+
+// Delete removes a variant profile. Existing variant files are removed by the backfill job.
+func (r *variantProfileRepository) Delete(id uint) error {
+	return r.db.Delete(&models.VariantProfile{}, id).Error
+}
+
+// CountVariantsByType returns the number of stored variants per variant type
+func (r *variantProfileRepository) CountVariantsByType() (map[string]int64, error) {
+	var rows []struct {
+		VariantType string
+		Count       int64
+	}
+	err := r.db.Model(&models.ImageVariant{}).
+		Select("variant_type, COUNT(*) AS count").
+		Group("variant_type").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.VariantType] = row.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
+)
+
+// reencryptCredentials verschlüsselt S3-Zugangsdaten der Speicherpools mit dem aktuellen Schlüssel
+// aus STORAGE_CREDENTIAL_KEYS. Klartext-Werte werden verschlüsselt, Werte älterer Schlüssel rotiert.
+func reencryptCredentials() {
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		env.GetEnv("DB_USER", "pixelfox"),
+		env.GetEnv("DB_PASSWORD", "pixelfox"),
+		env.GetEnv("DB_HOST", "db"),
+		env.GetEnv("DB_PORT", "3306"),
+		env.GetEnv("DB_NAME", "pixelfox_db"),
+	)
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("Fehler beim Verbinden mit der Datenbank: %v", err)
+	}
+
+	updated, err := models.ReencryptStoragePoolCredentials(db)
+	if err != nil {
+		log.Fatalf("Fehler beim Verschlüsseln der Zugangsdaten (%d Speicherpools aktualisiert): %v", updated, err)
+	}
+	log.Printf("Zugangsdaten von %d Speicherpools neu verschlüsselt", updated)
+}
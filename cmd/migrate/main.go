@@ -23,6 +23,12 @@ func main() {
 
 	command := os.Args[1]
 
+	// Zugangsdaten werden über GORM aktualisiert, nicht über Migrationsdateien
+	if command == "reencrypt-credentials" {
+		reencryptCredentials()
+		return
+	}
+
 	// Datenbankverbindung für Migrationen erstellen
 	dbURL := fmt.Sprintf("mysql://%s:%s@tcp(%s:%s)/%s?multiStatements=true",
 		env.GetEnv("DB_USER", "pixelfox"),
@@ -137,4 +143,5 @@ func printUsage() {
 	fmt.Println("  goto N - Migriere zur Version N")
 	fmt.Println("  status - Zeige aktuelle Migrationsversion an")
 	fmt.Println("  force N- Force Version N (bereinigt dirty state)")
+	fmt.Println("  reencrypt-credentials - Verschlüssele S3-Zugangsdaten mit dem aktuellen Schlüssel (Rotation)")
 }
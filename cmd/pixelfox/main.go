@@ -25,6 +25,7 @@ import (
 	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/jobqueue"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/middleware"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/router"
 	storagemod "github.com/ManuelReschke/PixelFox/internal/pkg/storage"
 )
@@ -140,7 +141,9 @@ func NewApplication() *fiber.App {
 		Compress:      true,
 	})
 
-	// static uploads
+	// uploads are served from the pool that holds them; originals of watermarked images are owner-only
+	app.Use(constants.UploadsRoute, middleware.ProtectWatermarkedOriginals)
+	app.Use(constants.UploadsRoute, middleware.DeliverFromStoragePool)
 	app.Static(constants.UploadsRoute, basePath+"uploads", fiber.Static{
 		CacheDuration: 10 * time.Second,
 		Compress:      false,
@@ -24,6 +24,18 @@ const (
 	UploadTokenAuthScopes = "UploadTokenAuth.Scopes"
 )
 
+// Defines values for ImageDeleteAcceptedStatus.
+const (
+	Scheduled ImageDeleteAcceptedStatus = "scheduled"
+)
+
+// Defines values for ImageDetailsAvailableVariants.
+const (
+	ImageDetailsAvailableVariantsAvif     ImageDetailsAvailableVariants = "avif"
+	ImageDetailsAvailableVariantsOriginal ImageDetailsAvailableVariants = "original"
+	ImageDetailsAvailableVariantsWebp     ImageDetailsAvailableVariants = "webp"
+)
+
 // Defines values for ImageResourceAvailableVariants.
 const (
 	ImageResourceAvailableVariantsAvif     ImageResourceAvailableVariants = "avif"
@@ -31,13 +43,159 @@ const (
 	ImageResourceAvailableVariantsWebp     ImageResourceAvailableVariants = "webp"
 )
 
+// Defines values for SearchResultListType.
+const (
+	SearchResultListTypeAlbums SearchResultListType = "albums"
+	SearchResultListTypeImages SearchResultListType = "images"
+	SearchResultListTypeUsers  SearchResultListType = "users"
+)
+
 // Defines values for StorageUploadResponseAvailableVariants.
 const (
-	StorageUploadResponseAvailableVariantsAvif     StorageUploadResponseAvailableVariants = "avif"
-	StorageUploadResponseAvailableVariantsOriginal StorageUploadResponseAvailableVariants = "original"
-	StorageUploadResponseAvailableVariantsWebp     StorageUploadResponseAvailableVariants = "webp"
+	Avif     StorageUploadResponseAvailableVariants = "avif"
+	Original StorageUploadResponseAvailableVariants = "original"
+	Webp     StorageUploadResponseAvailableVariants = "webp"
+)
+
+// Defines values for UserAccountPreferencesMetadataPolicy.
+const (
+	UserAccountPreferencesMetadataPolicyKeep          UserAccountPreferencesMetadataPolicy = "keep"
+	UserAccountPreferencesMetadataPolicyStripAll      UserAccountPreferencesMetadataPolicy = "strip_all"
+	UserAccountPreferencesMetadataPolicyStripLocation UserAccountPreferencesMetadataPolicy = "strip_location"
 )
 
+// Defines values for ListImagesParamsVisibility.
+const (
+	Private ListImagesParamsVisibility = "private"
+	Public  ListImagesParamsVisibility = "public"
+)
+
+// Defines values for SearchContentParamsType.
+const (
+	SearchContentParamsTypeAlbums SearchContentParamsType = "albums"
+	SearchContentParamsTypeImages SearchContentParamsType = "images"
+	SearchContentParamsTypeUsers  SearchContentParamsType = "users"
+)
+
+// Defines values for SearchContentParamsOrientation.
+const (
+	Landscape SearchContentParamsOrientation = "landscape"
+	Portrait  SearchContentParamsOrientation = "portrait"
+	Square    SearchContentParamsOrientation = "square"
+)
+
+// Defines values for PostDirectUploadMultipartBodyMetadataPolicy.
+const (
+	PostDirectUploadMultipartBodyMetadataPolicyKeep          PostDirectUploadMultipartBodyMetadataPolicy = "keep"
+	PostDirectUploadMultipartBodyMetadataPolicyStripAll      PostDirectUploadMultipartBodyMetadataPolicy = "strip_all"
+	PostDirectUploadMultipartBodyMetadataPolicyStripLocation PostDirectUploadMultipartBodyMetadataPolicy = "strip_location"
+)
+
+// Album defines model for Album.
+type Album struct {
+	CoverImageUuid *string    `json:"cover_image_uuid"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	Description    *string    `json:"description,omitempty"`
+	Id             int        `json:"id"`
+	ImageCount     int64      `json:"image_count"`
+	IsPublic       bool       `json:"is_public"`
+	Title          string     `json:"title"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+	ViewCount      *int       `json:"view_count,omitempty"`
+
+	// ViewUrl Public share page (only reachable while the album is public)
+	ViewUrl *string `json:"view_url,omitempty"`
+}
+
+// AlbumCoverRequest defines model for AlbumCoverRequest.
+type AlbumCoverRequest struct {
+	ImageUuid string `json:"image_uuid"`
+}
+
+// AlbumCreateRequest defines model for AlbumCreateRequest.
+type AlbumCreateRequest struct {
+	Description *string `json:"description,omitempty"`
+	IsPublic    *bool   `json:"is_public,omitempty"`
+	Title       string  `json:"title"`
+}
+
+// AlbumDetails defines model for AlbumDetails.
+type AlbumDetails struct {
+	CoverImageUuid *string         `json:"cover_image_uuid"`
+	CreatedAt      *time.Time      `json:"created_at,omitempty"`
+	Description    *string         `json:"description,omitempty"`
+	Id             int             `json:"id"`
+	ImageCount     int64           `json:"image_count"`
+	Images         *[]ImageDetails `json:"images,omitempty"`
+	IsPublic       bool            `json:"is_public"`
+	Title          string          `json:"title"`
+	UpdatedAt      *time.Time      `json:"updated_at,omitempty"`
+	ViewCount      *int            `json:"view_count,omitempty"`
+
+	// ViewUrl Public share page (only reachable while the album is public)
+	ViewUrl *string `json:"view_url,omitempty"`
+}
+
+// AlbumImageUUIDs defines model for AlbumImageUUIDs.
+type AlbumImageUUIDs struct {
+	ImageUuids []string `json:"image_uuids"`
+}
+
+// AlbumImagesChange defines model for AlbumImagesChange.
+type AlbumImagesChange struct {
+	Added      *int   `json:"added,omitempty"`
+	ImageCount *int64 `json:"image_count,omitempty"`
+
+	// NotFound UUIDs that do not belong to the caller
+	NotFound *[]string `json:"not_found,omitempty"`
+	Removed  *int64    `json:"removed,omitempty"`
+}
+
+// AlbumList defines model for AlbumList.
+type AlbumList struct {
+	Items   []Album `json:"items"`
+	Page    int     `json:"page"`
+	PerPage int     `json:"per_page"`
+	Total   int64   `json:"total"`
+}
+
+// AlbumUpdateRequest defines model for AlbumUpdateRequest.
+type AlbumUpdateRequest struct {
+	Description *string `json:"description,omitempty"`
+	IsPublic    *bool   `json:"is_public,omitempty"`
+	Title       *string `json:"title,omitempty"`
+}
+
+// Comment defines model for Comment.
+type Comment struct {
+	Author CommentAuthor `json:"author"`
+
+	// CanDelete Whether the caller may delete this comment
+	CanDelete *bool     `json:"can_delete,omitempty"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+	Id        int64     `json:"id"`
+}
+
+// CommentAuthor defines model for CommentAuthor.
+type CommentAuthor struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// CommentCreateRequest defines model for CommentCreateRequest.
+type CommentCreateRequest struct {
+	Content string `json:"content"`
+}
+
+// CommentList defines model for CommentList.
+type CommentList struct {
+	Items   []Comment `json:"items"`
+	Page    int       `json:"page"`
+	PerPage int       `json:"per_page"`
+	Total   int64     `json:"total"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	// Details Additional error details
@@ -57,11 +215,78 @@ type FormatVariants struct {
 	Small    *VariantSize `json:"small,omitempty"`
 }
 
+// ImageDeleteAccepted defines model for ImageDeleteAccepted.
+type ImageDeleteAccepted struct {
+	ImageUuid string                    `json:"image_uuid"`
+	JobId     string                    `json:"job_id"`
+	Status    ImageDeleteAcceptedStatus `json:"status"`
+}
+
+// ImageDeleteAcceptedStatus defines model for ImageDeleteAccepted.Status.
+type ImageDeleteAcceptedStatus string
+
+// ImageDetails defines model for ImageDetails.
+type ImageDetails struct {
+	// AvailableVariants List of available variant-families for this image
+	AvailableVariants *[]ImageDetailsAvailableVariants `json:"available_variants,omitempty"`
+	CreatedAt         *time.Time                       `json:"created_at,omitempty"`
+	Description       *string                          `json:"description,omitempty"`
+	FileSize          *int64                           `json:"file_size,omitempty"`
+	Height            *int                             `json:"height,omitempty"`
+	ImageUuid         string                           `json:"image_uuid"`
+	IsPublic          *bool                            `json:"is_public,omitempty"`
+	Placeholder       *ImagePlaceholder                `json:"placeholder,omitempty"`
+	Tags              *[]string                        `json:"tags,omitempty"`
+	Title             *string                          `json:"title,omitempty"`
+
+	// Url Direct URL to the original image file
+	Url *string `json:"url,omitempty"`
+
+	// Variants URLs of available variants grouped by family
+	Variants *struct {
+		Avif     *FormatVariants `json:"avif,omitempty"`
+		Original *FormatVariants `json:"original,omitempty"`
+		Webp     *FormatVariants `json:"webp,omitempty"`
+	} `json:"variants,omitempty"`
+
+	// ViewUrl Share page URL (HTML view)
+	ViewUrl *string `json:"view_url,omitempty"`
+	Width   *int    `json:"width,omitempty"`
+}
+
+// ImageDetailsAvailableVariants defines model for ImageDetails.AvailableVariants.
+type ImageDetailsAvailableVariants string
+
+// ImageList defines model for ImageList.
+type ImageList struct {
+	Items []ImageDetails `json:"items"`
+	Limit int            `json:"limit"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string `json:"next_cursor"`
+}
+
+// ImagePlaceholder defines model for ImagePlaceholder.
+type ImagePlaceholder struct {
+	// AverageColor Average color as hex value (empty for fully transparent images)
+	AverageColor *string `json:"average_color,omitempty"`
+
+	// Blurhash BlurHash (https://blurha.sh) of the image
+	Blurhash string `json:"blurhash"`
+
+	// DominantColor Most frequent color as hex value (empty for fully transparent images)
+	DominantColor *string `json:"dominant_color,omitempty"`
+
+	// MostlyTransparent More than half of the pixels are transparent
+	MostlyTransparent *bool `json:"mostly_transparent,omitempty"`
+}
+
 // ImageResource defines model for ImageResource.
 type ImageResource struct {
 	// AvailableVariants List of available variant-families for this image
 	AvailableVariants *[]ImageResourceAvailableVariants `json:"available_variants,omitempty"`
 	ImageUuid         string                            `json:"image_uuid"`
+	Placeholder       *ImagePlaceholder                 `json:"placeholder,omitempty"`
 
 	// Url Direct URL to the original image file
 	Url *string `json:"url,omitempty"`
@@ -80,12 +305,59 @@ type ImageResource struct {
 // ImageResourceAvailableVariants defines model for ImageResource.AvailableVariants.
 type ImageResourceAvailableVariants string
 
+// ImageTransformList defines model for ImageTransformList.
+type ImageTransformList struct {
+	ImageUuid  string `json:"image_uuid"`
+	Transforms []struct {
+		Name string `json:"name"`
+		Spec string `json:"spec"`
+		Url  string `json:"url"`
+	} `json:"transforms"`
+}
+
+// ImageUpdateRequest defines model for ImageUpdateRequest.
+type ImageUpdateRequest struct {
+	Description *string `json:"description,omitempty"`
+	IsPublic    *bool   `json:"is_public,omitempty"`
+
+	// Tags Replaces all tags; tags are normalized and capped at 15
+	Tags  *[]string `json:"tags,omitempty"`
+	Title *string   `json:"title,omitempty"`
+}
+
+// LikeStatus defines model for LikeStatus.
+type LikeStatus struct {
+	LikeCount int64 `json:"like_count"`
+	Liked     bool  `json:"liked"`
+}
+
+// LikedImageList defines model for LikedImageList.
+type LikedImageList struct {
+	Items   []ImageResource `json:"items"`
+	Page    int             `json:"page"`
+	PerPage int             `json:"per_page"`
+	Total   int64           `json:"total"`
+}
+
 // Pong defines model for Pong.
 type Pong struct {
 	// Ping Simple response confirming API availability
 	Ping string `json:"ping"`
 }
 
+// SearchResultList defines model for SearchResultList.
+type SearchResultList struct {
+	// Items Image resources (see ImageDetails), albums or users depending on type
+	Items   []map[string]interface{} `json:"items"`
+	Page    int                      `json:"page"`
+	PerPage int                      `json:"per_page"`
+	Total   int64                    `json:"total"`
+	Type    SearchResultListType     `json:"type"`
+}
+
+// SearchResultListType defines model for SearchResultList.Type.
+type SearchResultListType string
+
 // StorageUploadResponse defines model for StorageUploadResponse.
 type StorageUploadResponse struct {
 	// AvailableVariants List of available variant-families for this image
@@ -95,7 +367,8 @@ type StorageUploadResponse struct {
 	Duplicate *bool `json:"duplicate,omitempty"`
 
 	// ImageUuid UUID of the uploaded image
-	ImageUuid *string `json:"image_uuid,omitempty"`
+	ImageUuid   *string           `json:"image_uuid,omitempty"`
+	Placeholder *ImagePlaceholder `json:"placeholder,omitempty"`
 
 	// Url Direct URL to the original image file
 	Url *string `json:"url,omitempty"`
@@ -140,7 +413,7 @@ type UploadSessionResponse struct {
 
 // UserAccount defines model for UserAccount.
 type UserAccount struct {
-	// ApiKeyLastUsedAt Last usage timestamp of the current API key
+	// ApiKeyLastUsedAt Last usage timestamp of any of the user's API keys
 	ApiKeyLastUsedAt *time.Time `json:"api_key_last_used_at"`
 
 	// CreatedAt Registration timestamp
@@ -191,6 +464,9 @@ type UserAccountLimits struct {
 
 // UserAccountPreferences defines model for UserAccountPreferences.
 type UserAccountPreferences struct {
+	// MetadataPolicy Default metadata handling for uploaded originals
+	MetadataPolicy *UserAccountPreferencesMetadataPolicy `json:"metadata_policy,omitempty"`
+
 	// ThumbnailAvif Prefers AVIF thumbnails
 	ThumbnailAvif bool `json:"thumbnail_avif"`
 
@@ -201,6 +477,9 @@ type UserAccountPreferences struct {
 	ThumbnailWebp bool `json:"thumbnail_webp"`
 }
 
+// UserAccountPreferencesMetadataPolicy Default metadata handling for uploaded originals
+type UserAccountPreferencesMetadataPolicy string
+
 // UserAccountStats defines model for UserAccountStats.
 type UserAccountStats struct {
 	Albums struct {
@@ -265,14 +544,110 @@ type Unauthorized = Error
 // UnsupportedMediaType defines model for UnsupportedMediaType.
 type UnsupportedMediaType = Error
 
+// ListAlbumsParams defines parameters for ListAlbums.
+type ListAlbumsParams struct {
+	Page    *int `form:"page,omitempty" json:"page,omitempty"`
+	PerPage *int `form:"per_page,omitempty" json:"per_page,omitempty"`
+}
+
+// ListImagesParams defines parameters for ListImages.
+type ListImagesParams struct {
+	// Cursor Opaque cursor from `next_cursor` of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// AlbumId Only images of this (own) album
+	AlbumId       *int                        `form:"album_id,omitempty" json:"album_id,omitempty"`
+	Visibility    *ListImagesParamsVisibility `form:"visibility,omitempty" json:"visibility,omitempty"`
+	CreatedAfter  *time.Time                  `form:"created_after,omitempty" json:"created_after,omitempty"`
+	CreatedBefore *time.Time                  `form:"created_before,omitempty" json:"created_before,omitempty"`
+}
+
+// ListImagesParamsVisibility defines parameters for ListImages.
+type ListImagesParamsVisibility string
+
+// GetImageCommentsParams defines parameters for GetImageComments.
+type GetImageCommentsParams struct {
+	Page    *int `form:"page,omitempty" json:"page,omitempty"`
+	PerPage *int `form:"per_page,omitempty" json:"per_page,omitempty"`
+}
+
+// SearchContentParams defines parameters for SearchContent.
+type SearchContentParams struct {
+	Q    *string                  `form:"q,omitempty" json:"q,omitempty"`
+	Type *SearchContentParamsType `form:"type,omitempty" json:"type,omitempty"`
+
+	// From Uploaded on or after this day (YYYY-MM-DD)
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Uploaded on or before this day (YYYY-MM-DD)
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+
+	// Format Comma separated list of file formats (images only)
+	Format      *string                         `form:"format,omitempty" json:"format,omitempty"`
+	MinWidth    *int                            `form:"min_width,omitempty" json:"min_width,omitempty"`
+	MinHeight   *int                            `form:"min_height,omitempty" json:"min_height,omitempty"`
+	Orientation *SearchContentParamsOrientation `form:"orientation,omitempty" json:"orientation,omitempty"`
+
+	// User Only content of this user ID
+	User *int `form:"user,omitempty" json:"user,omitempty"`
+
+	// Mine Only the caller's own content (requires API key)
+	Mine    *bool `form:"mine,omitempty" json:"mine,omitempty"`
+	Page    *int  `form:"page,omitempty" json:"page,omitempty"`
+	PerPage *int  `form:"per_page,omitempty" json:"per_page,omitempty"`
+}
+
+// SearchContentParamsType defines parameters for SearchContent.
+type SearchContentParamsType string
+
+// SearchContentParamsOrientation defines parameters for SearchContent.
+type SearchContentParamsOrientation string
+
 // PostDirectUploadMultipartBody defines parameters for PostDirectUpload.
 type PostDirectUploadMultipartBody struct {
 	File openapi_types.File `json:"file"`
 
+	// MetadataPolicy Metadata handling for this upload's original; defaults to the account setting. Variants never contain EXIF/GPS data.
+	MetadataPolicy *PostDirectUploadMultipartBodyMetadataPolicy `json:"metadata_policy,omitempty"`
+
 	// Token Alternative to Authorization header: pass upload token as multipart field
 	Token *string `json:"token,omitempty"`
 }
 
+// PostDirectUploadMultipartBodyMetadataPolicy defines parameters for PostDirectUpload.
+type PostDirectUploadMultipartBodyMetadataPolicy string
+
+// GetUserLikesParams defines parameters for GetUserLikes.
+type GetUserLikesParams struct {
+	Page    *int `form:"page,omitempty" json:"page,omitempty"`
+	PerPage *int `form:"per_page,omitempty" json:"per_page,omitempty"`
+}
+
+// CreateAlbumJSONRequestBody defines body for CreateAlbum for application/json ContentType.
+type CreateAlbumJSONRequestBody = AlbumCreateRequest
+
+// UpdateAlbumJSONRequestBody defines body for UpdateAlbum for application/json ContentType.
+type UpdateAlbumJSONRequestBody = AlbumUpdateRequest
+
+// SetAlbumCoverJSONRequestBody defines body for SetAlbumCover for application/json ContentType.
+type SetAlbumCoverJSONRequestBody = AlbumCoverRequest
+
+// AddAlbumImagesJSONRequestBody defines body for AddAlbumImages for application/json ContentType.
+type AddAlbumImagesJSONRequestBody = AlbumImageUUIDs
+
+// ReorderAlbumImagesJSONRequestBody defines body for ReorderAlbumImages for application/json ContentType.
+type ReorderAlbumImagesJSONRequestBody = AlbumImageUUIDs
+
+// RemoveAlbumImagesJSONRequestBody defines body for RemoveAlbumImages for application/json ContentType.
+type RemoveAlbumImagesJSONRequestBody = AlbumImageUUIDs
+
+// UpdateImageJSONRequestBody defines body for UpdateImage for application/json ContentType.
+type UpdateImageJSONRequestBody = ImageUpdateRequest
+
+// PostImageCommentJSONRequestBody defines body for PostImageComment for application/json ContentType.
+type PostImageCommentJSONRequestBody = CommentCreateRequest
+
 // PostDirectUploadMultipartRequestBody defines body for PostDirectUpload for multipart/form-data ContentType.
 type PostDirectUploadMultipartRequestBody PostDirectUploadMultipartBody
 
@@ -281,21 +656,81 @@ type PostUserUploadSessionJSONRequestBody = UploadSessionRequest
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List own albums
+	// (GET /albums)
+	ListAlbums(c *fiber.Ctx, params ListAlbumsParams) error
+	// Create an album
+	// (POST /albums)
+	CreateAlbum(c *fiber.Ctx) error
+	// Delete an own album
+	// (DELETE /albums/{id})
+	DeleteAlbum(c *fiber.Ctx, id int) error
+	// Get an own album
+	// (GET /albums/{id})
+	GetAlbum(c *fiber.Ctx, id int) error
+	// Update an own album
+	// (PATCH /albums/{id})
+	UpdateAlbum(c *fiber.Ctx, id int) error
+	// Set the album cover
+	// (PUT /albums/{id}/cover)
+	SetAlbumCover(c *fiber.Ctx, id int) error
+	// Add images to an album
+	// (POST /albums/{id}/images)
+	AddAlbumImages(c *fiber.Ctx, id int) error
+	// Reorder album images
+	// (PUT /albums/{id}/images/order)
+	ReorderAlbumImages(c *fiber.Ctx, id int) error
+	// Remove images from an album
+	// (POST /albums/{id}/images/remove)
+	RemoveAlbumImages(c *fiber.Ctx, id int) error
+	// List own images
+	// (GET /images)
+	ListImages(c *fiber.Ctx, params ListImagesParams) error
+	// Delete an own image
+	// (DELETE /images/{uuid})
+	DeleteImage(c *fiber.Ctx, uuid string) error
 	// Get image resource
 	// (GET /images/{uuid})
 	GetImage(c *fiber.Ctx, uuid string) error
+	// Update an own image
+	// (PATCH /images/{uuid})
+	UpdateImage(c *fiber.Ctx, uuid string) error
+	// List image comments
+	// (GET /images/{uuid}/comments)
+	GetImageComments(c *fiber.Ctx, uuid string, params GetImageCommentsParams) error
+	// Create image comment
+	// (POST /images/{uuid}/comments)
+	PostImageComment(c *fiber.Ctx, uuid string) error
+	// Delete image comment
+	// (DELETE /images/{uuid}/comments/{comment_id})
+	DeleteImageComment(c *fiber.Ctx, uuid string, commentId int64) error
+	// Unlike image
+	// (DELETE /images/{uuid}/like)
+	UnlikeImage(c *fiber.Ctx, uuid string) error
+	// Like image
+	// (POST /images/{uuid}/like)
+	LikeImage(c *fiber.Ctx, uuid string) error
 	// Get processing status
 	// (GET /images/{uuid}/status)
 	GetImageStatus(c *fiber.Ctx, uuid string) error
+	// List signed transformation URLs
+	// (GET /images/{uuid}/transforms)
+	ListImageTransforms(c *fiber.Ctx, uuid string) error
 	// Health check endpoint
 	// (GET /ping)
 	GetPing(c *fiber.Ctx) error
+	// Search images, albums or users
+	// (GET /search)
+	SearchContent(c *fiber.Ctx, params SearchContentParams) error
 	// Direct storage upload
 	// (POST /upload)
 	PostDirectUpload(c *fiber.Ctx) error
 	// Issue direct upload session
 	// (POST /upload/sessions)
 	PostUserUploadSession(c *fiber.Ctx) error
+	// List liked images
+	// (GET /user/likes)
+	GetUserLikes(c *fiber.Ctx, params GetUserLikesParams) error
 	// Get authenticated user profile
 	// (GET /user/profile)
 	GetUserProfile(c *fiber.Ctx) error
@@ -308,6 +743,252 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc fiber.Handler
 
+// ListAlbums operation middleware
+func (siw *ServerInterfaceWrapper) ListAlbums(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAlbumsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "per_page", query, &params.PerPage)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter per_page: %w", err).Error())
+	}
+
+	return siw.Handler.ListAlbums(c, params)
+}
+
+// CreateAlbum operation middleware
+func (siw *ServerInterfaceWrapper) CreateAlbum(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.CreateAlbum(c)
+}
+
+// DeleteAlbum operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAlbum(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.DeleteAlbum(c, id)
+}
+
+// GetAlbum operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbum(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.GetAlbum(c, id)
+}
+
+// UpdateAlbum operation middleware
+func (siw *ServerInterfaceWrapper) UpdateAlbum(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.UpdateAlbum(c, id)
+}
+
+// SetAlbumCover operation middleware
+func (siw *ServerInterfaceWrapper) SetAlbumCover(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.SetAlbumCover(c, id)
+}
+
+// AddAlbumImages operation middleware
+func (siw *ServerInterfaceWrapper) AddAlbumImages(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.AddAlbumImages(c, id)
+}
+
+// ReorderAlbumImages operation middleware
+func (siw *ServerInterfaceWrapper) ReorderAlbumImages(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.ReorderAlbumImages(c, id)
+}
+
+// RemoveAlbumImages operation middleware
+func (siw *ServerInterfaceWrapper) RemoveAlbumImages(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.RemoveAlbumImages(c, id)
+}
+
+// ListImages operation middleware
+func (siw *ServerInterfaceWrapper) ListImages(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListImagesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", query, &params.Cursor)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter cursor: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "album_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "album_id", query, &params.AlbumId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter album_id: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "visibility" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "visibility", query, &params.Visibility)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter visibility: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "created_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_after", query, &params.CreatedAfter)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter created_after: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "created_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_before", query, &params.CreatedBefore)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter created_before: %w", err).Error())
+	}
+
+	return siw.Handler.ListImages(c, params)
+}
+
+// DeleteImage operation middleware
+func (siw *ServerInterfaceWrapper) DeleteImage(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", c.Params("uuid"), &uuid, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uuid: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.DeleteImage(c, uuid)
+}
+
 // GetImage operation middleware
 func (siw *ServerInterfaceWrapper) GetImage(c *fiber.Ctx) error {
 
@@ -326,6 +1007,145 @@ func (siw *ServerInterfaceWrapper) GetImage(c *fiber.Ctx) error {
 	return siw.Handler.GetImage(c, uuid)
 }
 
+// UpdateImage operation middleware
+func (siw *ServerInterfaceWrapper) UpdateImage(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", c.Params("uuid"), &uuid, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uuid: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.UpdateImage(c, uuid)
+}
+
+// GetImageComments operation middleware
+func (siw *ServerInterfaceWrapper) GetImageComments(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", c.Params("uuid"), &uuid, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uuid: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetImageCommentsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "per_page", query, &params.PerPage)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter per_page: %w", err).Error())
+	}
+
+	return siw.Handler.GetImageComments(c, uuid, params)
+}
+
+// PostImageComment operation middleware
+func (siw *ServerInterfaceWrapper) PostImageComment(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", c.Params("uuid"), &uuid, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uuid: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.PostImageComment(c, uuid)
+}
+
+// DeleteImageComment operation middleware
+func (siw *ServerInterfaceWrapper) DeleteImageComment(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", c.Params("uuid"), &uuid, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uuid: %w", err).Error())
+	}
+
+	// ------------- Path parameter "comment_id" -------------
+	var commentId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "comment_id", c.Params("comment_id"), &commentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter comment_id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.DeleteImageComment(c, uuid, commentId)
+}
+
+// UnlikeImage operation middleware
+func (siw *ServerInterfaceWrapper) UnlikeImage(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", c.Params("uuid"), &uuid, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uuid: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.UnlikeImage(c, uuid)
+}
+
+// LikeImage operation middleware
+func (siw *ServerInterfaceWrapper) LikeImage(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", c.Params("uuid"), &uuid, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uuid: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.LikeImage(c, uuid)
+}
+
 // GetImageStatus operation middleware
 func (siw *ServerInterfaceWrapper) GetImageStatus(c *fiber.Ctx) error {
 
@@ -344,12 +1164,133 @@ func (siw *ServerInterfaceWrapper) GetImageStatus(c *fiber.Ctx) error {
 	return siw.Handler.GetImageStatus(c, uuid)
 }
 
+// ListImageTransforms operation middleware
+func (siw *ServerInterfaceWrapper) ListImageTransforms(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", c.Params("uuid"), &uuid, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uuid: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.ListImageTransforms(c, uuid)
+}
+
 // GetPing operation middleware
 func (siw *ServerInterfaceWrapper) GetPing(c *fiber.Ctx) error {
 
 	return siw.Handler.GetPing(c)
 }
 
+// SearchContent operation middleware
+func (siw *ServerInterfaceWrapper) SearchContent(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchContentParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", query, &params.Q)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter q: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", query, &params.Type)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter type: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", query, &params.From)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter from: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", query, &params.To)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter to: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", query, &params.Format)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter format: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "min_width" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_width", query, &params.MinWidth)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter min_width: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "min_height" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_height", query, &params.MinHeight)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter min_height: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "orientation" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "orientation", query, &params.Orientation)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter orientation: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "user" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user", query, &params.User)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "mine" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mine", query, &params.Mine)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter mine: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "per_page", query, &params.PerPage)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter per_page: %w", err).Error())
+	}
+
+	return siw.Handler.SearchContent(c, params)
+}
+
 // PostDirectUpload operation middleware
 func (siw *ServerInterfaceWrapper) PostDirectUpload(c *fiber.Ctx) error {
 
@@ -366,6 +1307,39 @@ func (siw *ServerInterfaceWrapper) PostUserUploadSession(c *fiber.Ctx) error {
 	return siw.Handler.PostUserUploadSession(c)
 }
 
+// GetUserLikes operation middleware
+func (siw *ServerInterfaceWrapper) GetUserLikes(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserLikesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "per_page", query, &params.PerPage)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter per_page: %w", err).Error())
+	}
+
+	return siw.Handler.GetUserLikes(c, params)
+}
+
 // GetUserProfile operation middleware
 func (siw *ServerInterfaceWrapper) GetUserProfile(c *fiber.Ctx) error {
 
@@ -395,16 +1369,56 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 		router.Use(fiber.Handler(m))
 	}
 
+	router.Get(options.BaseURL+"/albums", wrapper.ListAlbums)
+
+	router.Post(options.BaseURL+"/albums", wrapper.CreateAlbum)
+
+	router.Delete(options.BaseURL+"/albums/:id", wrapper.DeleteAlbum)
+
+	router.Get(options.BaseURL+"/albums/:id", wrapper.GetAlbum)
+
+	router.Patch(options.BaseURL+"/albums/:id", wrapper.UpdateAlbum)
+
+	router.Put(options.BaseURL+"/albums/:id/cover", wrapper.SetAlbumCover)
+
+	router.Post(options.BaseURL+"/albums/:id/images", wrapper.AddAlbumImages)
+
+	router.Put(options.BaseURL+"/albums/:id/images/order", wrapper.ReorderAlbumImages)
+
+	router.Post(options.BaseURL+"/albums/:id/images/remove", wrapper.RemoveAlbumImages)
+
+	router.Get(options.BaseURL+"/images", wrapper.ListImages)
+
+	router.Delete(options.BaseURL+"/images/:uuid", wrapper.DeleteImage)
+
 	router.Get(options.BaseURL+"/images/:uuid", wrapper.GetImage)
 
+	router.Patch(options.BaseURL+"/images/:uuid", wrapper.UpdateImage)
+
+	router.Get(options.BaseURL+"/images/:uuid/comments", wrapper.GetImageComments)
+
+	router.Post(options.BaseURL+"/images/:uuid/comments", wrapper.PostImageComment)
+
+	router.Delete(options.BaseURL+"/images/:uuid/comments/:comment_id", wrapper.DeleteImageComment)
+
+	router.Delete(options.BaseURL+"/images/:uuid/like", wrapper.UnlikeImage)
+
+	router.Post(options.BaseURL+"/images/:uuid/like", wrapper.LikeImage)
+
 	router.Get(options.BaseURL+"/images/:uuid/status", wrapper.GetImageStatus)
 
+	router.Get(options.BaseURL+"/images/:uuid/transforms", wrapper.ListImageTransforms)
+
 	router.Get(options.BaseURL+"/ping", wrapper.GetPing)
 
+	router.Get(options.BaseURL+"/search", wrapper.SearchContent)
+
 	router.Post(options.BaseURL+"/upload", wrapper.PostDirectUpload)
 
 	router.Post(options.BaseURL+"/upload/sessions", wrapper.PostUserUploadSession)
 
+	router.Get(options.BaseURL+"/user/likes", wrapper.GetUserLikes)
+
 	router.Get(options.BaseURL+"/user/profile", wrapper.GetUserProfile)
 
 }
@@ -412,60 +1426,115 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xa/2/bOLL/Vwi990MKKLbTdt/rBTjgstuma2yyNfJle0AROLQ0srmRSC1JOfEW/t8P",
-	"Q1IyJdGx02uCu8P9llgkZzjzme/8GiWiKAUHrlV0/DWSoErBFZh/fqTpBfxRgdL4XyK4Bm7+pGWZs4Rq",
-	"JvjwdyU4/qaSBRQU//pfCVl0HP3PcHP00H5Vww9SChmt1+s4SkElkpV4SHQcjfmS5iwl0hIkJV3lgqbR",
-	"Oo5OhZyxNAX+/FycJImouCZcaELzXNxDSrQgJchMyILoBVOEJmbxOo7GXIPkNLfHvYCILDmiQC5BErAL",
-	"4+hXoU9FxdPnZ+EClKhkAkZAmaG5jqOJ1dWVEGdUzuEl2DAggZRkLAei2J9A4CEBSBUpc8qJkOSPSmhK",
-	"clYwrZDJS5BLlsA1p0vKcjrLX4BPR5NUHtF1HF0JcU75yt1CPT8fV0KQgvJVbV1GINecVnohJPsTXgA5",
-	"50wpxueoGeZMHckD146QZUlVZSmkhvQcUkavVuULaMmjSgokSzTSxYVuLx7dGHkpRQlSM+sjU9CU5e7P",
-	"litJU4Z/0twaKqlXxhE80KJE/EXvqaYzqoAkgnMwjoVklOWQRnFkuDiOlJaMz1E8ULPQpmQ4MzwTlqJA",
-	"MwayRYY5zzG1nmNqDwpQKEApai24TePnqqD8UAJNEcTuRvVqn9QJJxWHhxISFKddJ5KkkjJ0p3UcISQZ",
-	"fjz+EtV81QffNBvE7HdItIsGBdW/Ucmoi1m0kfTEU01GcwVxR1uo3qrYhRR3+CX705irkGzOOM2fuE0V",
-	"NH/annXguuOCzqF2u334NX5luvQk0tbdGVOaiIw0a4lbe5jRguUMFMkQQBjcWGEVyjQU5ijgKK8vGyHE",
-	"0T3MyiiO6JJlnoY2IHI/UCnpCv83Z06rihk301teybzP83smIdHk+uIM469eAKkZsCwaxx/FUWbQEB1H",
-	"lWQhQC/3xUnHJVycqaDIFJlLUZWQktmKGPGtorinE5bt0nsHxU9AWX+n0ccTd4WgtmRwPw1q43JBJZAS",
-	"5Y4aOfj56vyM4PJXO03aU37ImieCz/uoLpn9tcMFQx9D6hQVfWbGZIFR5WQyrnXFcqZXLZdUIo1dfBqS",
-	"IQ4vtZB0DtclpjgXjva/pyGmlY2dAf8+5qn5ooytVeaydXpFc/T7KwIPTGmPxUqBF0RmQuRAed/gO5Z1",
-	"PX6PMmiRqS/7X9/wH+8beidbw7oEpZjgXrnZlhuqdIp5fp+B06YEYJzMVhrUwFc+4/r/3mJOwTgr0ISO",
-	"GiYwLZqD7PmCDbGb3QxvcwjwUDIJakp1IGPDbyaHJZoVQKgilFxz9mD+VZoWJTlQkAieqlfB23RvEEcF",
-	"fZia2/fJndMHvLpXLNWSIjRJoMRM7Z7phTVrLe6A70m0FCKfhsx83CSitbEryG1OqKxDJbh3TzKGpT6R",
-	"K1bAoanwIHXOxHJPDn4EKkHa/4wE+67FrA9j+mSmRF5pi+iWsyLA01Iwrgc7HUwHVB69+kIb+cU+XHxd",
-	"BgGoQLpGRSAOlWx6B6tpTpWeVgrSIADPqNKkwhzbQ5y7qMnVuTYx9Q5W/j1TquEQN0RxxKvcVdFaVhAQ",
-	"cCKB6i30L2DOlPZMwHCwjVS/ECooy0Pxld9h0YFfCU1TCapdbpWg4W/u30EiCp+gPTNALBjFFEgyfu8f",
-	"/vb1XlA2esnFnPGgYK662sD1xKwnB8wLOq++WS+uJbIjKHggO2t6KGVOA2b4kwOMqmbNz6YN05a9hAJr",
-	"rwBHpYQMJPAEnsLWxNuF5ZamT7rVpVnvNlahAt71At13/y400WwZTlgUFtoFbIFMylSZ0xUxS1rSYQ+Q",
-	"Izx3J9ToLRoycYPbhk0nec/8auE0um+LfIePOWvw0vE0tj861YuqmHHK8qnFY0iUTarULCb1Yk8K/eT2",
-	"xkuAd6a3CeXToso1m1pf2+fj8wL0AiQxqw6dR2eqbvUGk9nUZJvuyClwvEbg6I+5mJnWrNZYkGCSbHe6",
-	"wKEey5SffrZNdB87GmOIO3hHWuDkYBKDEqRNEw7qFOGVr6EfXr99/e7daLSXq3OBfmo6sduYuBKa5l4u",
-	"XWcHB+jHCMtIxV2EfxXKFbZ4u22ZXU8oYS4DWNqiq234iB8xjx32Nml7w7bRbU6rK4m2OO1mRU5+G59u",
-	"jC2MkM1Zfn0RPq+psPY+s648wud9htnk8bM6mgsw26MWd8WzQ9KXdcjoOrZZVQR+bzKu9pV+rYqZTXOd",
-	"yyXuAM9ujvZJDjo3tuRCVzBI/BYGEet1ud3h792TTFpi1OGMz7eZ9UW9oDHopuT4Tpa9YcZkuVv4uFZe",
-	"xVGz4N/8/9+8HY3evN7LpQUVFOSjr7VQW0wZN2GwElKz3xvug5SzAsG2PcjZYaVSoE2Ic+vJAQzmg5h8",
-	"HJ8O0QhfBc14izT7pTbq8Y6Le/5oyT0KqW8BbL4IwHWCqRCxX/3jAyUvK2Cq3YyoE9vG5x/sSMTk0bUk",
-	"Hukx7eoW3bNUL7Zxaz4+xmy3GJR5GCMKkkoyvbrEhNWq4KRkv8DqpApRdzWaSQk647Q4YrhiATQ1bTqb",
-	"lUZ/PzyZjA9/MXVdnUAZApvWxhUWpzW9mSmkT2vh1IXrvoW4lwfVVtgEVJOUG+AZGhuGFlqXdlDHeCbq",
-	"ASBNDFjcPYzcT8WDKVMv7fwucso0B6jj4dBk1Zl4GCRJ1Bv7ff508QsZ/0omF58+Xny4vCSH5FOWsYTR",
-	"3ByKrDdUbL6lFhThgMWNRryYjDoB1/9xnJ2Pr3qMiBK4HeEMhJwP3SY1xLUYNZnO/UsNksSwsDyK4mgJ",
-	"UlmOjwajwcj0A0vgtGTRcfTG/BRHJdULg5ah9SzDr1XF0jX+ModgAa4ryVWtm+uLM0J52u9pkpzxu7rn",
-	"C1YMg8hwYKv3cYoJKuixa+GWVNICNEgVHX/5ajGIzG0QaPrCvjlY/74Z4nbrn5u4/Szl9Wj03YbC7fFa",
-	"6NGFUbxsVsTR29HRtlMbNoet4brZ9Hb3puYdh+8JjBh9H/DlBiWiqqKgcmWF7+DZcBlHms5RAZZ9Fd3g",
-	"iW1oDDd176MIuW/CiVM/KaVIwE7zmSJ4kxw0GPzgoiWDewMov12xHTOXTd36r4ecbnplb+pR8kKmG9sH",
-	"v/nd8x39mX6LPPCsxIisGYRZfI1248t7z/VkhHlKbzoNQZDVs7tHQUWJssO8UvD5ZqKnBVmCZNkqNNDr",
-	"wWfCzFzv2XyDmU2GxF8lKIqswiyF5npBkgUkdy19/LCPPtrvxzoqaWngZ59O3YL2NGC/Ow1suh+lUAEl",
-	"2CiPKaE3L3ORIF+hEppQbfRNTYc2GN8H5BrVtgByu+lv3xJp1GwHYbeTT5dXxDE1VHZsom4JtaNGTeUc",
-	"TAAakCvM0+rbuUMUYYV5lKMhXxGaaZBuaIGseY15dD9/VFCBCdANWvFUb2bMlH3bR+YVlZRrQO6peey3",
-	"GeNRuRl6zoEj7CAdkInIc3L78cMVCXrSW1JxzXJyW/uJv6KB3w7IOCO31jvYX2LfmICn9fSFcvteZkA+",
-	"L4B3zzEXrM8xg8nbmGSgkwXJvNlnHQNISjUlS0ZDHN92ZW16YEp48V9wcjukJRsuj5zubu2YCrmimlmz",
-	"JFJUOuDcJ0JpO6a9rrM99/TsR5GuOhZq+iwllXqIOdUhMv6YGzbTXT9hnzGOVhIagIfnRie5sTvNlsbl",
-	"nLhIbacRNmE+JiVVqp3LUkUaVknGIN/9oMnwehN06e0otn5GPxZ+wRB6COcsqZ4JIuLaft+8kaNqxZOF",
-	"FFxUKl99U/T51izq6M3uTd3XqGbfD/sQCzw+xM2v/7J7c/dJ53cJAV/7BVk3NL/fUlvVgcGZnx8YGh+8",
-	"PUL8ZBpZaO2tHjZxOw0wZBPKNRSlkFSutoSGJhq0YgT1n5p4YcDUGiYiaUm5ykCG3cu1AtkaxT/qY77d",
-	"eoLvE17YgsNPDrZbcK0nplQFKVFNwvLC5jraw1w3r/v/CQP/FlvDXXtQC7wbf1LyPEYdhO1oq50qkMNS",
-	"ijrSPZ5KuzGle14cu7E+5iRMaZao2Jirnfw1hbzXLMKUToWs7CMYI5s4Rp4T396LhhCqFUji5EHck7E+",
-	"pp+/Kv8+Pn1XpdVXTX13Hy8K6+Sb9T6nmzfftqjuPKSDJeSiLMzw3qxqNa6Oh8NcJDRfCKWP343ejVw+",
-	"GK3j/lhHpJV9vh44qNOKa465ae7Te24eqnfUphPg6p0+I20HqAz027a3gLxEcTRnOdMLnIWiLyinc0AR",
-	"eTuM8PsbTvJZVQR3nNguf39Lu72kAimXLbXdOa7SXt+s/xEAAP//B56Edrk1AAA=",
+	"H4sIAAAAAAAC/+x9fXPbNtL4V8HwfjNn/4aRnLdeHnc687hJk3qaXDy2c71O65EhciWhJgEGAG3rMv7u",
+	"zyxe+CKCouTabtLLP20sEsBise+7WH6KEpEXggPXKtr/FElQheAKzB/f0/QYPpagNP6VCK6Bm3/SoshY",
+	"QjUTfPy7Ehx/U8kCcor/+n8SZtF+9LdxPfXYPlXjH6QUMrq5uYmjFFQiWYGTRPvRIb+kGUuJtAuSgi4z",
+	"QdPoJo5eCzllaQr8/qE4SBJRck240IRmmbiClGhBCpAzIXOiF0wRmpiXb+LokGuQnGZ2ugdAkV2OKJCX",
+	"IAnYF+Pon0K/FiVP7x+EY1CilAkYBM3MmjdxdGTP6lSIt1TO4SHAMEQCKZmxDIhi/wEC1wlAqkiRUU6E",
+	"JB9LoSnJWM60QiBPQF6yBD5weklZRqfZA8Dp1iRlY9GbODoV4h3lS7cLdf9wnApBcsqXnrsMQj5wWuqF",
+	"kOw/8ACU844pxfgcT4Y5VsflgWu3kAVJlUUhpIb0HaSMni6LBzilxqokx2WJxnXxRTcWpz7IpmWO/yik",
+	"KEBqBu7gLkFOWE7nMClLZjDJy8wRmJYlxJGZbj9SWjI+x30mEqiGdELNllC04L+ilGp4pFkOUWBMC+ZP",
+	"3ecsbfzMuIY5GOFgQTNirbUY4/qbZ1EcGqEmRTnNWNKYcCpEBtQckmY6g8ajGoSySLfe1iWDqxq6LjDm",
+	"eSkzfNo+tiMDI1ELKoEUdA5kR/AMaZwmC0Q/uVqgdNALIBQPjzBF7MZ2u5DcxBEyB5PIDL8iNv1Omwhp",
+	"o/OsmkVMf4dEI7yGTF4iUTRUZ5tk2sQyAEf9bv9qhpp6lxsknOZxpzCjZaaj/RnNFMTrjj+n12+Bz/Ui",
+	"2n/y/PkQQu2w3j28Ak1ZZuClWfZ+Fu3/up6bzajoJg4i1/5LQ66GhMIhvu4Xv6mgo1LSZXRT/+DBPfMA",
+	"m4EfPhy+UusOuA1IB/U5vT60D5/v7cVRzrj783EAkjBZqH6cGhDVywXlc+gCSdMU7kxkcKEnM2+CrEhX",
+	"xBHRC6pJKozdMIVM8DnaVciaCc0ykMhZvWhqowIxkYtLSDeC7aYPO29ZkDc9EBtRjyfCDoQojsK4LUBO",
+	"+p9qoWm26b5aBGHAdQs3VvFT9hLJhyK9M+Hxh4RFB7yXIs+BB2CyZsvQ2bjhB/ZlVLqUT1LIQEOXSH9e",
+	"gF6AbBAkyemS2Net2Z84cEIysWGf3ImuZ+mtaCCNalBij6YWBGf9eD6osLrCEemmMoDmsIFCSyP36hpY",
+	"BjRaA90Nunq852Ro9cOQTvLzrIHkLqSEm+qLlROVe7sqGiqVveJEpynDf9LMuqjEvxlHcE3zAiVC9Ipq",
+	"OqUKSCI4B+NSkxllGaQhhgAPQnslA5mx1glLgWs2YyBbyzDnM0+szzyxEwVWyEEph+72Gj+WOeWPJNDU",
+	"2JR2R/7t5lIHnJQcrgtI0JGw74kkKaUM7WnlXDxcfuLQObw2Z/0vKhl10RpaYfqocTTOdGufFjo2ZT5E",
+	"qm7yE/Yf46gKyeaM02zLYSqn2XZjQvLfmWYZaDhIEii01fhb2NJx9LuYTnoeKU11aeYAjoj51Th7aYkU",
+	"eBZvbpZXi1RTnvVvZksj14zyMZeAsXsffiRGVCYYUdlQ7C+AzRc9ztuQdUDnAwbyqrzs9z2vWKoXjSdr",
+	"DMAzfx53IdzXOxBxZAJQYexwuNaTpJQqJNpemt+JmBmbBF81Pm5MMLhABDc/Z1TZn6N4KOjQowcseL0U",
+	"e5TRBBYiSyGgAOglSOsoZKEdHNjHxDwmVJEFXJNLmpVAdiAv9JLMhCSzMsuWREvKVUElcE2sB7fbkq1/",
+	"+2b6D/riRYiep1kpF1QtugB8n5XyR6oWZGehdaH2x2P77kgtdj1izWKtpd7+8OO/vuE/f/9kefGiWIo9",
+	"mh7//9E/Ll6+S/nvQX4SOeOU6z40vBNKkxniHjd3J8h4Sp/PXiRBLSaUzpaTxgwhgCSatJSTBc1mHhEF",
+	"u4ZMEYrPGqO71u4KIVXo76WhSn4FCMgFRSeXDaXWhhZ5FGGs3iXu3UczmrOMgTKYMya6P8yKcb1kr/RY",
+	"HF3BtIjiiF6yWUDMd/l3QMEUbQYZlBRNhsKIWSiw9YpJSDT5cPzW+8gefrtDE/SO4lo4l5IFQ2ubWgor",
+	"DvvxWxXEuCJzKcoCUjJdEoP9ZRR3jpTNhhCxYsdsYWd0R5rj3HJUyNjoDzOe1PFFPJGdH0/fvSX4+gZB",
+	"xPXBO0MQp8hseJI9ymg9+Wk/uq2w2nP0OGZxpAowmrkWLS/29q6/2duLZ0x/ZyLb8SzX3yGS44/fvdgL",
+	"UZlD2npMGBDcgnZICCEbhryi1r57MbtdZKPhSD43juTWoQ46784bHYOREAqziQRf+db818hZjnSZYfqF",
+	"UJ6ShBbIW1STx8+j+DZG0S2CLG/ZBZxUlnAbQxm72C4KiAPSEHZWjtK+FzcXOOuBLb1bQ61hS3+ZvviR",
+	"4PMuJgrG513aO2HI1cSn9NHTnjGZYxbu4OjQy3eWMb1s2RcFrjEk28ySIQhPgMpkcQyqzIaiJyvpbaPb",
+	"pDshRXYUAGma17uxzeMoTCKWCqQiKRTAU9wRWsQISoNxwjqvZR33yZ6HoAb/S22muNxFHNltoqTEXQ67",
+	"o62db0dQJ1pIIywxg3/sSOXLNNXS0qaGA0GcQ56aJ8qYU6XZrK8eoJkEmi4JXDOlGyAi7oPR3rZO7mY7",
+	"vEldLeM3+9V6/Go9rrceOzNbvjwBpZjgvbZMK2bTBuB1VSDDOJkuNahRFHeFU844y8u8GTTvU1v1YmfD",
+	"APfJE7gumATlIlcrUV18Zio8iGY5oLdMyQfOrs2fStO8QOWQCJ6q3eBuuqI2p9cTs/uAP0yvcetk1sEU",
+	"oS7uSK6YXlipoMUF8A0XLYTIJiEpcVgFq72sUJDZuLGy8pjg2A2XMSB1FzllOTwy8R1InSyy0JOd74FK",
+	"kPav3VE9abOUA98P0/TBVIms1JaiW7KOAE8LwbgeDQqYFaJqrOc3VOMvbpJL8yyDBKhAujK+gBor2OQC",
+	"lhMMnE1KVYVOVxQZVZqUCo+hpjiUaXxZ7VeB/LsydtQFLFVzu83o65aVQKu+w5wp3eAEA0jfUp2pIacs",
+	"C2lpfgEpMU8JTVMJqp2ZKUDD/7o/R4nImwvaOXszliuqQIEkh6+akz97shFFm+PJxJzxIGJOm4dSxUHN",
+	"+2SHNXTP7q3PxdUNDuiGBq29rQoNsQAxGM81AT1VTqufTa1iG/cSckzThGwFCTOQwBPYBqyjxiiX9Nhm",
+	"+Il5v5UtCRfMuufNvWCd7GWQMpF5fFAiQDIpU0VGl8QFDRrYwRhlARo2K9+qlokruq3AdJhvsJ9HTnX2",
+	"bZQPiJq3Fb2sCBxbRDzRizKfcsqyiaXHECori6l6mfiXG1jomshn2wQLsAoiLzPNJlbk9tdCmLce2bcI",
+	"U74eOmgSp8bodFNOgOM2AlO/ycTU1C9rjT4bmtp2pNMfap29vf3cZuDaqVGVuIkHrAOHB2MfFCCttbDj",
+	"LYVWgP75k2dPXrzAGNIGos7p+4kpV+4D4hTdt4ZJ7QaRHZMMYjNScqfod0MmQ4+06zPwOkgJQxmgpZ6z",
+	"6qOPeA17DPDbUVsarua6NU2pppNCZCxZBnwkW+VI/ItkQXmaebKp/DbPaIb/nH96AVAYhEhWTDLhapf9",
+	"D5j3PmuKrM57XQattu69n5UyV7NTRQ7+dfi6lgxhcq7navpE4fn8G5vP6b2l8Hw/w/Ro/VyrQYsusJ3V",
+	"OugZIIsTr99WpbCJpwQKicpQhuyfZT61prnTD6QKyFQn+3gTS6ZTb9QX6qyrVrcEEBnTRxhW4HuxlfyR",
+	"qCI54/M+GXTsX6ikT+Um3ZEYqoExlnkPHB8UpB0Qmjv/x9Nne3tPn+zt3faAgnB0Ty2Un2gE70LH3Kx5",
+	"6RIpZznVkK6rTkQ1rBRoo4/d+2QHRvNRTN4cvh4jE+4G2bgHm93wAJ7jBRdXfG2YYG99KciKiEC7jdin",
+	"zem7M+Qsh4kPi64o4sN3P9hSL2P0e0ysSUkNRbiqkpEQtObhOmBXHdhgTgtpGpJSMr08QevaHsFBwX6C",
+	"JRZcBqxA61EaRdS+IDMiP8HSZo68WGK2DIR6I9zaPsqlk6RcEpWIAtT+b/zc0ue+BJqekx38n5MaPqoe",
+	"E8zH2MGFZJdUA1EmmE+kiear3bie50oyDedkx975GLtKWT+jK5a1k5lpzVi7UDXWbiMmiSlRN++6eeyL",
+	"u/jTb/zcKuRqQGWL2SCT2h0Rf5XKBGpEqQ1a/PFYJBAJupScPNt7Ss4ZV+VsxhIGXE/M4/PRb3jKDM9g",
+	"ATQF6etU96N/Pzo4Onz0EyxrMqDmCOuA1ymGLPyJTk145bUnPx/O2DQ80zCLvZyr7CvjoxnWNmvUAC20",
+	"LuzlJsZnwlfJ0kTXGWBL2a/FtQlbnNg7Ty4dux/5GhnjZM3E9ShJOuVi0c/vj38ih/8kR8fv3xz/cHJC",
+	"HpH3Bo80M5Mi6NUq1vxWC4oMh76uRo40DlYCLiroIHt3eNoBRBTAbS5oJOR87AapMb5bZz2rTY2SxIBw",
+	"+TiKo0uQykL8eLQ32sP3cTpasGg/emp+iqOC6oXhx3FtH8whGIhBylGN6vC/q4ppOFwBVvgwqUzYC0W6",
+	"4dfD1OVEDrzxUFBJc9Aglan8M7T2sQS5rEnN5WvqG23VhZzHQ0HangnrJFBg0qd7xgdys7r66f41zuL2",
+	"BeEne3t3dj2vvpQRuKJnU0tX3GEdz/PZ3uO+KSsYx607jmbQs+FB1XXamzh6vrc3PKB9D7gp9M05N8X9",
+	"r2eIRFXmOZXLwMZ8HcGvkaOaMxNHVgGqtEXyKGLt4BF5jYa3jVWjlLtaAG/cfjOypir3wluyTNnLcpB2",
+	"CdfOboCIrKYDpb8X6fJuD7xd6X/T1qpalnDTIbnHdwtBiNwsVBVmLeVsQAiNC/O3p9Cnw4PqG/EPQKKr",
+	"yAiQ6E3sZej4E0tvLK2Gb9rYgm5V0+WInPo6TPNrriC7BGvnXEARkKmuJtwBExKqKNlrEWiCgW2qagrD",
+	"DaTcs759IFYq7v3rSKXQ5oKSaVBduou3PMlKU6bBtPJnzdzMRMgUZPeY34B+yDO+Y01WlYR3pcsb0H9R",
+	"uunsLKzOqE4Cno+t11PEGHYxaTw0nsElU8wWK43I+5xp2/0BslQRpemSlNy6EQFdZme+Z2K6JwXZrmLc",
+	"SEHu3b+CtFAFqPihlORnRvghfAzrybEpsUXAijIgR09AO58DX7Ni89v61gLJS6XJFEhBZWXXWZXa4YAT",
+	"0HVjgi+OB1rtFD4XFjgB3dBw9iT/m1kghI8NWKAR+Q56OQdpqgxT2RexeAxr7L71f/vqPdZ0dagEoi5Y",
+	"UYS0wUGaNtojfHHM0Gg88WewQqupRKiDVppWxrz4kzyoz4wzelCyMXOMjYHcrya0kM6hmrNL4M6etqXT",
+	"ynTcyJhploW5S3yPSSIho1ieYV8mdKZd9wP3quCguqxzDOb1r+xzH26BQ653mSx6/5v5JoiQzbnGdonp",
+	"1yzH5rlaUSwj8jIDKluG14wwTa6oInbKNMQY+OArX9ybWrEI9mc1kyL/qluWA3jpYZXa4to82eHzes1k",
+	"R0xK09HP3mDHGnfGbaIymAXp44v26u8L+rEEP6fZz3njnvx5Fb+WcMlEqfzt91Dqww6JAixVVw2Gkyb2",
+	"VvxdZEzizgaxRZ07Lp/I3hFXfLc6tBBA5plrMtEvH3p2U0dOWsN9RVPV2s4lfYPXjcIzV+WTaD60Jt+k",
+	"RHlo1inMhITtp73P2F59HTEgpN5zd9VDzL7q70Zuq6O6nSxoyqPxp7IcSB38yFJn55ohhOWmW6eGbGki",
+	"hL5/i33H6Gqa4Vng3Vus11SEKjKlyQXeROIp+V1M+7ILh+7K1rASdzeSB9X4GvJ8crfkudIzJ0Co5g0M",
+	"rNYtb/6iuYvq6t0q5Q3kLtL6Fh2SVuc2G8kYv/CXBR1BBlMYD0pIdyzn6tvSgWbUrdu6D0U+2yYjWBvK",
+	"IB1snY6IG7kIQx046UBS4ltyjm+dE7naEKAvXXHfhHP3fkSg68IDuxLtZkx9aQt/IfdrwmJQSnb089hX",
+	"9m3mP7iXjRJ2vZcdT+4IiavbP8QVt/eCa6djd6iyysvWlx6ge2GV+LMo03ry+ZRpNftiDpjAFan8tSxa",
+	"S7JJTXaeaypK7K/ZMtkM6gebcHCLLTo1XMy6u8gg5mKcuThTLT4ibk1bEcyErelCzvaVpQXYXhldBjoS",
+	"qsVBX5SuCTaKfeDyMY+3UAFZ81g8MX+uBWRbs9mzJ/8zPGD1IxcPV6nWYtAwf/brtfEn96/JhoVsFTeP",
+	"yIH7Yoz3CtwDYg/ImoqV92pYeq37ea98GQenqfe+drLhezUb1dB5LrEoTj9nkn8gr/VWpIv3K9ZRqk90",
+	"1LRn4qqtAO+MXgrJNKgR+cAzdoGB3co8M837mU8lYvcAhnTPxSNRBNwXHA9fqN/b6IcWEuvuEj9u0Fx5",
+	"h7+QR2COreMKIEIGLZqasrTopau3FVX5mg1HS2bgOop6+5WevkRbeQ01dYVY3WBirVd51fhQgyWcQooE",
+	"7Lel7FcaChf/s8oWey2ZMF6zL0i/N3lSNYj4/Ohs9Wqw3Wm4G6VrpR981uxWNdw1evVGY7cQzqCsajN4",
+	"K1t327he49Crlh4bBC/a7VLXEholis05pIZ20KCDS5BLUs1gva1CgoL6U4EugkFTdM9N6x4hR8S0RFvQ",
+	"S/spKhxMzsd6/AkXoLqUcDP+pApI8H8LKgFDyzfn7hInxwpPyKeAn+txvmK2/JZI4ClIe9tTosBNFs7b",
+	"s2yBo3HSNcnY0xobX2Z8ut07N0Sa9ghXDg3P4/OWszhqA5M28EnD7cMZqh9HPTzlu40O8o9pP1oIPq97",
+	"kGpBLkGy2TLUgrQjko+Y6UR6b1RkuqmG6KZMULzMSry1TjO9IMkCkouWjPvj+rN1Fj821/Ft1BonYJ+7",
+	"E7DXsvvPgJoWX9hN/pHGLwfY90ldRm6zGqqV1lCxyUe46+DuDSeFcpCU5CKFTI1+46aGwYWt3DnYu4R4",
+	"9JjrMOErSuxnHv2N9pZdiFFnf8m8MYW9KAQpJmqvIMtGv/Gf3Y3u84/nVn6yTEPd88JIP79yK2RMdly3",
+	"WNyCbRfrZBfOtWvve6+WySOeXlafUNrgwu7H29SXmNeCkd66jcRtmsJ2c1i+k435JKqv8mSKpHRJdn75",
+	"5ZdfHr179+jVq92euhN0FPsLMKLtQbA1HVvBoMUfgwB9Z0oU4GFqSE2FK4anTQcnO5siO74kh2fLXmTY",
+	"lZvA1I1+fi/mcRHumxymgZzxie1A0ZzwNrkDnMm13vijUwnJgOuqp1GnViijPFUJNdRbCKkltXVSH0sq",
+	"YSOSNLLDc7wvgCqrToEhoFwj3vWFT4FVOgLHL7vj5EDVv7HvxHPGIbRyo6XR12v8bZXaaf89kCRqdxi5",
+	"tVl2B97sTbzeWrI7W2maUrUib6hq+6JT1XWXvXD8xMpHVQfbjFiy5SdIw6LuAWLcHUp0X+OQEfmgrI9x",
+	"XrdTPa+V43RJzo/en5wSB9TYN1A5R3WL4zSVczBVL3grm6nKEHGTqHbNlVEntkcugtboA4sq92MJJeDv",
+	"tbNm73pXxqALKVIyL6mkXIMLNWJpRNU1GvW7DxjNgYMR4iNyJLKMnL/54ZQEAwnnpOSaZeTcu8nfaVnC",
+	"+Ygczsi5dY7tL3HTlwSe+ma/lNtPuI3Izwvgq/OYDfp5TB/s85jMQCcLMmu02vaFJ8Q0mbtkNATx+Squ",
+	"Ta9FJRpFR4KT8zEt2Pjy8dg3w6Eu9VBQ7WtRpCg1hBN9tiv4B99Gpj8lZ/r5FVTqMaq7Rwj4uigEUmtL",
+	"K08Zp3IZ/sLeQFe+d8FufFZBGMD/Xnet+5Y44ad8yHGlCdKI+JbdhKPrboQ/ZZz88O/D1+M3RyfmTEbb",
+	"dvbr7Kqn+fJBZkSNuWKjBTlwwsrMSWx/oX1SUKVaHIyHWh2ALSQabHVqTuAsGKd5uMKb8FcEghU4Vj74",
+	"xtq22VQzmGM+w07VkicLKbgoVbZ80PTp4w0c7yO6tE2fxFuUmHbc800WC3zf/k9OqHb6V3VSUz2tqLy6",
+	"sxO01F2lWfr1XtW9pt0B1jf1MoQhq1iChrwQksplj8KrdFxL89Hm5x4ays1+aw31rI169FVHfFAgW/3s",
+	"76kTTrDJ/wNzcLhvfz8H+3NiSpWQElVFTB6YXbdP/d6SwT/jON4hnkGYj3r5VIE0iePNCvnq9jz+89g4",
+	"No1JLpQmEhLgaK2aH9cU7CFD2RTQ12Zog2nDdOO7Jo086p/qQQ0Hm1uQhtOChjILKbxluT7K7Iw+94Xp",
+	"2H21QWmqmdIsUbHrQJkzXVfrN/pqQtpTHeeo9cgBcp+St/HBipC8VSCJwwdxsbSutP3LdA/qHI3fe1OS",
+	"2QDozSazm89+h24avoJLyERhCpDsW60OlPvjMfoA2UIovf9i78We87+ibrDpSIq0tF8wD0y00lOzmuas",
+	"2k/nnlMoFaBqKWafR32xVi/6beC7rRUWkBUgG3PZIaG5EPU55XQOrhbJjzDI7w4wd0yDIw58z8ahL751",
+	"nQGb2XXzHHoB11NHptBPblbTNsa+rGuRw1DUvW+rkpV6tJVP3aGvgxmWFghVoKjKQtTTukDRzdnN/w0A",
+	"ZYddJ4ePAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
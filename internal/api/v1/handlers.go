@@ -46,6 +46,21 @@ func (s *APIServer) GetImage(c *fiber.Ctx, uuid string) error {
 	return controllers.HandleGetImageResourceAPI(c)
 }
 
+// ListImages lists the caller's images with cursor pagination (API key protected).
+func (s *APIServer) ListImages(c *fiber.Ctx, params ListImagesParams) error {
+	return controllers.HandleListImagesAPI(c)
+}
+
+// UpdateImage updates title, description, visibility and tags of an own image (API key protected).
+func (s *APIServer) UpdateImage(c *fiber.Ctx, uuid string) error {
+	return controllers.HandleUpdateImageAPI(c)
+}
+
+// DeleteImage schedules the deletion of an own image (API key protected).
+func (s *APIServer) DeleteImage(c *fiber.Ctx, uuid string) error {
+	return controllers.HandleDeleteImageAPI(c)
+}
+
 // GetImageStatus returns processing status for an image (JSON)
 func (s *APIServer) GetImageStatus(c *fiber.Ctx, uuid string) error {
 	if uuid == "" {
@@ -75,3 +90,88 @@ func (s *APIServer) GetImageStatus(c *fiber.Ctx, uuid string) error {
 func (s *APIServer) PostUserUploadSession(c *fiber.Ctx) error {
 	return controllers.HandleCreateUploadSessionAPI(c)
 }
+
+// GetImageComments lists comments of a public image (API key protected).
+func (s *APIServer) GetImageComments(c *fiber.Ctx, uuid string, params GetImageCommentsParams) error {
+	return controllers.HandleListImageCommentsAPI(c)
+}
+
+// PostImageComment adds a comment to a public image (API key protected).
+func (s *APIServer) PostImageComment(c *fiber.Ctx, uuid string) error {
+	return controllers.HandleCreateImageCommentAPI(c)
+}
+
+// DeleteImageComment removes a comment as author or image owner (API key protected).
+func (s *APIServer) DeleteImageComment(c *fiber.Ctx, uuid string, commentId int64) error {
+	return controllers.HandleDeleteImageCommentAPI(c)
+}
+
+// LikeImage adds an image to the caller's favorites (API key protected).
+func (s *APIServer) LikeImage(c *fiber.Ctx, uuid string) error {
+	return controllers.HandleLikeImageAPI(c)
+}
+
+// UnlikeImage removes an image from the caller's favorites (API key protected).
+func (s *APIServer) UnlikeImage(c *fiber.Ctx, uuid string) error {
+	return controllers.HandleUnlikeImageAPI(c)
+}
+
+// GetUserLikes lists the images the caller liked (API key protected).
+func (s *APIServer) GetUserLikes(c *fiber.Ctx, params GetUserLikesParams) error {
+	return controllers.HandleListUserLikesAPI(c)
+}
+
+// SearchContent runs the public full-text search (API key optional).
+func (s *APIServer) SearchContent(c *fiber.Ctx, params SearchContentParams) error {
+	return controllers.HandleSearchAPI(c)
+}
+
+// ListAlbums lists the caller's albums (API key protected).
+func (s *APIServer) ListAlbums(c *fiber.Ctx, params ListAlbumsParams) error {
+	return controllers.HandleListAlbumsAPI(c)
+}
+
+// CreateAlbum creates an album within the plan limits (API key protected).
+func (s *APIServer) CreateAlbum(c *fiber.Ctx) error {
+	return controllers.HandleCreateAlbumAPI(c)
+}
+
+// GetAlbum returns an own album with its images (API key protected).
+func (s *APIServer) GetAlbum(c *fiber.Ctx, id int) error {
+	return controllers.HandleGetAlbumAPI(c)
+}
+
+// UpdateAlbum updates an own album (API key protected).
+func (s *APIServer) UpdateAlbum(c *fiber.Ctx, id int) error {
+	return controllers.HandleUpdateAlbumAPI(c)
+}
+
+// DeleteAlbum deletes an own album (API key protected).
+func (s *APIServer) DeleteAlbum(c *fiber.Ctx, id int) error {
+	return controllers.HandleDeleteAlbumAPI(c)
+}
+
+// AddAlbumImages adds images to an album in bulk (API key protected).
+func (s *APIServer) AddAlbumImages(c *fiber.Ctx, id int) error {
+	return controllers.HandleAddAlbumImagesAPI(c)
+}
+
+// RemoveAlbumImages removes images from an album in bulk (API key protected).
+func (s *APIServer) RemoveAlbumImages(c *fiber.Ctx, id int) error {
+	return controllers.HandleRemoveAlbumImagesAPI(c)
+}
+
+// ReorderAlbumImages stores a new image order (API key protected).
+func (s *APIServer) ReorderAlbumImages(c *fiber.Ctx, id int) error {
+	return controllers.HandleReorderAlbumImagesAPI(c)
+}
+
+// SetAlbumCover sets the cover image of an album (API key protected).
+func (s *APIServer) SetAlbumCover(c *fiber.Ctx, id int) error {
+	return controllers.HandleSetAlbumCoverAPI(c)
+}
+
+// ListImageTransforms returns signed transformation URLs for an own image (API key protected).
+func (s *APIServer) ListImageTransforms(c *fiber.Ctx, uuid string) error {
+	return controllers.HandleImageTransformURLsAPI(c)
+}
@@ -28,6 +28,17 @@ func planRank(plan string) int {
 	}
 }
 
+func planDisplayName(plan string) string {
+	switch normalizePlan(plan) {
+	case string(entitlements.PlanPremiumMax):
+		return "Premium Max"
+	case string(entitlements.PlanPremium):
+		return "Premium"
+	default:
+		return "Free"
+	}
+}
+
 func normalizeInterval(interval string) string {
 	i := strings.ToLower(strings.TrimSpace(interval))
 	switch i {
@@ -19,6 +19,7 @@ type Repository interface {
 	SaveUserSettings(us *models.UserSettings) error
 	CreateWebhookEventIfNotExists(event *models.BillingWebhookEvent) (bool, *models.BillingWebhookEvent, error)
 	MarkWebhookProcessed(id uint, processingError string) error
+	CreateNotification(notification *models.Notification) error
 }
 
 type gormRepository struct {
@@ -142,3 +143,7 @@ func (r *gormRepository) MarkWebhookProcessed(id uint, processingError string) e
 	}
 	return r.db.Model(&models.BillingWebhookEvent{}).Where("id = ?", id).Updates(updates).Error
 }
+
+func (r *gormRepository) CreateNotification(notification *models.Notification) error {
+	return r.db.Create(notification).Error
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -225,16 +226,33 @@ func (s *Service) ReconcileUserPlan(ctx context.Context, userID uint) (string, e
 	if err != nil {
 		return "", err
 	}
-	if normalizePlan(us.Plan) == best {
+	previous := normalizePlan(us.Plan)
+	if previous == best {
 		return best, nil
 	}
 	us.Plan = best
 	if err := s.repo.SaveUserSettings(us); err != nil {
 		return "", err
 	}
+
+	// Best effort: a missing notification must not fail the reconciliation
+	_ = s.repo.CreateNotification(&models.Notification{
+		UserID:  userID,
+		Type:    models.NotificationTypePlanChanged,
+		Content: planChangeMessage(previous, best),
+		Link:    "/user/settings/membership",
+	})
 	return best, nil
 }
 
+// planChangeMessage builds the notification text for a plan change
+func planChangeMessage(previous, current string) string {
+	if planRank(current) > planRank(previous) {
+		return fmt.Sprintf("Deine Mitgliedschaft wurde auf %s hochgestuft.", planDisplayName(current))
+	}
+	return fmt.Sprintf("Deine Mitgliedschaft wurde von %s auf %s geändert.", planDisplayName(previous), planDisplayName(current))
+}
+
 // RecordWebhookEvent persists webhook payloads idempotently.
 func (s *Service) RecordWebhookEvent(ctx context.Context, in WebhookEventInput) (bool, *models.BillingWebhookEvent, error) {
 	_ = ctx
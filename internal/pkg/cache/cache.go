@@ -97,3 +97,19 @@ func GetInt(key string) (int, error) {
 func Delete(key string) error {
 	return GetClient().Del(ctx, key).Err()
 }
+
+// IncrWithExpiry increments a counter and starts its expiration window on the first hit.
+// A missing TTL (e.g. from a crashed writer) is repaired so counters never become permanent.
+func IncrWithExpiry(key string, expiration time.Duration) (int64, error) {
+	cli := GetClient()
+	n, err := cli.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if n == 1 {
+		cli.Expire(ctx, key, expiration)
+	} else if ttl, _ := cli.TTL(ctx, key).Result(); ttl < 0 {
+		cli.Expire(ctx, key, expiration)
+	}
+	return n, nil
+}
@@ -125,24 +125,54 @@ func runAutoMigrateWithLock(db *gorm.DB) error {
 		}
 	}()
 
+	// Likes violating the unique index on (user_id, image_id) must be gone before AutoMigrate creates it
+	if err := models.PrepareLikesUniqueIndex(db); err != nil {
+		return fmt.Errorf("failed to purge soft-deleted and duplicate likes: %w", err)
+	}
+
 	if err := runAutoMigrate(db); err != nil {
 		return err
 	}
 
+	// Move single per-user API keys from user settings into the api_keys table
+	if err := models.MigrateLegacyAPIKeys(db); err != nil {
+		log.Printf("Warning: failed to migrate legacy API keys: %v", err)
+	}
+
+	// Number album images that were added before albums had an order
+	if err := models.BackfillAlbumImagePositions(db); err != nil {
+		log.Printf("Warning: failed to backfill album image positions: %v", err)
+	}
+
+	// Split perceptual hashes stored before the near-duplicate lookup used hash bands
+	if err := models.BackfillImageHashBands(db); err != nil {
+		log.Printf("Warning: failed to backfill perceptual hash bands: %v", err)
+	}
+
+	// Seed the variant profiles matching the formerly hard-coded thumbnail sizes
+	if err := models.SeedVariantProfiles(db); err != nil {
+		log.Printf("Warning: failed to seed variant profiles: %v", err)
+	}
+
 	return nil
 }
 
 func runAutoMigrate(db *gorm.DB) error {
 	return db.AutoMigrate(
 		&models.User{},
+		&models.RecoveryCode{},
 		&models.ProviderAccount{},
 		&models.BillingAccount{},
 		&models.BillingSubscription{},
 		&models.BillingWebhookEvent{},
 		&models.BillingPlanMapping{},
 		&models.UserSettings{},
+		&models.APIKey{},
 		&models.Image{},
 		&models.ImageVariant{},
+		&models.VariantProfile{},
+		&models.DerivedVariant{},
+		&models.TransformPreset{},
 		&models.ImageMetadata{},
 		&models.ImageReport{},
 		&models.Album{},
@@ -156,6 +186,10 @@ func runAutoMigrate(db *gorm.DB) error {
 		&models.Page{},
 		&models.Setting{},
 		&models.StoragePool{},
+		&models.ScrubReport{},
+		&models.ScrubFinding{},
+		&models.ImageReplica{},
+		&models.ImageHashBand{},
 	)
 }
 
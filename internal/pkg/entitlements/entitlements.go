@@ -87,6 +87,16 @@ func CanMultiUpload(plan Plan) bool {
 	}
 }
 
+// CanWatermark returns whether a plan may watermark the served variants of its images.
+func CanWatermark(plan Plan) bool {
+	switch plan {
+	case PlanPremium, PlanPremiumMax:
+		return true
+	default:
+		return false
+	}
+}
+
 // MaxFilesPerBatch returns the maximum number of files allowed per batch
 // upload for a given plan. -1 means unlimited (not recommended in UI).
 func MaxFilesPerBatch(plan Plan) int {
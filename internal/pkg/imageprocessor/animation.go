@@ -0,0 +1,312 @@
+package imageprocessor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2/log"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+)
+
+// AnimationInfo describes the frames of an animated GIF or WebP
+type AnimationInfo struct {
+	FrameCount int
+	DurationMs int
+	Width      int // canvas size, 0 if unknown
+	Height     int
+}
+
+// IsAnimated reports whether the image has more than one frame
+func (a AnimationInfo) IsAnimated() bool {
+	return a.FrameCount > 1
+}
+
+// DetectAnimation reads the frame count and total duration of GIF and WebP files
+// without decoding the frames. Other formats are reported as a single frame.
+func DetectAnimation(filePath string) (AnimationInfo, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return AnimationInfo{}, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".gif":
+		return detectGIFAnimation(r)
+	case ".webp":
+		return detectWebPAnimation(r)
+	}
+	return AnimationInfo{FrameCount: 1}, nil
+}
+
+// gifFrameDelayMs converts a GIF frame delay (1/100 s) to milliseconds.
+// Browsers play delays below 2 as 10, so the same is recorded here.
+func gifFrameDelayMs(delay uint16) int {
+	if delay < 2 {
+		delay = 10
+	}
+	return int(delay) * 10
+}
+
+// webpFrameDurationMs applies the same minimum frame duration browsers use for animated WebP
+func webpFrameDurationMs(duration int) int {
+	if duration <= 10 {
+		return 100
+	}
+	return duration
+}
+
+// detectGIFAnimation walks the GIF block structure, counting image descriptors
+// and summing the delays of their graphic control extensions
+func detectGIFAnimation(r *bufio.Reader) (AnimationInfo, error) {
+	header := make([]byte, 13)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return AnimationInfo{}, fmt.Errorf("gif header: %w", err)
+	}
+	if !bytes.HasPrefix(header, []byte("GIF8")) {
+		return AnimationInfo{}, errors.New("not a GIF file")
+	}
+	if header[10]&0x80 != 0 {
+		if _, err := r.Discard(3 << (int(header[10]&0x07) + 1)); err != nil {
+			return AnimationInfo{}, fmt.Errorf("gif color table: %w", err)
+		}
+	}
+
+	info := AnimationInfo{
+		Width:  int(binary.LittleEndian.Uint16(header[6:8])),
+		Height: int(binary.LittleEndian.Uint16(header[8:10])),
+	}
+	pendingDelay := -1
+	for {
+		introducer, err := r.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) && info.FrameCount > 0 {
+				return info, nil // tolerate a missing trailer like browsers do
+			}
+			return info, fmt.Errorf("gif block: %w", err)
+		}
+		switch introducer {
+		case 0x21: // extension
+			label, err := r.ReadByte()
+			if err != nil {
+				return info, err
+			}
+			if label == 0xF9 {
+				gce := make([]byte, 6) // size, flags, delay (2), transparent index, terminator
+				if _, err := io.ReadFull(r, gce); err != nil {
+					return info, fmt.Errorf("gif graphic control: %w", err)
+				}
+				pendingDelay = gifFrameDelayMs(binary.LittleEndian.Uint16(gce[2:4]))
+				continue
+			}
+			if err := skipGIFSubBlocks(r); err != nil {
+				return info, err
+			}
+		case 0x2C: // image descriptor
+			descriptor := make([]byte, 9)
+			if _, err := io.ReadFull(r, descriptor); err != nil {
+				return info, fmt.Errorf("gif image descriptor: %w", err)
+			}
+			if descriptor[8]&0x80 != 0 {
+				if _, err := r.Discard(3 << (int(descriptor[8]&0x07) + 1)); err != nil {
+					return info, fmt.Errorf("gif local color table: %w", err)
+				}
+			}
+			if _, err := r.ReadByte(); err != nil { // LZW minimum code size
+				return info, err
+			}
+			if err := skipGIFSubBlocks(r); err != nil {
+				return info, err
+			}
+			info.FrameCount++
+			if pendingDelay < 0 {
+				pendingDelay = gifFrameDelayMs(0)
+			}
+			info.DurationMs += pendingDelay
+			pendingDelay = -1
+		case 0x3B: // trailer
+			return info, nil
+		default:
+			return info, fmt.Errorf("unexpected gif block 0x%02x", introducer)
+		}
+	}
+}
+
+// skipGIFSubBlocks skips a chain of data sub-blocks up to the block terminator
+func skipGIFSubBlocks(r *bufio.Reader) error {
+	for {
+		size, err := r.ReadByte()
+		if err != nil {
+			return fmt.Errorf("gif sub-block: %w", err)
+		}
+		if size == 0 {
+			return nil
+		}
+		if _, err := r.Discard(int(size)); err != nil {
+			return fmt.Errorf("gif sub-block: %w", err)
+		}
+	}
+}
+
+// detectWebPAnimation walks the RIFF chunks of a WebP file, counting ANMF frames
+func detectWebPAnimation(r *bufio.Reader) (AnimationInfo, error) {
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return AnimationInfo{}, fmt.Errorf("webp header: %w", err)
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WEBP" {
+		return AnimationInfo{}, errors.New("not a WebP file")
+	}
+
+	var info AnimationInfo
+	chunk := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return info, fmt.Errorf("webp chunk: %w", err)
+		}
+		size := int(binary.LittleEndian.Uint32(chunk[4:8]))
+		padded := size + size%2
+		if string(chunk[0:4]) == "VP8X" && size >= 10 {
+			// VP8X: flags (1), reserved (3), canvas width-1 (3), canvas height-1 (3)
+			vp8x := make([]byte, 10)
+			if _, err := io.ReadFull(r, vp8x); err != nil {
+				return info, fmt.Errorf("webp VP8X chunk: %w", err)
+			}
+			info.Width = 1 + (int(vp8x[4]) | int(vp8x[5])<<8 | int(vp8x[6])<<16)
+			info.Height = 1 + (int(vp8x[7]) | int(vp8x[8])<<8 | int(vp8x[9])<<16)
+			if _, err := r.Discard(padded - len(vp8x)); err != nil {
+				break
+			}
+			continue
+		}
+		if string(chunk[0:4]) != "ANMF" {
+			if _, err := r.Discard(padded); err != nil {
+				break
+			}
+			continue
+		}
+		// ANMF: X (3), Y (3), width-1 (3), height-1 (3), duration (3), flags (1), frame data
+		frame := make([]byte, 16)
+		if size < len(frame) {
+			return info, errors.New("webp ANMF chunk too small")
+		}
+		if _, err := io.ReadFull(r, frame); err != nil {
+			return info, fmt.Errorf("webp ANMF chunk: %w", err)
+		}
+		info.FrameCount++
+		info.DurationMs += webpFrameDurationMs(int(frame[12]) | int(frame[13])<<8 | int(frame[14])<<16)
+		if _, err := r.Discard(padded - len(frame)); err != nil {
+			break
+		}
+	}
+	if info.FrameCount == 0 {
+		info.FrameCount = 1
+	}
+	return info, nil
+}
+
+// animatedSource is the original file of an animated image whose animation is preserved in the variants
+type animatedSource struct {
+	path string
+	info AnimationInfo
+}
+
+// detectAnimatedSource records the frame count and duration on the image and returns the source
+// for animated variants. It returns nil for still images or when ffmpeg is not available.
+func detectAnimatedSource(imageModel *models.Image, originalFilePath string) *animatedSource {
+	info, err := DetectAnimation(originalFilePath)
+	if err != nil {
+		log.Warnf("[ImageProcessor] Could not read frames of %s: %v", imageModel.UUID, err)
+		info = AnimationInfo{FrameCount: 1}
+	}
+	imageModel.FrameCount = info.FrameCount
+	imageModel.DurationMs = info.DurationMs
+	if !info.IsAnimated() {
+		return nil
+	}
+	log.Infof("[ImageProcessor] Animated image %s: %d frames, %d ms", imageModel.UUID, info.FrameCount, info.DurationMs)
+	if !IsFFmpegAvailable {
+		log.Warnf("[ImageProcessor] ffmpeg not found, variants of animated image %s will be still images", imageModel.UUID)
+		return nil
+	}
+	if info.Width <= 0 || info.Height <= 0 {
+		log.Warnf("[ImageProcessor] Unknown canvas size of animated image %s, variants will be still images", imageModel.UUID)
+		return nil
+	}
+	return &animatedSource{path: originalFilePath, info: info}
+}
+
+// animatedTargetSize returns the dimensions of an animated variant; video codecs need even dimensions
+func animatedTargetSize(profile *models.VariantProfile, width, height int, even bool) (int, int) {
+	w, h := width, height
+	switch {
+	case profile.IsFullSize():
+	case profile.MaxHeight == 0:
+		w, h = profile.MaxWidth, height*profile.MaxWidth/width
+	case profile.MaxWidth == 0:
+		w, h = width*profile.MaxHeight/height, profile.MaxHeight
+	default:
+		if width*profile.MaxHeight > height*profile.MaxWidth {
+			w, h = profile.MaxWidth, height*profile.MaxWidth/width
+		} else {
+			w, h = width*profile.MaxHeight/height, profile.MaxHeight
+		}
+	}
+	if even {
+		w, h = w-w%2, h-h%2
+	}
+	return max(w, 2), max(h, 2)
+}
+
+// renderAnimatedVariant encodes an animated variant of the source with ffmpeg.
+// WebP profiles become animated WebP, original-format profiles keep GIF/WebP and
+// MP4/WebM profiles become silent looping videos.
+func renderAnimatedVariant(src *animatedSource, outputPath string, profile *models.VariantProfile) (int, int, error) {
+	if !IsFFmpegAvailable {
+		return 0, 0, fmt.Errorf("ffmpeg is not available for animated variants")
+	}
+	isVideo := profile.IsVideo()
+	w, h := animatedTargetSize(profile, src.info.Width, src.info.Height, isVideo)
+	scale := fmt.Sprintf("scale=%d:%d:flags=lanczos", w, h)
+
+	// Variants never carry the EXIF/XMP data of the source, whatever the metadata policy
+	args := []string{"-v", "error", "-i", src.path, "-an", "-map_metadata", "-1"}
+	switch {
+	case profile.Format == models.VariantFormatMP4:
+		args = append(args, "-vf", scale, "-c:v", "libx264", "-crf", strconv.Itoa(profile.Quality), "-preset", "medium", "-pix_fmt", "yuv420p", "-movflags", "+faststart")
+	case profile.Format == models.VariantFormatWebM:
+		args = append(args, "-vf", scale, "-c:v", "libvpx-vp9", "-crf", strconv.Itoa(profile.Quality), "-b:v", "0", "-pix_fmt", "yuv420p")
+	case strings.EqualFold(filepath.Ext(outputPath), ".gif"):
+		// Palette per variant keeps the resized GIF close to the original colors
+		args = append(args, "-filter_complex", scale+",split[a][b];[a]palettegen[p];[b][p]paletteuse", "-loop", "0")
+	default:
+		args = append(args, "-vf", scale, "-c:v", "libwebp_anim", "-quality", strconv.Itoa(profile.Quality), "-loop", "0")
+	}
+	args = append(args, "-y", outputPath)
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return 0, 0, fmt.Errorf("failed to create directory for %s: %w", outputPath, err)
+	}
+	cmd := exec.Command("ffmpeg", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		_ = os.Remove(outputPath)
+		return 0, 0, fmt.Errorf("ffmpeg command failed for %s: %w\nStderr: %s", outputPath, err, stderr.String())
+	}
+	return w, h, nil
+}
@@ -0,0 +1,78 @@
+package imageprocessor_test
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestGIF(t *testing.T, delays []int) string {
+	palette := color.Palette{color.Black, color.White}
+	anim := &gif.GIF{}
+	for i, delay := range delays {
+		frame := image.NewPaletted(image.Rect(0, 0, 40, 30), palette)
+		frame.SetColorIndex(i%40, 0, 1)
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	path := filepath.Join(t.TempDir(), "anim.gif")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, gif.EncodeAll(f, anim))
+	return path
+}
+
+func TestDetectAnimationGIF(t *testing.T) {
+	info, err := imageprocessor.DetectAnimation(writeTestGIF(t, []int{5, 20, 0}))
+	require.NoError(t, err)
+	assert.True(t, info.IsAnimated())
+	assert.Equal(t, 3, info.FrameCount)
+	// Delays below 2 are played as 10 by browsers
+	assert.Equal(t, 50+200+100, info.DurationMs)
+	assert.Equal(t, 40, info.Width)
+	assert.Equal(t, 30, info.Height)
+
+	info, err = imageprocessor.DetectAnimation(writeTestGIF(t, []int{0}))
+	require.NoError(t, err)
+	assert.False(t, info.IsAnimated())
+}
+
+func TestDetectAnimationStillFormats(t *testing.T) {
+	_, err := imageprocessor.DetectAnimation(filepath.Join(t.TempDir(), "missing.jpg"))
+	require.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "still.png")
+	require.NoError(t, os.WriteFile(path, []byte("not decoded"), 0644))
+	info, err := imageprocessor.DetectAnimation(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, info.FrameCount)
+
+	path = filepath.Join(t.TempDir(), "broken.gif")
+	require.NoError(t, os.WriteFile(path, []byte("PNG"), 0644))
+	_, err = imageprocessor.DetectAnimation(path)
+	assert.Error(t, err)
+}
+
+func TestDetectAnimationTestdata(t *testing.T) {
+	for _, name := range []string{"image-animated.gif", "image-animated.webp"} {
+		info, err := imageprocessor.DetectAnimation(filepath.Join("testdata", name))
+		require.NoError(t, err, name)
+		assert.True(t, info.IsAnimated(), name)
+		assert.Positive(t, info.DurationMs, name)
+		assert.Positive(t, info.Width, name)
+		assert.Positive(t, info.Height, name)
+	}
+
+	info, err := imageprocessor.DetectAnimation(filepath.Join("testdata", "image.webp"))
+	require.NoError(t, err)
+	assert.False(t, info.IsAnimated())
+}
@@ -0,0 +1,123 @@
+package imageprocessor
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+)
+
+// colorSampleSize is the maximum edge length images are reduced to before the colors are counted
+const colorSampleSize = 64
+
+// ColorInfo contains the placeholder data shown while an image is loading
+type ColorInfo struct {
+	BlurHash          string
+	DominantColor     string // #rrggbb, empty for fully transparent images
+	AverageColor      string // #rrggbb, empty for fully transparent images
+	MostlyTransparent bool   // more than half of the pixels are (nearly) transparent
+}
+
+// AnalyzeColors computes the BlurHash, the dominant and the average color of an image.
+// Transparent pixels are ignored for the colors; the dominant color is the mean of the most frequent color bucket.
+func AnalyzeColors(img image.Image) ColorInfo {
+	// Landscape images get more horizontal components, portrait images more vertical ones
+	xComponents, yComponents := 4, 3
+	if b := img.Bounds(); b.Dy() > b.Dx() {
+		xComponents, yComponents = 3, 4
+	}
+	info := ColorInfo{BlurHash: EncodeBlurHash(img, xComponents, yComponents)}
+
+	sample := imaging.Fit(img, colorSampleSize, colorSampleSize, imaging.Box)
+	type bucket struct {
+		count   int
+		r, g, b int
+	}
+	buckets := make(map[int]*bucket)
+	var sumR, sumG, sumB, sumA, transparent, total int
+	for y := 0; y < sample.Bounds().Dy(); y++ {
+		row := sample.Pix[y*sample.Stride:]
+		for x := 0; x < sample.Bounds().Dx(); x++ {
+			r, g, b, a := int(row[x*4]), int(row[x*4+1]), int(row[x*4+2]), int(row[x*4+3])
+			total++
+			sumR += r * a
+			sumG += g * a
+			sumB += b * a
+			sumA += a
+			if a < 128 {
+				transparent++
+				continue
+			}
+			// 4 bits per channel are enough to group similar shades
+			key := (r>>4)<<8 | (g>>4)<<4 | b>>4
+			bk := buckets[key]
+			if bk == nil {
+				bk = &bucket{}
+				buckets[key] = bk
+			}
+			bk.count++
+			bk.r += r
+			bk.g += g
+			bk.b += b
+		}
+	}
+	info.MostlyTransparent = total > 0 && transparent*2 > total
+
+	if sumA > 0 {
+		info.AverageColor = hexColor(sumR/sumA, sumG/sumA, sumB/sumA)
+	}
+	var best *bucket
+	bestKey := -1
+	for key, bk := range buckets {
+		// Ties are resolved by the bucket key so the result does not depend on map order
+		if best == nil || bk.count > best.count || (bk.count == best.count && key < bestKey) {
+			best, bestKey = bk, key
+		}
+	}
+	if best != nil {
+		info.DominantColor = hexColor(best.r/best.count, best.g/best.count, best.b/best.count)
+	}
+	return info
+}
+
+func hexColor(r, g, b int) string {
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// setColorInfo stores the placeholder data of the decoded original on the model
+func setColorInfo(imageModel *models.Image, img image.Image) {
+	if img == nil {
+		return
+	}
+	info := AnalyzeColors(img)
+	imageModel.BlurHash = info.BlurHash
+	imageModel.DominantColor = info.DominantColor
+	imageModel.AverageColor = info.AverageColor
+	imageModel.IsMostlyTransparent = info.MostlyTransparent
+}
+
+// ComputeImagePlaceholder decodes the original of an existing image and stores its BlurHash and colors
+func ComputeImagePlaceholder(imageModel *models.Image) error {
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	img, err := decodeStoredOriginal(imageModel)
+	if err != nil {
+		return err
+	}
+
+	setColorInfo(imageModel, img)
+	if err := db.Model(&models.Image{}).Where("id = ?", imageModel.ID).UpdateColumns(map[string]interface{}{
+		"blur_hash":             imageModel.BlurHash,
+		"dominant_color":        imageModel.DominantColor,
+		"average_color":         imageModel.AverageColor,
+		"is_mostly_transparent": imageModel.IsMostlyTransparent,
+	}).Error; err != nil {
+		return fmt.Errorf("failed to store placeholder of image %d: %w", imageModel.ID, err)
+	}
+	return nil
+}
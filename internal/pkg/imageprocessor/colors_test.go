@@ -0,0 +1,56 @@
+package imageprocessor_test
+
+import (
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+)
+
+func TestEncodeBlurHash(t *testing.T) {
+	red := imaging.New(40, 30, color.NRGBA{R: 255, A: 255})
+	hash := imageprocessor.EncodeBlurHash(red, 4, 3)
+	// size flag "L" (4x3 components) followed by the max AC value and the DC color #ff0000 in base83
+	assert.Equal(t, "L", hash[:1])
+	assert.Equal(t, "TI:j", hash[2:6])
+	assert.Len(t, hash, 4+2*4*3)
+
+	photo, err := imaging.Open(filepath.Join("testdata", "image-big.jpg"))
+	require.NoError(t, err)
+	photoHash := imageprocessor.EncodeBlurHash(photo, 4, 3)
+	assert.Len(t, photoHash, 28)
+	assert.NotEqual(t, hash, photoHash)
+	assert.Len(t, imageprocessor.EncodeBlurHash(photo, 3, 4), 28)
+}
+
+func TestAnalyzeColors(t *testing.T) {
+	// Three quarters blue, one quarter white
+	img := imaging.New(40, 40, color.NRGBA{B: 255, A: 255})
+	white := imaging.New(20, 20, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+	img = imaging.Paste(img, white, image.Pt(0, 0))
+
+	info := imageprocessor.AnalyzeColors(img)
+	assert.NotEmpty(t, info.BlurHash)
+	assert.Equal(t, "#0000ff", info.DominantColor)
+	assert.Equal(t, "#3f3fff", info.AverageColor)
+	assert.False(t, info.MostlyTransparent)
+
+	// Mostly transparent image: the colors come from the visible pixels only
+	transparent := imaging.New(40, 40, color.NRGBA{})
+	transparent = imaging.Paste(transparent, imaging.New(10, 40, color.NRGBA{G: 255, A: 255}), image.Pt(0, 0))
+	info = imageprocessor.AnalyzeColors(transparent)
+	assert.True(t, info.MostlyTransparent)
+	assert.Equal(t, "#00ff00", info.DominantColor)
+	assert.Equal(t, "#00ff00", info.AverageColor)
+
+	empty := imageprocessor.AnalyzeColors(imaging.New(8, 8, color.NRGBA{}))
+	assert.True(t, empty.MostlyTransparent)
+	assert.Empty(t, empty.DominantColor)
+	assert.Empty(t, empty.AverageColor)
+}
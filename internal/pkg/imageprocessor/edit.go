@@ -0,0 +1,316 @@
+package imageprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/cache"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
+)
+
+const (
+	// editedOriginalQuality is the JPEG quality of originals written by an edit
+	editedOriginalQuality = 95
+	// editLockTTL bounds how long a crashed edit keeps the image locked
+	editLockTTL = 5 * time.Minute
+)
+
+var (
+	// ErrEditUnsupported is returned for legacy images without a storage pool
+	ErrEditUnsupported = errors.New("editing requires a storage pool")
+	// ErrEditAnimated is returned for animated images, an edit would drop all frames but the first
+	ErrEditAnimated = errors.New("animated images cannot be edited")
+	// ErrNothingToUndo is returned when no original of a previous revision is kept
+	ErrNothingToUndo = errors.New("no previous revision to restore")
+	// ErrEditBusy is returned while the image is still processed or another edit of it runs
+	ErrEditBusy = errors.New("image is being processed or edited")
+)
+
+func editLockKey(imageID uint) string {
+	return fmt.Sprintf("lock:image_edit:%d", imageID)
+}
+
+// beginImageEdit locks the image against concurrent edits and undos and reloads it, so the
+// edit builds on the latest revision. Images whose processing has not finished are rejected,
+// the running job would otherwise write variants of the replaced original.
+func beginImageEdit(db *gorm.DB, imageModel *models.Image) (func(), error) {
+	unlock := func() {}
+	if cli := cache.GetClient(); cli != nil {
+		ctx := context.Background()
+		key := editLockKey(imageModel.ID)
+		ok, err := cli.SetNX(ctx, key, "1", editLockTTL).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock image %s for editing: %w", imageModel.UUID, err)
+		}
+		if !ok {
+			return nil, ErrEditBusy
+		}
+		unlock = func() { _ = cli.Del(ctx, key).Err() }
+	}
+	if !IsImageProcessingComplete(imageModel.UUID) {
+		unlock()
+		return nil, ErrEditBusy
+	}
+	if err := db.First(imageModel, imageModel.ID).Error; err != nil {
+		unlock()
+		return nil, fmt.Errorf("failed to reload image %s: %w", imageModel.UUID, err)
+	}
+	if err := loadEditableImage(db, imageModel); err != nil {
+		unlock()
+		return nil, err
+	}
+	return unlock, nil
+}
+
+// EditOperations describes a server-side edit of an original. The crop rectangle is given in
+// pixels of the current original and applied first, then the clockwise rotation, then the flips.
+type EditOperations struct {
+	Rotate int             // clockwise degrees: 0, 90, 180 or 270
+	FlipH  bool            // mirror left to right
+	FlipV  bool            // mirror top to bottom
+	Crop   image.Rectangle // empty = no crop
+}
+
+// IsEmpty reports whether the operations leave the image unchanged
+func (o EditOperations) IsEmpty() bool {
+	return o.Rotate == 0 && !o.FlipH && !o.FlipV && o.Crop.Empty()
+}
+
+// Validate checks the operations against the size of the original
+func (o EditOperations) Validate(width, height int) error {
+	switch o.Rotate {
+	case 0, 90, 180, 270:
+	default:
+		return fmt.Errorf("unsupported rotation %d, allowed are 90, 180 and 270 degrees", o.Rotate)
+	}
+	if !o.Crop.Empty() && !o.Crop.In(image.Rect(0, 0, width, height)) {
+		return fmt.Errorf("crop rectangle %v exceeds the image size %dx%d", o.Crop, width, height)
+	}
+	return nil
+}
+
+// ApplyEditOperations renders the operations onto an image
+func ApplyEditOperations(img image.Image, ops EditOperations) *image.NRGBA {
+	var result *image.NRGBA
+	if !ops.Crop.Empty() {
+		b := img.Bounds()
+		result = imaging.Crop(img, ops.Crop.Add(b.Min))
+	} else {
+		result = imaging.Clone(img)
+	}
+	// imaging rotates counter-clockwise
+	switch ops.Rotate {
+	case 90:
+		result = imaging.Rotate270(result)
+	case 180:
+		result = imaging.Rotate180(result)
+	case 270:
+		result = imaging.Rotate90(result)
+	}
+	if ops.FlipH {
+		result = imaging.FlipH(result)
+	}
+	if ops.FlipV {
+		result = imaging.FlipV(result)
+	}
+	return result
+}
+
+// editedFileType returns the file type an edited original is written as. JPEG and PNG keep their
+// type, everything else becomes JPEG, or PNG for images with transparency.
+func editedFileType(fileType string, img *image.NRGBA) string {
+	switch ft := normalizeFileType(fileType); ft {
+	case ".jpg", ".jpeg", ".png":
+		return ft
+	}
+	if !img.Opaque() {
+		return ".png"
+	}
+	return ".jpg"
+}
+
+// loadEditableImage makes sure the image's original lives in a storage pool and can be edited
+func loadEditableImage(db *gorm.DB, imageModel *models.Image) error {
+	if imageModel.FrameCount > 1 {
+		return ErrEditAnimated
+	}
+	if imageModel.StoragePool == nil && imageModel.StoragePoolID > 0 {
+		if pool, err := models.FindStoragePoolByID(db, imageModel.StoragePoolID); err == nil {
+			imageModel.StoragePool = pool
+		}
+	}
+	if imageModel.StoragePool == nil {
+		return ErrEditUnsupported
+	}
+	return nil
+}
+
+// EditImage writes the edited original as a new revision next to the current one. The current
+// original is kept for a one-step undo, an older kept original is deleted. All variants and
+// cached transformations are removed; the caller re-enqueues the image processing afterwards.
+// UUID, share link, albums and counters are untouched.
+func EditImage(imageModel *models.Image, ops EditOperations) error {
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	unlock, err := beginImageEdit(db, imageModel)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	ws, err := openWorkspace(imageModel)
+	if err != nil {
+		return err
+	}
+	defer ws.close()
+	img, err := ws.decodeOriginal()
+	if err != nil {
+		return err
+	}
+	if err := ops.Validate(img.Bounds().Dx(), img.Bounds().Dy()); err != nil {
+		return err
+	}
+	edited := ApplyEditOperations(img, ops)
+
+	pool := imageModel.StoragePool
+	revision := imageModel.Revision + 1
+	fileType := editedFileType(imageModel.FileType, edited)
+	fileName := fmt.Sprintf("%s_r%d%s", imageModel.UUID, revision, fileType)
+	relPath := path.Join(filepath.ToSlash(imageModel.FilePath), fileName)
+	fullPath := ws.localPath(relPath)
+	if err := saveOriginalFormatQuality(edited, fullPath, fileType, editedOriginalQuality); err != nil {
+		return err
+	}
+	size, err := ws.store(relPath)
+	if err != nil {
+		return fmt.Errorf("failed to store edited original %s: %w", relPath, err)
+	}
+	contentHash, err := ws.contentHash(relPath)
+	if err != nil {
+		return fmt.Errorf("failed to hash edited original %s: %w", relPath, err)
+	}
+
+	sm := storage.NewStorageManager()
+	if imageModel.PreviousFileName != "" {
+		if _, err := sm.DeleteFile(filepath.Join(imageModel.FilePath, imageModel.PreviousFileName), pool.ID); err != nil {
+			log.Warnf("[ImageProcessor] Failed to delete previous original %s of %s: %v", imageModel.PreviousFileName, imageModel.UUID, err)
+		}
+	}
+	if err := pool.UpdateUsedSize(db, size); err != nil {
+		log.Warnf("[ImageProcessor] Failed to update pool usage for %s: %v", imageModel.UUID, err)
+	}
+
+	imageModel.PreviousFileName = imageModel.FileName
+	imageModel.PreviousFileType = imageModel.FileType
+	imageModel.PreviousFileSize = imageModel.FileSize
+	imageModel.FileName = fileName
+	imageModel.FileType = fileType
+	imageModel.FileSize = size
+	imageModel.ContentHash = contentHash
+	imageModel.Revision = revision
+	if err := replaceOriginal(db, sm, imageModel); err != nil {
+		return err
+	}
+	log.Infof("[ImageProcessor] Stored revision %d of image %s", revision, imageModel.UUID)
+	return nil
+}
+
+// UndoImageEdit restores the original kept by the last edit. The revision still increases so
+// the variants rendered from the restored original get new, cache-busting file names.
+func UndoImageEdit(imageModel *models.Image) error {
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	unlock, err := beginImageEdit(db, imageModel)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if !imageModel.CanUndoEdit() {
+		return ErrNothingToUndo
+	}
+	pool := imageModel.StoragePool
+	backend, err := storage.BackendFor(pool)
+	if err != nil {
+		return err
+	}
+	previousPath := path.Join(filepath.ToSlash(imageModel.FilePath), imageModel.PreviousFileName)
+	// The hash of the kept original is not recorded, so take it from the file being restored
+	contentHash, _, err := storage.Checksum(backend, previousPath)
+	if err != nil {
+		return fmt.Errorf("previous original %s is not available: %w", previousPath, err)
+	}
+
+	sm := storage.NewStorageManager()
+	if _, err := sm.DeleteFile(filepath.Join(imageModel.FilePath, imageModel.FileName), pool.ID); err != nil {
+		log.Warnf("[ImageProcessor] Failed to delete edited original %s of %s: %v", imageModel.FileName, imageModel.UUID, err)
+	}
+
+	imageModel.FileName = imageModel.PreviousFileName
+	imageModel.FileType = imageModel.PreviousFileType
+	imageModel.FileSize = imageModel.PreviousFileSize
+	imageModel.ContentHash = contentHash
+	imageModel.PreviousFileName = ""
+	imageModel.PreviousFileType = ""
+	imageModel.PreviousFileSize = 0
+	imageModel.Revision++
+	if err := replaceOriginal(db, sm, imageModel); err != nil {
+		return err
+	}
+	log.Infof("[ImageProcessor] Restored previous original of image %s as revision %d", imageModel.UUID, imageModel.Revision)
+	return nil
+}
+
+// replaceOriginal stores the new original of an image and drops everything derived from the old one.
+// The image is marked pending, so further edits wait for the variants of the new original.
+func replaceOriginal(db *gorm.DB, sm *storage.StorageManager, imageModel *models.Image) error {
+	deleteVariantFiles(db, sm, imageModel)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("image_id = ?", imageModel.ID).Delete(&models.ImageVariant{}).Error; err != nil {
+			return fmt.Errorf("failed to delete variants of image %s: %w", imageModel.UUID, err)
+		}
+		if err := models.SaveImageHashBands(tx, imageModel.ID, imageModel.UserID, nil); err != nil {
+			return fmt.Errorf("failed to delete perceptual hash bands of image %s: %w", imageModel.UUID, err)
+		}
+		imageModel.PerceptualHash = nil
+		imageModel.BlurHash = ""
+		imageModel.DominantColor = ""
+		imageModel.AverageColor = ""
+		imageModel.IsMostlyTransparent = false
+		return tx.Model(&models.Image{}).Where("id = ?", imageModel.ID).Updates(map[string]interface{}{
+			"file_name":             imageModel.FileName,
+			"file_type":             imageModel.FileType,
+			"file_size":             imageModel.FileSize,
+			"content_hash":          imageModel.ContentHash,
+			"revision":              imageModel.Revision,
+			"previous_file_name":    imageModel.PreviousFileName,
+			"previous_file_type":    imageModel.PreviousFileType,
+			"previous_file_size":    imageModel.PreviousFileSize,
+			"perceptual_hash":       nil,
+			"blur_hash":             "",
+			"dominant_color":        "",
+			"average_color":         "",
+			"is_mostly_transparent": false,
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	if err := SetImageStatus(imageModel.UUID, STATUS_PENDING); err != nil {
+		log.Warnf("[ImageProcessor] Failed to mark edited image %s as pending: %v", imageModel.UUID, err)
+	}
+	return nil
+}
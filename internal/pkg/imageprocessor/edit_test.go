@@ -0,0 +1,45 @@
+package imageprocessor_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+)
+
+func TestEditOperationsValidate(t *testing.T) {
+	assert.True(t, imageprocessor.EditOperations{}.IsEmpty())
+	assert.NoError(t, imageprocessor.EditOperations{Rotate: 270}.Validate(100, 50))
+	assert.Error(t, imageprocessor.EditOperations{Rotate: 45}.Validate(100, 50))
+
+	crop := imageprocessor.EditOperations{Crop: image.Rect(10, 10, 100, 50)}
+	assert.False(t, crop.IsEmpty())
+	assert.NoError(t, crop.Validate(100, 50))
+	assert.Error(t, crop.Validate(99, 50))
+}
+
+func TestApplyEditOperations(t *testing.T) {
+	// 4x2 image with a red top-left pixel
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for i := range src.Pix {
+		src.Pix[i] = 255
+	}
+	red := color.NRGBA{R: 255, A: 255}
+	src.SetNRGBA(0, 0, red)
+
+	// 90° clockwise: the top-left pixel moves to the top-right corner
+	rotated := imageprocessor.ApplyEditOperations(src, imageprocessor.EditOperations{Rotate: 90})
+	assert.Equal(t, image.Rect(0, 0, 2, 4), rotated.Bounds())
+	assert.Equal(t, red, rotated.NRGBAAt(1, 0))
+
+	flipped := imageprocessor.ApplyEditOperations(src, imageprocessor.EditOperations{FlipH: true, FlipV: true})
+	assert.Equal(t, red, flipped.NRGBAAt(3, 1))
+
+	// Crop happens before the rotation
+	cropped := imageprocessor.ApplyEditOperations(src, imageprocessor.EditOperations{Crop: image.Rect(0, 0, 2, 1), Rotate: 180})
+	assert.Equal(t, image.Rect(0, 0, 2, 1), cropped.Bounds())
+	assert.Equal(t, red, cropped.NRGBAAt(1, 0))
+}
@@ -0,0 +1,139 @@
+package imageprocessor
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/gofiber/fiber/v2/log"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+)
+
+// webFallbackTypes lists the originals browsers cannot display with the file type their
+// variants in the "original" format are written as instead
+var webFallbackTypes = map[string]string{
+	".heic": ".jpg",
+	".heif": ".jpg",
+	".jxl":  ".jpg",
+	".tif":  ".png",
+	".tiff": ".png",
+}
+
+// NeedsWebVariant reports whether originals of the file type (HEIC/HEIF, TIFF, JPEG XL) cannot be
+// displayed by browsers and therefore get a full-size web variant
+func NeedsWebVariant(fileType string) bool {
+	_, ok := webFallbackTypes[normalizeFileType(fileType)]
+	return ok
+}
+
+func normalizeFileType(fileType string) string {
+	fileType = strings.ToLower(strings.TrimSpace(fileType))
+	if fileType != "" && !strings.HasPrefix(fileType, ".") {
+		fileType = "." + fileType
+	}
+	return fileType
+}
+
+// browserFileType returns the file type variants in the "original" format are written as.
+// Browser-safe originals keep their type; the others become JPEG, or PNG for TIFF and images with transparency.
+func browserFileType(fileType string, img image.Image) string {
+	fallback, ok := webFallbackTypes[normalizeFileType(fileType)]
+	if !ok {
+		return fileType
+	}
+	if o, isOpaquer := img.(interface{ Opaque() bool }); isOpaquer && !o.Opaque() {
+		return ".png"
+	}
+	return fallback
+}
+
+// openOriginal decodes an original for variant rendering. HEIC/HEIF and JPEG XL are decoded
+// through ffmpeg, TIFF natively with ffmpeg as fallback for layouts the Go decoder does not handle.
+func openOriginal(filePath, fileType string) (image.Image, error) {
+	if !NeedsWebVariant(fileType) {
+		return imaging.Open(filePath, imaging.AutoOrientation(true))
+	}
+	if ft := normalizeFileType(fileType); ft == ".tif" || ft == ".tiff" {
+		img, err := imaging.Open(filePath, imaging.AutoOrientation(true))
+		if err == nil || !IsFFmpegAvailable {
+			return img, err
+		}
+		log.Debugf("[ImageProcessor] Native TIFF decoding failed for %s, trying ffmpeg: %v", filePath, err)
+	}
+	return decodeWithFFmpeg(filePath)
+}
+
+// decodeWithFFmpeg renders the primary image of a file into an image.Image.
+// ffmpeg applies the rotation stored in the container, so the result is upright.
+func decodeWithFFmpeg(filePath string) (image.Image, error) {
+	if !IsFFmpegAvailable {
+		return nil, fmt.Errorf("ffmpeg is not available for decoding '%s'", filePath)
+	}
+	cmd := exec.Command("ffmpeg", "-v", "error", "-i", filePath, "-frames:v", "1", "-f", "image2pipe", "-vcodec", "png", "-")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg decoding failed for '%s': %w, stderr: %s", filePath, err, strings.TrimSpace(stderr.String()))
+	}
+	img, err := png.Decode(&stdout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ffmpeg output for '%s': %w", filePath, err)
+	}
+	log.Debugf("[ImageProcessor] Decoded %s through ffmpeg (%dx%d)", filePath, img.Bounds().Dx(), img.Bounds().Dy())
+	return img, nil
+}
+
+// decodeStoredOriginal decodes the original of an already processed image from its storage pool
+func decodeStoredOriginal(imageModel *models.Image) (image.Image, error) {
+	if database.GetDB() == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+	ws, err := openWorkspace(imageModel)
+	if err != nil {
+		return nil, err
+	}
+	defer ws.close()
+	return ws.decodeOriginal()
+}
+
+// decodeOriginal decodes the current original of the workspace's image
+func (ws *workspace) decodeOriginal() (image.Image, error) {
+	imageModel := ws.imageModel
+	originalFilePath, err := ws.original()
+	if err != nil {
+		return nil, fmt.Errorf("original of image %d not available: %w", imageModel.ID, err)
+	}
+
+	var img image.Image
+	if normalizeFileType(imageModel.FileType) == ".avif" {
+		img, err = decodeWithFFmpeg(originalFilePath)
+	} else {
+		img, err = openOriginal(originalFilePath, imageModel.FileType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode original of image %d: %w", imageModel.ID, err)
+	}
+	return img, nil
+}
+
+// generateWebVariant writes the full-size browser-safe rendition of an original browsers cannot display
+func generateWebVariant(imageModel *models.Image, img image.Image, variantsBaseDir string, wm *watermark) (GeneratedVariant, error) {
+	profile := models.WebVariantProfile()
+	fileType := browserFileType(imageModel.FileType, img)
+	fileName := profile.FileName(imageModel.VariantBaseName(), fileType)
+	if wm.appliesTo(&profile) {
+		img = wm.apply(img)
+	}
+	if err := saveOriginalFormatQuality(img, filepath.Join(variantsBaseDir, fileName), fileType, profile.Quality); err != nil {
+		return GeneratedVariant{}, err
+	}
+	return GeneratedVariant{Profile: profile, FileName: fileName, Width: img.Bounds().Dx(), Height: img.Bounds().Dy()}, nil
+}
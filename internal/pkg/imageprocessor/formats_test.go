@@ -0,0 +1,17 @@
+package imageprocessor_test
+
+import (
+	"testing"
+
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNeedsWebVariant(t *testing.T) {
+	for _, fileType := range []string{".heic", ".HEIF", "tiff", ".tif", ".jxl"} {
+		assert.True(t, imageprocessor.NeedsWebVariant(fileType), fileType)
+	}
+	for _, fileType := range []string{".jpg", ".png", ".gif", ".webp", ".avif", ".bmp", ""} {
+		assert.False(t, imageprocessor.NeedsWebVariant(fileType), fileType)
+	}
+}
@@ -24,27 +24,18 @@ import (
 	"github.com/ManuelReschke/PixelFox/app/models"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/constants"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
-	"github.com/ManuelReschke/PixelFox/internal/pkg/entitlements"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
-// Thumbnail sizes
-const (
-	SmallThumbnailSize  = 200
-	MediumThumbnailSize = 500
-)
-
 // Directory paths and worker settings
 var (
 	// DEPRECATED: MaxWorkers - old in-memory queue used unified job queue now
 	MaxWorkers = 3
 
 	// DEPRECATED: Old quality settings - now handled in functions directly
-	WebPQuality     = 90  // DEPRECATED: Default quality for WebP conversion (1-100)
-	SmallThumbSize  = 200 // DEPRECATED: Use SmallThumbnailSize constant instead
-	MediumThumbSize = 500 // DEPRECATED: Use MediumThumbnailSize constant instead
+	WebPQuality = 90 // DEPRECATED: Default quality for WebP conversion (1-100)
 
 	// Directory Paths
 	OriginalDir = "uploads/original"
@@ -68,6 +59,9 @@ func init() {
 	} else {
 		log.Info("[ImageProcessor] ffmpeg found, AVIF conversion enabled.")
 	}
+	// Optional optimisers for original-format variants and originals
+	IsPNGQuantAvailable = checkToolAvailable("pngquant")
+	IsJPEGOptimAvailable = checkToolAvailable("jpegoptim")
 }
 
 // ImageProcessor handles image processing with a worker pool
@@ -244,71 +238,31 @@ func processImageCore(imageModel *models.Image) (errResult error) {
 		return fmt.Errorf("invalid image data provided")
 	}
 
-	// Construct original file path considering storage pools
-	var originalFilePath string
-	if imageModel.StoragePoolID > 0 && imageModel.StoragePool != nil {
-		// Use storage pool base path
-		originalFilePath = filepath.Join(imageModel.StoragePool.BasePath, imageModel.FilePath, imageModel.FileName)
-		log.Debugf("[ImageProcessor] Using storage pool original file path: %s", originalFilePath)
-	} else {
-		// Fallback to legacy path
-		originalFilePath = filepath.Join(imageModel.FilePath, imageModel.FileName)
-		log.Debugf("[ImageProcessor] Using legacy original file path: %s", originalFilePath)
+	// Work on the files in place, or on staged copies for pools that are not mounted locally
+	ws, err := openWorkspace(imageModel)
+	if err != nil {
+		return fmt.Errorf("failed to open storage of image %s: %w", imageModel.UUID, err)
 	}
+	defer ws.close()
 
-	if _, err := os.Stat(originalFilePath); os.IsNotExist(err) {
+	originalFilePath, err := ws.original()
+	if os.IsNotExist(err) {
 		return fmt.Errorf("original file not found: %s", originalFilePath)
 	} else if err != nil {
 		return fmt.Errorf("error accessing original file '%s': %w", originalFilePath, err)
 	}
+	log.Debugf("[ImageProcessor] Using original file path: %s", originalFilePath)
 
-	// Determine paths for variants based on storage pool structure
-	var variantsBaseDir string
-
-	if imageModel.StoragePoolID > 0 && imageModel.StoragePool != nil {
-		// Use storage pool base path
-		storagePoolBasePath := imageModel.StoragePool.BasePath
-
-		// Extract relative path from image FilePath (remove 'original/' prefix)
-		relativePath := strings.TrimPrefix(imageModel.FilePath, "original/")
-		relativePath = strings.TrimPrefix(relativePath, string(filepath.Separator))
-
-		variantsBaseDir = filepath.Join(storagePoolBasePath, "variants", relativePath)
-		log.Debugf("[ImageProcessor] Using storage pool variants path: %s", variantsBaseDir)
-	} else {
-		// Fallback to legacy structure
-		relativePath := strings.TrimPrefix(imageModel.FilePath, OriginalDir)
-		relativePath = strings.TrimPrefix(relativePath, string(filepath.Separator))
-		variantsBaseDir = filepath.Join(VariantsDir, relativePath)
-		log.Debugf("[ImageProcessor] Using legacy variants path: %s", variantsBaseDir)
-	}
-
-	baseFileName := imageModel.UUID
-
-	if err := os.MkdirAll(variantsBaseDir, 0755); err != nil {
-		return fmt.Errorf("failed to create variants directory: %w", err)
+	// Variants live next to the original under variants/ of the storage pool
+	variantsBaseDir, err := ws.variantsDir()
+	if err != nil {
+		return err
 	}
+	log.Debugf("[ImageProcessor] Using variants path: %s", variantsBaseDir)
 
-	optimizedWebPPath := filepath.Join(variantsBaseDir, baseFileName+".webp")
-	optimizedAVIFPath := filepath.Join(variantsBaseDir, baseFileName+".avif")
-	smallThumbWebPPath := filepath.Join(variantsBaseDir, baseFileName+"_small.webp")
-	smallThumbAVIFPath := filepath.Join(variantsBaseDir, baseFileName+"_small.avif")
-	mediumThumbWebPPath := filepath.Join(variantsBaseDir, baseFileName+"_medium.webp")
-	mediumThumbAVIFPath := filepath.Join(variantsBaseDir, baseFileName+"_medium.avif")
-	smallThumbOriginalPath := filepath.Join(variantsBaseDir, baseFileName+"_small"+imageModel.FileType)
-	mediumThumbOriginalPath := filepath.Join(variantsBaseDir, baseFileName+"_medium"+imageModel.FileType)
-
-	var hasWebp, hasAvif, hasThumbnailSmall, hasThumbnailMedium bool
 	var width, height int
-	var smallThumb image.Image
-	var mediumThumb image.Image
-	// DB handle used for user settings lookup
+	// DB handle used for variant profiles and user settings lookup
 	db := database.GetDB()
-	defer func() {
-		smallThumb = nil
-		mediumThumb = nil
-		log.Debugf("[ImageProcessor] Cleared thumbnail references for %s", imageModel.UUID)
-	}()
 
 	if err := ExtractMetadata(imageModel, originalFilePath); err != nil {
 		log.Warnf("[ImageProcessor] Could not extract metadata for %s: %v. Processing continues.", imageModel.UUID, err)
@@ -316,6 +270,50 @@ func processImageCore(imageModel *models.Image) (errResult error) {
 		log.Debugf("[ImageProcessor] Successfully extracted metadata for %s", imageModel.UUID)
 	}
 
+	// Remove location or all metadata from the served original before any variant is derived
+	originalChanged := false
+	policy := resolveMetadataPolicy(db, imageModel)
+	if changed, sizeChange, err := scrubOriginal(imageModel, originalFilePath, policy); err != nil {
+		log.Warnf("[ImageProcessor] Could not apply metadata policy %s to %s: %v", policy, imageModel.UUID, err)
+	} else if changed {
+		originalChanged = true
+		imageModel.FileSize += sizeChange
+		if sizeChange != 0 && db != nil && imageModel.StoragePool != nil {
+			if err := imageModel.StoragePool.UpdateUsedSize(db, sizeChange); err != nil {
+				log.Warnf("[ImageProcessor] Failed to update pool usage for %s: %v", imageModel.UUID, err)
+			}
+		}
+	}
+
+	// Optional lossless optimisation of the stored original
+	imageModel.BytesSaved = 0
+	optimizeVariants, optimizeOriginal := optimizationSettings()
+	if optimizeOriginal {
+		if saved, err := OptimizeOriginalFile(originalFilePath, imageModel.FileType); err != nil {
+			log.Warnf("[ImageProcessor] Could not optimise original of %s: %v", imageModel.UUID, err)
+		} else if saved > 0 {
+			originalChanged = true
+			imageModel.FileSize -= saved
+			imageModel.BytesSaved += saved
+			if db != nil && imageModel.StoragePool != nil {
+				if err := imageModel.StoragePool.UpdateUsedSize(db, -saved); err != nil {
+					log.Warnf("[ImageProcessor] Failed to update pool usage for %s: %v", imageModel.UUID, err)
+				}
+			}
+		}
+	}
+
+	if originalChanged {
+		if _, err := ws.store(ws.originalRelPath()); err != nil {
+			return fmt.Errorf("failed to store original of %s: %w", imageModel.UUID, err)
+		}
+		if hash, err := ws.contentHash(ws.originalRelPath()); err != nil {
+			log.Warnf("[ImageProcessor] Could not hash rewritten original of %s: %v", imageModel.UUID, err)
+		} else {
+			imageModel.ContentHash = hash
+		}
+	}
+
 	lowerFilePath := strings.ToLower(originalFilePath)
 	lowerFileType := strings.ToLower(strings.TrimPrefix(imageModel.FileType, "."))
 	isAVIF := strings.HasSuffix(lowerFilePath, ".avif") || lowerFileType == "avif"
@@ -323,6 +321,8 @@ func processImageCore(imageModel *models.Image) (errResult error) {
 	log.Debugf("[ImageProcessor] Checking AVIF input: %s (Path: %s, Type: %s) => isAVIF=%v",
 		imageModel.UUID, originalFilePath, imageModel.FileType, isAVIF)
 
+	imageModel.IsWatermarked = false
+
 	// --- Special Handling for AVIF input ---
 	if isAVIF {
 		log.Infof("[ImageProcessor] AVIF input file detected: %s", imageModel.UUID)
@@ -335,266 +335,87 @@ func processImageCore(imageModel *models.Image) (errResult error) {
 			return fmt.Errorf("failed to get AVIF dimensions: %w", ffprobeErr)
 		}
 		log.Infof("[ImageProcessor] AVIF dimensions successfully retrieved: %dx%d for %s", width, height, imageModel.UUID)
-		hasWebp, hasAvif, hasThumbnailSmall, hasThumbnailMedium = false, false, false, false
+		if img, err := decodeWithFFmpeg(originalFilePath); err == nil {
+			setPerceptualHash(imageModel, img)
+			setColorInfo(imageModel, img)
+		} else {
+			log.Warnf("[ImageProcessor] Could not decode AVIF %s for perceptual hashing and placeholders: %v", imageModel.UUID, err)
+		}
 
-		// Update Database record (flags, dimensions, metadata)
-		if err := UpdateImageRecordFunc(imageModel, width, height, hasWebp, hasAvif, hasThumbnailSmall, hasThumbnailMedium); err != nil {
+		// Update Database record (dimensions, metadata); no variants are generated for AVIF input
+		if err := UpdateImageRecordFunc(imageModel, width, height, nil); err != nil {
 			return err // Return DB update error
 		}
 		log.Infof("[ImageProcessor] AVIF input file %s processed successfully (DB updated).", imageModel.UUID)
 		return nil // Success for AVIF handling
 	}
 
-	// --- Handling for non-AVIF input (e.g., JPEG, PNG, GIF, WebP) ---
-	log.Debugf("[ImageProcessor] Opening and decoding image using imaging.Open: %s", originalFilePath)
-	imgDecoded, err := imaging.Open(originalFilePath, imaging.AutoOrientation(true))
+	// Animated GIF/WebP keep their animation in the variants (needs ffmpeg)
+	anim := detectAnimatedSource(imageModel, originalFilePath)
+
+	// --- Handling for non-AVIF input (e.g., JPEG, PNG, GIF, WebP; HEIC/HEIF, TIFF and JPEG XL through ffmpeg) ---
+	log.Debugf("[ImageProcessor] Opening and decoding image: %s", originalFilePath)
+	imgDecoded, err := openOriginal(originalFilePath, imageModel.FileType)
 	if err != nil {
-		return fmt.Errorf("failed to open/decode image '%s': %w", originalFilePath, err)
+		if NeedsWebVariant(imageModel.FileType) {
+			// Keep the original downloadable and record its dimensions even without variants
+			if IsFFmpegAvailable {
+				if w, h, probeErr := getImageDimensionsWithFFprobe(originalFilePath); probeErr == nil {
+					log.Warnf("[ImageProcessor] Could not decode %s, storing it without variants: %v", imageModel.UUID, err)
+					return UpdateImageRecordFunc(imageModel, w, h, nil)
+				}
+			}
+			return fmt.Errorf("failed to decode image '%s': %w", originalFilePath, err)
+		}
+		// Animated WebP cannot be decoded into a single frame; ffmpeg still renders its variants
+		if anim == nil {
+			return fmt.Errorf("failed to open/decode image '%s': %w", originalFilePath, err)
+		}
+		log.Warnf("[ImageProcessor] Could not decode first frame of animated image %s, generating animated variants only: %v", imageModel.UUID, err)
+		width, height = anim.info.Width, anim.info.Height
+	} else {
+		bounds := imgDecoded.Bounds()
+		width = bounds.Dx()
+		height = bounds.Dy()
+		setPerceptualHash(imageModel, imgDecoded)
+		setColorInfo(imageModel, imgDecoded)
 	}
 	defer func() {
 		imgDecoded = nil
 		log.Debugf("[ImageProcessor] Cleared main decoded image reference for %s", imageModel.UUID)
 	}()
-	log.Debugf("[ImageProcessor] Successfully decoded image: %s", imageModel.UUID)
-
-	bounds := imgDecoded.Bounds()
-	width = bounds.Dx()
-	height = bounds.Dy()
 	log.Infof("[ImageProcessor] Processing image %s (%dx%d)", imageModel.UUID, width, height)
 
 	isGif := strings.HasSuffix(strings.ToLower(originalFilePath), ".gif")
-
-	// --- GIF Handling ---
-	if isGif {
-		log.Debugf("[ImageProcessor] GIF detected, creating thumbnails for %s", imageModel.UUID)
-
-		// Resolve effective thumbnail entitlements for user
-		settings := models.GetAppSettings()
-		var userSettings *models.UserSettings
-		if imageModel.UserID > 0 {
-			if us, err := models.GetOrCreateUserSettings(db, imageModel.UserID); err == nil {
-				userSettings = us
-			}
-		}
-		// Fallback defaults if missing
-		if userSettings == nil {
-			userSettings = &models.UserSettings{Plan: "free", PrefThumbOriginal: true}
-		}
-		origOK, webpOK, avifOK := entitlements.EffectiveThumbs(userSettings, settings)
-
-		// Small Thumbnail
-		smallThumb = imaging.Resize(imgDecoded, SmallThumbnailSize, 0, imaging.Lanczos)
-
-		// Save WebP version if enabled
-		if webpOK {
-			if err := saveWebP(smallThumb, smallThumbWebPPath); err != nil {
-				log.Errorf("[ImageProcessor] Failed to save small WebP thumbnail for GIF %s: %v", imageModel.UUID, err)
-			} else {
-				hasThumbnailSmall = true
-				log.Debugf("[ImageProcessor] Saved small WebP thumbnail for GIF %s", imageModel.UUID)
-			}
-		} else {
-			log.Debugf("[ImageProcessor] Skipping small WebP thumbnail for GIF %s: WebP thumbnails disabled", imageModel.UUID)
-		}
-
-		// Save original format version if enabled
-		if origOK {
-			if err := saveOriginalFormat(smallThumb, smallThumbOriginalPath, imageModel.FileType); err != nil {
-				log.Errorf("[ImageProcessor] Failed to save small original format thumbnail for GIF %s: %v", imageModel.UUID, err)
-			} else {
-				hasThumbnailSmall = true
-				log.Debugf("[ImageProcessor] Saved small original format thumbnail for GIF %s", imageModel.UUID)
-			}
-		} else {
-			log.Debugf("[ImageProcessor] Skipping small original format thumbnail for GIF %s: Original thumbnails disabled", imageModel.UUID)
-		}
-
-		// Save AVIF version if available and enabled
-		if avifOK && IsFFmpegAvailable {
-			if err := convertToAVIF(smallThumb, smallThumbAVIFPath); err != nil {
-				log.Errorf("[ImageProcessor] Failed to create small AVIF thumbnail for GIF %s: %v", imageModel.UUID, err)
-			} else {
-				hasThumbnailSmall = true
-				log.Debugf("[ImageProcessor] Saved small AVIF thumbnail for GIF %s", smallThumbAVIFPath)
-			}
-		} else if !avifOK {
-			log.Debugf("[ImageProcessor] Skipping small AVIF thumbnail for GIF %s: AVIF thumbnails disabled", imageModel.UUID)
-		}
-		smallThumb = nil
-
-		// Medium Thumbnail
-		mediumThumb = imaging.Resize(imgDecoded, MediumThumbnailSize, 0, imaging.Lanczos)
-
-		// Save WebP version if enabled
-		if webpOK {
-			if err := saveWebP(mediumThumb, mediumThumbWebPPath); err != nil {
-				log.Errorf("[ImageProcessor] Failed to save medium WebP thumbnail for GIF %s: %v", imageModel.UUID, err)
-			} else {
-				hasThumbnailMedium = true
-				log.Debugf("[ImageProcessor] Saved medium WebP thumbnail for GIF %s", imageModel.UUID)
-			}
-		} else {
-			log.Debugf("[ImageProcessor] Skipping medium WebP thumbnail for GIF %s: WebP thumbnails disabled", imageModel.UUID)
-		}
-
-		// Save original format version if enabled
-		if origOK {
-			if err := saveOriginalFormat(mediumThumb, mediumThumbOriginalPath, imageModel.FileType); err != nil {
-				log.Errorf("[ImageProcessor] Failed to save medium original format thumbnail for GIF %s: %v", imageModel.UUID, err)
-			} else {
-				hasThumbnailMedium = true
-				log.Debugf("[ImageProcessor] Saved medium original format thumbnail for GIF %s", imageModel.UUID)
-			}
-		} else {
-			log.Debugf("[ImageProcessor] Skipping medium original format thumbnail for GIF %s: Original thumbnails disabled", imageModel.UUID)
-		}
-
-		// Save AVIF version if available and enabled
-		if avifOK && IsFFmpegAvailable {
-			if err := convertToAVIF(mediumThumb, mediumThumbAVIFPath); err != nil {
-				log.Errorf("[ImageProcessor] Failed to create medium AVIF thumbnail for GIF %s: %v", imageModel.UUID, err)
-			} else {
-				hasThumbnailMedium = true
-				log.Debugf("[ImageProcessor] Saved medium AVIF thumbnail for GIF %s", mediumThumbAVIFPath)
-			}
-		} else if !avifOK {
-			log.Debugf("[ImageProcessor] Skipping medium AVIF thumbnail for GIF %s: AVIF thumbnails disabled", imageModel.UUID)
-		}
-		mediumThumb = nil
-	} else {
-		// --- Standard Image Handling ---
-		log.Debugf("[ImageProcessor] Standard image detected, creating optimized WebP/AVIF and thumbnails for %s", imageModel.UUID)
-
-		// Resolve effective thumbnail entitlements for user
-		settings := models.GetAppSettings()
-		var userSettings *models.UserSettings
-		if imageModel.UserID > 0 {
-			if us, err := models.GetOrCreateUserSettings(db, imageModel.UserID); err == nil {
-				userSettings = us
-			}
-		}
-		if userSettings == nil {
-			userSettings = &models.UserSettings{Plan: "free", PrefThumbOriginal: true}
-		}
-		origOK, webpOK, avifOK := entitlements.EffectiveThumbs(userSettings, settings)
-
-		// Optimized WebP (controlled by effective entitlements)
-		if webpOK {
-			if err := saveWebP(imgDecoded, optimizedWebPPath); err != nil {
-				log.Errorf("[ImageProcessor] Failed to create optimized WebP for %s: %v", imageModel.UUID, err)
-			} else {
-				hasWebp = true
-				log.Debugf("[ImageProcessor] Saved optimized WebP for %s", imageModel.UUID)
-			}
-		} else {
-			log.Debugf("[ImageProcessor] Skipping optimized WebP for %s: WebP disabled in settings", imageModel.UUID)
-		}
-
-		// Optimized AVIF (controlled by effective entitlements + ffmpeg availability)
-		if avifOK && IsFFmpegAvailable {
-			// Guard: libsvtav1 requires at least 64x64 input
-			w := imgDecoded.Bounds().Dx()
-			h := imgDecoded.Bounds().Dy()
-			if w < 64 || h < 64 {
-				log.Debugf("[ImageProcessor] Skipping optimized AVIF for %s: original dimensions %dx%d below 64x64", imageModel.UUID, w, h)
-			} else {
-				if err := convertToAVIF(imgDecoded, optimizedAVIFPath); err != nil {
-					log.Errorf("[ImageProcessor] Failed to convert to optimized AVIF for %s: %v", imageModel.UUID, err)
-				} else {
-					hasAvif = true
-					log.Debugf("[ImageProcessor] Saved optimized AVIF for %s", imageModel.UUID)
-				}
-			}
-		} else if !avifOK {
-			log.Debugf("[ImageProcessor] Skipping optimized AVIF for %s: AVIF disabled in settings", imageModel.UUID)
-		} else {
-			log.Warnf("[ImageProcessor] Skipping optimized AVIF conversion for %s: ffmpeg not found.", imageModel.UUID)
-		}
-		// Small Thumbnail
-		smallThumb = imaging.Resize(imgDecoded, SmallThumbnailSize, 0, imaging.Lanczos)
-
-		// Save WebP version if enabled
-		if webpOK {
-			if err := saveWebP(smallThumb, smallThumbWebPPath); err != nil {
-				log.Errorf("[ImageProcessor] Failed to save small WebP thumbnail for %s: %v", imageModel.UUID, err)
-			} else {
-				hasThumbnailSmall = true
-				log.Debugf("[ImageProcessor] Saved small WebP thumbnail for %s", imageModel.UUID)
-			}
-		} else {
-			log.Debugf("[ImageProcessor] Skipping small WebP thumbnail for %s: WebP thumbnails disabled", imageModel.UUID)
-		}
-
-		// Save original format version if enabled
-		if origOK {
-			if err := saveOriginalFormat(smallThumb, smallThumbOriginalPath, imageModel.FileType); err != nil {
-				log.Errorf("[ImageProcessor] Failed to save small original format thumbnail for %s: %v", imageModel.UUID, err)
-			} else {
-				hasThumbnailSmall = true
-				log.Debugf("[ImageProcessor] Saved small original format thumbnail for %s", imageModel.UUID)
-			}
-		} else {
-			log.Debugf("[ImageProcessor] Skipping small original format thumbnail for %s: Original thumbnails disabled", imageModel.UUID)
-		}
-
-		// Save AVIF version if available and enabled
-		if avifOK && IsFFmpegAvailable {
-			if err := convertToAVIF(smallThumb, smallThumbAVIFPath); err != nil {
-				log.Errorf("[ImageProcessor] Failed to save small AVIF thumbnail for %s: %v", imageModel.UUID, err)
-			} else {
-				hasThumbnailSmall = true
-				log.Debugf("[ImageProcessor] Saved small AVIF thumbnail for %s", imageModel.UUID)
-			}
-		} else if !avifOK {
-			log.Debugf("[ImageProcessor] Skipping small AVIF thumbnail for %s: AVIF thumbnails disabled", imageModel.UUID)
-		}
-		smallThumb = nil
-
-		// Medium Thumbnail
-		mediumThumb = imaging.Resize(imgDecoded, MediumThumbnailSize, 0, imaging.Lanczos)
-
-		// Save WebP version if enabled
-		if webpOK {
-			if err := saveWebP(mediumThumb, mediumThumbWebPPath); err != nil {
-				log.Errorf("[ImageProcessor] Failed to save medium WebP thumbnail for %s: %v", imageModel.UUID, err)
-			} else {
-				hasThumbnailMedium = true
-				log.Debugf("[ImageProcessor] Saved medium WebP thumbnail for %s", imageModel.UUID)
-			}
-		} else {
-			log.Debugf("[ImageProcessor] Skipping medium WebP thumbnail for %s: WebP thumbnails disabled", imageModel.UUID)
-		}
-
-		// Save original format version if enabled
-		if origOK {
-			if err := saveOriginalFormat(mediumThumb, mediumThumbOriginalPath, imageModel.FileType); err != nil {
-				log.Errorf("[ImageProcessor] Failed to save medium original format thumbnail for %s: %v", imageModel.UUID, err)
-			} else {
-				hasThumbnailMedium = true
-				log.Debugf("[ImageProcessor] Saved medium original format thumbnail for %s", imageModel.UUID)
-			}
+	thumbnailsOnly := isGif && anim == nil
+	if thumbnailsOnly {
+		log.Debugf("[ImageProcessor] GIF detected, creating resized variants only for %s", imageModel.UUID)
+	}
+
+	// Generate all active variant profiles the owner is entitled to
+	profiles := loadVariantProfiles(db)
+	wm := resolveWatermark(db, imageModel)
+	if anim != nil {
+		// Animated variants keep their frames and are not watermarked
+		wm = nil
+	}
+	imageModel.IsWatermarked = wm != nil && imgDecoded != nil
+	generated := generateProfileVariants(imageModel, imgDecoded, variantsBaseDir, profiles, resolveVariantEntitlements(db, imageModel), thumbnailsOnly, anim, wm)
+	if imgDecoded != nil && NeedsWebVariant(imageModel.FileType) {
+		if gv, err := generateWebVariant(imageModel, imgDecoded, variantsBaseDir, wm); err != nil {
+			log.Errorf("[ImageProcessor] Failed to save web variant for %s: %v", imageModel.UUID, err)
 		} else {
-			log.Debugf("[ImageProcessor] Skipping medium original format thumbnail for %s: Original thumbnails disabled", imageModel.UUID)
+			generated = append(generated, gv)
 		}
-
-		// Save AVIF version if available and enabled
-		if avifOK && IsFFmpegAvailable {
-			if err := convertToAVIF(mediumThumb, mediumThumbAVIFPath); err != nil {
-				log.Errorf("[ImageProcessor] Failed to save medium AVIF thumbnail for %s: %v", imageModel.UUID, err)
-			} else {
-				hasThumbnailMedium = true
-				log.Debugf("[ImageProcessor] Saved medium AVIF thumbnail for %s", imageModel.UUID)
-			}
-		} else if !avifOK {
-			log.Debugf("[ImageProcessor] Skipping medium AVIF thumbnail for %s: AVIF thumbnails disabled", imageModel.UUID)
-		}
-		mediumThumb = nil
-		imgDecoded = nil // Release main image memory
-		log.Debugf("[ImageProcessor] Released main decoded image reference after standard processing: %s", imageModel.UUID)
 	}
+	imgDecoded = nil // Release main image memory
+	if optimizeVariants {
+		imageModel.BytesSaved += optimizeGeneratedVariants(imageModel, variantsBaseDir, generated)
+	}
+	generated = ws.storeVariants(generated)
 
 	// --- Database Update ---
-	if err := UpdateImageRecordFunc(imageModel, width, height, hasWebp, hasAvif, hasThumbnailSmall, hasThumbnailMedium); err != nil {
+	if err := UpdateImageRecordFunc(imageModel, width, height, generated); err != nil {
 		return err // Return DB update error
 	}
 
@@ -654,17 +475,37 @@ func processImage(imageModel *models.Image) (errResult error) {
 }
 
 // updateImageRecord updates the database record for the image and creates variants.
-func updateImageRecord(imageModel *models.Image, width, height int, hasWebp, hasAvif, hasThumbSmall, hasThumbMedium bool) error {
+func updateImageRecord(imageModel *models.Image, width, height int, generated []GeneratedVariant) error {
 	db := database.GetDB()
 	if db == nil {
 		log.Error("[ImageProcessor] Database connection is nil, cannot update image record.")
 		return fmt.Errorf("database connection is nil")
 	}
 
-	// Update image dimensions only (remove variant flags)
+	// Update image dimensions and animation info only (remove variant flags)
+	frameCount := max(imageModel.FrameCount, 1)
 	imageUpdateData := map[string]interface{}{
-		"width":  width,
-		"height": height,
+		"width":          width,
+		"height":         height,
+		"frame_count":    frameCount,
+		"duration_ms":    imageModel.DurationMs,
+		"is_watermarked": imageModel.IsWatermarked,
+		"bytes_saved":    imageModel.BytesSaved,
+	}
+	if imageModel.FileSize > 0 {
+		imageUpdateData["file_size"] = imageModel.FileSize // changes when metadata was stripped
+	}
+	if imageModel.ContentHash != "" {
+		imageUpdateData["content_hash"] = imageModel.ContentHash
+	}
+	if imageModel.PerceptualHash != nil {
+		imageUpdateData["perceptual_hash"] = *imageModel.PerceptualHash
+	}
+	if imageModel.BlurHash != "" {
+		imageUpdateData["blur_hash"] = imageModel.BlurHash
+		imageUpdateData["dominant_color"] = imageModel.DominantColor
+		imageUpdateData["average_color"] = imageModel.AverageColor
+		imageUpdateData["is_mostly_transparent"] = imageModel.IsMostlyTransparent
 	}
 
 	log.Debugf("[ImageProcessor] Updating image record for %s with data: %+v", imageModel.UUID, imageUpdateData)
@@ -672,13 +513,18 @@ func updateImageRecord(imageModel *models.Image, width, height int, hasWebp, has
 		log.Errorf("[ImageProcessor] Failed to update image %s in database: %v", imageModel.UUID, err)
 		return fmt.Errorf("failed to update image in database: %w", err)
 	}
+	if imageModel.PerceptualHash != nil {
+		if err := models.SaveImageHashBands(db, imageModel.ID, imageModel.UserID, imageModel.PerceptualHash); err != nil {
+			log.Errorf("[ImageProcessor] Failed to store perceptual hash bands of image %s: %v", imageModel.UUID, err)
+		}
+	}
 
 	// Update the imageModel struct with the new dimensions so variants have correct width/height
 	imageModel.Width = width
 	imageModel.Height = height
 
 	// Create variant records based on what was successfully processed
-	if err := createImageVariants(db, imageModel, hasWebp, hasAvif, hasThumbSmall, hasThumbMedium); err != nil {
+	if err := createImageVariants(db, imageModel, variantsBaseDirFor(imageModel), generated); err != nil {
 		return fmt.Errorf("failed to create image variants: %w", err)
 	}
 
@@ -727,6 +573,11 @@ func updateImageRecord(imageModel *models.Image, width, height int, hasWebp, has
 
 // convertToAVIF converts an image (provided as image.Image) to AVIF format using ffmpeg.
 func convertToAVIF(img image.Image, outputPath string) error {
+	return convertToAVIFWithCRF(img, outputPath, 35)
+}
+
+// convertToAVIFWithCRF converts an image to AVIF using the given CRF (0-63, lower is better).
+func convertToAVIFWithCRF(img image.Image, outputPath string, crf int) error {
 	if !IsFFmpegAvailable {
 		return fmt.Errorf("ffmpeg is not available for AVIF conversion")
 	}
@@ -757,7 +608,7 @@ func convertToAVIF(img image.Image, outputPath string) error {
 	r, w := io.Pipe()
 	defer r.Close()
 
-	cmd := exec.Command("ffmpeg", "-f", "image2pipe", "-vcodec", "png", "-i", "pipe:0", "-c:v", "libsvtav1", "-crf", "35", "-preset", "8", "-pix_fmt", "yuv420p", "-movflags", "+faststart", "-y", outputPath)
+	cmd := exec.Command("ffmpeg", "-f", "image2pipe", "-vcodec", "png", "-i", "pipe:0", "-c:v", "libsvtav1", "-crf", strconv.Itoa(crf), "-preset", "8", "-pix_fmt", "yuv420p", "-movflags", "+faststart", "-y", outputPath)
 	cmd.Stdin = r
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
@@ -827,8 +678,8 @@ func getImageDimensionsWithFFprobe(filePath string) (int, int, error) {
 	return width, height, nil
 }
 
-// saveOriginalFormat saves an image in its original format (JPEG/PNG)
-func saveOriginalFormat(img image.Image, outputPath, fileType string) error {
+// saveOriginalFormatQuality saves an image in its original format (JPEG/PNG); quality applies to JPEG only.
+func saveOriginalFormatQuality(img image.Image, outputPath, fileType string, quality int) error {
 	if img == nil {
 		return fmt.Errorf("input image for original format saving is nil")
 	}
@@ -848,7 +699,7 @@ func saveOriginalFormat(img image.Image, outputPath, fileType string) error {
 
 	switch lowerFileType {
 	case ".jpg", ".jpeg":
-		if err := imaging.Encode(outputFile, img, imaging.JPEG, imaging.JPEGQuality(90)); err != nil {
+		if err := imaging.Encode(outputFile, img, imaging.JPEG, imaging.JPEGQuality(quality)); err != nil {
 			_ = outputFile.Close()
 			_ = os.Remove(outputPath)
 			log.Errorf("[ImageProcessor] Failed to encode JPEG image to %s: %v", outputPath, err)
@@ -875,8 +726,8 @@ func saveOriginalFormat(img image.Image, outputPath, fileType string) error {
 	return nil
 }
 
-// saveWebP saves an image in WebP format using the go-webp library.
-func saveWebP(img image.Image, outputPath string) error {
+// saveWebPQuality saves an image in lossy WebP format with the given quality (1-100).
+func saveWebPQuality(img image.Image, outputPath string, quality float32) error {
 	if img == nil {
 		return fmt.Errorf("input image for WebP saving is nil")
 	}
@@ -889,7 +740,7 @@ func saveWebP(img image.Image, outputPath string) error {
 		return fmt.Errorf("error creating WebP file '%s': %w", outputPath, err)
 	}
 	defer outputFile.Close()
-	options, err := encoder.NewLossyEncoderOptions(encoder.PresetDefault, 85)
+	options, err := encoder.NewLossyEncoderOptions(encoder.PresetDefault, quality)
 	if err != nil {
 		log.Errorf("[ImageProcessor] Failed to create WebP encoder options: %v", err)
 		return fmt.Errorf("error creating webp encoder options: %w", err)
@@ -943,12 +794,12 @@ func GetImagePath(imageModel *models.Image, format string, size string) string {
 			return ""
 		}
 
-		// If image has a storage pool, use the storage pool's base path
+		// If image has a storage pool on this node, use its local path
 		if imageModel.StoragePoolID > 0 && imageModel.StoragePool != nil {
-			// Construct full path using storage pool base path
-			fullPath := filepath.Join(imageModel.StoragePool.BasePath, imageModel.FilePath, imageModel.FileName)
-			log.Debugf("[GetImagePath] Using storage pool path for %s: %s", imageModel.UUID, fullPath)
-			return fullPath
+			if fullPath, ok := storage.LocalPath(imageModel.StoragePool, filepath.Join(imageModel.FilePath, imageModel.FileName)); ok {
+				log.Debugf("[GetImagePath] Using storage pool path for %s: %s", imageModel.UUID, fullPath)
+				return fullPath
+			}
 		}
 
 		// Fallback to legacy path structure
@@ -969,7 +820,8 @@ func GetImagePath(imageModel *models.Image, format string, size string) string {
 }
 
 // GetImageURL returns the web-accessible URL path for a specific image variant
-// This function strips storage pool base paths to generate proper web URLs
+// This function strips storage pool base paths to generate proper web URLs.
+// The size may also be the name of any variant profile.
 func GetImageURL(imageModel *models.Image, format string, size string) string {
 	if imageModel == nil || imageModel.UUID == "" {
 		log.Warn("[GetImageURL] Called with invalid image data (nil model or empty UUID)")
@@ -1005,10 +857,25 @@ func GetImageURL(imageModel *models.Image, format string, size string) string {
 		return webPath
 	}
 
+	return GetVariantURL(imageModel, variantType)
+}
+
+// GetVariantURL returns the web-accessible URL path of the variant generated for a variant profile
+func GetVariantURL(imageModel *models.Image, profileName string) string {
+	if imageModel == nil || imageModel.UUID == "" {
+		return ""
+	}
+	db := database.GetDB()
+	if db == nil {
+		log.Error("[GetVariantURL] Database connection is nil")
+		return ""
+	}
+	variantType := profileName
+
 	// Find variant in database
 	variant, err := models.FindVariantByImageIDAndType(db, imageModel.ID, variantType)
 	if err != nil {
-		log.Debugf("[GetImageURL] Variant '%s' not found for image %s: %v", variantType, imageModel.UUID, err)
+		log.Debugf("[GetVariantURL] Variant '%s' not found for image %s: %v", variantType, imageModel.UUID, err)
 		return ""
 	}
 
@@ -1016,7 +883,7 @@ func GetImageURL(imageModel *models.Image, format string, size string) string {
 	// Variants are stored with storage pool base path, but web URLs need relative paths
 	var webPath string
 
-	log.Debugf("[GetImageURL] Processing variant FilePath: %s, StoragePoolID: %d", variant.FilePath, variant.StoragePoolID)
+	log.Debugf("[GetVariantURL] Processing variant FilePath: %s, StoragePoolID: %d", variant.FilePath, variant.StoragePoolID)
 
 	if variant.StoragePoolID > 0 {
 		// Extract the relative path from the variant FilePath
@@ -1029,7 +896,7 @@ func GetImageURL(imageModel *models.Image, format string, size string) string {
 			// Extract from "variants" onwards and prepend uploads path
 			relativePath := variant.FilePath[variantsIndex:]
 			webPath = "/" + filepath.Join(constants.UploadsPath, relativePath, variant.FileName)
-			log.Debugf("[GetImageURL] Extracted relative path: %s -> webPath: %s", relativePath, webPath)
+			log.Debugf("[GetVariantURL] Extracted relative path: %s -> webPath: %s", relativePath, webPath)
 		} else {
 			// If no "variants" found, try to extract everything after storage pool base path
 			// Try to remove common storage pool base paths
@@ -1040,21 +907,22 @@ func GetImageURL(imageModel *models.Image, format string, size string) string {
 				cleanPath = strings.TrimPrefix(cleanPath, "/uploads/")
 			}
 			webPath = "/" + filepath.Join(constants.UploadsPath, cleanPath, variant.FileName)
-			log.Debugf("[GetImageURL] No 'variants' found, using cleaned path: %s -> webPath: %s", cleanPath, webPath)
+			log.Debugf("[GetVariantURL] No 'variants' found, using cleaned path: %s -> webPath: %s", cleanPath, webPath)
 		}
 	} else {
 		// Legacy path structure
 		webPath = filepath.Join(variant.FilePath, variant.FileName)
-		log.Debugf("[GetImageURL] Using legacy path: %s", webPath)
+		log.Debugf("[GetVariantURL] Using legacy path: %s", webPath)
 	}
 
 	// Convert to forward slashes for web URLs
 	webPath = strings.ReplaceAll(webPath, "\\", "/")
-	log.Debugf("[GetImageURL] Variant URL for %s (Type: %s): %s", imageModel.UUID, variantType, webPath)
+	log.Debugf("[GetVariantURL] Variant URL for %s (Type: %s): %s", imageModel.UUID, variantType, webPath)
 	return webPath
 }
 
-// getVariantType determines the variant type based on format and size
+// getVariantType determines the variant type (profile name) based on format and size.
+// small/medium/full map to the default profiles; other sizes are taken as profile name.
 func getVariantType(format, size string) string {
 	lowerFormat := strings.ToLower(format)
 	lowerSize := strings.ToLower(size)
@@ -1100,219 +968,23 @@ func getVariantType(format, size string) string {
 		case "avif":
 			return models.VariantTypeAVIF
 		}
+		return ""
 	}
 
-	return ""
-}
-
-// createImageVariants creates variant records in the database
-func createImageVariants(db *gorm.DB, imageModel *models.Image, hasWebp, hasAvif, hasThumbSmall, hasThumbMedium bool) error {
-	// Determine variants base directory based on storage pool structure
-	var variantsBaseDir string
-
-	if imageModel.StoragePoolID > 0 && imageModel.StoragePool != nil {
-		// Use storage pool base path
-		storagePoolBasePath := imageModel.StoragePool.BasePath
-
-		// Extract relative path from image FilePath (remove 'original/' prefix)
-		relativePath := strings.TrimPrefix(imageModel.FilePath, "original/")
-		relativePath = strings.TrimPrefix(relativePath, string(filepath.Separator))
-
-		variantsBaseDir = filepath.Join(storagePoolBasePath, "variants", relativePath)
-		log.Debugf("[ImageProcessor] Using storage pool variants base dir: %s", variantsBaseDir)
-	} else {
-		// Fallback to legacy structure
-		relativePath := strings.TrimPrefix(imageModel.FilePath, OriginalDir)
-		relativePath = strings.TrimPrefix(relativePath, string(filepath.Separator))
-		variantsBaseDir = filepath.Join(VariantsDir, relativePath)
-		log.Debugf("[ImageProcessor] Using legacy variants base dir: %s", variantsBaseDir)
-	}
-
-	baseFileName := imageModel.UUID
-
-	// Original variant is NO LONGER created here - original data is stored in the images table
-
-	// Create WebP variant if available
-	if hasWebp {
-		webpPath := filepath.Join(variantsBaseDir, baseFileName+".webp")
-		if fileInfo, err := os.Stat(webpPath); err == nil {
-			webpVariant := models.ImageVariant{
-				ImageID:       imageModel.ID,
-				StoragePoolID: imageModel.StoragePoolID,
-				VariantType:   models.VariantTypeWebP,
-				FilePath:      variantsBaseDir,
-				FileName:      baseFileName + ".webp",
-				FileType:      ".webp",
-				FileSize:      fileInfo.Size(),
-				Width:         imageModel.Width,
-				Height:        imageModel.Height,
-				Quality:       85,
-			}
-			if err := db.Create(&webpVariant).Error; err != nil {
-				log.Errorf("[ImageProcessor] Failed to create webp variant for %s: %v", imageModel.UUID, err)
-			}
-		}
-	}
-
-	// Create AVIF variant if available
-	if hasAvif {
-		avifPath := filepath.Join(variantsBaseDir, baseFileName+".avif")
-		if fileInfo, err := os.Stat(avifPath); err == nil {
-			avifVariant := models.ImageVariant{
-				ImageID:       imageModel.ID,
-				StoragePoolID: imageModel.StoragePoolID,
-				VariantType:   models.VariantTypeAVIF,
-				FilePath:      variantsBaseDir,
-				FileName:      baseFileName + ".avif",
-				FileType:      ".avif",
-				FileSize:      fileInfo.Size(),
-				Width:         imageModel.Width,
-				Height:        imageModel.Height,
-				Quality:       35,
-			}
-			if err := db.Create(&avifVariant).Error; err != nil {
-				log.Errorf("[ImageProcessor] Failed to create avif variant for %s: %v", imageModel.UUID, err)
-			}
-		}
-	}
-
-	// Create small thumbnail variants
-	if hasThumbSmall {
-		// Create WebP small thumbnail variant
-		smallWebpPath := filepath.Join(variantsBaseDir, baseFileName+"_small.webp")
-		if fileInfo, err := os.Stat(smallWebpPath); err == nil {
-			smallVariant := models.ImageVariant{
-				ImageID:       imageModel.ID,
-				StoragePoolID: imageModel.StoragePoolID,
-				VariantType:   models.VariantTypeThumbnailSmallWebP,
-				FilePath:      variantsBaseDir,
-				FileName:      baseFileName + "_small.webp",
-				FileType:      ".webp",
-				FileSize:      fileInfo.Size(),
-				Width:         SmallThumbnailSize,
-				Height:        calculateProportionalHeight(imageModel.Width, imageModel.Height, SmallThumbnailSize),
-				Quality:       85,
-			}
-			if err := db.Create(&smallVariant).Error; err != nil {
-				log.Errorf("[ImageProcessor] Failed to create small WebP thumbnail variant for %s: %v", imageModel.UUID, err)
-			}
-		}
-
-		// Create original format small thumbnail variant
-		smallOriginalPath := filepath.Join(variantsBaseDir, baseFileName+"_small"+imageModel.FileType)
-		if fileInfo, err := os.Stat(smallOriginalPath); err == nil {
-			smallOriginalVariant := models.ImageVariant{
-				ImageID:       imageModel.ID,
-				StoragePoolID: imageModel.StoragePoolID,
-				VariantType:   models.VariantTypeThumbnailSmallOrig,
-				FilePath:      variantsBaseDir,
-				FileName:      baseFileName + "_small" + imageModel.FileType,
-				FileType:      imageModel.FileType,
-				FileSize:      fileInfo.Size(),
-				Width:         SmallThumbnailSize,
-				Height:        calculateProportionalHeight(imageModel.Width, imageModel.Height, SmallThumbnailSize),
-				Quality:       90, // Higher quality for original format
-			}
-			if err := db.Create(&smallOriginalVariant).Error; err != nil {
-				log.Errorf("[ImageProcessor] Failed to create small original format thumbnail variant for %s: %v", imageModel.UUID, err)
-			}
-		}
-
-		// Create AVIF small thumbnail variant if it exists
-		smallAvifPath := filepath.Join(variantsBaseDir, baseFileName+"_small.avif")
-		if fileInfo, err := os.Stat(smallAvifPath); err == nil {
-			smallAvifVariant := models.ImageVariant{
-				ImageID:       imageModel.ID,
-				StoragePoolID: imageModel.StoragePoolID,
-				VariantType:   models.VariantTypeThumbnailSmallAVIF,
-				FilePath:      variantsBaseDir,
-				FileName:      baseFileName + "_small.avif",
-				FileType:      ".avif",
-				FileSize:      fileInfo.Size(),
-				Width:         SmallThumbnailSize,
-				Height:        calculateProportionalHeight(imageModel.Width, imageModel.Height, SmallThumbnailSize),
-				Quality:       35,
-			}
-			if err := db.Create(&smallAvifVariant).Error; err != nil {
-				log.Errorf("[ImageProcessor] Failed to create small AVIF thumbnail variant for %s: %v", imageModel.UUID, err)
-			}
-		}
-	}
-
-	// Create medium thumbnail variants
-	if hasThumbMedium {
-		// Create WebP medium thumbnail variant
-		mediumWebpPath := filepath.Join(variantsBaseDir, baseFileName+"_medium.webp")
-		if fileInfo, err := os.Stat(mediumWebpPath); err == nil {
-			mediumVariant := models.ImageVariant{
-				ImageID:       imageModel.ID,
-				StoragePoolID: imageModel.StoragePoolID,
-				VariantType:   models.VariantTypeThumbnailMediumWebP,
-				FilePath:      variantsBaseDir,
-				FileName:      baseFileName + "_medium.webp",
-				FileType:      ".webp",
-				FileSize:      fileInfo.Size(),
-				Width:         MediumThumbnailSize,
-				Height:        calculateProportionalHeight(imageModel.Width, imageModel.Height, MediumThumbnailSize),
-				Quality:       85,
-			}
-			if err := db.Create(&mediumVariant).Error; err != nil {
-				log.Errorf("[ImageProcessor] Failed to create medium WebP thumbnail variant for %s: %v", imageModel.UUID, err)
-			}
-		}
-
-		// Create original format medium thumbnail variant
-		mediumOriginalPath := filepath.Join(variantsBaseDir, baseFileName+"_medium"+imageModel.FileType)
-		if fileInfo, err := os.Stat(mediumOriginalPath); err == nil {
-			mediumOriginalVariant := models.ImageVariant{
-				ImageID:       imageModel.ID,
-				StoragePoolID: imageModel.StoragePoolID,
-				VariantType:   models.VariantTypeThumbnailMediumOrig,
-				FilePath:      variantsBaseDir,
-				FileName:      baseFileName + "_medium" + imageModel.FileType,
-				FileType:      imageModel.FileType,
-				FileSize:      fileInfo.Size(),
-				Width:         MediumThumbnailSize,
-				Height:        calculateProportionalHeight(imageModel.Width, imageModel.Height, MediumThumbnailSize),
-				Quality:       90, // Higher quality for original format
-			}
-			if err := db.Create(&mediumOriginalVariant).Error; err != nil {
-				log.Errorf("[ImageProcessor] Failed to create medium original format thumbnail variant for %s: %v", imageModel.UUID, err)
-			}
-		}
-
-		// Create AVIF medium thumbnail variant if it exists
-		mediumAvifPath := filepath.Join(variantsBaseDir, baseFileName+"_medium.avif")
-		if fileInfo, err := os.Stat(mediumAvifPath); err == nil {
-			mediumAvifVariant := models.ImageVariant{
-				ImageID:       imageModel.ID,
-				StoragePoolID: imageModel.StoragePoolID,
-				VariantType:   models.VariantTypeThumbnailMediumAVIF,
-				FilePath:      variantsBaseDir,
-				FileName:      baseFileName + "_medium.avif",
-				FileType:      ".avif",
-				FileSize:      fileInfo.Size(),
-				Width:         MediumThumbnailSize,
-				Height:        calculateProportionalHeight(imageModel.Width, imageModel.Height, MediumThumbnailSize),
-				Quality:       35,
-			}
-			if err := db.Create(&mediumAvifVariant).Error; err != nil {
-				log.Errorf("[ImageProcessor] Failed to create medium AVIF thumbnail variant for %s: %v", imageModel.UUID, err)
-			}
-		}
+	// Any other size names a variant profile directly, e.g. ("", "hero_webp")
+	if models.IsValidVariantProfileName(lowerSize) {
+		return lowerSize
 	}
-
-	log.Debugf("[ImageProcessor] Successfully created variant records for image %s", imageModel.UUID)
-	return nil
+	return ""
 }
 
-// calculateProportionalHeight calculates the proportional height for a given width
-func calculateProportionalHeight(originalWidth, originalHeight, newWidth int) int {
-	if originalWidth == 0 {
-		return newWidth // fallback
+// originalFileNames returns the stored original and, after an edit, the previous original
+func originalFileNames(imageModel *models.Image) []string {
+	names := []string{imageModel.FileName}
+	if imageModel.PreviousFileName != "" && imageModel.PreviousFileName != imageModel.FileName {
+		names = append(names, imageModel.PreviousFileName)
 	}
-	ratio := float64(newWidth) / float64(originalWidth)
-	return int(float64(originalHeight) * ratio)
+	return names
 }
 
 // DeleteImageAndVariants removes all physical files and database records for an image
@@ -1340,6 +1012,58 @@ func DeleteImageAndVariants(imageModel *models.Image) error {
 		}
 	}
 
+	deleteVariantFiles(db, sm, imageModel)
+
+	// Delete original file and the pre-edit original kept for undo
+	for _, fileName := range originalFileNames(imageModel) {
+		originalRelPath := filepath.Join(imageModel.FilePath, fileName)
+		if imageModel.StoragePoolID > 0 {
+			if _, err := sm.DeleteFile(originalRelPath, imageModel.StoragePoolID); err != nil {
+				log.Errorf("[ImageProcessor] Failed to delete original file %s from pool %d: %v", originalRelPath, imageModel.StoragePoolID, err)
+			}
+		} else if err := os.Remove(originalRelPath); err != nil && !os.IsNotExist(err) {
+			log.Errorf("[ImageProcessor] Failed to delete original file %s: %v", originalRelPath, err)
+		}
+	}
+
+	// Clean up empty local directories (not relevant for object storage)
+	if imageModel.StoragePoolID > 0 && imageModel.StoragePool != nil {
+		for _, relDir := range []string{variantsRelDir(imageModel), imageModel.FilePath} {
+			dir, ok := storage.LocalPath(imageModel.StoragePool, relDir)
+			if !ok {
+				break
+			}
+			if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+				log.Debugf("[ImageProcessor] Could not remove directory %s (may not be empty): %v", dir, err)
+			}
+		}
+	}
+
+	// Delete database records - variants first due to foreign key constraints
+	if err := db.Where("image_id = ?", imageModel.ID).Delete(&models.ImageVariant{}).Error; err != nil {
+		log.Errorf("[ImageProcessor] Failed to delete image variants from database for %s: %v", imageModel.UUID, err)
+		return fmt.Errorf("failed to delete image variants from database: %w", err)
+	}
+
+	// Delete metadata
+	if err := db.Where("image_id = ?", imageModel.ID).Delete(&models.ImageMetadata{}).Error; err != nil {
+		log.Errorf("[ImageProcessor] Failed to delete image metadata from database for %s: %v", imageModel.UUID, err)
+		// Don't return error here, continue with image deletion
+	}
+
+	// Delete the main image record
+	if err := db.Delete(imageModel).Error; err != nil {
+		log.Errorf("[ImageProcessor] Failed to delete image from database for %s: %v", imageModel.UUID, err)
+		return fmt.Errorf("failed to delete image from database: %w", err)
+	}
+
+	log.Infof("[ImageProcessor] Successfully deleted image %s and all variants", imageModel.UUID)
+	return nil
+}
+
+// deleteVariantFiles removes the files of all variants and cached transformations of an image.
+// The variant records are left to the caller, the derived variant records are deleted.
+func deleteVariantFiles(db *gorm.DB, sm *storage.StorageManager, imageModel *models.Image) {
 	// Get all variants for this image
 	variants, err := models.FindVariantsByImageID(db, imageModel.ID)
 	if err != nil {
@@ -1387,54 +1111,21 @@ func DeleteImageAndVariants(imageModel *models.Image) error {
 		}
 	}
 
-	// Delete original file
-	if imageModel.StoragePoolID > 0 {
-		originalRelPath := filepath.Join(imageModel.FilePath, imageModel.FileName)
-		if _, err := sm.DeleteFile(originalRelPath, imageModel.StoragePoolID); err != nil {
-			log.Errorf("[ImageProcessor] Failed to delete original file %s from pool %d: %v", originalRelPath, imageModel.StoragePoolID, err)
-		}
+	// Delete cached on-the-fly transformations
+	if derived, err := models.FindDerivedVariantsByImageID(db, imageModel.ID); err != nil {
+		log.Errorf("[ImageProcessor] Failed to find derived variants for image %s: %v", imageModel.UUID, err)
 	} else {
-		legacyOriginal := filepath.Join(imageModel.FilePath, imageModel.FileName)
-		if err := os.Remove(legacyOriginal); err != nil && !os.IsNotExist(err) {
-			log.Errorf("[ImageProcessor] Failed to delete original file %s: %v", legacyOriginal, err)
-		}
-	}
-
-	// Clean up empty local directories (not relevant for S3 pools)
-	if imageModel.StoragePoolID > 0 && imageModel.StoragePool != nil && !imageModel.StoragePool.IsS3Storage() {
-		relativePath := strings.TrimPrefix(imageModel.FilePath, "original/")
-		relativePath = strings.TrimPrefix(relativePath, string(filepath.Separator))
-		variantsDir := filepath.Join(imageModel.StoragePool.BasePath, "variants", relativePath)
-		if err := os.Remove(variantsDir); err != nil && !os.IsNotExist(err) {
-			log.Debugf("[ImageProcessor] Could not remove variants directory %s (may not be empty): %v", variantsDir, err)
-		}
-
-		originalDir := filepath.Join(imageModel.StoragePool.BasePath, imageModel.FilePath)
-		if err := os.Remove(originalDir); err != nil && !os.IsNotExist(err) {
-			log.Debugf("[ImageProcessor] Could not remove original directory %s (may not be empty): %v", originalDir, err)
+		for i := range derived {
+			if err := DeleteDerivedVariant(db, loadPool(derived[i].StoragePoolID), &derived[i]); err != nil {
+				log.Errorf("[ImageProcessor] Failed to delete derived variant %s of image %s: %v", derived[i].Spec, imageModel.UUID, err)
+			}
 		}
 	}
+}
 
-	// Delete database records - variants first due to foreign key constraints
-	if err := db.Where("image_id = ?", imageModel.ID).Delete(&models.ImageVariant{}).Error; err != nil {
-		log.Errorf("[ImageProcessor] Failed to delete image variants from database for %s: %v", imageModel.UUID, err)
-		return fmt.Errorf("failed to delete image variants from database: %w", err)
-	}
-
-	// Delete metadata
-	if err := db.Where("image_id = ?", imageModel.ID).Delete(&models.ImageMetadata{}).Error; err != nil {
-		log.Errorf("[ImageProcessor] Failed to delete image metadata from database for %s: %v", imageModel.UUID, err)
-		// Don't return error here, continue with image deletion
-	}
-
-	// Delete the main image record
-	if err := db.Delete(imageModel).Error; err != nil {
-		log.Errorf("[ImageProcessor] Failed to delete image from database for %s: %v", imageModel.UUID, err)
-		return fmt.Errorf("failed to delete image from database: %w", err)
-	}
-
-	log.Infof("[ImageProcessor] Successfully deleted image %s and all variants", imageModel.UUID)
-	return nil
+// VariantRelativePath returns the path of a variant's file within its storage pool
+func VariantRelativePath(variant *models.ImageVariant, pool *models.StoragePool) string {
+	return resolveVariantRelativePath(variant.FilePath, variant.FileName, pool)
 }
 
 func resolveVariantRelativePath(filePath, fileName string, pool *models.StoragePool) string {
@@ -1447,7 +1138,7 @@ func resolveVariantRelativePath(filePath, fileName string, pool *models.StorageP
 		return path.Join(rel[idx:], strings.TrimSpace(fileName))
 	}
 
-	if pool != nil && !pool.IsS3Storage() {
+	if pool != nil && pool.BasePath != "" {
 		base := filepath.ToSlash(strings.TrimRight(pool.BasePath, string(filepath.Separator)))
 		if base != "" && strings.HasPrefix(rel, base+"/") {
 			trimmed := strings.TrimPrefix(rel, base+"/")
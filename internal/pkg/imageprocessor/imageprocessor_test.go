@@ -117,22 +117,30 @@ func (m *mockCache) delete(key string) error {
 }
 
 // Mock-Implementierung von updateImageRecord
-func (m *mockCache) updateImageRecord(imageModel *models.Image, width, height int, hasWebp, hasAvif, hasThumbSmall, hasThumbMedium bool) error {
+func (m *mockCache) updateImageRecord(imageModel *models.Image, width, height int, generated []imageprocessor.GeneratedVariant) error {
 	m.mux.Lock()
 	defer m.mux.Unlock()
 	m.updateRecordCalls++
 
-	// Aktualisiere das Image-Model direkt
+	// Aktualisiere das Image-Model direkt anhand der erzeugten Profile
 	imageModel.Width = width
 	imageModel.Height = height
-	imageModel.HasWebp = hasWebp
-	imageModel.HasAVIF = hasAvif
-	imageModel.HasThumbnailSmall = hasThumbSmall
-	imageModel.HasThumbnailMedium = hasThumbMedium
+	for _, gv := range generated {
+		switch {
+		case gv.Profile.Name == models.VariantTypeWebP:
+			imageModel.HasWebp = true
+		case gv.Profile.Name == models.VariantTypeAVIF:
+			imageModel.HasAVIF = true
+		case strings.HasPrefix(gv.Profile.Name, "thumbnail_small_"):
+			imageModel.HasThumbnailSmall = true
+		case strings.HasPrefix(gv.Profile.Name, "thumbnail_medium_"):
+			imageModel.HasThumbnailMedium = true
+		}
+	}
 
 	// Protokollieren
 	fmt.Printf("[MockDB] Updated image %s: w=%d, h=%d, webp=%v, avif=%v, thumbS=%v, thumbM=%v\n",
-		imageModel.UUID, width, height, hasWebp, hasAvif, hasThumbSmall, hasThumbMedium)
+		imageModel.UUID, width, height, imageModel.HasWebp, imageModel.HasAVIF, imageModel.HasThumbnailSmall, imageModel.HasThumbnailMedium)
 
 	return nil
 }
@@ -1,6 +1,7 @@
 package imageprocessor
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -38,6 +39,10 @@ func ExtractMetadata(image *models.Image, filePath string) error {
 
 	// Try to decode EXIF data
 	x, err := exif.Decode(f)
+	if err != nil {
+		// HEIF and JPEG XL keep the EXIF TIFF structure in an item or box of their container
+		x, err = decodeEmbeddedExif(filePath)
+	}
 	if err != nil {
 		// Some images don't have EXIF data, this is not a critical error
 		log.Info(fmt.Sprintf("No EXIF data found for image %s: %v", image.UUID, err))
@@ -136,3 +141,19 @@ func ExtractMetadata(image *models.Image, filePath string) error {
 
 	return nil
 }
+
+// decodeEmbeddedExif decodes the EXIF data stored in the container of HEIF and JPEG XL files
+func decodeEmbeddedExif(filePath string) (*exif.Exif, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 12 || (string(data[4:8]) != "ftyp" && !bytes.HasPrefix(data, jxlContainerSignature)) {
+		return nil, fmt.Errorf("no container with embedded EXIF data")
+	}
+	offset := embeddedTIFFOffset(data)
+	if offset < 0 {
+		return nil, fmt.Errorf("no embedded EXIF data found")
+	}
+	return exif.Decode(bytes.NewReader(data[offset:]))
+}
@@ -0,0 +1,172 @@
+package imageprocessor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/gofiber/fiber/v2/log"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+)
+
+// pngquantQuality is the quality range pngquant may use for original-format variants.
+// Variants that would drop below it are left untouched.
+const pngquantQuality = "80-100"
+
+// pngquant exit codes for files it deliberately did not write (--skip-if-larger, --quality)
+const (
+	pngquantExitSkippedLarger = 98
+	pngquantExitQualityTooLow = 99
+)
+
+// checkToolAvailable checks if an optional command line tool is available in the system's PATH
+func checkToolAvailable(name string) bool {
+	if _, err := exec.LookPath(name); err != nil {
+		log.Infof("[ImageProcessor] '%s' not found in PATH, using the built-in optimisation instead", name)
+		return false
+	}
+	return true
+}
+
+// optimizationSettings reports whether the optimisation pass is enabled for variants and originals
+func optimizationSettings() (variants bool, originals bool) {
+	settings := models.GetAppSettings()
+	if settings == nil || !settings.IsOptimizationEnabled() {
+		return false, false
+	}
+	return true, settings.IsOriginalOptimizationEnabled()
+}
+
+// OptimizeVariantFile shrinks an original-format variant written by PixelFox in place and returns
+// the bytes saved. PNGs go through pngquant, or a pure-Go maximum-compression pass when it is
+// missing; JPEGs are optimised losslessly by jpegoptim. Files only ever get smaller.
+func OptimizeVariantFile(path, fileType string) (int64, error) {
+	return optimizeFile(path, fileType, false)
+}
+
+// OptimizeOriginalFile optimises a stored original in place and returns the bytes saved. Only lossless
+// steps that keep all metadata run on originals: jpegoptim for JPEGs; other formats are left untouched.
+func OptimizeOriginalFile(path, fileType string) (int64, error) {
+	return optimizeFile(path, fileType, true)
+}
+
+// optimizeGeneratedVariants runs the optimisation pass on the original-format variants of an image
+// and returns the bytes saved. WebP and AVIF variants are already encoded by quality.
+func optimizeGeneratedVariants(imageModel *models.Image, variantsBaseDir string, generated []GeneratedVariant) int64 {
+	var saved int64
+	for _, gv := range generated {
+		if gv.Profile.Format != models.VariantFormatOriginal {
+			continue
+		}
+		n, err := OptimizeVariantFile(filepath.Join(variantsBaseDir, gv.FileName), filepath.Ext(gv.FileName))
+		if err != nil {
+			log.Warnf("[ImageProcessor] Could not optimise variant %s of %s: %v", gv.Profile.Name, imageModel.UUID, err)
+			continue
+		}
+		saved += n
+	}
+	return saved
+}
+
+func optimizeFile(path, fileType string, original bool) (int64, error) {
+	before, err := os.Stat(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat '%s' for optimisation: %w", path, err)
+	}
+
+	switch normalizeFileType(fileType) {
+	case ".jpg", ".jpeg":
+		if !IsJPEGOptimAvailable {
+			return 0, nil
+		}
+		// Without --max jpegoptim only rewrites the Huffman tables: lossless, markers are kept
+		err = runOptimizer("jpegoptim", "--quiet", "--preserve", "--", path)
+	case ".png":
+		switch {
+		case original:
+			return 0, nil
+		case IsPNGQuantAvailable:
+			err = runPNGQuant(path)
+		default:
+			err = recompressPNG(path)
+		}
+	default:
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	after, err := os.Stat(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat optimised '%s': %w", path, err)
+	}
+	return max(before.Size()-after.Size(), 0), nil
+}
+
+// runOptimizer runs an external optimisation tool
+func runOptimizer(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w, stderr: %s", name, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return nil
+}
+
+// runPNGQuant quantises a PNG in place; skipped files are not an error
+func runPNGQuant(path string) error {
+	err := runOptimizer("pngquant", "--force", "--skip-if-larger", "--strip", "--quality="+pngquantQuality, "--ext", ".png", "--", path)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		switch exitErr.ExitCode() {
+		case pngquantExitSkippedLarger, pngquantExitQualityTooLow:
+			return nil
+		}
+	}
+	return err
+}
+
+// recompressPNG is the pure-Go fallback for pngquant: it re-encodes the pixels losslessly with
+// the best zlib compression and replaces the file only if the result is smaller
+func recompressPNG(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read '%s': %w", path, err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to decode '%s': %w", path, err)
+	}
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := encoder.Encode(&buf, img); err != nil {
+		return fmt.Errorf("failed to encode '%s': %w", path, err)
+	}
+	if buf.Len() >= len(data) {
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".optimize-*.png")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for '%s': %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write optimised '%s': %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write optimised '%s': %w", path, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to set permissions of optimised '%s': %w", path, err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,101 @@
+package imageprocessor_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+)
+
+// writeUncompressedPNG writes a gradient PNG without zlib compression
+func writeUncompressedPNG(t *testing.T, path string) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x * 4), G: uint8(y * 4), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.NoCompression}
+	require.NoError(t, encoder.Encode(&buf, img))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+	return img
+}
+
+func TestOptimizeVariantFilePNGFallback(t *testing.T) {
+	prev := imageprocessor.IsPNGQuantAvailable
+	imageprocessor.IsPNGQuantAvailable = false
+	t.Cleanup(func() { imageprocessor.IsPNGQuantAvailable = prev })
+
+	path := filepath.Join(t.TempDir(), "variant.png")
+	src := writeUncompressedPNG(t, path)
+	before, err := os.Stat(path)
+	require.NoError(t, err)
+
+	saved, err := imageprocessor.OptimizeVariantFile(path, ".png")
+	require.NoError(t, err)
+	assert.Greater(t, saved, int64(0))
+
+	after, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, before.Size()-saved, after.Size())
+
+	// the fallback is lossless
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	out, err := png.Decode(f)
+	require.NoError(t, err)
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			assert.Equal(t, src.NRGBAAt(x, y), color.NRGBAModel.Convert(out.At(x, y)))
+		}
+	}
+
+	// a second pass finds nothing left to save
+	saved, err = imageprocessor.OptimizeVariantFile(path, ".png")
+	require.NoError(t, err)
+	assert.Zero(t, saved)
+}
+
+func TestOptimizeOriginalFileLeavesPNGUntouched(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "original.png")
+	writeUncompressedPNG(t, path)
+	before, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	saved, err := imageprocessor.OptimizeOriginalFile(path, ".png")
+	require.NoError(t, err)
+	assert.Zero(t, saved)
+
+	after, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+}
+
+func TestOptimizeJPEGWithoutJPEGOptim(t *testing.T) {
+	prev := imageprocessor.IsJPEGOptimAvailable
+	imageprocessor.IsJPEGOptimAvailable = false
+	t.Cleanup(func() { imageprocessor.IsJPEGOptimAvailable = prev })
+
+	path := filepath.Join(t.TempDir(), "variant.jpg")
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 16, 16)), &jpeg.Options{Quality: 100}))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+
+	saved, err := imageprocessor.OptimizeVariantFile(path, ".jpg")
+	require.NoError(t, err)
+	assert.Zero(t, saved)
+
+	_, err = imageprocessor.OptimizeVariantFile(filepath.Join(t.TempDir(), "missing.jpg"), ".jpg")
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+package imageprocessor
+
+import (
+	"fmt"
+	"image"
+	"math/bits"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+)
+
+// PerceptualHash returns the 64 bit difference hash (dHash) of an image. The image is reduced to
+// 9x8 grayscale pixels and every bit records whether a pixel is brighter than its right neighbour,
+// so resized or re-encoded copies end up within a small Hamming distance of each other.
+func PerceptualHash(img image.Image) uint64 {
+	small := imaging.Resize(imaging.Grayscale(img), 9, 8, imaging.Box)
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		row := small.Pix[y*small.Stride:]
+		for x := 0; x < 8; x++ {
+			hash <<= 1
+			// Grayscale stores the luminance in every colour channel, R is enough
+			if row[x*4] > row[(x+1)*4] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// HashDistance returns the Hamming distance of two perceptual hashes (0 = identical, 64 = inverted)
+func HashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// setPerceptualHash stores the hash of the decoded original on the model
+func setPerceptualHash(imageModel *models.Image, img image.Image) {
+	if img == nil {
+		return
+	}
+	hash := PerceptualHash(img)
+	imageModel.PerceptualHash = &hash
+}
+
+// ComputeImagePerceptualHash decodes the original of an existing image and stores its perceptual hash
+func ComputeImagePerceptualHash(imageModel *models.Image) error {
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	img, err := decodeStoredOriginal(imageModel)
+	if err != nil {
+		return err
+	}
+
+	setPerceptualHash(imageModel, img)
+	if err := db.Model(&models.Image{}).Where("id = ?", imageModel.ID).
+		UpdateColumn("perceptual_hash", *imageModel.PerceptualHash).Error; err != nil {
+		return fmt.Errorf("failed to store perceptual hash of image %d: %w", imageModel.ID, err)
+	}
+	if err := models.SaveImageHashBands(db, imageModel.ID, imageModel.UserID, imageModel.PerceptualHash); err != nil {
+		return fmt.Errorf("failed to store perceptual hash bands of image %d: %w", imageModel.ID, err)
+	}
+	return nil
+}
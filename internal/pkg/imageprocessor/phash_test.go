@@ -0,0 +1,37 @@
+package imageprocessor_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+)
+
+func TestPerceptualHash(t *testing.T) {
+	big, err := imaging.Open(filepath.Join("testdata", "image-big.jpg"))
+	require.NoError(t, err)
+	other, err := imaging.Open(filepath.Join("testdata", "image.png"))
+	require.NoError(t, err)
+
+	hash := imageprocessor.PerceptualHash(big)
+	assert.Equal(t, hash, imageprocessor.PerceptualHash(big), "hash must be deterministic")
+
+	// Resized and brightened copies stay close to the original
+	resized := imaging.Resize(big, 320, 0, imaging.Lanczos)
+	assert.LessOrEqual(t, imageprocessor.HashDistance(hash, imageprocessor.PerceptualHash(resized)), 4)
+	brighter := imaging.AdjustBrightness(big, 10)
+	assert.LessOrEqual(t, imageprocessor.HashDistance(hash, imageprocessor.PerceptualHash(brighter)), 4)
+
+	// A different image is far away
+	assert.Greater(t, imageprocessor.HashDistance(hash, imageprocessor.PerceptualHash(other)), 16)
+}
+
+func TestHashDistance(t *testing.T) {
+	assert.Equal(t, 0, imageprocessor.HashDistance(0xF0F0, 0xF0F0))
+	assert.Equal(t, 4, imageprocessor.HashDistance(0xF0F0, 0xF0FF))
+	assert.Equal(t, 64, imageprocessor.HashDistance(0, ^uint64(0)))
+}
@@ -0,0 +1,648 @@
+package imageprocessor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+)
+
+// errMalformedExif is returned when the EXIF block cannot be walked safely
+var errMalformedExif = errors.New("malformed EXIF data")
+
+// exifHeader prefixes the TIFF structure in JPEG APP1 segments
+var exifHeader = []byte("Exif\x00\x00")
+
+// xmpHeader prefixes XMP packets in JPEG APP1 segments (including extended XMP)
+var xmpHeader = []byte("http://ns.adobe.com/xap/1.0/")
+
+// StripMetadata rewrites the file according to the metadata policy and reports whether it changed.
+// JPEG, PNG and WebP are supported; other formats are left untouched. The EXIF orientation is
+// kept when stripping all metadata so the original is still displayed upright.
+// TIFF, HEIF/AVIF and JPEG XL files keep their layout: the GPS (and for strip_all the EXIF)
+// sub-IFD and the XMP packet are zeroed or blanked in place, the camera make and model in IFD0 remain.
+func StripMetadata(filePath, policy string) (bool, error) {
+	if policy != models.MetadataPolicyStripLocation && policy != models.MetadataPolicyStripAll {
+		return false, nil
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return false, err
+	}
+
+	var out []byte
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8}):
+		out, err = stripJPEGMetadata(data, policy)
+	case bytes.HasPrefix(data, pngSignature):
+		out, err = stripPNGMetadata(data, policy)
+	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		out, err = stripWebPMetadata(data, policy)
+	case isTIFFHeader(data):
+		out = append([]byte(nil), data...)
+		err = stripTIFFInPlace(out, policy)
+	case len(data) >= 12 && (string(data[4:8]) == "ftyp" || bytes.HasPrefix(data, jxlContainerSignature)):
+		out = append([]byte(nil), data...)
+		if offset := embeddedTIFFOffset(out); offset >= 0 {
+			err = stripTIFFInPlace(out[offset:], policy)
+		}
+		blankXMPPackets(out)
+	default:
+		log.Debugf("[ImageProcessor] Metadata stripping not supported for %s", filepath.Base(filePath))
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(out, data) {
+		return false, nil
+	}
+
+	// Write next to the original and rename so a crash never leaves a truncated file
+	tmpPath := filePath + ".strip"
+	if err := os.WriteFile(tmpPath, out, 0644); err != nil {
+		_ = os.Remove(tmpPath)
+		return false, fmt.Errorf("failed to write stripped file: %w", err)
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		_ = os.Remove(tmpPath)
+		return false, fmt.Errorf("failed to replace original: %w", err)
+	}
+	return true, nil
+}
+
+// stripJPEGMetadata drops or rewrites the metadata segments in front of the image data
+func stripJPEGMetadata(data []byte, policy string) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[0:2]...)
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil, errors.New("invalid JPEG segment marker")
+		}
+		marker := data[pos+1]
+		if marker == 0xFF { // fill byte
+			pos++
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 { // start of scan / end of image: copy the rest verbatim
+			break
+		}
+		size := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		end := pos + 2 + size
+		if size < 2 || end > len(data) {
+			return nil, errors.New("truncated JPEG segment")
+		}
+		segment := data[pos:end]
+		payload := data[pos+4 : end]
+		pos = end
+
+		switch {
+		case marker == 0xE1 && bytes.HasPrefix(payload, exifHeader):
+			tiff := append([]byte(nil), payload[len(exifHeader):]...)
+			if policy == models.MetadataPolicyStripAll {
+				if orientation := exifOrientation(tiff); orientation > 1 {
+					out = appendJPEGSegment(out, 0xE1, append(append([]byte(nil), exifHeader...), orientationOnlyTIFF(orientation)...))
+				}
+				continue
+			}
+			if _, err := removeGPSFromTIFF(tiff); err != nil {
+				log.Warnf("[ImageProcessor] Dropping unreadable EXIF block: %v", err)
+				continue
+			}
+			out = appendJPEGSegment(out, 0xE1, append(append([]byte(nil), exifHeader...), tiff...))
+		case marker == 0xE1 && bytes.HasPrefix(payload, xmpHeader):
+			// XMP may repeat the GPS position, so it is dropped for both policies
+			continue
+		case policy == models.MetadataPolicyStripAll && (marker == 0xE1 || marker == 0xEC || marker == 0xED || marker == 0xFE):
+			// Other APP1, Ducky (APP12), IPTC/Photoshop (APP13) and comments
+			continue
+		default:
+			out = append(out, segment...)
+		}
+	}
+	return append(out, data[pos:]...), nil
+}
+
+func appendJPEGSegment(out []byte, marker byte, payload []byte) []byte {
+	size := len(payload) + 2
+	return append(append(out, 0xFF, marker, byte(size>>8), byte(size)), payload...)
+}
+
+var pngSignature = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}
+
+// stripPNGMetadata drops or rewrites the eXIf and text chunks of a PNG
+func stripPNGMetadata(data []byte, policy string) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	pos := len(pngSignature)
+	for pos+12 <= len(data) {
+		size := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		end := pos + 12 + size
+		if end > len(data) {
+			return nil, errors.New("truncated PNG chunk")
+		}
+		kind := string(data[pos+4 : pos+8])
+		body := data[pos+8 : pos+8+size]
+		chunk := data[pos:end]
+		pos = end
+
+		switch kind {
+		case "eXIf":
+			tiff := append([]byte(nil), body...)
+			if policy == models.MetadataPolicyStripAll {
+				if orientation := exifOrientation(tiff); orientation > 1 {
+					out = appendPNGChunk(out, kind, orientationOnlyTIFF(orientation))
+				}
+				continue
+			}
+			if _, err := removeGPSFromTIFF(tiff); err != nil {
+				log.Warnf("[ImageProcessor] Dropping unreadable EXIF chunk: %v", err)
+				continue
+			}
+			out = appendPNGChunk(out, kind, tiff)
+		case "iTXt":
+			if policy == models.MetadataPolicyStripAll || bytes.HasPrefix(body, []byte("XML:com.adobe.xmp\x00")) {
+				continue
+			}
+			out = append(out, chunk...)
+		case "tEXt", "zTXt", "tIME":
+			if policy == models.MetadataPolicyStripAll {
+				continue
+			}
+			out = append(out, chunk...)
+		default:
+			out = append(out, chunk...)
+		}
+		if kind == "IEND" {
+			break
+		}
+	}
+	return out, nil
+}
+
+func appendPNGChunk(out []byte, kind string, body []byte) []byte {
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[0:4], uint32(len(body)))
+	copy(header[4:8], kind)
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:8])
+	crc.Write(body)
+	out = append(append(out, header[:]...), body...)
+	return binary.BigEndian.AppendUint32(out, crc.Sum32())
+}
+
+// stripWebPMetadata drops or rewrites the EXIF and XMP chunks of a WebP and fixes the VP8X flags
+func stripWebPMetadata(data []byte, policy string) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[0:12]...)
+	vp8xFlags := -1
+	keptExif := false
+	pos := 12
+	for pos+8 <= len(data) {
+		kind := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		end := pos + 8 + size + size%2
+		if end > len(data) {
+			if pos+8+size > len(data) {
+				return nil, errors.New("truncated WebP chunk")
+			}
+			end = len(data) // missing padding byte at the end of the file
+		}
+		body := data[pos+8 : pos+8+size]
+		chunk := data[pos:end]
+		pos = end
+
+		switch kind {
+		case "EXIF":
+			if policy == models.MetadataPolicyStripAll {
+				continue
+			}
+			tiff := append([]byte(nil), body...)
+			// Some encoders store the JPEG-style "Exif\0\0" prefix in front of the TIFF header
+			offset := 0
+			if bytes.HasPrefix(tiff, exifHeader) {
+				offset = len(exifHeader)
+			}
+			if _, err := removeGPSFromTIFF(tiff[offset:]); err != nil {
+				log.Warnf("[ImageProcessor] Dropping unreadable EXIF chunk: %v", err)
+				continue
+			}
+			out = appendWebPChunk(out, kind, tiff)
+			keptExif = true
+		case "XMP ":
+			continue
+		case "VP8X":
+			if size >= 1 {
+				vp8xFlags = len(out) + 8
+			}
+			out = append(out, chunk...)
+		default:
+			out = append(out, chunk...)
+		}
+	}
+	if vp8xFlags >= 0 {
+		out[vp8xFlags] &^= 0x04 // XMP
+		if !keptExif {
+			out[vp8xFlags] &^= 0x08 // EXIF
+		}
+	}
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+	return out, nil
+}
+
+func appendWebPChunk(out []byte, kind string, body []byte) []byte {
+	out = append(out, kind...)
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(body)))
+	out = append(out, body...)
+	if len(body)%2 == 1 {
+		out = append(out, 0)
+	}
+	return out
+}
+
+// tiffReader walks the IFDs of an EXIF TIFF structure in either byte order
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func newTIFFReader(data []byte) (*tiffReader, uint32, error) {
+	if len(data) < 8 {
+		return nil, 0, errMalformedExif
+	}
+	var order binary.ByteOrder
+	switch string(data[0:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, 0, errMalformedExif
+	}
+	if order.Uint16(data[2:4]) != 42 {
+		return nil, 0, errMalformedExif
+	}
+	return &tiffReader{data: data, order: order}, order.Uint32(data[4:8]), nil
+}
+
+// entries returns the number of entries of the IFD at offset after checking that it fits
+func (t *tiffReader) entries(offset uint32) (int, error) {
+	if uint64(offset)+2 > uint64(len(t.data)) {
+		return 0, errMalformedExif
+	}
+	n := int(t.order.Uint16(t.data[offset : offset+2]))
+	if uint64(offset)+2+uint64(n)*12+4 > uint64(len(t.data)) {
+		return 0, errMalformedExif
+	}
+	return n, nil
+}
+
+// tiffTypeSizes maps the TIFF field types to their size in bytes
+var tiffTypeSizes = map[uint16]uint64{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8}
+
+const (
+	tiffTagOrientation = 0x0112
+	tiffTagExifIFD     = 0x8769
+	tiffTagGPSInfo     = 0x8825
+	tiffTagXMP         = 0x02BC
+)
+
+// exifOrientation returns the orientation tag of IFD0 or 0 if it is missing
+func exifOrientation(data []byte) int {
+	t, ifd0, err := newTIFFReader(data)
+	if err != nil {
+		return 0
+	}
+	n, err := t.entries(ifd0)
+	if err != nil {
+		return 0
+	}
+	for i := 0; i < n; i++ {
+		entry := t.data[ifd0+2+uint32(i)*12:]
+		if t.order.Uint16(entry[0:2]) == tiffTagOrientation && t.order.Uint16(entry[2:4]) == 3 {
+			return int(t.order.Uint16(entry[8:10]))
+		}
+	}
+	return 0
+}
+
+// orientationOnlyTIFF builds a minimal little-endian TIFF structure holding only the orientation
+func orientationOnlyTIFF(orientation int) []byte {
+	data := []byte{'I', 'I', 42, 0, 8, 0, 0, 0, 1, 0}
+	data = binary.LittleEndian.AppendUint16(data, tiffTagOrientation)
+	data = binary.LittleEndian.AppendUint16(data, 3) // SHORT
+	data = binary.LittleEndian.AppendUint32(data, 1)
+	data = binary.LittleEndian.AppendUint16(data, uint16(orientation))
+	data = append(data, 0, 0)
+	return binary.LittleEndian.AppendUint32(data, 0) // no next IFD
+}
+
+// removeGPSFromTIFF removes the GPS IFD pointer from IFD0 and zeroes the GPS IFD including its
+// values in place, so all other offsets stay valid. It reports whether GPS data was present.
+func removeGPSFromTIFF(data []byte) (bool, error) {
+	return removeSubIFDFromTIFF(data, tiffTagGPSInfo)
+}
+
+// removeSubIFDFromTIFF removes the pointer tag of a sub-IFD from IFD0 and zeroes the sub-IFD in place
+func removeSubIFDFromTIFF(data []byte, tag uint16) (bool, error) {
+	return removeTagFromTIFF(data, tag, func(t *tiffReader, entry []byte) error {
+		return t.zeroIFD(t.order.Uint32(entry[8:12]))
+	})
+}
+
+// removeXMPFromTIFF removes the XMP packet (tag 700) from IFD0 and zeroes its value in place
+func removeXMPFromTIFF(data []byte) (bool, error) {
+	return removeTagFromTIFF(data, tiffTagXMP, func(t *tiffReader, entry []byte) error {
+		return t.zeroValue(entry)
+	})
+}
+
+// removeTagFromTIFF removes a tag from IFD0 after zero has cleared the data the entry references
+func removeTagFromTIFF(data []byte, tag uint16, zero func(t *tiffReader, entry []byte) error) (bool, error) {
+	t, ifd0, err := newTIFFReader(data)
+	if err != nil {
+		return false, err
+	}
+	n, err := t.entries(ifd0)
+	if err != nil {
+		return false, err
+	}
+	for i := 0; i < n; i++ {
+		entryOffset := ifd0 + 2 + uint32(i)*12
+		entry := t.data[entryOffset : entryOffset+12]
+		if t.order.Uint16(entry[0:2]) != tag {
+			continue
+		}
+		if err := zero(t, entry); err != nil {
+			return false, err
+		}
+		// Shift the following entries and the next-IFD offset up by one entry
+		tableEnd := ifd0 + 2 + uint32(n)*12 + 4
+		copy(t.data[entryOffset:], t.data[entryOffset+12:tableEnd])
+		clear(t.data[tableEnd-12 : tableEnd])
+		t.order.PutUint16(t.data[ifd0:ifd0+2], uint16(n-1))
+		return true, nil
+	}
+	return false, nil
+}
+
+// isTIFFHeader reports whether data starts with a TIFF header in either byte order
+func isTIFFHeader(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("II*\x00")) || bytes.HasPrefix(data, []byte("MM\x00*"))
+}
+
+// jxlContainerSignature starts JPEG XL files using the ISO BMFF based container
+var jxlContainerSignature = []byte("\x00\x00\x00\x0cJXL \r\n\x87\n")
+
+// embeddedTIFFOffset returns the offset of the EXIF TIFF structure HEIF stores in its "Exif" item
+// and JPEG XL in its "Exif" box, or -1 if there is none
+func embeddedTIFFOffset(data []byte) int {
+	for start := 0; start < len(data); {
+		i := bytes.Index(data[start:], []byte("Exif"))
+		if i < 0 {
+			return -1
+		}
+		i += start + 4
+		// HEIF items continue with "\0\0", JPEG XL boxes with a 4 byte header offset
+		for j := i; j <= i+8 && j+4 <= len(data); j++ {
+			if isTIFFHeader(data[j:]) {
+				return j
+			}
+		}
+		start = i
+	}
+	return -1
+}
+
+// stripTIFFInPlace zeroes the GPS sub-IFD, the XMP packet and, for strip_all, the EXIF sub-IFD of a TIFF structure
+func stripTIFFInPlace(data []byte, policy string) error {
+	if _, err := removeGPSFromTIFF(data); err != nil {
+		return err
+	}
+	// XMP may repeat the GPS position, so it is dropped for both policies
+	if _, err := removeXMPFromTIFF(data); err != nil {
+		return err
+	}
+	if policy == models.MetadataPolicyStripAll {
+		if _, err := removeSubIFDFromTIFF(data, tiffTagExifIFD); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// xmpPacketMarkers delimit XMP packets, with or without the optional xpacket wrapper
+var xmpPacketMarkers = []struct{ begin, end, tail []byte }{
+	{[]byte("<?xpacket begin="), []byte("<?xpacket end="), []byte("?>")},
+	{[]byte("<x:xmpmeta"), []byte("</x:xmpmeta>"), nil},
+}
+
+// blankXMPPackets overwrites every XMP packet with spaces, keeping the file layout. HEIF stores
+// XMP as "mime" item and JPEG XL as "xml " box; both hold the packet uncompressed, and XML
+// whitespace keeps item and box sizes valid. It reports whether a packet was found.
+func blankXMPPackets(data []byte) bool {
+	found := false
+	for _, m := range xmpPacketMarkers {
+		for start := 0; start < len(data); {
+			i := bytes.Index(data[start:], m.begin)
+			if i < 0 {
+				break
+			}
+			i += start
+			j := bytes.Index(data[i:], m.end)
+			if j < 0 {
+				break
+			}
+			end := i + j + len(m.end)
+			if m.tail != nil {
+				if k := bytes.Index(data[end:], m.tail); k >= 0 {
+					end += k + len(m.tail)
+				}
+			}
+			for p := i; p < end; p++ {
+				data[p] = ' '
+			}
+			found = true
+			start = end
+		}
+	}
+	return found
+}
+
+// zeroValue overwrites the value an IFD entry references when it does not fit into the entry
+func (t *tiffReader) zeroValue(entry []byte) error {
+	size := tiffTypeSizes[t.order.Uint16(entry[2:4])] * uint64(t.order.Uint32(entry[4:8]))
+	if size <= 4 {
+		return nil
+	}
+	valueOffset := uint64(t.order.Uint32(entry[8:12]))
+	if valueOffset+size > uint64(len(t.data)) {
+		return errMalformedExif
+	}
+	clear(t.data[valueOffset : valueOffset+size])
+	return nil
+}
+
+// zeroIFD overwrites an IFD and all values it references with zeros
+func (t *tiffReader) zeroIFD(offset uint32) error {
+	n, err := t.entries(offset)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		if err := t.zeroValue(t.data[offset+2+uint32(i)*12:]); err != nil {
+			return err
+		}
+	}
+	clear(t.data[offset : offset+2+uint32(n)*12+4])
+	return nil
+}
+
+// resolveMetadataPolicy returns the policy chosen at upload or the owner's current setting
+func resolveMetadataPolicy(db *gorm.DB, imageModel *models.Image) string {
+	if models.IsValidMetadataPolicy(imageModel.MetadataPolicy) {
+		return imageModel.MetadataPolicy
+	}
+	if db != nil && imageModel.UserID > 0 {
+		if us, err := models.GetOrCreateUserSettings(db, imageModel.UserID); err == nil {
+			return models.NormalizeMetadataPolicy(us.MetadataPolicy)
+		}
+	}
+	return models.NormalizeMetadataPolicy("")
+}
+
+// applyMetadataPolicy clears the stored metadata fields that were removed from the original
+func applyMetadataPolicy(metadata *models.ImageMetadata, policy string) {
+	if metadata == nil || policy == models.MetadataPolicyKeep {
+		return
+	}
+	if metadata.Latitude != nil || metadata.Longitude != nil {
+		metadata.LocationHidden = true
+	}
+	metadata.Latitude = nil
+	metadata.Longitude = nil
+
+	if policy == models.MetadataPolicyStripAll {
+		metadata.CameraModel = nil
+		metadata.TakenAt = nil
+		metadata.ExposureTime = nil
+		metadata.Aperture = nil
+		metadata.ISO = nil
+		metadata.FocalLength = nil
+		metadata.Metadata = nil
+		return
+	}
+	if metadata.Metadata == nil {
+		return
+	}
+	var all map[string]interface{}
+	if err := json.Unmarshal(*metadata.Metadata, &all); err != nil {
+		metadata.Metadata = nil
+		return
+	}
+	for key := range all {
+		if strings.HasPrefix(key, "GPS") {
+			delete(all, key)
+		}
+	}
+	if raw, err := json.Marshal(all); err == nil {
+		meta := models.JSON(raw)
+		metadata.Metadata = &meta
+	}
+}
+
+// scrubOriginal applies the metadata policy to the original file and the extracted metadata.
+// It reports whether the file changed and its size change; in-place strips change the file
+// without changing its size.
+func scrubOriginal(imageModel *models.Image, originalFilePath, policy string) (bool, int64, error) {
+	applyMetadataPolicy(imageModel.Metadata, policy)
+
+	before, err := os.Stat(originalFilePath)
+	if err != nil {
+		return false, 0, err
+	}
+	changed, err := StripMetadata(originalFilePath, policy)
+	if err != nil || !changed {
+		return false, 0, err
+	}
+	after, err := os.Stat(originalFilePath)
+	if err != nil {
+		return true, 0, err
+	}
+	log.Infof("[ImageProcessor] Applied metadata policy %s to original of %s (%d -> %d bytes)", policy, imageModel.UUID, before.Size(), after.Size())
+	return true, after.Size() - before.Size(), nil
+}
+
+// ScrubImageMetadata applies the current metadata policy to an already processed image.
+// It reports whether the original file changed. Removed metadata cannot be restored, so
+// switching back to "keep" has no effect on scrubbed images.
+func ScrubImageMetadata(imageModel *models.Image) (bool, error) {
+	db := database.GetDB()
+	if db == nil {
+		return false, fmt.Errorf("database connection is nil")
+	}
+	policy := resolveMetadataPolicy(db, imageModel)
+	if policy == models.MetadataPolicyKeep {
+		return false, nil
+	}
+
+	ws, err := openWorkspace(imageModel)
+	if err != nil {
+		return false, err
+	}
+	defer ws.close()
+	originalFilePath, err := ws.original()
+	if err != nil {
+		return false, fmt.Errorf("original of image %s not available: %w", imageModel.UUID, err)
+	}
+	if metadata, err := models.FindMetadataByImageID(db, imageModel.ID); err == nil {
+		imageModel.Metadata = metadata
+	} else {
+		imageModel.Metadata = nil
+	}
+
+	changed, sizeChange, err := scrubOriginal(imageModel, originalFilePath, policy)
+	if err != nil {
+		return false, err
+	}
+	if imageModel.Metadata != nil {
+		if err := db.Save(imageModel.Metadata).Error; err != nil {
+			return false, fmt.Errorf("failed to save metadata: %w", err)
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+	if _, err := ws.store(ws.originalRelPath()); err != nil {
+		return false, fmt.Errorf("failed to store scrubbed original: %w", err)
+	}
+	imageModel.FileSize += sizeChange
+	updates := map[string]interface{}{"file_size": imageModel.FileSize}
+	if hash, err := ws.contentHash(ws.originalRelPath()); err != nil {
+		log.Warnf("[ImageProcessor] Could not hash scrubbed original of %s: %v", imageModel.UUID, err)
+	} else {
+		imageModel.ContentHash = hash
+		updates["content_hash"] = hash
+	}
+	if err := db.Model(&models.Image{}).Where("id = ?", imageModel.ID).Updates(updates).Error; err != nil {
+		return true, fmt.Errorf("failed to update file size: %w", err)
+	}
+	if sizeChange != 0 && imageModel.StoragePool != nil {
+		if err := imageModel.StoragePool.UpdateUsedSize(db, sizeChange); err != nil {
+			log.Warnf("[ImageProcessor] Failed to update pool usage for %s: %v", imageModel.UUID, err)
+		}
+	}
+	return true, nil
+}
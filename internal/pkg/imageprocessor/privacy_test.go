@@ -0,0 +1,163 @@
+package imageprocessor_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func copyTestImage(t *testing.T, name string) string {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	return path
+}
+
+func TestStripMetadataLocation(t *testing.T) {
+	path := copyTestImage(t, "image-with-meta-data.jpg")
+
+	changed, err := imageprocessor.StripMetadata(path, models.MetadataPolicyStripLocation)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	image := &models.Image{}
+	require.NoError(t, imageprocessor.ExtractMetadata(image, path))
+	require.NotNil(t, image.Metadata.CameraModel)
+	assert.Equal(t, "ONEPLUS A3003", *image.Metadata.CameraModel)
+	assert.Nil(t, image.Metadata.Latitude)
+	assert.Nil(t, image.Metadata.Longitude)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = jpeg.Decode(f)
+	assert.NoError(t, err, "stripped JPEG must still decode")
+
+	// Nothing left to remove on a second run
+	changed, err = imageprocessor.StripMetadata(path, models.MetadataPolicyStripLocation)
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestStripMetadataAll(t *testing.T) {
+	path := copyTestImage(t, "image-with-meta-data.jpg")
+	before, err := os.Stat(path)
+	require.NoError(t, err)
+
+	changed, err := imageprocessor.StripMetadata(path, models.MetadataPolicyStripAll)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	after, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Less(t, after.Size(), before.Size())
+
+	image := &models.Image{}
+	require.NoError(t, imageprocessor.ExtractMetadata(image, path))
+	assert.Nil(t, image.Metadata.CameraModel)
+	assert.Nil(t, image.Metadata.Latitude)
+}
+
+func TestStripMetadataKeep(t *testing.T) {
+	path := copyTestImage(t, "image-with-meta-data.jpg")
+	changed, err := imageprocessor.StripMetadata(path, models.MetadataPolicyKeep)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// Formats without EXIF support are left untouched
+	path = copyTestImage(t, "image.bmp")
+	changed, err = imageprocessor.StripMetadata(path, models.MetadataPolicyStripAll)
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+// exifTIFFFromJPEG returns the EXIF TIFF structure of the JPEG test image (it includes GPS data)
+func exifTIFFFromJPEG(t *testing.T) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", "image-with-meta-data.jpg"))
+	require.NoError(t, err)
+	idx := bytes.Index(data, []byte("Exif\x00\x00"))
+	require.Greater(t, idx, 2)
+	size := int(binary.BigEndian.Uint16(data[idx-2 : idx]))
+	return append([]byte(nil), data[idx+6:idx-2+size]...)
+}
+
+func TestStripMetadataTIFFAndHEIF(t *testing.T) {
+	tiff := exifTIFFFromJPEG(t)
+	// Minimal HEIF layout: ftyp box followed by the EXIF item data in an mdat box
+	heif := append([]byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00mif1heic\x00\x00\x00\x00mdat\x00\x00\x00\x06Exif\x00\x00"), tiff...)
+	files := map[string][]byte{"exif.tiff": tiff, "exif.heic": heif}
+
+	for name, data := range files {
+		path := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(path, data, 0644))
+
+		image := &models.Image{}
+		require.NoError(t, imageprocessor.ExtractMetadata(image, path))
+		require.NotNil(t, image.Metadata.Latitude, name)
+
+		changed, err := imageprocessor.StripMetadata(path, models.MetadataPolicyStripLocation)
+		require.NoError(t, err)
+		assert.True(t, changed, name)
+		stripped, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Len(t, stripped, len(data), "%s must be stripped in place", name)
+
+		image = &models.Image{}
+		require.NoError(t, imageprocessor.ExtractMetadata(image, path))
+		assert.Nil(t, image.Metadata.Latitude, name)
+		require.NotNil(t, image.Metadata.CameraModel, name)
+		assert.Equal(t, "ONEPLUS A3003", *image.Metadata.CameraModel)
+
+		changed, err = imageprocessor.StripMetadata(path, models.MetadataPolicyStripAll)
+		require.NoError(t, err)
+		assert.True(t, changed, name)
+		image = &models.Image{}
+		require.NoError(t, imageprocessor.ExtractMetadata(image, path))
+		assert.Nil(t, image.Metadata.ExposureTime, name)
+	}
+}
+
+func TestStripMetadataBlanksXMPInPlace(t *testing.T) {
+	xmp := []byte(`<?xpacket begin="" id="W5M0MpCehiHzreSzNTczkc9d"?><x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF><rdf:Description exif:GPSLatitude="52,31.2N"/></rdf:RDF></x:xmpmeta><?xpacket end="w"?>`)
+
+	// TIFF with the XMP packet in tag 700 of IFD0
+	tiff := []byte{'I', 'I', 42, 0, 8, 0, 0, 0, 1, 0}
+	tiff = binary.LittleEndian.AppendUint16(tiff, 700)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 7) // UNDEFINED
+	tiff = binary.LittleEndian.AppendUint32(tiff, uint32(len(xmp)))
+	tiff = binary.LittleEndian.AppendUint32(tiff, 26)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 0)
+	tiff = append(tiff, xmp...)
+
+	// HEIF and AVIF keep XMP as "mime" item, JPEG XL in an "xml " box
+	mdat := append(binary.BigEndian.AppendUint32(nil, uint32(8+len(xmp))), "mdat"...)
+	heif := append([]byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00mif1heic\x00\x00\x00\x00infe\x00\x00\x00\x00mime\x00application/rdf+xml\x00"), append(mdat, xmp...)...)
+	avif := append([]byte("\x00\x00\x00\x18ftypavif\x00\x00\x00\x00mif1avif"), append(mdat, xmp...)...)
+	xmlBox := append(binary.BigEndian.AppendUint32(nil, uint32(8+len(xmp))), "xml "...)
+	jxl := append([]byte("\x00\x00\x00\x0cJXL \r\n\x87\n\x00\x00\x00\x14ftypjxl \x00\x00\x00\x00jxl "), append(xmlBox, xmp...)...)
+
+	files := map[string][]byte{"xmp.tiff": tiff, "xmp.heic": heif, "xmp.avif": avif, "xmp.jxl": jxl}
+	for name, data := range files {
+		for _, policy := range []string{models.MetadataPolicyStripLocation, models.MetadataPolicyStripAll} {
+			path := filepath.Join(t.TempDir(), name)
+			require.NoError(t, os.WriteFile(path, data, 0644))
+
+			changed, err := imageprocessor.StripMetadata(path, policy)
+			require.NoError(t, err)
+			assert.True(t, changed, "%s %s", name, policy)
+			stripped, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Len(t, stripped, len(data), "%s must be stripped in place", name)
+			assert.NotContains(t, string(stripped), "GPSLatitude", "%s %s", name, policy)
+			assert.NotContains(t, string(stripped), "xmpmeta", "%s %s", name, policy)
+		}
+	}
+}
@@ -3,7 +3,6 @@ package imageprocessor
 import (
 	"fmt"
 	"github.com/gofiber/fiber/v2/log"
-	"strings"
 	"time"
 
 	"github.com/ManuelReschke/PixelFox/app/models"
@@ -236,25 +235,11 @@ func IsImageProcessingComplete(imageUUID string) bool {
 		return false
 	}
 
-	// Determine if optimization was expected based on file type
-	fileExt := strings.ToLower(strings.TrimPrefix(image.FileType, "."))
-	isGif := fileExt == "gif"
-	// isWebP := fileExt == "webp" // <-- Removed unused variable
-	isAVIFInput := fileExt == "avif" // Check if the *input* was AVIF
-	// Optimization is skipped if input is GIF or AVIF. WebP input might still generate thumbnails.
-	optimizationSkipped := isGif || isAVIFInput
-
 	dbIndicatesComplete := false
-	// Check if any variants exist for this image (indicates completion)
+	// Any variant of a variant profile indicates completion
 	variantInfo, err := GetImageVariantInfo(image.ID)
 	if err == nil {
-		if optimizationSkipped {
-			// For GIF/AVIF input, completion means thumbnails exist (or it's old)
-			dbIndicatesComplete = variantInfo.HasThumbnailSmall || variantInfo.HasThumbnailMedium
-		} else {
-			// For other types, completion means optimized versions OR thumbnails exist
-			dbIndicatesComplete = variantInfo.HasWebP || variantInfo.HasAVIF || variantInfo.HasThumbnailSmall || variantInfo.HasThumbnailMedium
-		}
+		dbIndicatesComplete = len(variantInfo.AvailableVariants) > 0
 	}
 
 	if dbIndicatesComplete {
@@ -0,0 +1,373 @@
+package imageprocessor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"github.com/gofiber/fiber/v2/log"
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
+)
+
+// Transformation limits and defaults
+const (
+	TransformMaxDimension   = 4096
+	TransformDefaultQuality = 80
+
+	TransformFitCover   = "cover"   // fill the box and crop the overflow (centered)
+	TransformFitContain = "contain" // scale down to fit into the box, keep aspect ratio
+
+	TransformFormatWebP = "webp"
+	TransformFormatJPEG = "jpeg"
+	TransformFormatPNG  = "png"
+	TransformFormatAVIF = "avif"
+)
+
+// ErrTransformUnsupported is returned when the original cannot be rendered on this node
+var ErrTransformUnsupported = errors.New("transformation not supported for this image")
+
+// TransformSpec describes an on-the-fly transformation such as "800x600,fit=cover,fmt=webp,q=80".
+// A zero width or height keeps the aspect ratio for that dimension.
+type TransformSpec struct {
+	Width   int
+	Height  int
+	Fit     string
+	Format  string
+	Quality int
+}
+
+// ParseTransformSpec parses and validates a transformation spec string
+func ParseTransformSpec(raw string) (TransformSpec, error) {
+	spec := TransformSpec{Fit: TransformFitContain, Format: TransformFormatWebP, Quality: TransformDefaultQuality}
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(raw)), ",")
+	size := strings.SplitN(parts[0], "x", 2)
+	if len(size) != 2 {
+		return spec, fmt.Errorf("size must be given as {w}x{h}")
+	}
+	var err error
+	if spec.Width, err = parseTransformDimension(size[0]); err != nil {
+		return spec, err
+	}
+	if spec.Height, err = parseTransformDimension(size[1]); err != nil {
+		return spec, err
+	}
+	if spec.Width == 0 && spec.Height == 0 {
+		return spec, fmt.Errorf("width or height is required")
+	}
+
+	seen := map[string]bool{}
+	for _, part := range parts[1:] {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || seen[key] {
+			return spec, fmt.Errorf("invalid parameter %q", part)
+		}
+		seen[key] = true
+		switch key {
+		case "fit":
+			if value != TransformFitCover && value != TransformFitContain {
+				return spec, fmt.Errorf("unsupported fit %q", value)
+			}
+			spec.Fit = value
+		case "fmt":
+			if value == "jpg" {
+				value = TransformFormatJPEG
+			}
+			switch value {
+			case TransformFormatWebP, TransformFormatJPEG, TransformFormatPNG, TransformFormatAVIF:
+			default:
+				return spec, fmt.Errorf("unsupported format %q", value)
+			}
+			spec.Format = value
+		case "q":
+			q, err := strconv.Atoi(value)
+			if err != nil || q < 1 || q > 100 {
+				return spec, fmt.Errorf("quality must be between 1 and 100")
+			}
+			spec.Quality = q
+		default:
+			return spec, fmt.Errorf("unknown parameter %q", key)
+		}
+	}
+	if spec.Fit == TransformFitCover && (spec.Width == 0 || spec.Height == 0) {
+		return spec, fmt.Errorf("fit=cover needs width and height")
+	}
+	return spec, nil
+}
+
+func parseTransformDimension(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < 0 || v > TransformMaxDimension {
+		return 0, fmt.Errorf("dimension must be between 0 and %d", TransformMaxDimension)
+	}
+	return v, nil
+}
+
+// String returns the canonical form of the spec; it is used for signatures, presets and the cache key
+func (s TransformSpec) String() string {
+	return fmt.Sprintf("%dx%d,fit=%s,fmt=%s,q=%d", s.Width, s.Height, s.Fit, s.Format, s.Quality)
+}
+
+// Extension returns the file extension of the output format
+func (s TransformSpec) Extension() string {
+	if s.Format == TransformFormatJPEG {
+		return ".jpg"
+	}
+	return "." + s.Format
+}
+
+// ContentType returns the MIME type of the output format
+func (s TransformSpec) ContentType() string {
+	return "image/" + s.Format
+}
+
+// Render applies the spec to a decoded image. Images are never upscaled beyond their original size.
+func (s TransformSpec) Render(img image.Image) image.Image {
+	ow, oh := img.Bounds().Dx(), img.Bounds().Dy()
+	if s.Fit == TransformFitCover {
+		w, h := s.Width, s.Height
+		if w > ow || h > oh {
+			// Shrink the box proportionally so the crop keeps the requested aspect ratio
+			scale := math.Min(float64(ow)/float64(w), float64(oh)/float64(h))
+			w = max(1, int(float64(w)*scale))
+			h = max(1, int(float64(h)*scale))
+		}
+		return imaging.Fill(img, w, h, imaging.Center, imaging.Lanczos)
+	}
+	w, h := min(s.Width, ow), min(s.Height, oh)
+	switch {
+	case w == 0:
+		return imaging.Resize(img, 0, h, imaging.Lanczos)
+	case h == 0:
+		return imaging.Resize(img, w, 0, imaging.Lanczos)
+	}
+	return imaging.Fit(img, w, h, imaging.Lanczos)
+}
+
+// save encodes the rendered image in the spec's output format
+func (s TransformSpec) save(img image.Image, outputPath string) error {
+	switch s.Format {
+	case TransformFormatWebP:
+		return saveWebPQuality(img, outputPath, float32(s.Quality))
+	case TransformFormatAVIF:
+		if !IsFFmpegAvailable {
+			return ErrTransformUnsupported
+		}
+		return convertToAVIF(img, outputPath)
+	case TransformFormatJPEG:
+		return imaging.Save(img, outputPath, imaging.JPEGQuality(s.Quality))
+	default:
+		return imaging.Save(img, outputPath)
+	}
+}
+
+// DerivedVariantFileName returns the cache file name of a transformation of the image
+func DerivedVariantFileName(imageModel *models.Image, spec TransformSpec) string {
+	sum := sha256.Sum256([]byte(spec.String()))
+	return fmt.Sprintf("%s_t_%s%s", imageModel.VariantBaseName(), hex.EncodeToString(sum[:6]), spec.Extension())
+}
+
+var transformGroup singleflight.Group
+
+// GetOrCreateDerivedVariant returns the cached transformation of the image and renders it from
+// the original on a cache miss. Concurrent requests for the same transformation render only once.
+// The returned path is the absolute file path of the cached variant.
+func GetOrCreateDerivedVariant(imageModel *models.Image, spec TransformSpec) (*models.DerivedVariant, string, error) {
+	db := database.GetDB()
+	if db == nil {
+		return nil, "", fmt.Errorf("database connection is nil")
+	}
+	pool := imageModel.StoragePool
+	if imageModel.StoragePoolID == 0 || pool == nil {
+		return nil, "", ErrTransformUnsupported
+	}
+	// Transformations are cached next to the variants and sent from disk
+	if _, ok := storage.LocalPath(pool, imageModel.FilePath); !ok {
+		return nil, "", ErrTransformUnsupported
+	}
+	if strings.EqualFold(imageModel.FileType, ".avif") || (spec.Format == TransformFormatAVIF && !IsFFmpegAvailable) {
+		return nil, "", ErrTransformUnsupported
+	}
+
+	key := spec.String()
+	if dv, path, ok := loadDerivedVariant(db, imageModel, key); ok {
+		return dv, path, nil
+	}
+
+	res, err, _ := transformGroup.Do(fmt.Sprintf("%d:%s", imageModel.ID, key), func() (interface{}, error) {
+		// Another request may have finished rendering while we were waiting
+		if dv, _, ok := loadDerivedVariant(db, imageModel, key); ok {
+			return dv, nil
+		}
+		return renderDerivedVariant(db, imageModel, spec)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	dv := res.(*models.DerivedVariant)
+	fullPath, _ := storage.LocalPath(pool, dv.FilePath)
+	return dv, fullPath, nil
+}
+
+// loadDerivedVariant looks up a cached transformation whose file still exists and records the access
+func loadDerivedVariant(db *gorm.DB, imageModel *models.Image, key string) (*models.DerivedVariant, string, bool) {
+	var dv models.DerivedVariant
+	if err := db.Where("image_id = ? AND spec = ?", imageModel.ID, key).First(&dv).Error; err != nil {
+		return nil, "", false
+	}
+	fullPath, _ := storage.LocalPath(imageModel.StoragePool, dv.FilePath)
+	if _, err := os.Stat(fullPath); err != nil {
+		// The file vanished (e.g. manual cleanup); drop the stale record and render again
+		db.Delete(&dv)
+		return nil, "", false
+	}
+	if err := db.Model(&dv).UpdateColumns(map[string]any{
+		"hit_count":        gorm.Expr("hit_count + 1"),
+		"last_accessed_at": time.Now(),
+	}).Error; err != nil {
+		log.Warnf("[Transform] Failed to record access for derived variant %d: %v", dv.ID, err)
+	}
+	return &dv, fullPath, true
+}
+
+func renderDerivedVariant(db *gorm.DB, imageModel *models.Image, spec TransformSpec) (*models.DerivedVariant, error) {
+	pool := imageModel.StoragePool
+	originalPath, _ := storage.LocalPath(pool, filepath.Join(imageModel.FilePath, imageModel.FileName))
+	img, err := openOriginal(originalPath, imageModel.FileType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open original '%s': %w", originalPath, err)
+	}
+	rendered := spec.Render(img)
+	img = nil
+	// Transformations are public renditions and carry the owner's watermark like the variants
+	if imageModel.IsWatermarked {
+		if wm := resolveWatermark(db, imageModel); wm != nil {
+			rendered = wm.apply(rendered)
+		}
+	}
+
+	relativePath := strings.TrimPrefix(imageModel.FilePath, "original/")
+	relativePath = strings.TrimPrefix(relativePath, string(filepath.Separator))
+	relFile := filepath.ToSlash(filepath.Join("variants", relativePath, DerivedVariantFileName(imageModel, spec)))
+	fullPath, _ := storage.LocalPath(pool, relFile)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create variants directory: %w", err)
+	}
+	if err := spec.save(rendered, fullPath); err != nil {
+		_ = os.Remove(fullPath)
+		return nil, fmt.Errorf("failed to save transformation %s of %s: %w", spec, imageModel.UUID, err)
+	}
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat transformation %s: %w", fullPath, err)
+	}
+
+	now := time.Now()
+	dv := &models.DerivedVariant{
+		ImageID:        imageModel.ID,
+		StoragePoolID:  pool.ID,
+		Spec:           spec.String(),
+		FilePath:       relFile,
+		FileSize:       info.Size(),
+		Width:          rendered.Bounds().Dx(),
+		Height:         rendered.Bounds().Dy(),
+		ContentType:    spec.ContentType(),
+		LastAccessedAt: now,
+	}
+	if err := db.Create(dv).Error; err != nil {
+		_ = os.Remove(fullPath)
+		return nil, fmt.Errorf("failed to store derived variant record: %w", err)
+	}
+	if err := pool.UpdateUsedSize(db, dv.FileSize); err != nil {
+		log.Warnf("[Transform] Failed to update usage of pool %s: %v", pool.Name, err)
+	}
+	log.Infof("[Transform] Rendered %s for image %s (%d bytes)", dv.Spec, imageModel.UUID, dv.FileSize)
+
+	go EnforceDerivedVariantCacheLimit(pool)
+	return dv, nil
+}
+
+// evictionMu serializes cache evictions so concurrent renders don't evict the same entries twice
+var evictionMu sync.Mutex
+
+// EnforceDerivedVariantCacheLimit evicts the least recently used transformations of a pool
+// until the cache fits into the configured size limit.
+func EnforceDerivedVariantCacheLimit(pool *models.StoragePool) {
+	settings := models.GetAppSettings()
+	if settings == nil || pool == nil {
+		return
+	}
+	limit := int64(settings.GetTransformCacheMaxMBPerPool()) * 1024 * 1024
+	if limit <= 0 {
+		return
+	}
+	db := database.GetDB()
+	if db == nil {
+		return
+	}
+	evictionMu.Lock()
+	defer evictionMu.Unlock()
+
+	var total int64
+	if err := db.Model(&models.DerivedVariant{}).Where("storage_pool_id = ?", pool.ID).
+		Select("COALESCE(SUM(file_size), 0)").Scan(&total).Error; err != nil {
+		log.Errorf("[Transform] Failed to sum cache size of pool %s: %v", pool.Name, err)
+		return
+	}
+	for total > limit {
+		var batch []models.DerivedVariant
+		if err := db.Where("storage_pool_id = ?", pool.ID).
+			Order("last_accessed_at ASC, id ASC").Limit(100).Find(&batch).Error; err != nil || len(batch) == 0 {
+			return
+		}
+		for _, dv := range batch {
+			if total <= limit {
+				break
+			}
+			if err := DeleteDerivedVariant(db, pool, &dv); err != nil {
+				log.Errorf("[Transform] Failed to evict derived variant %d: %v", dv.ID, err)
+				return
+			}
+			total -= dv.FileSize
+		}
+	}
+}
+
+// DeleteDerivedVariant removes a cached transformation file and its record
+func DeleteDerivedVariant(db *gorm.DB, pool *models.StoragePool, dv *models.DerivedVariant) error {
+	if pool != nil {
+		backend, err := storage.BackendFor(pool)
+		if err != nil {
+			return err
+		}
+		if err := backend.Delete(dv.FilePath); err != nil {
+			return err
+		}
+	}
+	if err := db.Delete(dv).Error; err != nil {
+		return err
+	}
+	if pool != nil {
+		if err := pool.UpdateUsedSize(db, -dv.FileSize); err != nil {
+			log.Warnf("[Transform] Failed to update usage of pool %s: %v", pool.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package imageprocessor_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransformSpec(t *testing.T) {
+	spec, err := imageprocessor.ParseTransformSpec(" 800X600,fmt=jpg,fit=cover ")
+	require.NoError(t, err)
+	assert.Equal(t, "800x600,fit=cover,fmt=jpeg,q=80", spec.String())
+	assert.Equal(t, ".jpg", spec.Extension())
+	assert.Equal(t, "image/jpeg", spec.ContentType())
+
+	spec, err = imageprocessor.ParseTransformSpec("x300,q=55")
+	require.NoError(t, err)
+	assert.Equal(t, "0x300,fit=contain,fmt=webp,q=55", spec.String())
+
+	for _, raw := range []string{
+		"",
+		"800",
+		"0x0",
+		"5000x100",
+		"800x-1",
+		"800x0,fit=cover",
+		"800x600,fmt=gif",
+		"800x600,q=0",
+		"800x600,q=80,q=90",
+		"800x600,blur=3",
+	} {
+		_, err := imageprocessor.ParseTransformSpec(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestTransformSpecRender(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 1000, 500))
+
+	cases := []struct {
+		spec          string
+		width, height int
+	}{
+		{"400x400,fit=contain", 400, 200},
+		{"400x400,fit=cover", 400, 400},
+		{"0x100", 200, 100},
+		// never upscale
+		{"2000x0", 1000, 500},
+		{"2000x2000,fit=cover", 500, 500},
+	}
+	for _, tc := range cases {
+		spec, err := imageprocessor.ParseTransformSpec(tc.spec)
+		require.NoError(t, err, tc.spec)
+		out := spec.Render(src)
+		assert.Equal(t, tc.width, out.Bounds().Dx(), tc.spec)
+		assert.Equal(t, tc.height, out.Bounds().Dy(), tc.spec)
+	}
+}
@@ -37,16 +37,16 @@ func GetImageVariantInfo(imageID uint) (*VariantInfo, error) {
 		AvailableVariants: variants,
 	}
 
-	// Check which variants are available
+	// Check which variants of the default profiles are available
 	for _, variant := range variants {
-		switch variant.VariantType {
-		case "webp":
+		switch {
+		case variant.VariantType == models.VariantTypeWebP:
 			info.HasWebP = true
-		case "avif":
+		case variant.VariantType == models.VariantTypeAVIF:
 			info.HasAVIF = true
-		case "thumbnail_small_webp", "thumbnail_small_avif", "thumbnail_small_original":
+		case strings.HasPrefix(variant.VariantType, "thumbnail_small_"):
 			info.HasThumbnailSmall = true
-		case "thumbnail_medium_webp", "thumbnail_medium_avif", "thumbnail_medium_original":
+		case strings.HasPrefix(variant.VariantType, "thumbnail_medium_"):
 			info.HasThumbnailMedium = true
 		}
 	}
@@ -125,23 +125,26 @@ func BuildImagePaths(imageModel *models.Image) map[string]string {
 		// Convert storage pool path to web path
 		webPath := convertStoragePoolPathToWebPath(variant.FilePath, variant.FileName)
 
+		// Keys are the profile names; the full-size defaults keep their historic keys
 		switch variant.VariantType {
-		case "webp":
+		case models.VariantTypeWebP:
 			paths["webp_full"] = webPath
-		case "avif":
+		case models.VariantTypeAVIF:
 			paths["avif_full"] = webPath
-		case "thumbnail_small_webp":
-			paths["thumbnail_small_webp"] = webPath
-		case "thumbnail_small_avif":
-			paths["thumbnail_small_avif"] = webPath
-		case "thumbnail_small_original":
-			paths["thumbnail_small_original"] = webPath
-		case "thumbnail_medium_webp":
-			paths["thumbnail_medium_webp"] = webPath
-		case "thumbnail_medium_avif":
-			paths["thumbnail_medium_avif"] = webPath
-		case "thumbnail_medium_original":
-			paths["thumbnail_medium_original"] = webPath
+		default:
+			paths[variant.VariantType] = webPath
+		}
+
+		// Video variants are also keyed by container so viewers need not know the profile names
+		switch strings.ToLower(variant.FileType) {
+		case ".mp4":
+			if _, exists := paths[models.VariantTypeVideoMP4]; !exists {
+				paths[models.VariantTypeVideoMP4] = webPath
+			}
+		case ".webm":
+			if _, exists := paths[models.VariantTypeVideoWebM]; !exists {
+				paths[models.VariantTypeVideoWebM] = webPath
+			}
 		}
 	}
 
@@ -237,7 +240,7 @@ func GetImageAbsoluteURL(imageModel *models.Image, format string, size string) s
 
 // GetBestPreviewURL returns an absolute URL for a suitable preview image.
 // Preference order: medium (AVIF -> WebP -> Original), then small (AVIF -> WebP -> Original),
-// and finally falls back to the original image URL.
+// then the web variant and finally falls back to the original image URL.
 func GetBestPreviewURL(imageModel *models.Image) string {
 	if imageModel == nil || imageModel.UUID == "" {
 		return ""
@@ -265,6 +268,34 @@ func GetBestPreviewURL(imageModel *models.Image) string {
 		return MakeAbsoluteForImage(imageModel, p)
 	}
 
-	// Final fallback to original
-	return GetImageAbsoluteURL(imageModel, "original", "")
+	// Full-size web variant of originals browsers cannot display
+	if p := GetVariantURL(imageModel, models.VariantTypeWeb); p != "" {
+		return MakeAbsoluteForImage(imageModel, p)
+	}
+
+	// Final fallback to the full-size image visitors may see
+	return MakeAbsoluteForImage(imageModel, GetPublicOriginalURL(imageModel))
+}
+
+// GetPublicOriginalURL returns the web path of the full-size image shown to visitors. The original of
+// a watermarked image is owner-only, visitors get the watermarked full-size variant instead.
+func GetPublicOriginalURL(imageModel *models.Image) string {
+	if imageModel == nil || !imageModel.IsWatermarked {
+		return GetImageURL(imageModel, "original", "")
+	}
+	for _, variantType := range []string{models.VariantTypeWeb, models.VariantTypeWebP, models.VariantTypeAVIF, models.VariantTypeThumbnailMediumOrig} {
+		if p := GetVariantURL(imageModel, variantType); p != "" {
+			return p
+		}
+	}
+	return ""
+}
+
+// GetOriginalURLFor returns the full-size web path for a viewer: the original for its owner,
+// the public rendition for everybody else
+func GetOriginalURLFor(imageModel *models.Image, viewerID uint) string {
+	if imageModel != nil && viewerID != 0 && imageModel.UserID == viewerID {
+		return GetImageURL(imageModel, "original", "")
+	}
+	return GetPublicOriginalURL(imageModel)
 }
@@ -0,0 +1,347 @@
+package imageprocessor
+
+import (
+	"fmt"
+	"image"
+	"path/filepath"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/entitlements"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
+)
+
+// GeneratedVariant describes a variant file written for a variant profile
+type GeneratedVariant struct {
+	Profile  models.VariantProfile
+	FileName string
+	Width    int
+	Height   int
+	Size     int64 // set once the file is stored in the pool
+}
+
+// loadVariantProfiles returns the active variant profiles. Without a database connection
+// (e.g. in tests) the default profiles are used.
+func loadVariantProfiles(db *gorm.DB) []models.VariantProfile {
+	if db == nil {
+		return activeDefaultVariantProfiles()
+	}
+	profiles, err := models.FindActiveVariantProfiles(db)
+	if err != nil {
+		log.Errorf("[ImageProcessor] Failed to load variant profiles, using defaults: %v", err)
+		return activeDefaultVariantProfiles()
+	}
+	return profiles
+}
+
+func activeDefaultVariantProfiles() []models.VariantProfile {
+	var profiles []models.VariantProfile
+	for _, profile := range models.DefaultVariantProfiles() {
+		if profile.IsActive {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
+// variantEntitlements resolves the plan and the allowed output formats for the image owner
+type variantEntitlements struct {
+	plan                   string
+	origOK, webpOK, avifOK bool
+}
+
+func resolveVariantEntitlements(db *gorm.DB, imageModel *models.Image) variantEntitlements {
+	var userSettings *models.UserSettings
+	if db != nil && imageModel.UserID > 0 {
+		if us, err := models.GetOrCreateUserSettings(db, imageModel.UserID); err == nil {
+			userSettings = us
+		}
+	}
+	// Fallback defaults if missing
+	if userSettings == nil {
+		userSettings = &models.UserSettings{Plan: "free", PrefThumbOriginal: true}
+	}
+	origOK, webpOK, avifOK := entitlements.EffectiveThumbs(userSettings, models.GetAppSettings())
+	return variantEntitlements{plan: userSettings.Plan, origOK: origOK, webpOK: webpOK, avifOK: avifOK}
+}
+
+// allows reports whether a profile should be generated for the image owner
+func (e variantEntitlements) allows(profile *models.VariantProfile) bool {
+	if !profile.AvailableForPlan(e.plan) {
+		return false
+	}
+	switch profile.Format {
+	case models.VariantFormatWebP:
+		return e.webpOK
+	case models.VariantFormatAVIF:
+		return e.avifOK
+	case models.VariantFormatOriginal:
+		return e.origOK
+	case models.VariantFormatMP4, models.VariantFormatWebM:
+		// Video profiles are only restricted by their plans
+		return true
+	}
+	return false
+}
+
+// variantsBaseDirFor returns the directory recorded for the variants of an image: the local
+// directory for local and NFS pools, the directory within the pool for other backends
+func variantsBaseDirFor(imageModel *models.Image) string {
+	if imageModel.StoragePoolID > 0 && imageModel.StoragePool != nil {
+		relDir := variantsRelDir(imageModel)
+		if localDir, ok := storage.LocalPath(imageModel.StoragePool, relDir); ok {
+			return localDir
+		}
+		return relDir
+	}
+	// Fallback to legacy structure
+	relativePath := strings.TrimPrefix(imageModel.FilePath, OriginalDir)
+	relativePath = strings.TrimPrefix(relativePath, string(filepath.Separator))
+	return filepath.Join(VariantsDir, relativePath)
+}
+
+// renderVariantProfile resizes the decoded image for a profile.
+// A single bound keeps the aspect ratio, two bounds fit the image into the box.
+func renderVariantProfile(img image.Image, profile *models.VariantProfile) image.Image {
+	switch {
+	case profile.IsFullSize():
+		return img
+	case profile.MaxHeight == 0:
+		return imaging.Resize(img, profile.MaxWidth, 0, imaging.Lanczos)
+	case profile.MaxWidth == 0:
+		return imaging.Resize(img, 0, profile.MaxHeight, imaging.Lanczos)
+	}
+	return imaging.Fit(img, profile.MaxWidth, profile.MaxHeight, imaging.Lanczos)
+}
+
+// saveVariantProfile encodes a rendered variant in the profile's format
+func saveVariantProfile(img image.Image, outputPath string, profile *models.VariantProfile, fileType string) error {
+	switch profile.Format {
+	case models.VariantFormatWebP:
+		return saveWebPQuality(img, outputPath, float32(profile.Quality))
+	case models.VariantFormatAVIF:
+		return convertToAVIFWithCRF(img, outputPath, profile.Quality)
+	default:
+		return saveOriginalFormatQuality(img, outputPath, fileType, profile.Quality)
+	}
+}
+
+// generateProfileVariants writes the variant files of all given profiles the owner is entitled to.
+// Variants are encoded from the decoded pixels and therefore never contain EXIF or GPS data.
+// For animated sources (anim != nil) WebP and original-format variants keep the animation and
+// video profiles are rendered; AVIF is skipped there. Non-optimizable inputs (still GIF) only get resized variants.
+// A non-nil watermark is drawn onto the still full-size and original-format variants.
+func generateProfileVariants(imageModel *models.Image, img image.Image, variantsBaseDir string, profiles []models.VariantProfile, ent variantEntitlements, thumbnailsOnly bool, anim *animatedSource, wm *watermark) []GeneratedVariant {
+	var generated []GeneratedVariant
+	// HEIC/HEIF, TIFF and JPEG XL variants in the "original" format are written browser-safe
+	fileType := browserFileType(imageModel.FileType, img)
+	for i := range profiles {
+		profile := &profiles[i]
+		if thumbnailsOnly && profile.IsFullSize() {
+			continue
+		}
+		if !ent.allows(profile) {
+			log.Debugf("[ImageProcessor] Skipping variant %s for %s: not enabled for this user", profile.Name, imageModel.UUID)
+			continue
+		}
+		if profile.IsVideo() && anim == nil {
+			continue
+		}
+		if profile.Format == models.VariantFormatAVIF && anim != nil {
+			log.Debugf("[ImageProcessor] Skipping AVIF variant %s for %s: animation would be lost", profile.Name, imageModel.UUID)
+			continue
+		}
+		if profile.Format == models.VariantFormatAVIF && !IsFFmpegAvailable {
+			log.Warnf("[ImageProcessor] Skipping AVIF variant %s for %s: ffmpeg not found.", profile.Name, imageModel.UUID)
+			continue
+		}
+
+		fileName := profile.FileName(imageModel.VariantBaseName(), fileType)
+		outputPath := filepath.Join(variantsBaseDir, fileName)
+
+		if anim != nil {
+			w, h, err := renderAnimatedVariant(anim, outputPath, profile)
+			if err == nil {
+				log.Debugf("[ImageProcessor] Saved animated variant %s for %s", profile.Name, imageModel.UUID)
+				generated = append(generated, GeneratedVariant{Profile: *profile, FileName: fileName, Width: w, Height: h})
+				continue
+			}
+			if profile.IsVideo() {
+				log.Errorf("[ImageProcessor] Failed to save video variant %s for %s: %v", profile.Name, imageModel.UUID, err)
+				continue
+			}
+			log.Warnf("[ImageProcessor] Failed to save animated variant %s for %s, falling back to first frame: %v", profile.Name, imageModel.UUID, err)
+		}
+
+		if img == nil {
+			continue
+		}
+		rendered := renderVariantProfile(img, profile)
+		if wm.appliesTo(profile) {
+			rendered = wm.apply(rendered)
+		}
+		w, h := rendered.Bounds().Dx(), rendered.Bounds().Dy()
+		// Guard: libsvtav1 requires at least 64x64 input
+		if profile.Format == models.VariantFormatAVIF && (w < 64 || h < 64) {
+			log.Debugf("[ImageProcessor] Skipping AVIF variant %s for %s: dimensions %dx%d below 64x64", profile.Name, imageModel.UUID, w, h)
+			continue
+		}
+
+		if err := saveVariantProfile(rendered, outputPath, profile, fileType); err != nil {
+			log.Errorf("[ImageProcessor] Failed to save variant %s for %s: %v", profile.Name, imageModel.UUID, err)
+			continue
+		}
+		log.Debugf("[ImageProcessor] Saved variant %s for %s", profile.Name, imageModel.UUID)
+		generated = append(generated, GeneratedVariant{Profile: *profile, FileName: fileName, Width: w, Height: h})
+	}
+	return generated
+}
+
+// createImageVariants creates the variant records for generated variants stored in the pool.
+// Existing records of the same profile are replaced.
+func createImageVariants(db *gorm.DB, imageModel *models.Image, variantsBaseDir string, generated []GeneratedVariant) error {
+	for _, gv := range generated {
+		fileType := filepath.Ext(gv.FileName)
+		variant := models.ImageVariant{
+			ImageID:       imageModel.ID,
+			StoragePoolID: imageModel.StoragePoolID,
+			VariantType:   gv.Profile.Name,
+			FilePath:      variantsBaseDir,
+			FileName:      gv.FileName,
+			FileType:      fileType,
+			FileSize:      gv.Size,
+			Width:         gv.Width,
+			Height:        gv.Height,
+			Quality:       gv.Profile.Quality,
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Unscoped().Where("image_id = ? AND variant_type = ?", imageModel.ID, gv.Profile.Name).Delete(&models.ImageVariant{}).Error; err != nil {
+				return err
+			}
+			return tx.Create(&variant).Error
+		})
+		if err != nil {
+			log.Errorf("[ImageProcessor] Failed to create %s variant for %s: %v", gv.Profile.Name, imageModel.UUID, err)
+		}
+	}
+
+	log.Debugf("[ImageProcessor] Successfully created variant records for image %s", imageModel.UUID)
+	return nil
+}
+
+// SyncVariantProfiles brings the variants of an image in line with the configured profiles:
+// missing variants of active profiles are generated and variants whose profile was deleted are removed.
+// Local and NFS pools must be mounted on the node running it.
+func SyncVariantProfiles(imageModel *models.Image) (created int, removed int, err error) {
+	db := database.GetDB()
+	if db == nil {
+		return 0, 0, fmt.Errorf("database connection is nil")
+	}
+	ws, err := openWorkspace(imageModel)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer ws.close()
+
+	existing, err := models.FindVariantsByImageID(db, imageModel.ID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to load variants: %w", err)
+	}
+	known, err := models.FindAllVariantProfileNames(db)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to load variant profiles: %w", err)
+	}
+	knownSet := make(map[string]bool, len(known)+1)
+	for _, name := range known {
+		knownSet[name] = true
+	}
+	needsWeb := NeedsWebVariant(imageModel.FileType)
+	knownSet[models.VariantTypeWeb] = needsWeb
+	have := make(map[string]bool, len(existing))
+
+	// Remove variants of deleted profiles
+	sm := storage.NewStorageManager()
+	for i := range existing {
+		v := &existing[i]
+		if knownSet[v.VariantType] {
+			have[v.VariantType] = true
+			continue
+		}
+		poolID := v.StoragePoolID
+		if poolID == 0 {
+			poolID = imageModel.StoragePoolID
+		}
+		if relPath := resolveVariantRelativePath(v.FilePath, v.FileName, imageModel.StoragePool); relPath != "" && poolID > 0 {
+			if _, err := sm.DeleteFile(relPath, poolID); err != nil {
+				log.Warnf("[ImageProcessor] Failed to delete variant file %s of image %s: %v", relPath, imageModel.UUID, err)
+			}
+		}
+		if err := db.Unscoped().Delete(v).Error; err != nil {
+			return created, removed, fmt.Errorf("failed to delete variant %d: %w", v.ID, err)
+		}
+		removed++
+	}
+
+	// Generate variants of profiles that are missing
+	ent := resolveVariantEntitlements(db, imageModel)
+	var missing []models.VariantProfile
+	for _, profile := range loadVariantProfiles(db) {
+		if !have[profile.Name] && ent.allows(&profile) {
+			missing = append(missing, profile)
+		}
+	}
+	missingWeb := needsWeb && !have[models.VariantTypeWeb]
+	lowerFileType := strings.ToLower(strings.TrimPrefix(imageModel.FileType, "."))
+	if (len(missing) == 0 && !missingWeb) || lowerFileType == "avif" {
+		return created, removed, nil
+	}
+
+	originalFilePath, err := ws.original()
+	if err != nil {
+		return created, removed, fmt.Errorf("original of image %s not available: %w", imageModel.UUID, err)
+	}
+	anim := detectAnimatedSource(imageModel, originalFilePath)
+	imgDecoded, err := openOriginal(originalFilePath, imageModel.FileType)
+	if err != nil && anim == nil {
+		return created, removed, fmt.Errorf("failed to open/decode image '%s': %w", originalFilePath, err)
+	}
+	variantsBaseDir, err := ws.variantsDir()
+	if err != nil {
+		return created, removed, err
+	}
+	if err := db.Model(&models.Image{}).Where("id = ?", imageModel.ID).
+		Updates(map[string]interface{}{"frame_count": imageModel.FrameCount, "duration_ms": imageModel.DurationMs}).Error; err != nil {
+		log.Warnf("[ImageProcessor] Failed to store frame info of image %s: %v", imageModel.UUID, err)
+	}
+	// Missing variants follow the watermark state of the existing ones
+	var wm *watermark
+	if imageModel.IsWatermarked {
+		wm = resolveWatermark(db, imageModel)
+	}
+	generated := generateProfileVariants(imageModel, imgDecoded, variantsBaseDir, missing, ent, lowerFileType == "gif" && anim == nil, anim, wm)
+	if missingWeb && imgDecoded != nil {
+		if gv, err := generateWebVariant(imageModel, imgDecoded, variantsBaseDir, wm); err != nil {
+			log.Errorf("[ImageProcessor] Failed to save web variant for %s: %v", imageModel.UUID, err)
+		} else {
+			generated = append(generated, gv)
+		}
+	}
+	if optimizeVariants, _ := optimizationSettings(); optimizeVariants {
+		if saved := optimizeGeneratedVariants(imageModel, variantsBaseDir, generated); saved > 0 {
+			if err := db.Model(&models.Image{}).Where("id = ?", imageModel.ID).
+				UpdateColumn("bytes_saved", gorm.Expr("bytes_saved + ?", saved)).Error; err != nil {
+				log.Warnf("[ImageProcessor] Failed to record optimisation savings of %s: %v", imageModel.UUID, err)
+			}
+		}
+	}
+	generated = ws.storeVariants(generated)
+	if err := createImageVariants(db, imageModel, variantsBaseDirFor(imageModel), generated); err != nil {
+		return created, removed, err
+	}
+	return len(generated), removed, nil
+}
@@ -0,0 +1,270 @@
+package imageprocessor
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/gofiber/fiber/v2/log"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/entitlements"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
+)
+
+// watermarkTextSize is the font size text watermarks are rendered at before scaling
+const watermarkTextSize = 64
+
+// watermark is a resolved watermark ready to be drawn onto variants
+type watermark struct {
+	mark *image.NRGBA
+	cfg  models.WatermarkConfig
+}
+
+// appliesTo reports whether a profile is watermarked: all full-size renditions and the
+// original-format variants. Resized WebP/AVIF thumbnails stay clean.
+func (w *watermark) appliesTo(profile *models.VariantProfile) bool {
+	if w == nil || profile.IsVideo() {
+		return false
+	}
+	return profile.IsFullSize() || profile.Format == models.VariantFormatOriginal
+}
+
+// apply draws the watermark onto an image
+func (w *watermark) apply(img image.Image) image.Image {
+	if w == nil {
+		return img
+	}
+	return ApplyWatermark(img, w.mark, w.cfg)
+}
+
+// ApplyWatermark draws a mark onto an image. The mark is scaled to cfg.Scale percent of the image
+// width (and never beyond the image), placed at cfg.Position with a small margin and blended with cfg.Opacity.
+func ApplyWatermark(img image.Image, mark image.Image, cfg models.WatermarkConfig) *image.NRGBA {
+	cfg.Normalize()
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	markW := max(w*cfg.Scale/100, 1)
+	scaled := imaging.Resize(mark, markW, 0, imaging.Lanczos)
+	if scaled.Bounds().Dy() > h {
+		scaled = imaging.Resize(mark, 0, h, imaging.Lanczos)
+	}
+	mw, mh := scaled.Bounds().Dx(), scaled.Bounds().Dy()
+
+	margin := min(w, h) * 2 / 100
+	var x, y int
+	switch cfg.Position {
+	case models.WatermarkTopLeft:
+		x, y = margin, margin
+	case models.WatermarkTopRight:
+		x, y = w-mw-margin, margin
+	case models.WatermarkBottomLeft:
+		x, y = margin, h-mh-margin
+	case models.WatermarkCenter:
+		x, y = (w-mw)/2, (h-mh)/2
+	default:
+		x, y = w-mw-margin, h-mh-margin
+	}
+	return imaging.Overlay(imaging.Clone(img), scaled, image.Pt(max(x, 0), max(y, 0)), float64(cfg.Opacity)/100)
+}
+
+// RenderTextWatermark renders text as a white mark with a dark outline on a transparent background
+func RenderTextWatermark(text string) (*image.NRGBA, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("watermark text is empty")
+	}
+	ttf, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse watermark font: %w", err)
+	}
+	face, err := opentype.NewFace(ttf, &opentype.FaceOptions{Size: watermarkTextSize, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create watermark font face: %w", err)
+	}
+	defer face.Close()
+
+	const outline = 2
+	metrics := face.Metrics()
+	width := font.MeasureString(face, text).Ceil() + 2*outline
+	height := (metrics.Ascent + metrics.Descent).Ceil() + 2*outline
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	baseline := outline + metrics.Ascent.Ceil()
+
+	drawer := &font.Drawer{Dst: dst, Face: face}
+	drawer.Src = image.NewUniform(color.NRGBA{A: 160})
+	for dx := -outline; dx <= outline; dx += outline {
+		for dy := -outline; dy <= outline; dy += outline {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+			drawer.Dot = fixed.P(outline+dx, baseline+dy)
+			drawer.DrawString(text)
+		}
+	}
+	drawer.Src = image.White
+	drawer.Dot = fixed.P(outline, baseline)
+	drawer.DrawString(text)
+	return dst, nil
+}
+
+// decodeWatermarkImage decodes an uploaded PNG watermark
+func decodeWatermarkImage(data []byte) (*image.NRGBA, error) {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode watermark image: %w", err)
+	}
+	dst := image.NewNRGBA(img.Bounds())
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Src)
+	return dst, nil
+}
+
+// ValidateWatermarkImage checks an uploaded watermark before it is stored
+func ValidateWatermarkImage(data []byte) error {
+	if len(data) > models.MaxWatermarkImageBytes {
+		return fmt.Errorf("watermark image exceeds %d bytes", models.MaxWatermarkImageBytes)
+	}
+	cfg, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("watermark must be a PNG image: %w", err)
+	}
+	if cfg.Width > 4096 || cfg.Height > 4096 {
+		return fmt.Errorf("watermark image is larger than 4096x4096")
+	}
+	return nil
+}
+
+// resolveWatermark returns the watermark for an image: the override of an album containing it,
+// otherwise the owner's setting. Owners whose plan does not include watermarks get none.
+func resolveWatermark(db *gorm.DB, imageModel *models.Image) *watermark {
+	if db == nil || imageModel.UserID == 0 {
+		return nil
+	}
+	us, err := models.GetOrCreateUserSettings(db, imageModel.UserID)
+	if err != nil || !entitlements.CanWatermark(entitlements.Plan(strings.ToLower(us.Plan))) {
+		return nil
+	}
+	cfg := us.Watermark
+	if album, err := models.FindWatermarkOverrideAlbum(db, imageModel.ID); err != nil {
+		log.Warnf("[ImageProcessor] Failed to look up watermark override of image %s: %v", imageModel.UUID, err)
+	} else if album != nil {
+		cfg = album.Watermark
+	}
+	if !cfg.HasMark() {
+		return nil
+	}
+
+	var mark *image.NRGBA
+	if len(cfg.Image) > 0 {
+		mark, err = decodeWatermarkImage(cfg.Image)
+	} else {
+		mark, err = RenderTextWatermark(cfg.Text)
+	}
+	if err != nil {
+		log.Warnf("[ImageProcessor] Skipping watermark of image %s: %v", imageModel.UUID, err)
+		return nil
+	}
+	return &watermark{mark: mark, cfg: cfg}
+}
+
+// RerenderWatermark renders the watermarked variants of an image again after its watermark changed.
+// The revision is increased so the new files get cache-busting names; the replaced files and all
+// cached transformations are deleted. Animated and AVIF originals are never watermarked and skipped.
+func RerenderWatermark(imageModel *models.Image) error {
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	if imageModel.FrameCount > 1 || normalizeFileType(imageModel.FileType) == ".avif" {
+		return nil
+	}
+	wm := resolveWatermark(db, imageModel)
+	if wm == nil && !imageModel.IsWatermarked {
+		return nil
+	}
+	ws, err := openWorkspace(imageModel)
+	if err != nil {
+		return err
+	}
+	defer ws.close()
+	img, err := ws.decodeOriginal()
+	if err != nil {
+		return err
+	}
+
+	existing, err := models.FindVariantsByImageID(db, imageModel.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load variants of image %s: %w", imageModel.UUID, err)
+	}
+	var profiles []models.VariantProfile
+	probe := &watermark{}
+	for _, profile := range loadVariantProfiles(db) {
+		if probe.appliesTo(&profile) {
+			profiles = append(profiles, profile)
+		}
+	}
+
+	imageModel.Revision++
+	variantsBaseDir, err := ws.variantsDir()
+	if err != nil {
+		return err
+	}
+	thumbnailsOnly := normalizeFileType(imageModel.FileType) == ".gif"
+	generated := generateProfileVariants(imageModel, img, variantsBaseDir, profiles, resolveVariantEntitlements(db, imageModel), thumbnailsOnly, nil, wm)
+	if NeedsWebVariant(imageModel.FileType) {
+		if gv, err := generateWebVariant(imageModel, img, variantsBaseDir, wm); err != nil {
+			log.Errorf("[ImageProcessor] Failed to save web variant for %s: %v", imageModel.UUID, err)
+		} else {
+			generated = append(generated, gv)
+		}
+	}
+	// Savings were recorded when the image was processed, the replaced variants are optimised alike
+	if optimizeVariants, _ := optimizationSettings(); optimizeVariants {
+		optimizeGeneratedVariants(imageModel, variantsBaseDir, generated)
+	}
+	generated = ws.storeVariants(generated)
+	if err := createImageVariants(db, imageModel, variantsBaseDirFor(imageModel), generated); err != nil {
+		return err
+	}
+
+	// Remove the files the new variants replaced
+	replaced := make(map[string]bool, len(generated))
+	for _, gv := range generated {
+		replaced[gv.Profile.Name] = true
+	}
+	sm := storage.NewStorageManager()
+	for _, v := range existing {
+		if !replaced[v.VariantType] {
+			continue
+		}
+		if relPath := resolveVariantRelativePath(v.FilePath, v.FileName, imageModel.StoragePool); relPath != "" {
+			if _, err := sm.DeleteFile(relPath, imageModel.StoragePoolID); err != nil {
+				log.Warnf("[ImageProcessor] Failed to delete replaced variant %s of %s: %v", relPath, imageModel.UUID, err)
+			}
+		}
+	}
+	if derived, err := models.FindDerivedVariantsByImageID(db, imageModel.ID); err == nil {
+		for i := range derived {
+			if err := DeleteDerivedVariant(db, imageModel.StoragePool, &derived[i]); err != nil {
+				log.Warnf("[ImageProcessor] Failed to delete derived variant %s of %s: %v", derived[i].Spec, imageModel.UUID, err)
+			}
+		}
+	}
+
+	imageModel.IsWatermarked = wm != nil
+	return db.Model(&models.Image{}).Where("id = ?", imageModel.ID).Updates(map[string]interface{}{
+		"revision":       imageModel.Revision,
+		"is_watermarked": imageModel.IsWatermarked,
+	}).Error
+}
@@ -0,0 +1,57 @@
+package imageprocessor_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+)
+
+func TestApplyWatermark(t *testing.T) {
+	img := imaging.New(200, 100, color.NRGBA{A: 255})
+	mark := imaging.New(10, 10, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+
+	cfg := models.WatermarkConfig{Position: models.WatermarkTopLeft, Opacity: 100, Scale: 10}
+	out := imageprocessor.ApplyWatermark(img, mark, cfg)
+	assert.Equal(t, img.Bounds(), out.Bounds())
+	// 10% of 200px = 20px mark, placed after a 2px margin
+	assert.Equal(t, color.NRGBA{R: 255, G: 255, B: 255, A: 255}, out.NRGBAAt(2, 2))
+	assert.Equal(t, color.NRGBA{R: 255, G: 255, B: 255, A: 255}, out.NRGBAAt(21, 21))
+	assert.Equal(t, color.NRGBA{A: 255}, out.NRGBAAt(22, 22))
+	assert.Equal(t, color.NRGBA{A: 255}, out.NRGBAAt(190, 90))
+	// the source image is left untouched
+	assert.Equal(t, color.NRGBA{A: 255}, img.NRGBAAt(2, 2))
+
+	cfg = models.WatermarkConfig{Position: models.WatermarkBottomRight, Opacity: 50, Scale: 10}
+	out = imageprocessor.ApplyWatermark(img, mark, cfg)
+	px := out.NRGBAAt(190, 90)
+	assert.InDelta(t, 128, int(px.R), 2)
+	assert.Equal(t, color.NRGBA{A: 255}, out.NRGBAAt(2, 2))
+}
+
+func TestRenderTextWatermark(t *testing.T) {
+	mark, err := imageprocessor.RenderTextWatermark("© PixelFox")
+	require.NoError(t, err)
+	assert.Greater(t, mark.Bounds().Dx(), mark.Bounds().Dy())
+	assert.False(t, mark.Opaque())
+
+	_, err = imageprocessor.RenderTextWatermark("  ")
+	assert.Error(t, err)
+}
+
+func TestValidateWatermarkImage(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 16, 16))))
+	assert.NoError(t, imageprocessor.ValidateWatermarkImage(buf.Bytes()))
+
+	assert.Error(t, imageprocessor.ValidateWatermarkImage([]byte("GIF89a")))
+	assert.Error(t, imageprocessor.ValidateWatermarkImage(make([]byte, models.MaxWatermarkImageBytes+1)))
+}
@@ -0,0 +1,195 @@
+package imageprocessor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gofiber/fiber/v2/log"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
+)
+
+// workspace gives the processing steps local files of an image. Files of local and NFS pools are
+// used in place; files of other backends, e.g. S3, are staged in a temporary directory: they are
+// downloaded on first use and changed or new files are written back with store.
+type workspace struct {
+	imageModel *models.Image
+	backend    storage.Backend // nil for legacy images without a storage pool
+	stageDir   string          // set when files are staged
+}
+
+// openWorkspace prepares the workspace of an image; the caller must close it
+func openWorkspace(imageModel *models.Image) (*workspace, error) {
+	ws := &workspace{imageModel: imageModel}
+	if imageModel.StoragePoolID > 0 && imageModel.StoragePool == nil {
+		if db := database.GetDB(); db != nil {
+			if pool, err := models.FindStoragePoolByID(db, imageModel.StoragePoolID); err == nil {
+				imageModel.StoragePool = pool
+			}
+		}
+	}
+	if imageModel.StoragePoolID == 0 || imageModel.StoragePool == nil {
+		return ws, nil
+	}
+
+	backend, err := storage.BackendFor(imageModel.StoragePool)
+	if err != nil {
+		return nil, err
+	}
+	ws.backend = backend
+	if _, ok := backend.(storage.LocalBackend); !ok {
+		dir, err := os.MkdirTemp("", "pixelfox-stage-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create staging directory: %w", err)
+		}
+		ws.stageDir = dir
+	}
+	return ws, nil
+}
+
+// close removes staged files
+func (ws *workspace) close() {
+	if ws.stageDir != "" {
+		if err := os.RemoveAll(ws.stageDir); err != nil {
+			log.Warnf("[ImageProcessor] Failed to remove staging directory %s: %v", ws.stageDir, err)
+		}
+	}
+}
+
+func (ws *workspace) staged() bool {
+	return ws.stageDir != ""
+}
+
+// localPath returns where a file of the pool is worked on
+func (ws *workspace) localPath(relativePath string) string {
+	switch {
+	case ws.staged():
+		return filepath.Join(ws.stageDir, filepath.FromSlash(relativePath))
+	case ws.backend != nil:
+		return ws.backend.(storage.LocalBackend).LocalPath(relativePath)
+	}
+	// Legacy images are stored relative to the working directory
+	return filepath.FromSlash(relativePath)
+}
+
+// originalRelPath returns the path of the current original within the pool
+func (ws *workspace) originalRelPath() string {
+	return path.Join(filepath.ToSlash(ws.imageModel.FilePath), ws.imageModel.FileName)
+}
+
+// original returns the local path of the current original, downloading it first if staged
+func (ws *workspace) original() (string, error) {
+	return ws.fetch(ws.originalRelPath())
+}
+
+// fetch returns the local path of a file of the pool, downloading it first if staged
+func (ws *workspace) fetch(relativePath string) (string, error) {
+	localPath := ws.localPath(relativePath)
+	if _, err := os.Stat(localPath); err == nil || !ws.staged() {
+		return localPath, err
+	}
+
+	src, err := ws.backend.Open(relativePath)
+	if err != nil {
+		return localPath, err
+	}
+	defer src.Close()
+	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return localPath, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	dst, err := os.Create(localPath)
+	if err != nil {
+		return localPath, fmt.Errorf("failed to stage %s: %w", relativePath, err)
+	}
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(localPath)
+		return localPath, fmt.Errorf("failed to stage %s: %w", relativePath, err)
+	}
+	return localPath, nil
+}
+
+// store writes a changed or new file back to the pool and returns its size
+func (ws *workspace) store(relativePath string) (int64, error) {
+	localPath := ws.localPath(relativePath)
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return 0, err
+	}
+	if !ws.staged() {
+		return info.Size(), nil
+	}
+
+	f, err := os.Open(localPath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return ws.backend.Create(relativePath, f)
+}
+
+// contentHash returns the SHA-256 of a file in the workspace, see models.Image.StoredFileHash
+func (ws *workspace) contentHash(relativePath string) (string, error) {
+	f, err := os.Open(ws.localPath(relativePath))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// variantsDir creates and returns the local directory variants are written to
+func (ws *workspace) variantsDir() (string, error) {
+	dir := variantsBaseDirFor(ws.imageModel)
+	if ws.backend != nil {
+		dir = ws.localPath(variantsRelDir(ws.imageModel))
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return dir, fmt.Errorf("failed to create variants directory: %w", err)
+	}
+	return dir, nil
+}
+
+// storeVariants writes generated variants back to the pool and records their sizes.
+// Variants whose file is missing are dropped.
+func (ws *workspace) storeVariants(generated []GeneratedVariant) []GeneratedVariant {
+	stored := make([]GeneratedVariant, 0, len(generated))
+	for _, gv := range generated {
+		var err error
+		if ws.backend == nil {
+			var info os.FileInfo
+			if info, err = os.Stat(filepath.Join(variantsBaseDirFor(ws.imageModel), gv.FileName)); err == nil {
+				gv.Size = info.Size()
+			}
+		} else {
+			gv.Size, err = ws.store(path.Join(variantsRelDir(ws.imageModel), gv.FileName))
+		}
+		if err != nil {
+			log.Errorf("[ImageProcessor] Variant file %s of %s missing: %v", gv.FileName, ws.imageModel.UUID, err)
+			continue
+		}
+		stored = append(stored, gv)
+	}
+	return stored
+}
+
+// variantsRelDir returns the directory of an image's variants within its pool, e.g. "variants/2025/08/10"
+func variantsRelDir(imageModel *models.Image) string {
+	relativePath := strings.TrimPrefix(filepath.ToSlash(imageModel.FilePath), "original/")
+	return path.Join("variants", strings.TrimPrefix(relativePath, "/"))
+}
@@ -0,0 +1,205 @@
+package imageprocessor_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
+)
+
+// nextTestPoolID keeps pool IDs unique, storage caches backends per pool
+var nextTestPoolID uint = 9200
+
+// newS3TestPool returns a storage pool backed by an in-memory S3 server
+func newS3TestPool(t *testing.T) (*models.StoragePool, storage.Backend) {
+	s3 := s3mem.New()
+	require.NoError(t, s3.CreateBucket("pixelfox"))
+	srv := httptest.NewServer(gofakes3.New(s3).Server())
+	t.Cleanup(srv.Close)
+
+	accessKey, secretKey, region, bucket, endpoint := "key", "secret", "us-east-1", "pixelfox", srv.URL
+	nextTestPoolID++
+	pool := &models.StoragePool{
+		ID:                nextTestPoolID,
+		Name:              "cold",
+		StorageType:       models.StorageTypeS3,
+		S3AccessKeyID:     &accessKey,
+		S3SecretAccessKey: &secretKey,
+		S3Region:          &region,
+		S3BucketName:      &bucket,
+		S3EndpointURL:     &endpoint,
+	}
+	backend, err := storage.BackendFor(pool)
+	require.NoError(t, err)
+	return pool, backend
+}
+
+// storeTestOriginal uploads a testdata fixture as the original of a new image in the pool
+func storeTestOriginal(t *testing.T, pool *models.StoragePool, backend storage.Backend, fixture string) *models.Image {
+	imageUUID := uuid.New().String()
+	fileType := filepath.Ext(fixture)
+	image := &models.Image{
+		ID:            1,
+		UUID:          imageUUID,
+		StoragePoolID: pool.ID,
+		StoragePool:   pool,
+		FilePath:      "original/2025/08/10",
+		FileName:      imageUUID + fileType,
+		FileType:      fileType,
+	}
+	src, err := os.Open(filepath.Join("testdata", fixture))
+	require.NoError(t, err)
+	_, err = backend.Create(path.Join(image.FilePath, image.FileName), src)
+	require.NoError(t, src.Close())
+	require.NoError(t, err)
+	return image
+}
+
+// captureGeneratedVariants replaces the record update and returns the variants it was given
+func captureGeneratedVariants(t *testing.T) *[]imageprocessor.GeneratedVariant {
+	var generated []imageprocessor.GeneratedVariant
+	prev := imageprocessor.UpdateImageRecordFunc
+	imageprocessor.UpdateImageRecordFunc = func(_ *models.Image, width, height int, gv []imageprocessor.GeneratedVariant) error {
+		assert.Positive(t, width)
+		assert.Positive(t, height)
+		generated = gv
+		return nil
+	}
+	t.Cleanup(func() { imageprocessor.UpdateImageRecordFunc = prev })
+	return &generated
+}
+
+// TestProcessImageFromS3Pool processes an original held in an S3 pool through temp-file staging
+func TestProcessImageFromS3Pool(t *testing.T) {
+	pool, backend := newS3TestPool(t)
+	// browsers cannot show TIFF, so a web variant is always rendered
+	image := storeTestOriginal(t, pool, backend, "image.tiff")
+	generated := captureGeneratedVariants(t)
+
+	require.NoError(t, imageprocessor.ProcessImageSync(image))
+	require.NotEmpty(t, *generated)
+	for _, gv := range *generated {
+		info, err := backend.Stat(path.Join("variants/2025/08/10", gv.FileName))
+		require.NoError(t, err, gv.FileName)
+		assert.Equal(t, info.Size, gv.Size, gv.FileName)
+	}
+
+	// Nothing is written next to the working directory
+	_, err := os.Stat("variants")
+	assert.True(t, os.IsNotExist(err))
+}
+
+// TestProcessImageJPEGXL decodes a JPEG XL original through ffmpeg and renders its JPEG web variant
+func TestProcessImageJPEGXL(t *testing.T) {
+	if !imageprocessor.IsFFmpegAvailable {
+		t.Skip("ffmpeg is required to decode JPEG XL")
+	}
+	pool, backend := newS3TestPool(t)
+	image := storeTestOriginal(t, pool, backend, "image.jxl")
+	generated := captureGeneratedVariants(t)
+
+	require.NoError(t, imageprocessor.ProcessImageSync(image))
+	var web *imageprocessor.GeneratedVariant
+	for i, gv := range *generated {
+		if gv.Profile.Name == models.VariantTypeWeb {
+			web = &(*generated)[i]
+		}
+	}
+	require.NotNil(t, web, "JPEG XL originals get a web variant")
+	assert.Equal(t, ".jpg", filepath.Ext(web.FileName))
+	assert.Equal(t, 256, web.Width)
+	assert.Equal(t, 256, web.Height)
+	_, err := backend.Stat(path.Join("variants/2025/08/10", web.FileName))
+	require.NoError(t, err)
+}
+
+// gpsTIFF returns a 16x16 grayscale TIFF whose IFD0 points to a GPS IFD
+func gpsTIFF() []byte {
+	le := binary.LittleEndian
+	entry := func(b []byte, tag, typ uint16, count, value uint32) []byte {
+		b = le.AppendUint16(b, tag)
+		b = le.AppendUint16(b, typ)
+		b = le.AppendUint32(b, count)
+		return le.AppendUint32(b, value)
+	}
+	const gpsIFD, latOffset, lonOffset, pixelOffset = 122, 176, 200, 224
+
+	b := []byte{'I', 'I', 42, 0, 8, 0, 0, 0}
+	b = le.AppendUint16(b, 9)
+	b = entry(b, 256, 3, 1, 16)          // ImageWidth
+	b = entry(b, 257, 3, 1, 16)          // ImageLength
+	b = entry(b, 258, 3, 1, 8)           // BitsPerSample
+	b = entry(b, 259, 3, 1, 1)           // Compression: none
+	b = entry(b, 262, 3, 1, 1)           // PhotometricInterpretation: black is zero
+	b = entry(b, 273, 4, 1, pixelOffset) // StripOffsets
+	b = entry(b, 278, 3, 1, 16)          // RowsPerStrip
+	b = entry(b, 279, 4, 1, 256)         // StripByteCounts
+	b = entry(b, 34853, 4, 1, gpsIFD)    // GPSInfo
+	b = le.AppendUint32(b, 0)
+
+	b = le.AppendUint16(b, 4)
+	b = entry(b, 1, 2, 2, uint32('N')) // GPSLatitudeRef
+	b = entry(b, 2, 5, 3, latOffset)   // GPSLatitude
+	b = entry(b, 3, 2, 2, uint32('E')) // GPSLongitudeRef
+	b = entry(b, 4, 5, 3, lonOffset)   // GPSLongitude
+	b = le.AppendUint32(b, 0)
+
+	for _, v := range []uint32{52, 1, 31, 1, 12, 1, 13, 1, 24, 1, 36, 1} {
+		b = le.AppendUint32(b, v)
+	}
+	for i := 0; i < 256; i++ {
+		b = append(b, byte(i))
+	}
+	return b
+}
+
+// TestProcessImageStoresInPlaceStrip stores and re-hashes an original whose location was
+// removed without changing its size
+func TestProcessImageStoresInPlaceStrip(t *testing.T) {
+	data := gpsTIFF()
+	fixture := filepath.Join(t.TempDir(), "gps.tiff")
+	require.NoError(t, os.WriteFile(fixture, data, 0644))
+	parsed := &models.Image{}
+	require.NoError(t, imageprocessor.ExtractMetadata(parsed, fixture))
+	require.NotNil(t, parsed.Metadata.Latitude, "fixture must carry a location")
+
+	pool, backend := newS3TestPool(t)
+	image := storeTestOriginal(t, pool, backend, "image.tiff")
+	_, err := backend.Create(path.Join(image.FilePath, image.FileName), bytes.NewReader(data))
+	require.NoError(t, err)
+	image.MetadataPolicy = models.MetadataPolicyStripLocation
+	captureGeneratedVariants(t)
+
+	require.NoError(t, imageprocessor.ProcessImageSync(image))
+
+	rc, err := backend.Open(path.Join(image.FilePath, image.FileName))
+	require.NoError(t, err)
+	stored, err := io.ReadAll(rc)
+	require.NoError(t, rc.Close())
+	require.NoError(t, err)
+	assert.Len(t, stored, len(data), "the location is removed in place")
+	assert.NotEqual(t, data, stored, "the scrubbed original must replace the stored one")
+	sum := sha256.Sum256(stored)
+	assert.Equal(t, hex.EncodeToString(sum[:]), image.ContentHash)
+
+	require.NoError(t, os.WriteFile(fixture, stored, 0644))
+	parsed = &models.Image{}
+	require.NoError(t, imageprocessor.ExtractMetadata(parsed, fixture))
+	assert.Nil(t, parsed.Metadata.Latitude)
+}
@@ -53,6 +53,9 @@ func (q *Queue) processDeleteImageJob(ctx context.Context, job *Job) error {
 		return nil // nothing to do
 	}
 
+	// Replica files are deleted by their pool's node; the paths are resolved before the variants are gone
+	q.enqueueDeleteReplicas(db, &image)
+
 	// Delete files + soft-delete DB records (variants + image). This is idempotent enough.
 	if err := imageprocessor.DeleteImageAndVariants(&image); err != nil {
 		return fmt.Errorf("failed to delete image and variants: %w", err)
@@ -61,7 +64,17 @@ func (q *Queue) processDeleteImageJob(ctx context.Context, job *Job) error {
 	// Hard delete variants + metadata + image to avoid DB bloat.
 	_ = db.Unscoped().Where("image_id = ?", image.ID).Delete(&models.ImageVariant{}).Error
 	_ = db.Unscoped().Where("image_id = ?", image.ID).Delete(&models.ImageMetadata{}).Error
+	_ = db.Unscoped().Where("image_id = ?", image.ID).Delete(&models.Comment{}).Error
+	_ = db.Where("image_id = ?", image.ID).Delete(&models.Like{}).Error
+	_ = db.Where("image_id = ?", image.ID).Delete(&models.ImageHashBand{}).Error
+	var tagIDs []uint
+	_ = db.Model(&models.ImageTag{}).Where("image_id = ?", image.ID).Pluck("tag_id", &tagIDs).Error
+	_ = db.Where("image_id = ?", image.ID).Delete(&models.ImageTag{}).Error
 	_ = db.Unscoped().Delete(&image).Error
+	if len(tagIDs) > 0 {
+		_ = models.RefreshTagCounts(db, tagIDs)
+		_, _ = models.PruneUnusedTags(db, tagIDs)
+	}
 	log.Infof("[DeleteImageJob] Hard-deleted DB records for image %s", image.UUID)
 
 	// If this deletion was triggered from a report, mark it resolved (idempotent)
@@ -74,6 +87,8 @@ func (q *Queue) processDeleteImageJob(ctx context.Context, job *Job) error {
 				"resolved_by_id": payload.InitiatedByID,
 				"resolved_at":    now,
 			}).Error
+		_ = models.CreateNotification(db, image.UserID, models.NotificationTypeReportResolved,
+			fmt.Sprintf("Dein Bild \"%s\" wurde nach einer Meldung entfernt.", image.DisplayName()), "/user/images", *payload.FromReportID)
 	}
 
 	log.Infof("[DeleteImageJob] Completed delete for image %s (ID: %d)", image.UUID, image.ID)
@@ -2,6 +2,7 @@ package jobqueue
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,6 +14,7 @@ import (
 	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
 )
 
 var ErrRequeue = fmt.Errorf("requeue job for another node")
@@ -54,18 +56,25 @@ func (q *Queue) processImageProcessingJob(ctx context.Context, job *Job) error {
 		}
 	}
 
-	// Verify the original file exists using storage pool-aware path construction
-	var originalFilePath string
+	// Verify the original file exists in its storage pool
 	if image.StoragePoolID > 0 && image.StoragePool != nil {
-		// Use storage pool base path
-		originalFilePath = filepath.Join(image.StoragePool.BasePath, image.FilePath, image.FileName)
+		backend, err := storage.BackendFor(image.StoragePool)
+		if err != nil {
+			return err
+		}
+		originalPath := filepath.Join(image.FilePath, image.FileName)
+		if _, err := backend.Stat(originalPath); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("original file not found: %s in pool %s", originalPath, image.StoragePool.Name)
+			}
+			return fmt.Errorf("failed to check original file %s: %w", originalPath, err)
+		}
 	} else {
 		// Fallback to legacy path
-		originalFilePath = fmt.Sprintf("%s/%s", payload.FilePath, payload.FileName)
-	}
-
-	if _, err := os.Stat(originalFilePath); os.IsNotExist(err) {
-		return fmt.Errorf("original file not found: %s", originalFilePath)
+		originalFilePath := fmt.Sprintf("%s/%s", payload.FilePath, payload.FileName)
+		if _, err := os.Stat(originalFilePath); os.IsNotExist(err) {
+			return fmt.Errorf("original file not found: %s", originalFilePath)
+		}
 	}
 
 	// Set image status to processing using cache
@@ -84,6 +93,14 @@ func (q *Queue) processImageProcessingJob(ctx context.Context, job *Job) error {
 		return fmt.Errorf("image processing failed for %s: %w", payload.ImageUUID, err)
 	}
 
+	// Write the replicas of the tier before the upload counts as completed. Failures are caught up by the replication sweep.
+	var processed models.Image
+	if err := db.Preload("StoragePool").First(&processed, image.ID).Error; err != nil {
+		log.Errorf("[JobQueue] Failed to reload image %s for replication: %v", payload.ImageUUID, err)
+	} else if err := q.replicateImage(db, &processed); err != nil {
+		log.Errorf("[JobQueue] Replication of %s failed: %v", payload.ImageUUID, err)
+	}
+
 	// Set completed status in cache
 	if err := imageprocessor.SetImageStatus(payload.ImageUUID, imageprocessor.STATUS_COMPLETED); err != nil {
 		log.Errorf("[JobQueue] Failed to set completed status for %s: %v", payload.ImageUUID, err)
@@ -109,3 +126,23 @@ func (q *Queue) processImageCore(imageModel *models.Image) error {
 
 	return imageprocessor.ProcessImageSync(imageModel)
 }
+
+// notifyImageProcessingFailed informs the image owner once all retries of a processing job are exhausted
+func notifyImageProcessingFailed(job *Job) {
+	payload, err := ImageProcessingJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return
+	}
+	db := database.GetDB()
+	if db == nil {
+		return
+	}
+	var image models.Image
+	if err := db.Where("uuid = ?", payload.ImageUUID).First(&image).Error; err != nil {
+		return
+	}
+	content := fmt.Sprintf("Die Verarbeitung deines Bildes \"%s\" ist fehlgeschlagen.", image.DisplayName())
+	if err := models.CreateNotification(db, image.UserID, models.NotificationTypeProcessingFailed, content, "/image/"+image.UUID, image.ID); err != nil {
+		log.Errorf("[JobQueue] Failed to create processing failure notification for %s: %v", image.UUID, err)
+	}
+}
@@ -0,0 +1,534 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
+)
+
+const (
+	integrityScrubCheckInterval = time.Hour
+	integrityScrubImageBatch    = 100
+	integrityScrubOrphanBatch   = 500
+	// Files younger than this may belong to an upload whose database row is not written yet
+	integrityScrubOrphanMinAge = 24 * time.Hour
+	// Slow batches refresh the job so the stuck sweeper does not hand it to another worker
+	integrityScrubHeartbeat = time.Minute
+)
+
+// ErrScrubRunning is returned when a pool is already being scrubbed
+var ErrScrubRunning = errors.New("integrity scrub already running for this pool")
+
+// integrityScrubWorker periodically starts the integrity scrub of pools whose last scrub is due
+func (m *Manager) integrityScrubWorker() {
+	defer m.wg.Done()
+	for {
+		select {
+		case <-m.stopCh:
+			log.Info("[JobQueue Manager] Integrity scrub worker stopping")
+			return
+		case <-m.scrubTicker.C:
+			if err := m.runIntegrityScrubOnce(time.Now()); err != nil {
+				log.Errorf("[JobQueue Manager] Integrity scrub error: %v", err)
+			}
+		}
+	}
+}
+
+// runIntegrityScrubOnce starts a scrub for every pool that was not scrubbed within the configured interval
+func (m *Manager) runIntegrityScrubOnce(now time.Time) error {
+	settings := getAppSettings()
+	if settings == nil || !settings.IsIntegrityScrubEnabled() {
+		return nil
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	pools, err := models.FindAllStoragePools(db)
+	if err != nil {
+		return fmt.Errorf("failed to list storage pools: %w", err)
+	}
+	interval := time.Duration(settings.GetIntegrityScrubIntervalHours()) * time.Hour
+	for _, pool := range pools {
+		last, err := models.FindLatestScrubReport(db, pool.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Errorf("[IntegrityScrub] Failed to load last report of pool %s: %v", pool.Name, err)
+			continue
+		}
+		if last != nil && now.Sub(last.StartedAt) < interval {
+			continue
+		}
+		if _, err := m.queue.StartIntegrityScrub(pool.ID); err != nil && !errors.Is(err, ErrScrubRunning) {
+			log.Errorf("[IntegrityScrub] Failed to start scrub of pool %s: %v", pool.Name, err)
+		}
+	}
+	return nil
+}
+
+// StartIntegrityScrub creates a report and enqueues the first batch of the scrub of a pool
+func (q *Queue) StartIntegrityScrub(poolID uint) (*models.ScrubReport, error) {
+	db := database.GetDB()
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+	now := time.Now()
+	last, err := models.FindLatestScrubReport(db, poolID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if last != nil && last.Status == models.ScrubReportRunning {
+		if !last.IsStale(now) {
+			return nil, ErrScrubRunning
+		}
+		_ = models.FinishScrubReport(db, last.ID, "Abgebrochen: keine Fortschritte mehr", now)
+	}
+
+	report, err := models.StartScrubReport(db, poolID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scrub report: %w", err)
+	}
+	payload := IntegrityScrubJobPayload{PoolID: poolID, ReportID: report.ID, Phase: ScrubPhaseImages}
+	if _, err := q.EnqueueJob(JobTypeIntegrityScrub, payload.ToMap()); err != nil {
+		_ = models.FinishScrubReport(db, report.ID, err.Error(), now)
+		return nil, err
+	}
+	log.Infof("[IntegrityScrub] Started scrub %d of pool %d", report.ID, poolID)
+	return report, nil
+}
+
+// processIntegrityScrubJob checks one batch of a pool and enqueues the next one
+func (q *Queue) processIntegrityScrubJob(ctx context.Context, job *Job) error {
+	payload, err := IntegrityScrubJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid integrity scrub payload: %w", err)
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	report, err := models.FindScrubReportByID(db, payload.ReportID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warnf("[IntegrityScrub] Report %d not found; dropping job %s", payload.ReportID, job.ID)
+			return nil
+		}
+		return fmt.Errorf("failed to load scrub report: %w", err)
+	}
+	if report.Status != models.ScrubReportRunning {
+		return nil
+	}
+	pool, err := models.FindStoragePoolByID(db, payload.PoolID)
+	if err != nil {
+		_ = models.FinishScrubReport(db, report.ID, fmt.Sprintf("Speicherpool nicht gefunden: %v", err), time.Now())
+		return nil
+	}
+
+	// Node routing: files of local pools can only be checked on the pool's node
+	nodeID := strings.TrimSpace(env.GetEnv("NODE_ID", ""))
+	if poolNode := strings.TrimSpace(pool.NodeID); nodeID != "" && poolNode != "" && !strings.EqualFold(nodeID, poolNode) {
+		if err := q.requeueJob(ctx, job); err != nil {
+			log.Errorf("[IntegrityScrub] Failed to requeue job %s for node routing: %v", job.ID, err)
+		}
+		return ErrRequeue
+	}
+
+	backend, err := storage.BackendFor(pool)
+	if err != nil {
+		_ = models.FinishScrubReport(db, report.ID, err.Error(), time.Now())
+		return nil
+	}
+	rate := 20
+	if settings := getAppSettings(); settings != nil {
+		rate = settings.GetIntegrityScrubFilesPerSecond()
+	}
+	s := &poolScrub{
+		db:       db,
+		pool:     pool,
+		backend:  backend,
+		reportID: report.ID,
+		throttle: newScrubThrottle(rate),
+		lastBeat: time.Now(),
+		heartbeat: func() {
+			now := time.Now()
+			job.ProcessedAt = &now
+			job.UpdatedAt = now
+			q.updateJob(ctx, job)
+		},
+	}
+
+	switch payload.Phase {
+	case ScrubPhaseImages:
+		next, done, err := s.checkImages(payload.CursorID)
+		if err != nil {
+			_ = models.FinishScrubReport(db, report.ID, err.Error(), time.Now())
+			return nil
+		}
+		nextPayload := IntegrityScrubJobPayload{PoolID: pool.ID, ReportID: report.ID, Phase: ScrubPhaseImages, CursorID: next}
+		if done {
+			nextPayload = IntegrityScrubJobPayload{PoolID: pool.ID, ReportID: report.ID, Phase: ScrubPhaseOrphans}
+		}
+		if _, err := q.EnqueueJob(JobTypeIntegrityScrub, nextPayload.ToMap()); err != nil {
+			_ = models.FinishScrubReport(db, report.ID, fmt.Sprintf("Nächster Abschnitt konnte nicht eingereiht werden: %v", err), time.Now())
+		}
+		return nil
+	case ScrubPhaseOrphans:
+		errMsg := ""
+		if err := s.findOrphans(time.Now()); err != nil {
+			errMsg = err.Error()
+		}
+		if err := models.FinishScrubReport(db, report.ID, errMsg, time.Now()); err != nil {
+			return fmt.Errorf("failed to finish scrub report: %w", err)
+		}
+		log.Infof("[IntegrityScrub] Finished scrub %d of pool %s", report.ID, pool.Name)
+		return nil
+	}
+	_ = models.FinishScrubReport(db, report.ID, fmt.Sprintf("Unbekannte Phase %q", payload.Phase), time.Now())
+	return nil
+}
+
+// poolScrub checks the files of one storage pool
+type poolScrub struct {
+	db        *gorm.DB
+	pool      *models.StoragePool
+	backend   storage.Backend
+	reportID  uint
+	throttle  *scrubThrottle
+	heartbeat func()
+	lastBeat  time.Time
+}
+
+// beat refreshes the job if the last refresh is a while ago
+func (s *poolScrub) beat() {
+	if time.Since(s.lastBeat) >= integrityScrubHeartbeat {
+		s.heartbeat()
+		s.lastBeat = time.Now()
+	}
+}
+
+func (s *poolScrub) addFinding(f models.ScrubFinding) error {
+	f.ReportID = s.reportID
+	f.StoragePoolID = s.pool.ID
+	f.Status = models.ScrubFindingOpen
+	return s.db.Create(&f).Error
+}
+
+// checkImages checks the originals and variants of the next batch of images after cursor.
+// It returns the new cursor and whether all images were checked.
+func (s *poolScrub) checkImages(cursor uint) (uint, bool, error) {
+	var images []models.Image
+	if err := s.db.Where("storage_pool_id = ? AND id > ?", s.pool.ID, cursor).
+		Order("id ASC").Limit(integrityScrubImageBatch).Find(&images).Error; err != nil {
+		return cursor, false, fmt.Errorf("failed to list images: %w", err)
+	}
+	if len(images) == 0 {
+		return cursor, true, nil
+	}
+
+	var progress models.ScrubProgress
+	for i := range images {
+		image := &images[i]
+		cursor = image.ID
+		// Files of images in the upload pipeline may not be written yet
+		if status, err := imageprocessor.GetImageStatus(image.UUID); err == nil &&
+			(status == imageprocessor.STATUS_PENDING || status == imageprocessor.STATUS_PROCESSING) {
+			continue
+		}
+		if err := s.checkOriginal(image, &progress); err != nil {
+			return cursor, false, err
+		}
+		if err := s.checkVariants(image, &progress); err != nil {
+			return cursor, false, err
+		}
+		progress.ImagesChecked++
+		s.beat()
+	}
+	if err := models.AddScrubProgress(s.db, s.reportID, progress); err != nil {
+		return cursor, false, fmt.Errorf("failed to update scrub report: %w", err)
+	}
+	return cursor, len(images) < integrityScrubImageBatch, nil
+}
+
+// checkOriginal re-hashes the original against the hash recorded for it, or only stats it if none is recorded
+func (s *poolScrub) checkOriginal(image *models.Image, progress *models.ScrubProgress) error {
+	relativePath := path.Join(filepath.ToSlash(image.FilePath), image.FileName)
+	expected := image.StoredFileHash()
+	s.throttle.wait()
+
+	var actual string
+	var err error
+	if expected != "" {
+		var size int64
+		actual, size, err = storage.Checksum(s.backend, relativePath)
+		progress.BytesHashed += size
+	} else {
+		_, err = s.backend.Stat(relativePath)
+	}
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		progress.MissingCount++
+		return s.addFinding(models.ScrubFinding{
+			Kind:         models.ScrubFindingMissingOriginal,
+			ImageID:      image.ID,
+			FilePath:     relativePath,
+			FileSize:     image.FileSize,
+			ExpectedHash: expected,
+		})
+	case err != nil:
+		return fmt.Errorf("failed to check %s: %w", relativePath, err)
+	case expected == "" || actual == expected:
+		return nil
+	}
+
+	// The image may have been edited while the file was read
+	var current models.Image
+	if err := s.db.Select("id", "file_path", "file_name", "file_hash", "content_hash").First(&current, image.ID).Error; err != nil {
+		return nil
+	}
+	if current.FilePath != image.FilePath || current.FileName != image.FileName || current.StoredFileHash() != expected {
+		return nil
+	}
+	progress.CorruptCount++
+	return s.addFinding(models.ScrubFinding{
+		Kind:         models.ScrubFindingCorruptOriginal,
+		ImageID:      image.ID,
+		FilePath:     relativePath,
+		FileSize:     image.FileSize,
+		ExpectedHash: expected,
+		ActualHash:   actual,
+	})
+}
+
+// checkVariants stats the variants of the image that are stored in this pool
+func (s *poolScrub) checkVariants(image *models.Image, progress *models.ScrubProgress) error {
+	var variants []models.ImageVariant
+	if err := s.db.Where("image_id = ? AND (storage_pool_id = ? OR storage_pool_id IS NULL OR storage_pool_id = 0)", image.ID, s.pool.ID).
+		Find(&variants).Error; err != nil {
+		return fmt.Errorf("failed to list variants of image %d: %w", image.ID, err)
+	}
+	for i := range variants {
+		variant := &variants[i]
+		relativePath := imageprocessor.VariantRelativePath(variant, s.pool)
+		if relativePath == "" {
+			continue
+		}
+		s.throttle.wait()
+		progress.VariantsChecked++
+		_, err := s.backend.Stat(relativePath)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to check %s: %w", relativePath, err)
+		}
+		progress.MissingCount++
+		if err := s.addFinding(models.ScrubFinding{
+			Kind:      models.ScrubFindingMissingVariant,
+			ImageID:   image.ID,
+			VariantID: variant.ID,
+			FilePath:  relativePath,
+			FileSize:  variant.FileSize,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findOrphans lists the originals and variants of the pool and records files no image,
+// variant or cached transformation refers to
+func (s *poolScrub) findOrphans(now time.Time) error {
+	var batch []storage.FileInfo
+	var progress models.ScrubProgress
+	flush := func() error {
+		if len(batch) > 0 {
+			s.throttle.waitN(len(batch))
+			if err := s.recordOrphans(batch, &progress); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+		if err := models.AddScrubProgress(s.db, s.reportID, progress); err != nil {
+			return fmt.Errorf("failed to update scrub report: %w", err)
+		}
+		progress = models.ScrubProgress{}
+		s.beat()
+		return nil
+	}
+
+	for _, prefix := range []string{"original", "variants"} {
+		err := s.backend.List(prefix, func(fi storage.FileInfo) error {
+			progress.FilesListed++
+			if !fi.ModTime.IsZero() && now.Sub(fi.ModTime) < integrityScrubOrphanMinAge {
+				return nil
+			}
+			batch = append(batch, fi)
+			if len(batch) >= integrityScrubOrphanBatch {
+				return flush()
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", prefix, err)
+		}
+	}
+	return flush()
+}
+
+// recordOrphans records the files of the batch that nothing refers to
+func (s *poolScrub) recordOrphans(files []storage.FileInfo, progress *models.ScrubProgress) error {
+	uuids := make([]string, 0, len(files))
+	seen := make(map[string]bool, len(files))
+	for _, fi := range files {
+		if id := fileUUID(fi.Path); id != "" && !seen[id] {
+			seen[id] = true
+			uuids = append(uuids, id)
+		}
+	}
+	referenced, err := s.referencedFiles(uuids)
+	if err != nil {
+		return err
+	}
+	for _, fi := range files {
+		if referenced[fi.Path] {
+			continue
+		}
+		progress.OrphanCount++
+		if err := s.addFinding(models.ScrubFinding{
+			Kind:     models.ScrubFindingOrphan,
+			FilePath: fi.Path,
+			FileSize: fi.Size,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// referencedFiles returns the pool paths of all files that belong to the images with the given UUIDs.
+// Soft-deleted images still count; their files are removed by the delete job.
+func (s *poolScrub) referencedFiles(uuids []string) (map[string]bool, error) {
+	referenced := make(map[string]bool)
+	if len(uuids) == 0 {
+		return referenced, nil
+	}
+	var images []models.Image
+	if err := s.db.Unscoped().Select("id", "storage_pool_id", "file_path", "file_name", "previous_file_name").
+		Where("uuid IN ?", uuids).Find(&images).Error; err != nil {
+		return nil, fmt.Errorf("failed to load images: %w", err)
+	}
+	if len(images) == 0 {
+		return referenced, nil
+	}
+	ids := make([]uint, 0, len(images))
+	for _, image := range images {
+		ids = append(ids, image.ID)
+	}
+	// Replicas in this pool hold the files the image keeps in its primary pool
+	var replicated []uint
+	if err := s.db.Model(&models.ImageReplica{}).Where("image_id IN ? AND storage_pool_id = ?", ids, s.pool.ID).
+		Pluck("image_id", &replicated).Error; err != nil {
+		return nil, fmt.Errorf("failed to load replicas: %w", err)
+	}
+	replicaOf := make(map[uint]uint, len(replicated))
+	for _, id := range replicated {
+		replicaOf[id] = 0
+	}
+	inPool := make(map[uint]bool, len(images))
+	for _, image := range images {
+		if _, ok := replicaOf[image.ID]; ok {
+			replicaOf[image.ID] = image.StoragePoolID
+		} else if image.StoragePoolID != s.pool.ID {
+			continue
+		}
+		inPool[image.ID] = true
+		dir := filepath.ToSlash(image.FilePath)
+		referenced[path.Join(dir, image.FileName)] = true
+		if image.PreviousFileName != "" {
+			referenced[path.Join(dir, image.PreviousFileName)] = true
+		}
+	}
+
+	var variants []models.ImageVariant
+	if err := s.db.Unscoped().Where("image_id IN ?", ids).Find(&variants).Error; err != nil {
+		return nil, fmt.Errorf("failed to load variants: %w", err)
+	}
+	for i := range variants {
+		v := &variants[i]
+		if v.StoragePoolID == s.pool.ID || (v.StoragePoolID == 0 && inPool[v.ImageID]) {
+			referenced[imageprocessor.VariantRelativePath(v, s.pool)] = true
+		} else if primary, ok := replicaOf[v.ImageID]; ok && v.StoragePoolID == primary {
+			referenced[imageprocessor.VariantRelativePath(v, s.pool)] = true
+		}
+	}
+
+	var derived []models.DerivedVariant
+	if err := s.db.Select("file_path").Where("image_id IN ? AND storage_pool_id = ?", ids, s.pool.ID).
+		Find(&derived).Error; err != nil {
+		return nil, fmt.Errorf("failed to load transformations: %w", err)
+	}
+	for _, dv := range derived {
+		referenced[filepath.ToSlash(dv.FilePath)] = true
+	}
+	return referenced, nil
+}
+
+// fileUUID returns the image UUID a stored file name starts with, e.g. "<uuid>_thumb_small.webp"
+func fileUUID(relativePath string) string {
+	name := path.Base(relativePath)
+	if len(name) < 36 {
+		return ""
+	}
+	id, err := uuid.Parse(name[:36])
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
+
+// scrubThrottle limits how many files a scrub touches per second
+type scrubThrottle struct {
+	interval time.Duration
+	next     time.Time
+	sleep    func(time.Duration)
+	now      func() time.Time
+}
+
+func newScrubThrottle(filesPerSecond int) *scrubThrottle {
+	if filesPerSecond < 1 {
+		filesPerSecond = 1
+	}
+	return &scrubThrottle{interval: time.Second / time.Duration(filesPerSecond), sleep: time.Sleep, now: time.Now}
+}
+
+func (t *scrubThrottle) wait() {
+	t.waitN(1)
+}
+
+// waitN blocks until n more files may be touched
+func (t *scrubThrottle) waitN(n int) {
+	now := t.now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	if d := t.next.Sub(now); d > 0 {
+		t.sleep(d)
+	}
+	t.next = t.next.Add(time.Duration(n) * t.interval)
+}
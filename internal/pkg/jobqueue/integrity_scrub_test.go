@@ -0,0 +1,45 @@
+package jobqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileUUID(t *testing.T) {
+	const id = "0f8fad5b-d9cb-469f-a165-70867728950e"
+	assert.Equal(t, id, fileUUID("original/2025/08/10/"+id+".jpg"))
+	assert.Equal(t, id, fileUUID("variants/2025/08/10/"+id+"_r2_thumb_small.webp"))
+	assert.Equal(t, id, fileUUID("variants/2025/08/10/"+id+"_t_0a1b2c3d4e5f.png"))
+	assert.Empty(t, fileUUID("original/2025/08/10/.DS_Store"))
+	assert.Empty(t, fileUUID("original/2025/08/10/not-a-uuid-but-long-enough-to-parse.jpg"))
+}
+
+func TestScrubThrottle(t *testing.T) {
+	now := time.Unix(1000, 0)
+	var slept time.Duration
+	throttle := newScrubThrottle(10)
+	throttle.now = func() time.Time { return now }
+	throttle.sleep = func(d time.Duration) {
+		slept += d
+		now = now.Add(d)
+	}
+
+	for i := 0; i < 10; i++ {
+		throttle.wait()
+	}
+	assert.Equal(t, 900*time.Millisecond, slept)
+
+	// A batch reserves its files at once, so the next wait covers it
+	throttle.waitN(20)
+	throttle.wait()
+	assert.Equal(t, 3*time.Second, slept)
+
+	// Idle time is not saved up for later bursts
+	now = now.Add(time.Minute)
+	slept = 0
+	throttle.wait()
+	throttle.wait()
+	assert.Equal(t, 100*time.Millisecond, slept)
+}
@@ -14,6 +14,10 @@ type Manager struct {
 	queue              *Queue
 	counterFlushTicker *time.Ticker
 	tieringTicker      *time.Ticker
+	digestTicker       *time.Ticker
+	scrubTicker        *time.Ticker
+	replicationTicker  *time.Ticker
+	replicationCursors map[string]uint // last image ID enqueued per tier by the replication sweep
 	stopCh             chan struct{}
 	wg                 sync.WaitGroup
 	mu                 sync.Mutex
@@ -80,6 +84,21 @@ func (m *Manager) Start() {
 	m.wg.Add(1)
 	go m.tieringWorker()
 
+	// Email digests of unread notifications
+	m.digestTicker = time.NewTicker(notificationDigestCheckInterval)
+	m.wg.Add(1)
+	go m.notificationDigestWorker()
+
+	// Integrity scrubs of storage pools whose last scrub is due
+	m.scrubTicker = time.NewTicker(integrityScrubCheckInterval)
+	m.wg.Add(1)
+	go m.integrityScrubWorker()
+
+	// Replicate images with fewer copies than their tier requires
+	m.replicationTicker = time.NewTicker(replicationSweepInterval)
+	m.wg.Add(1)
+	go m.replicationWorker()
+
 	log.Info("[JobQueue Manager] Started successfully")
 }
 
@@ -99,6 +118,15 @@ func (m *Manager) Stop() {
 	if m.tieringTicker != nil {
 		m.tieringTicker.Stop()
 	}
+	if m.digestTicker != nil {
+		m.digestTicker.Stop()
+	}
+	if m.scrubTicker != nil {
+		m.scrubTicker.Stop()
+	}
+	if m.replicationTicker != nil {
+		m.replicationTicker.Stop()
+	}
 
 	stopCh := m.stopCh
 	m.running = false
@@ -117,6 +145,9 @@ func (m *Manager) Stop() {
 	m.stopCh = nil
 	m.counterFlushTicker = nil
 	m.tieringTicker = nil
+	m.digestTicker = nil
+	m.scrubTicker = nil
+	m.replicationTicker = nil
 	m.mu.Unlock()
 
 	log.Info("[JobQueue Manager] Stopped successfully")
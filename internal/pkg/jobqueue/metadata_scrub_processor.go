@@ -0,0 +1,102 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+)
+
+// EnqueueMetadataScrub enqueues a one-off scan over all images that applies the metadata policies to existing originals
+func (q *Queue) EnqueueMetadataScrub() (*Job, error) {
+	return q.EnqueueJob(JobTypeMetadataScrubEnqueue, MetadataScrubEnqueueJobPayload{}.ToMap())
+}
+
+// processMetadataScrubEnqueueJob scans images in batches and enqueues per-image metadata scrub jobs
+func (q *Queue) processMetadataScrubEnqueueJob(job *Job) error {
+	payload, err := MetadataScrubEnqueueJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid metadata scrub payload: %w", err)
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	const batchSize = 200
+	var images []models.Image
+	if err := db.Select("id", "uuid").Where("id > ?", payload.CursorID).
+		Order("id ASC").Limit(batchSize).Find(&images).Error; err != nil {
+		return fmt.Errorf("failed to list images for metadata scrub: %w", err)
+	}
+	if len(images) == 0 {
+		log.Infof("[MetadataScrub] No more images to enqueue (cursor %d)", payload.CursorID)
+		return nil
+	}
+	for _, img := range images {
+		p := ScrubMetadataJobPayload{ImageID: img.ID, ImageUUID: img.UUID}
+		if _, err := q.EnqueueJob(JobTypeScrubMetadata, p.ToMap()); err != nil {
+			log.Errorf("[MetadataScrub] Failed to enqueue scrub job for image %d: %v", img.ID, err)
+		}
+	}
+	next := MetadataScrubEnqueueJobPayload{CursorID: images[len(images)-1].ID}
+	if _, err := q.EnqueueJob(JobTypeMetadataScrubEnqueue, next.ToMap()); err != nil {
+		log.Errorf("[MetadataScrub] Failed to enqueue next batch: %v", err)
+	}
+	return nil
+}
+
+// processScrubMetadataJob strips the metadata of a single original according to its policy
+func (q *Queue) processScrubMetadataJob(ctx context.Context, job *Job) error {
+	payload, err := ScrubMetadataJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid scrub metadata payload: %w", err)
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	var image models.Image
+	if err := db.Preload("StoragePool").First(&image, payload.ImageID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warnf("[ScrubMetadata] Image %d not found; skipping job %s", payload.ImageID, job.ID)
+			return nil
+		}
+		return fmt.Errorf("image not found: %w", err)
+	}
+
+	// Node routing: the original is rewritten in place, so run on the pool's node
+	nodeID := strings.TrimSpace(env.GetEnv("NODE_ID", ""))
+	if nodeID != "" && image.StoragePool != nil {
+		poolNode := strings.TrimSpace(image.StoragePool.NodeID)
+		if poolNode != "" && !strings.EqualFold(nodeID, poolNode) {
+			if err := q.requeueJob(ctx, job); err != nil {
+				log.Errorf("[ScrubMetadata] Failed to requeue job %s for node routing: %v", job.ID, err)
+			}
+			return ErrRequeue
+		}
+	}
+
+	// Images still in the upload pipeline are scrubbed by the processing job
+	if status, err := imageprocessor.GetImageStatus(image.UUID); err == nil &&
+		(status == imageprocessor.STATUS_PENDING || status == imageprocessor.STATUS_PROCESSING) {
+		return nil
+	}
+
+	changed, err := imageprocessor.ScrubImageMetadata(&image)
+	if err != nil {
+		return fmt.Errorf("metadata scrub failed for image %d: %w", image.ID, err)
+	}
+	if changed {
+		log.Infof("[ScrubMetadata] Removed metadata from original of image %d", image.ID)
+	}
+	return nil
+}
@@ -173,11 +173,7 @@ func (q *Queue) processMoveImageJob(job *Job) error {
 		}
 
 		if callRemote {
-			srcFull, err := sm.GetFilePath(storedPath, sourcePoolID)
-			if err != nil {
-				return fmt.Errorf("resolve source path failed: %w", err)
-			}
-			if err := replicateFileToRemotePool(srcFull, storedPath, targetPoolID, targetPool.UploadAPIURL); err != nil {
+			if err := replicateFileToRemotePool(sourcePool, storedPath, targetPoolID, targetPool.UploadAPIURL); err != nil {
 				return err
 			}
 			// Remote stored successfully, delete local source
@@ -270,6 +266,7 @@ func (q *Queue) processMoveImageJob(job *Job) error {
 	}
 
 	log.Infof("[MoveImage] Moved image %d from pool %d to %d", image.ID, payload.SourcePoolID, payload.TargetPoolID)
+	q.rebalanceReplicasAfterMove(db, image.ID, tgtPool)
 
 	// Enqueue a reconciliation job to move any late-created variants after processing completes
 	if _, err := q.EnqueueJob(JobTypeReconcileVariants, ReconcileVariantsJobPayload{
@@ -0,0 +1,95 @@
+package jobqueue
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/mail"
+	email_views "github.com/ManuelReschke/PixelFox/views/email_views"
+)
+
+const (
+	notificationDigestCheckInterval = time.Hour
+	notificationDigestMaxItems      = 20
+)
+
+// notificationDigestWorker periodically sends email digests of unread notifications
+func (m *Manager) notificationDigestWorker() {
+	defer m.wg.Done()
+	for {
+		select {
+		case <-m.stopCh:
+			log.Info("[JobQueue Manager] Notification digest worker stopping")
+			return
+		case <-m.digestTicker.C:
+			if err := m.runNotificationDigestOnce(time.Now()); err != nil {
+				log.Errorf("[JobQueue Manager] Notification digest error: %v", err)
+			}
+		}
+	}
+}
+
+// runNotificationDigestOnce sends a digest to every user whose daily/weekly digest is due
+// and who received unread notifications since the last one.
+func (m *Manager) runNotificationDigestOnce(now time.Time) error {
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	var settings []models.UserSettings
+	if err := db.Where("email_digest IN ?", []string{models.EmailDigestDaily, models.EmailDigestWeekly}).Find(&settings).Error; err != nil {
+		return fmt.Errorf("failed to load digest subscribers: %w", err)
+	}
+
+	baseURL := strings.TrimRight(env.GetEnv("PUBLIC_DOMAIN", ""), "/")
+	for i := range settings {
+		us := &settings[i]
+		if !us.IsEmailDigestDue(now) {
+			continue
+		}
+		since := now.Add(-us.EmailDigestInterval())
+		if us.EmailDigestSentAt != nil {
+			since = *us.EmailDigestSentAt
+		}
+
+		// Mark the period as handled first so a failing mail server does not cause mail storms
+		if err := db.Model(&models.UserSettings{}).Where("id = ?", us.ID).Update("email_digest_sent_at", now).Error; err != nil {
+			log.Errorf("[NotificationDigest] Failed to update digest timestamp for user %d: %v", us.UserID, err)
+			continue
+		}
+
+		var notifications []models.Notification
+		if err := db.Where("user_id = ? AND is_read = ? AND created_at > ?", us.UserID, false, since).
+			Order("created_at DESC").Limit(notificationDigestMaxItems).Find(&notifications).Error; err != nil {
+			log.Errorf("[NotificationDigest] Failed to load notifications for user %d: %v", us.UserID, err)
+			continue
+		}
+		if len(notifications) == 0 {
+			continue
+		}
+
+		var user models.User
+		if err := db.First(&user, us.UserID).Error; err != nil || !user.IsActive() {
+			continue
+		}
+
+		var body bytes.Buffer
+		if err := email_views.NotificationDigestEmail(user.Name, notifications, baseURL).Render(context.Background(), &body); err != nil {
+			log.Errorf("[NotificationDigest] Failed to render digest for user %d: %v", us.UserID, err)
+			continue
+		}
+		if err := mail.SendMail(user.Email, "Deine Benachrichtigungen - PIXELFOX.cc", body.String()); err != nil {
+			log.Errorf("[NotificationDigest] Failed to send digest to user %d: %v", us.UserID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+)
+
+// EnqueuePerceptualHashBackfill enqueues a one-off scan that computes the perceptual hash of images uploaded before it existed
+func (q *Queue) EnqueuePerceptualHashBackfill() (*Job, error) {
+	return q.EnqueueJob(JobTypePerceptualHashEnqueue, PerceptualHashEnqueueJobPayload{}.ToMap())
+}
+
+// processPerceptualHashEnqueueJob scans images without perceptual hash in batches and enqueues per-image hash jobs
+func (q *Queue) processPerceptualHashEnqueueJob(job *Job) error {
+	payload, err := PerceptualHashEnqueueJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid perceptual hash payload: %w", err)
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	const batchSize = 200
+	var images []models.Image
+	if err := db.Select("id", "uuid").Where("id > ? AND perceptual_hash IS NULL", payload.CursorID).
+		Order("id ASC").Limit(batchSize).Find(&images).Error; err != nil {
+		return fmt.Errorf("failed to list images for perceptual hashing: %w", err)
+	}
+	if len(images) == 0 {
+		log.Infof("[PerceptualHash] No more images to enqueue (cursor %d)", payload.CursorID)
+		return nil
+	}
+	for _, img := range images {
+		p := ComputePerceptualHashJobPayload{ImageID: img.ID, ImageUUID: img.UUID}
+		if _, err := q.EnqueueJob(JobTypeComputePerceptualHash, p.ToMap()); err != nil {
+			log.Errorf("[PerceptualHash] Failed to enqueue hash job for image %d: %v", img.ID, err)
+		}
+	}
+	next := PerceptualHashEnqueueJobPayload{CursorID: images[len(images)-1].ID}
+	if _, err := q.EnqueueJob(JobTypePerceptualHashEnqueue, next.ToMap()); err != nil {
+		log.Errorf("[PerceptualHash] Failed to enqueue next batch: %v", err)
+	}
+	return nil
+}
+
+// processComputePerceptualHashJob computes and stores the perceptual hash of a single image
+func (q *Queue) processComputePerceptualHashJob(ctx context.Context, job *Job) error {
+	payload, err := ComputePerceptualHashJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid compute perceptual hash payload: %w", err)
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	var image models.Image
+	if err := db.Preload("StoragePool").First(&image, payload.ImageID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warnf("[PerceptualHash] Image %d not found; skipping job %s", payload.ImageID, job.ID)
+			return nil
+		}
+		return fmt.Errorf("image not found: %w", err)
+	}
+	if image.PerceptualHash != nil {
+		return nil
+	}
+
+	// Node routing: the original is read from disk, so run on the pool's node
+	nodeID := strings.TrimSpace(env.GetEnv("NODE_ID", ""))
+	if nodeID != "" && image.StoragePool != nil {
+		poolNode := strings.TrimSpace(image.StoragePool.NodeID)
+		if poolNode != "" && !strings.EqualFold(nodeID, poolNode) {
+			if err := q.requeueJob(ctx, job); err != nil {
+				log.Errorf("[PerceptualHash] Failed to requeue job %s for node routing: %v", job.ID, err)
+			}
+			return ErrRequeue
+		}
+	}
+
+	if err := imageprocessor.ComputeImagePerceptualHash(&image); err != nil {
+		return fmt.Errorf("perceptual hashing failed for image %d: %w", image.ID, err)
+	}
+	return nil
+}
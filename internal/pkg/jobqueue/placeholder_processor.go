@@ -0,0 +1,95 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+)
+
+// EnqueuePlaceholderBackfill enqueues a one-off scan that computes BlurHash and colors of images uploaded before they existed
+func (q *Queue) EnqueuePlaceholderBackfill() (*Job, error) {
+	return q.EnqueueJob(JobTypePlaceholderBackfillEnqueue, PlaceholderBackfillEnqueueJobPayload{}.ToMap())
+}
+
+// processPlaceholderBackfillEnqueueJob scans images without BlurHash in batches and enqueues per-image placeholder jobs
+func (q *Queue) processPlaceholderBackfillEnqueueJob(job *Job) error {
+	payload, err := PlaceholderBackfillEnqueueJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid placeholder backfill payload: %w", err)
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	const batchSize = 200
+	var images []models.Image
+	if err := db.Select("id", "uuid").Where("id > ? AND blur_hash = ''", payload.CursorID).
+		Order("id ASC").Limit(batchSize).Find(&images).Error; err != nil {
+		return fmt.Errorf("failed to list images for placeholder backfill: %w", err)
+	}
+	if len(images) == 0 {
+		log.Infof("[Placeholder] No more images to enqueue (cursor %d)", payload.CursorID)
+		return nil
+	}
+	for _, img := range images {
+		p := ComputePlaceholderJobPayload{ImageID: img.ID, ImageUUID: img.UUID}
+		if _, err := q.EnqueueJob(JobTypeComputePlaceholder, p.ToMap()); err != nil {
+			log.Errorf("[Placeholder] Failed to enqueue placeholder job for image %d: %v", img.ID, err)
+		}
+	}
+	next := PlaceholderBackfillEnqueueJobPayload{CursorID: images[len(images)-1].ID}
+	if _, err := q.EnqueueJob(JobTypePlaceholderBackfillEnqueue, next.ToMap()); err != nil {
+		log.Errorf("[Placeholder] Failed to enqueue next batch: %v", err)
+	}
+	return nil
+}
+
+// processComputePlaceholderJob computes and stores BlurHash and colors of a single image
+func (q *Queue) processComputePlaceholderJob(ctx context.Context, job *Job) error {
+	payload, err := ComputePlaceholderJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid compute placeholder payload: %w", err)
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	var image models.Image
+	if err := db.Preload("StoragePool").First(&image, payload.ImageID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warnf("[Placeholder] Image %d not found; skipping job %s", payload.ImageID, job.ID)
+			return nil
+		}
+		return fmt.Errorf("image not found: %w", err)
+	}
+	if image.BlurHash != "" {
+		return nil
+	}
+
+	// Node routing: the original is read from disk, so run on the pool's node
+	nodeID := strings.TrimSpace(env.GetEnv("NODE_ID", ""))
+	if nodeID != "" && image.StoragePool != nil {
+		poolNode := strings.TrimSpace(image.StoragePool.NodeID)
+		if poolNode != "" && !strings.EqualFold(nodeID, poolNode) {
+			if err := q.requeueJob(ctx, job); err != nil {
+				log.Errorf("[Placeholder] Failed to requeue job %s for node routing: %v", job.ID, err)
+			}
+			return ErrRequeue
+		}
+	}
+
+	if err := imageprocessor.ComputeImagePlaceholder(&image); err != nil {
+		return fmt.Errorf("placeholder computation failed for image %d: %w", image.ID, err)
+	}
+	return nil
+}
@@ -369,6 +369,34 @@ func (q *Queue) processJob(ctx context.Context, job *Job) {
 		err = q.processDeleteImageJob(ctx, job)
 	case JobTypeReconcileVariants:
 		err = q.processReconcileVariantsJob(job)
+	case JobTypeVariantBackfillEnqueue:
+		err = q.processVariantBackfillEnqueueJob(job)
+	case JobTypeSyncVariants:
+		err = q.processSyncVariantsJob(ctx, job)
+	case JobTypeMetadataScrubEnqueue:
+		err = q.processMetadataScrubEnqueueJob(job)
+	case JobTypeScrubMetadata:
+		err = q.processScrubMetadataJob(ctx, job)
+	case JobTypePerceptualHashEnqueue:
+		err = q.processPerceptualHashEnqueueJob(job)
+	case JobTypeComputePerceptualHash:
+		err = q.processComputePerceptualHashJob(ctx, job)
+	case JobTypePlaceholderBackfillEnqueue:
+		err = q.processPlaceholderBackfillEnqueueJob(job)
+	case JobTypeComputePlaceholder:
+		err = q.processComputePlaceholderJob(ctx, job)
+	case JobTypeWatermarkRerenderEnqueue:
+		err = q.processWatermarkRerenderEnqueueJob(job)
+	case JobTypeRerenderWatermark:
+		err = q.processRerenderWatermarkJob(ctx, job)
+	case JobTypeIntegrityScrub:
+		err = q.processIntegrityScrubJob(ctx, job)
+	case JobTypeScrubRepair:
+		err = q.processScrubRepairJob(ctx, job)
+	case JobTypeReplicateImage:
+		err = q.processReplicateImageJob(ctx, job)
+	case JobTypeDropReplica:
+		err = q.processDropReplicaJob(ctx, job)
 	default:
 		err = fmt.Errorf("unknown job type: %s", job.Type)
 	}
@@ -395,6 +423,9 @@ func (q *Queue) processJob(ctx context.Context, job *Job) {
 		} else {
 			log.Errorf("[JobQueue] Job %s permanently failed after %d retries", job.ID, job.RetryCount)
 			q.updateJobStats(ctx, JobStatusFailed, 1)
+			if job.Type == JobTypeImageProcessing {
+				notifyImageProcessingFailed(job)
+			}
 		}
 	} else {
 		log.Infof("[JobQueue] Job %s completed successfully", job.ID)
@@ -87,11 +87,7 @@ func (q *Queue) processReconcileVariantsJob(job *Job) error {
 			!strings.EqualFold(srcNode, tgtNode)
 
 		if remoteTarget {
-			srcFull, err := sm.GetFilePath(storedPath, srcPool.ID)
-			if err != nil {
-				return fmt.Errorf("resolve source path failed: %w", err)
-			}
-			if err := replicateFileToRemotePool(srcFull, storedPath, targetPoolID, tgtPool.UploadAPIURL); err != nil {
+			if err := replicateFileToRemotePool(srcPool, storedPath, targetPoolID, tgtPool.UploadAPIURL); err != nil {
 				return err
 			}
 			if _, err := sm.DeleteFile(storedPath, srcPool.ID); err != nil {
@@ -0,0 +1,584 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/cache"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
+)
+
+const (
+	replicationSweepInterval = 15 * time.Minute
+	// Images enqueued per tier and sweep; the next sweep continues after the last one
+	replicationSweepBatch = 500
+	// An enqueued image is not enqueued again while its job is pending, at most this long
+	replicationPendingTTL = time.Hour
+	// Images that could not be brought to policy are left alone this long, e.g. until pools were added
+	replicationFailedBackoff = 24 * time.Hour
+)
+
+func replicationPendingKey(imageID uint) string {
+	return fmt.Sprintf("replication_pending:%d", imageID)
+}
+
+func replicationFailedKey(imageID uint) string {
+	return fmt.Sprintf("replication_failed:%d", imageID)
+}
+
+// markReplicationFailed keeps the sweep from retrying the image before the backoff passed
+func markReplicationFailed(imageID uint) {
+	if err := cache.Set(replicationFailedKey(imageID), "1", replicationFailedBackoff); err != nil {
+		log.Warnf("[Replication] Failed to record failed replication of image %d: %v", imageID, err)
+	}
+}
+
+// clearReplicationFailed lets the sweep pick the image up again
+func clearReplicationFailed(imageID uint) {
+	_ = cache.Delete(replicationFailedKey(imageID))
+}
+
+// replicationWorker periodically enqueues replicate jobs for images with fewer copies than their tier requires
+func (m *Manager) replicationWorker() {
+	defer m.wg.Done()
+	for {
+		select {
+		case <-m.stopCh:
+			log.Info("[JobQueue Manager] Replication worker stopping")
+			return
+		case <-m.replicationTicker.C:
+			if err := m.runReplicationSweepOnce(); err != nil {
+				log.Errorf("[JobQueue Manager] Replication sweep error: %v", err)
+			}
+		}
+	}
+}
+
+// runReplicationSweepOnce enqueues a replicate job for the next batch of under-replicated images per tier.
+// Each tier keeps a cursor, so images that cannot reach the policy do not starve the ones after them.
+// Images with a pending job or a recent failed attempt are skipped.
+func (m *Manager) runReplicationSweepOnce() error {
+	settings := getAppSettings()
+	if settings == nil {
+		return nil
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	if m.replicationCursors == nil {
+		m.replicationCursors = make(map[string]uint)
+	}
+	ctx := context.Background()
+	for _, tier := range []string{models.StorageTierHot, models.StorageTierWarm, models.StorageTierCold} {
+		copies := settings.GetReplicaCount(tier)
+		if copies <= 1 {
+			delete(m.replicationCursors, tier)
+			continue
+		}
+		ids, err := models.FindUnderReplicatedImageIDs(db, tier, copies, m.replicationCursors[tier], replicationSweepBatch)
+		if err != nil {
+			return fmt.Errorf("failed to list under-replicated %s images: %w", tier, err)
+		}
+		if len(ids) < replicationSweepBatch {
+			// Start over with the next sweep
+			delete(m.replicationCursors, tier)
+		} else {
+			m.replicationCursors[tier] = ids[len(ids)-1]
+		}
+
+		enqueued := 0
+		for _, id := range ids {
+			if failed, err := cache.Get(replicationFailedKey(id)); err == nil && failed != "" {
+				continue
+			}
+			if client := m.queue.client; client != nil {
+				if ok, err := client.SetNX(ctx, replicationPendingKey(id), "1", replicationPendingTTL).Result(); err == nil && !ok {
+					continue
+				}
+			}
+			p := ReplicateImageJobPayload{ImageID: id}
+			if _, err := m.queue.EnqueueJob(JobTypeReplicateImage, p.ToMap()); err != nil {
+				_ = cache.Delete(replicationPendingKey(id))
+				return fmt.Errorf("failed to enqueue replication of image %d: %w", id, err)
+			}
+			enqueued++
+		}
+		if enqueued > 0 {
+			log.Infof("[Replication] Enqueued %d under-replicated %s images", enqueued, tier)
+		}
+	}
+	return nil
+}
+
+// processReplicateImageJob brings an image to the replica count of its tier, or copies it to a single target pool
+func (q *Queue) processReplicateImageJob(ctx context.Context, job *Job) error {
+	payload, err := ReplicateImageJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid replicate image payload: %w", err)
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	var image models.Image
+	if err := db.Preload("StoragePool").First(&image, payload.ImageID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warnf("[Replication] Image %d not found; skipping job %s", payload.ImageID, job.ID)
+			return nil
+		}
+		return fmt.Errorf("failed to load image: %w", err)
+	}
+	// The upload pipeline replicates itself once the variants are written
+	if status, err := imageprocessor.GetImageStatus(image.UUID); err == nil &&
+		(status == imageprocessor.STATUS_PENDING || status == imageprocessor.STATUS_PROCESSING) {
+		return nil
+	}
+
+	if payload.TargetPoolID == 0 {
+		defer func() { _ = cache.Delete(replicationPendingKey(image.ID)) }()
+		return q.replicateImage(db, &image)
+	}
+	if payload.TargetPoolID == image.StoragePoolID {
+		return nil
+	}
+
+	target, err := models.FindStoragePoolByID(db, payload.TargetPoolID)
+	if err != nil {
+		return fmt.Errorf("target pool not found: %w", err)
+	}
+	source, err := replicationSource(db, &image)
+	if err != nil {
+		return err
+	}
+	nodeID := strings.TrimSpace(env.GetEnv("NODE_ID", ""))
+	if !canReplicateOnNode(source, target, nodeID) {
+		if err := q.requeueJob(ctx, job); err != nil {
+			log.Errorf("[Replication] Failed to requeue job %s for node routing: %v", job.ID, err)
+		}
+		return ErrRequeue
+	}
+	replicas, err := models.FindImageReplicas(db, image.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load replicas: %w", err)
+	}
+	for _, r := range replicas {
+		if r.StoragePoolID == target.ID && r.IsCurrent(&image) {
+			return nil
+		}
+	}
+	return copyReplica(db, &image, source, target)
+}
+
+// replicateImage copies the image to as many pools as its tier's replica count is missing. Copies that
+// have to run on another node are handed over as replicate jobs for a fixed target.
+func (q *Queue) replicateImage(db *gorm.DB, image *models.Image) error {
+	primary := image.StoragePool
+	if primary == nil {
+		p, err := models.FindStoragePoolByID(db, image.StoragePoolID)
+		if err != nil {
+			return fmt.Errorf("primary pool not found: %w", err)
+		}
+		primary = p
+	}
+	settings := getAppSettings()
+	if settings == nil {
+		return nil
+	}
+	copies := settings.GetReplicaCount(primary.StorageTier)
+	if copies <= 1 {
+		return nil
+	}
+
+	replicas, err := models.FindImageReplicas(db, image.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load replicas: %w", err)
+	}
+	candidates, err := models.FindActiveStoragePoolsByTier(db, primary.StorageTier)
+	if err != nil {
+		return fmt.Errorf("failed to list %s pools: %w", primary.StorageTier, err)
+	}
+	targets := replicationTargets(primary, replicas, image, candidates, copies, image.FileSize, storage.PoolHealthy)
+	short := false
+	if missing := copies - 1 - currentReplicaCount(primary, replicas, image); len(targets) < missing {
+		log.Warnf("[Replication] Image %d: only %d of %d missing %s replicas can be placed on distinct nodes",
+			image.ID, len(targets), missing, primary.StorageTier)
+		short = true
+	}
+	if len(targets) == 0 {
+		if short {
+			markReplicationFailed(image.ID)
+		} else {
+			clearReplicationFailed(image.ID)
+		}
+		return nil
+	}
+
+	source, err := replicationSource(db, image)
+	if err != nil {
+		markReplicationFailed(image.ID)
+		return err
+	}
+	nodeID := strings.TrimSpace(env.GetEnv("NODE_ID", ""))
+	var failed []string
+	for i := range targets {
+		target := &targets[i]
+		if !canReplicateOnNode(source, target, nodeID) {
+			p := ReplicateImageJobPayload{ImageID: image.ID, TargetPoolID: target.ID}
+			if _, err := q.EnqueueJob(JobTypeReplicateImage, p.ToMap()); err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", target.Name, err))
+			}
+			continue
+		}
+		if err := copyReplica(db, image, source, target); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", target.Name, err))
+		}
+	}
+	if len(failed) > 0 {
+		markReplicationFailed(image.ID)
+		return fmt.Errorf("replication of image %d incomplete: %s", image.ID, strings.Join(failed, "; "))
+	}
+	if short {
+		markReplicationFailed(image.ID)
+	} else {
+		clearReplicationFailed(image.ID)
+	}
+	return nil
+}
+
+// replicationSource returns the pool to copy from: the primary, or a current replica while the primary is unhealthy
+func replicationSource(db *gorm.DB, image *models.Image) (*models.StoragePool, error) {
+	primary := image.StoragePool
+	if primary == nil {
+		p, err := models.FindStoragePoolByID(db, image.StoragePoolID)
+		if err != nil {
+			return nil, fmt.Errorf("primary pool not found: %w", err)
+		}
+		primary = p
+	}
+	if storage.PoolHealthy(primary.ID) {
+		return primary, nil
+	}
+	replicas, err := models.FindImageReplicas(db, image.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load replicas: %w", err)
+	}
+	for _, r := range replicas {
+		if r.StoragePool != nil && r.StoragePoolID != primary.ID && r.IsCurrent(image) && storage.PoolHealthy(r.StoragePoolID) {
+			return r.StoragePool, nil
+		}
+	}
+	return nil, fmt.Errorf("primary pool %s is unhealthy and no healthy replica exists", primary.Name)
+}
+
+// canReplicateOnNode mirrors the routing of move jobs: files of local pools are read on their node,
+// and an object storage source is copied on the node of a local target
+func canReplicateOnNode(source, target *models.StoragePool, nodeID string) bool {
+	if nodeID == "" {
+		return true
+	}
+	if isLocalLikeStoragePool(source) {
+		sourceNode := strings.TrimSpace(source.NodeID)
+		return sourceNode == "" || strings.EqualFold(sourceNode, nodeID)
+	}
+	if isLocalLikeStoragePool(target) {
+		targetNode := strings.TrimSpace(target.NodeID)
+		return targetNode == "" || strings.EqualFold(targetNode, nodeID)
+	}
+	return true
+}
+
+// replicaNode identifies the machine a pool's files live on. Local pools without a node ID share one node.
+func replicaNode(pool *models.StoragePool) string {
+	if isLocalLikeStoragePool(pool) {
+		return strings.ToLower(strings.TrimSpace(pool.NodeID))
+	}
+	return fmt.Sprintf("%s:%d", pool.StorageType, pool.ID)
+}
+
+// currentReplicaCount counts the replicas that hold the image's current original, the primary excluded
+func currentReplicaCount(primary *models.StoragePool, replicas []models.ImageReplica, image *models.Image) int {
+	count := 0
+	for _, r := range replicas {
+		if r.StoragePoolID != primary.ID && r.IsCurrent(image) {
+			count++
+		}
+	}
+	return count
+}
+
+// replicationTargets picks the pools that receive the missing copies: stale replicas are refreshed first,
+// then healthy same-tier pools with room are added. Every copy lives on a different node than the others.
+func replicationTargets(primary *models.StoragePool, replicas []models.ImageReplica, image *models.Image, candidates []models.StoragePool, copies int, size int64, healthy func(uint) bool) []models.StoragePool {
+	usedNodes := map[string]bool{replicaNode(primary): true}
+	usedPools := map[uint]bool{primary.ID: true}
+	var stale []models.StoragePool
+	have := 0
+	for _, r := range replicas {
+		if r.StoragePoolID == primary.ID {
+			continue
+		}
+		usedPools[r.StoragePoolID] = true
+		if r.IsCurrent(image) {
+			have++
+			if r.StoragePool != nil {
+				usedNodes[replicaNode(r.StoragePool)] = true
+			}
+		} else if r.StoragePool != nil {
+			stale = append(stale, *r.StoragePool)
+		}
+	}
+	need := copies - 1 - have
+	if need <= 0 {
+		return nil
+	}
+
+	var targets []models.StoragePool
+	pick := func(pool models.StoragePool) {
+		node := replicaNode(&pool)
+		if len(targets) >= need || usedNodes[node] || !healthy(pool.ID) {
+			return
+		}
+		usedNodes[node] = true
+		targets = append(targets, pool)
+	}
+	for _, pool := range stale {
+		pick(pool)
+	}
+	for _, pool := range candidates {
+		if usedPools[pool.ID] || !pool.IsActive || !pool.CanAcceptFile(size) {
+			continue
+		}
+		pick(pool)
+	}
+	return targets
+}
+
+// replicaFiles returns the pool paths of the image's original followed by the variants stored next to it
+func replicaFiles(db *gorm.DB, image *models.Image, primary *models.StoragePool) ([]string, error) {
+	files := []string{path.Join(filepath.ToSlash(image.FilePath), image.FileName)}
+	var variants []models.ImageVariant
+	if err := db.Where("image_id = ? AND (storage_pool_id = ? OR storage_pool_id IS NULL OR storage_pool_id = 0)", image.ID, primary.ID).
+		Order("id ASC").Find(&variants).Error; err != nil {
+		return nil, fmt.Errorf("failed to list variants of image %d: %w", image.ID, err)
+	}
+	for i := range variants {
+		if rel := imageprocessor.VariantRelativePath(&variants[i], primary); rel != "" {
+			files = append(files, rel)
+		}
+	}
+	return files, nil
+}
+
+// copyReplica copies the original and variants from source to target and records the replica.
+// The original is verified against the image's checksum first so a corrupt file is never spread.
+func copyReplica(db *gorm.DB, image *models.Image, source, target *models.StoragePool) error {
+	primary := image.StoragePool
+	if primary == nil || primary.ID != image.StoragePoolID {
+		p, err := models.FindStoragePoolByID(db, image.StoragePoolID)
+		if err != nil {
+			return fmt.Errorf("primary pool not found: %w", err)
+		}
+		primary = p
+	}
+	files, err := replicaFiles(db, image, primary)
+	if err != nil {
+		return err
+	}
+	backend, err := storage.BackendFor(source)
+	if err != nil {
+		return err
+	}
+
+	checksum, _, err := storage.Checksum(backend, files[0])
+	if err != nil {
+		return fmt.Errorf("failed to read original %s in pool %s: %w", files[0], source.Name, err)
+	}
+	switch expected := image.StoredFileHash(); {
+	case expected == "":
+		// Images uploaded before hashing was introduced get the checksum of their stored original
+		if err := db.Model(&models.Image{}).Where("id = ? AND file_hash = '' AND content_hash = ''", image.ID).
+			Update("content_hash", checksum).Error; err != nil {
+			return fmt.Errorf("failed to record checksum: %w", err)
+		}
+		image.ContentHash = checksum
+	case expected != checksum:
+		return fmt.Errorf("original %s in pool %s does not match its checksum", files[0], source.Name)
+	}
+
+	sm := storage.NewStorageManager()
+	remote := isLocalLikeStoragePool(source) && isLocalLikeStoragePool(target) &&
+		strings.TrimSpace(source.NodeID) != "" && strings.TrimSpace(target.NodeID) != "" &&
+		!strings.EqualFold(strings.TrimSpace(source.NodeID), strings.TrimSpace(target.NodeID))
+	var total int64
+	for i, rel := range files {
+		info, err := backend.Stat(rel)
+		if err != nil {
+			// A missing variant is left to the integrity scrub; the original is required
+			if i > 0 && errors.Is(err, os.ErrNotExist) {
+				log.Warnf("[Replication] Variant %s of image %d missing in pool %s; not replicated", rel, image.ID, source.Name)
+				continue
+			}
+			return fmt.Errorf("failed to stat %s in pool %s: %w", rel, source.Name, err)
+		}
+		if remote {
+			if err := replicateFileToRemotePool(source, rel, target.ID, target.UploadAPIURL); err != nil {
+				return fmt.Errorf("failed to replicate %s to pool %s: %w", rel, target.Name, err)
+			}
+		} else {
+			r, err := backend.Open(rel)
+			if err != nil {
+				return fmt.Errorf("failed to open %s in pool %s: %w", rel, source.Name, err)
+			}
+			_, err = sm.SaveFile(r, rel, target.ID)
+			r.Close()
+			if err != nil {
+				return fmt.Errorf("failed to replicate %s to pool %s: %w", rel, target.Name, err)
+			}
+		}
+		total += info.Size
+	}
+
+	replica := &models.ImageReplica{ImageID: image.ID, StoragePoolID: target.ID, Checksum: checksum, FileSize: total}
+	if err := models.SaveImageReplica(db, replica); err != nil {
+		return fmt.Errorf("failed to record replica: %w", err)
+	}
+	log.Infof("[Replication] Replicated image %d (%d files, %d bytes) from pool %s to %s", image.ID, len(files), total, source.Name, target.Name)
+	return nil
+}
+
+// enqueueDropReplicas hands the deletion of the given replicas to the replica pools' nodes.
+// Paths are resolved now because the image rows may be gone when the jobs run.
+func (q *Queue) enqueueDropReplicas(db *gorm.DB, image *models.Image, replicas []models.ImageReplica) {
+	if len(replicas) == 0 {
+		return
+	}
+	primary, err := models.FindStoragePoolByID(db, image.StoragePoolID)
+	if err != nil {
+		log.Errorf("[Replication] Primary pool of image %d not found: %v", image.ID, err)
+		return
+	}
+	files, err := replicaFiles(db, image, primary)
+	if err != nil {
+		log.Errorf("[Replication] %v", err)
+		return
+	}
+	if image.PreviousFileName != "" {
+		files = append(files, path.Join(filepath.ToSlash(image.FilePath), image.PreviousFileName))
+	}
+	for _, r := range replicas {
+		p := DropReplicaJobPayload{ImageID: image.ID, PoolID: r.StoragePoolID, Paths: files}
+		if _, err := q.EnqueueJob(JobTypeDropReplica, p.ToMap()); err != nil {
+			log.Errorf("[Replication] Failed to enqueue removal of replica of image %d in pool %d: %v", image.ID, r.StoragePoolID, err)
+		}
+	}
+}
+
+// enqueueDeleteReplicas drops every replica of an image that is about to be deleted
+func (q *Queue) enqueueDeleteReplicas(db *gorm.DB, image *models.Image) {
+	replicas, err := models.FindImageReplicas(db, image.ID)
+	if err != nil {
+		log.Errorf("[Replication] Failed to load replicas of image %d: %v", image.ID, err)
+		return
+	}
+	q.enqueueDropReplicas(db, image, replicas)
+}
+
+// rebalanceReplicasAfterMove adapts the replicas to the image's new primary pool: a replica in that pool
+// became the primary copy, replicas outside the new tier are dropped and the new tier's policy is applied.
+func (q *Queue) rebalanceReplicasAfterMove(db *gorm.DB, imageID uint, target *models.StoragePool) {
+	replicas, err := models.FindImageReplicas(db, imageID)
+	if err != nil {
+		log.Errorf("[Replication] Failed to load replicas of image %d: %v", imageID, err)
+		return
+	}
+	var drop []models.ImageReplica
+	for _, r := range replicas {
+		switch {
+		case r.StoragePoolID == target.ID:
+			// The move wrote the files again and counted them a second time
+			if err := target.UpdateUsedSize(db, -r.FileSize); err != nil {
+				log.Warnf("[Replication] Failed to update usage of pool %s: %v", target.Name, err)
+			}
+			if err := models.DeleteImageReplica(db, imageID, target.ID); err != nil {
+				log.Errorf("[Replication] Failed to forget replica of image %d in pool %s: %v", imageID, target.Name, err)
+			}
+		case r.StoragePool == nil || r.StoragePool.StorageTier != target.StorageTier:
+			drop = append(drop, r)
+		}
+	}
+	if len(drop) > 0 {
+		var image models.Image
+		if err := db.First(&image, imageID).Error; err != nil {
+			log.Errorf("[Replication] Failed to load image %d: %v", imageID, err)
+		} else {
+			q.enqueueDropReplicas(db, &image, drop)
+		}
+	}
+	if settings := getAppSettings(); settings != nil && settings.GetReplicaCount(target.StorageTier) > 1 {
+		p := ReplicateImageJobPayload{ImageID: imageID}
+		if _, err := q.EnqueueJob(JobTypeReplicateImage, p.ToMap()); err != nil {
+			log.Warnf("[Replication] Failed to enqueue replication of image %d: %v", imageID, err)
+		}
+	}
+}
+
+// processDropReplicaJob deletes the files of a replica and forgets it
+func (q *Queue) processDropReplicaJob(ctx context.Context, job *Job) error {
+	payload, err := DropReplicaJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid drop replica payload: %w", err)
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	pool, err := models.FindStoragePoolByID(db, payload.PoolID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.DeleteImageReplica(db, payload.ImageID, payload.PoolID)
+		}
+		return fmt.Errorf("failed to load pool: %w", err)
+	}
+
+	// Node routing: files of local pools can only be deleted on the pool's node
+	nodeID := strings.TrimSpace(env.GetEnv("NODE_ID", ""))
+	if poolNode := strings.TrimSpace(pool.NodeID); nodeID != "" && poolNode != "" && isLocalLikeStoragePool(pool) && !strings.EqualFold(nodeID, poolNode) {
+		if err := q.requeueJob(ctx, job); err != nil {
+			log.Errorf("[Replication] Failed to requeue job %s for node routing: %v", job.ID, err)
+		}
+		return ErrRequeue
+	}
+
+	// The image may have moved into this pool since; its files are the primary copy now
+	var primaryPoolID uint
+	if err := db.Unscoped().Model(&models.Image{}).Where("id = ?", payload.ImageID).Pluck("storage_pool_id", &primaryPoolID).Error; err != nil {
+		return fmt.Errorf("failed to load image: %w", err)
+	}
+	if primaryPoolID != pool.ID {
+		sm := storage.NewStorageManager()
+		for _, rel := range payload.Paths {
+			if _, err := sm.DeleteFile(rel, pool.ID); err != nil {
+				return fmt.Errorf("failed to delete %s from pool %s: %w", rel, pool.Name, err)
+			}
+		}
+	}
+	if err := models.DeleteImageReplica(db, payload.ImageID, payload.PoolID); err != nil {
+		return fmt.Errorf("failed to forget replica: %w", err)
+	}
+	log.Infof("[Replication] Dropped replica of image %d in pool %s", payload.ImageID, pool.Name)
+	return nil
+}
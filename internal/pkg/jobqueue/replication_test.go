@@ -0,0 +1,86 @@
+package jobqueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+)
+
+func replicationTestPool(id uint, storageType, nodeID string) models.StoragePool {
+	return models.StoragePool{
+		ID:          id,
+		Name:        storageType,
+		StorageType: storageType,
+		StorageTier: models.StorageTierHot,
+		NodeID:      nodeID,
+		IsActive:    true,
+		MaxSize:     1 << 30,
+	}
+}
+
+func poolIDs(pools []models.StoragePool) []uint {
+	ids := make([]uint, 0, len(pools))
+	for _, p := range pools {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+func TestReplicationTargets(t *testing.T) {
+	allHealthy := func(uint) bool { return true }
+	image := &models.Image{FileHash: "abc"}
+	primary := replicationTestPool(1, models.StorageTypeLocal, "node-a")
+	sameNode := replicationTestPool(2, models.StorageTypeLocal, "NODE-A")
+	otherNode := replicationTestPool(3, models.StorageTypeLocal, "node-b")
+	thirdNode := replicationTestPool(4, models.StorageTypeLocal, "node-c")
+	s3 := replicationTestPool(5, models.StorageTypeS3, "")
+	candidates := []models.StoragePool{primary, sameNode, otherNode, thirdNode, s3}
+
+	t.Run("distinct nodes", func(t *testing.T) {
+		targets := replicationTargets(&primary, nil, image, candidates, 3, 100, allHealthy)
+		assert.Equal(t, []uint{3, 4}, poolIDs(targets))
+	})
+
+	t.Run("policy met", func(t *testing.T) {
+		replicas := []models.ImageReplica{{StoragePoolID: 3, StoragePool: &otherNode, Checksum: "abc"}}
+		assert.Empty(t, replicationTargets(&primary, replicas, image, candidates, 2, 100, allHealthy))
+	})
+
+	t.Run("stale replica refreshed first", func(t *testing.T) {
+		replicas := []models.ImageReplica{{StoragePoolID: 4, StoragePool: &thirdNode, Checksum: "old"}}
+		targets := replicationTargets(&primary, replicas, image, candidates, 2, 100, allHealthy)
+		assert.Equal(t, []uint{4}, poolIDs(targets))
+	})
+
+	t.Run("unhealthy and full pools skipped", func(t *testing.T) {
+		full := otherNode
+		full.UsedSize = full.MaxSize
+		pools := []models.StoragePool{primary, full, thirdNode, s3}
+		healthy := func(id uint) bool { return id != 4 }
+		targets := replicationTargets(&primary, nil, image, pools, 3, 100, healthy)
+		assert.Equal(t, []uint{5}, poolIDs(targets))
+	})
+
+	t.Run("local pools without node id share a node", func(t *testing.T) {
+		a := replicationTestPool(6, models.StorageTypeLocal, "")
+		b := replicationTestPool(7, models.StorageTypeNFS, "")
+		targets := replicationTargets(&a, nil, image, []models.StoragePool{a, b, s3}, 3, 100, allHealthy)
+		assert.Equal(t, []uint{5}, poolIDs(targets))
+	})
+}
+
+func TestCanReplicateOnNode(t *testing.T) {
+	localA := replicationTestPool(1, models.StorageTypeLocal, "node-a")
+	localB := replicationTestPool(2, models.StorageTypeLocal, "node-b")
+	s3 := replicationTestPool(3, models.StorageTypeS3, "")
+
+	assert.True(t, canReplicateOnNode(&localA, &localB, ""))
+	assert.True(t, canReplicateOnNode(&localA, &localB, "node-a"))
+	assert.False(t, canReplicateOnNode(&localA, &localB, "node-b"))
+	assert.True(t, canReplicateOnNode(&localA, &s3, "NODE-A"))
+	// Object storage sources are copied on the node of a local target
+	assert.True(t, canReplicateOnNode(&s3, &localB, "node-b"))
+	assert.False(t, canReplicateOnNode(&s3, &localB, "node-a"))
+}
@@ -0,0 +1,222 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
+)
+
+// ErrScrubRepairNotApplicable is returned when a repair does not fit the finding or it is already being repaired
+var ErrScrubRepairNotApplicable = errors.New("repair action not applicable to this finding")
+
+// EnqueueScrubRepair marks a finding as being repaired and enqueues the repair
+func (q *Queue) EnqueueScrubRepair(findingID uint, action string) error {
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	finding, err := models.FindScrubFindingByID(db, findingID)
+	if err != nil {
+		return err
+	}
+	if !finding.CanRepair(action) {
+		return ErrScrubRepairNotApplicable
+	}
+	return q.enqueueScrubRepair(db, finding, action)
+}
+
+// EnqueueScrubRepairs enqueues the action for every open or failed finding of a pool it applies to
+func (q *Queue) EnqueueScrubRepairs(poolID uint, action string) (int, error) {
+	db := database.GetDB()
+	if db == nil {
+		return 0, fmt.Errorf("database connection is nil")
+	}
+	var findings []models.ScrubFinding
+	if err := db.Where("storage_pool_id = ? AND status IN ?", poolID, []string{models.ScrubFindingOpen, models.ScrubFindingFailed}).
+		Order("id ASC").Find(&findings).Error; err != nil {
+		return 0, err
+	}
+	enqueued := 0
+	for i := range findings {
+		if !findings[i].CanRepair(action) {
+			continue
+		}
+		if err := q.enqueueScrubRepair(db, &findings[i], action); err != nil {
+			return enqueued, err
+		}
+		enqueued++
+	}
+	return enqueued, nil
+}
+
+func (q *Queue) enqueueScrubRepair(db *gorm.DB, finding *models.ScrubFinding, action string) error {
+	if err := models.UpdateScrubFindingStatus(db, finding.ID, models.ScrubFindingRepairing, "", time.Now()); err != nil {
+		return err
+	}
+	payload := ScrubRepairJobPayload{FindingID: finding.ID, Action: action}
+	if _, err := q.EnqueueJob(JobTypeScrubRepair, payload.ToMap()); err != nil {
+		_ = models.UpdateScrubFindingStatus(db, finding.ID, finding.Status, finding.Detail, time.Now())
+		return err
+	}
+	return nil
+}
+
+// processScrubRepairJob repairs a single integrity scrub finding
+func (q *Queue) processScrubRepairJob(ctx context.Context, job *Job) error {
+	payload, err := ScrubRepairJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid scrub repair payload: %w", err)
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	finding, err := models.FindScrubFindingByID(db, payload.FindingID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warnf("[ScrubRepair] Finding %d not found; skipping job %s", payload.FindingID, job.ID)
+			return nil
+		}
+		return fmt.Errorf("failed to load finding: %w", err)
+	}
+	if finding.Status != models.ScrubFindingRepairing {
+		return nil
+	}
+	pool, err := models.FindStoragePoolByID(db, finding.StoragePoolID)
+	if err != nil {
+		_ = models.UpdateScrubFindingStatus(db, finding.ID, models.ScrubFindingFailed, fmt.Sprintf("Speicherpool nicht gefunden: %v", err), time.Now())
+		return nil
+	}
+
+	// Node routing: files of local pools can only be written on the pool's node
+	nodeID := strings.TrimSpace(env.GetEnv("NODE_ID", ""))
+	if poolNode := strings.TrimSpace(pool.NodeID); nodeID != "" && poolNode != "" && !strings.EqualFold(nodeID, poolNode) {
+		if err := q.requeueJob(ctx, job); err != nil {
+			log.Errorf("[ScrubRepair] Failed to requeue job %s for node routing: %v", job.ID, err)
+		}
+		return ErrRequeue
+	}
+
+	var status, detail string
+	switch payload.Action {
+	case models.ScrubRepairRegenerate:
+		status, detail, err = q.regenerateVariant(db, finding, pool)
+	case models.ScrubRepairRestore:
+		status, detail, err = restoreFromS3(db, finding, pool)
+	case models.ScrubRepairQuarantine:
+		status, detail, err = quarantineOrphan(finding, pool, time.Now())
+	default:
+		err = fmt.Errorf("unbekannte Reparatur %q", payload.Action)
+	}
+	if err != nil {
+		log.Errorf("[ScrubRepair] %s of finding %d failed: %v", payload.Action, finding.ID, err)
+		status, detail = models.ScrubFindingFailed, err.Error()
+	}
+	if err := models.UpdateScrubFindingStatus(db, finding.ID, status, detail, time.Now()); err != nil {
+		return fmt.Errorf("failed to update finding %d: %w", finding.ID, err)
+	}
+	return nil
+}
+
+// regenerateVariant drops the variant row whose file is missing and lets the variant sync render it again
+func (q *Queue) regenerateVariant(db *gorm.DB, finding *models.ScrubFinding, pool *models.StoragePool) (string, string, error) {
+	var variant models.ImageVariant
+	if err := db.First(&variant, finding.VariantID).Error; err == nil {
+		if err := db.Unscoped().Delete(&variant).Error; err != nil {
+			return "", "", fmt.Errorf("Variante konnte nicht entfernt werden: %w", err)
+		}
+		if err := pool.UpdateUsedSize(db, -variant.FileSize); err != nil {
+			log.Warnf("[ScrubRepair] Failed to update usage of pool %s: %v", pool.Name, err)
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", "", err
+	}
+
+	var image models.Image
+	if err := db.Select("id", "uuid").First(&image, finding.ImageID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ScrubFindingResolved, "Bild wurde inzwischen gelöscht", nil
+		}
+		return "", "", err
+	}
+	p := SyncVariantsJobPayload{ImageID: image.ID, ImageUUID: image.UUID}
+	if _, err := q.EnqueueJob(JobTypeSyncVariants, p.ToMap()); err != nil {
+		return "", "", fmt.Errorf("Neuerzeugung konnte nicht eingereiht werden: %w", err)
+	}
+	return models.ScrubFindingResolved, "Variante wird neu erzeugt", nil
+}
+
+// restoreFromS3 copies the file back from the first S3 pool that holds an intact copy
+func restoreFromS3(db *gorm.DB, finding *models.ScrubFinding, pool *models.StoragePool) (string, string, error) {
+	backend, err := storage.BackendFor(pool)
+	if err != nil {
+		return "", "", err
+	}
+	s3Pools, err := models.FindS3StoragePools(db)
+	if err != nil {
+		return "", "", fmt.Errorf("S3-Speicherpools konnten nicht geladen werden: %w", err)
+	}
+	for i := range s3Pools {
+		source := &s3Pools[i]
+		if source.ID == pool.ID {
+			continue
+		}
+		sourceBackend, err := storage.BackendFor(source)
+		if err != nil {
+			log.Warnf("[ScrubRepair] Skipping S3 pool %s: %v", source.Name, err)
+			continue
+		}
+		if finding.ExpectedHash != "" {
+			sum, _, err := storage.Checksum(sourceBackend, finding.FilePath)
+			if err != nil || sum != finding.ExpectedHash {
+				continue
+			}
+		} else if _, err := sourceBackend.Stat(finding.FilePath); err != nil {
+			continue
+		}
+
+		r, err := sourceBackend.Open(finding.FilePath)
+		if err != nil {
+			return "", "", err
+		}
+		_, err = backend.Create(finding.FilePath, r)
+		r.Close()
+		if err != nil {
+			return "", "", fmt.Errorf("Wiederherstellung fehlgeschlagen: %w", err)
+		}
+		return models.ScrubFindingResolved, fmt.Sprintf("Aus S3-Pool %s wiederhergestellt", source.Name), nil
+	}
+	return "", "", fmt.Errorf("keine intakte Kopie in einem S3-Pool gefunden")
+}
+
+// quarantineOrphan moves an orphaned file below quarantine/<date>/ so it can be inspected before deletion
+func quarantineOrphan(finding *models.ScrubFinding, pool *models.StoragePool, now time.Time) (string, string, error) {
+	backend, err := storage.BackendFor(pool)
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := backend.Stat(finding.FilePath); errors.Is(err, os.ErrNotExist) {
+		return models.ScrubFindingResolved, "Datei existiert nicht mehr", nil
+	}
+	target := path.Join("quarantine", now.Format("2006-01-02"), finding.FilePath)
+	if err := backend.Copy(finding.FilePath, target); err != nil {
+		return "", "", fmt.Errorf("Kopie nach %s fehlgeschlagen: %w", target, err)
+	}
+	if err := backend.Delete(finding.FilePath); err != nil {
+		return "", "", fmt.Errorf("Datei konnte nicht entfernt werden: %w", err)
+	}
+	return models.ScrubFindingQuarantined, "Verschoben nach " + target, nil
+}
@@ -7,7 +7,6 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
-	"os"
 	"path"
 	"path/filepath"
 	"strings"
@@ -15,6 +14,7 @@ import (
 
 	"github.com/ManuelReschke/PixelFox/app/models"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
 )
 
 func isLocalLikeStoragePool(pool *models.StoragePool) bool {
@@ -53,13 +53,17 @@ func normalizeVariantRelativePath(filePath string, sourcePool *models.StoragePoo
 	return strings.TrimLeft(rel, "/")
 }
 
-func replicateFileToRemotePool(sourceFullPath, storedPath string, targetPoolID uint, uploadAPIURL string) error {
-	info, err := os.Stat(sourceFullPath)
+func replicateFileToRemotePool(sourcePool *models.StoragePool, storedPath string, targetPoolID uint, uploadAPIURL string) error {
+	backend, err := storage.BackendFor(sourcePool)
+	if err != nil {
+		return err
+	}
+	info, err := backend.Stat(storedPath)
 	if err != nil {
 		return fmt.Errorf("stat source failed: %w", err)
 	}
 
-	file, err := os.Open(sourceFullPath)
+	file, err := backend.Open(storedPath)
 	if err != nil {
 		return fmt.Errorf("open source failed: %w", err)
 	}
@@ -84,7 +88,7 @@ func replicateFileToRemotePool(sourceFullPath, storedPath string, targetPoolID u
 
 		_ = mw.WriteField("pool_id", fmt.Sprintf("%d", targetPoolID))
 		_ = mw.WriteField("stored_path", cleanStoredPath)
-		_ = mw.WriteField("size", fmt.Sprintf("%d", info.Size()))
+		_ = mw.WriteField("size", fmt.Sprintf("%d", info.Size))
 
 		part, err := mw.CreateFormFile("file", path.Base(cleanStoredPath))
 		if err != nil {
@@ -9,11 +9,25 @@ import (
 type JobType string
 
 const (
-	JobTypeImageProcessing   JobType = "image_processing"
-	JobTypePoolMoveEnqueue   JobType = "pool_move_enqueue"
-	JobTypeMoveImage         JobType = "move_image"
-	JobTypeDeleteImage       JobType = "delete_image"
-	JobTypeReconcileVariants JobType = "reconcile_variants"
+	JobTypeImageProcessing            JobType = "image_processing"
+	JobTypePoolMoveEnqueue            JobType = "pool_move_enqueue"
+	JobTypeMoveImage                  JobType = "move_image"
+	JobTypeDeleteImage                JobType = "delete_image"
+	JobTypeReconcileVariants          JobType = "reconcile_variants"
+	JobTypeVariantBackfillEnqueue     JobType = "variant_backfill_enqueue"
+	JobTypeSyncVariants               JobType = "sync_variants"
+	JobTypeMetadataScrubEnqueue       JobType = "metadata_scrub_enqueue"
+	JobTypeScrubMetadata              JobType = "scrub_metadata"
+	JobTypePerceptualHashEnqueue      JobType = "perceptual_hash_enqueue"
+	JobTypeComputePerceptualHash      JobType = "compute_perceptual_hash"
+	JobTypePlaceholderBackfillEnqueue JobType = "placeholder_backfill_enqueue"
+	JobTypeComputePlaceholder         JobType = "compute_placeholder"
+	JobTypeWatermarkRerenderEnqueue   JobType = "watermark_rerender_enqueue"
+	JobTypeRerenderWatermark          JobType = "rerender_watermark"
+	JobTypeIntegrityScrub             JobType = "integrity_scrub"
+	JobTypeScrubRepair                JobType = "scrub_repair"
+	JobTypeReplicateImage             JobType = "replicate_image"
+	JobTypeDropReplica                JobType = "drop_replica"
 )
 
 // JobStatus defines the status of a job
@@ -153,6 +167,50 @@ func ReconcileVariantsJobPayloadFromMap(data map[string]interface{}) (*Reconcile
 	return &payload, err
 }
 
+// VariantBackfillEnqueueJobPayload contains payload for scanning all images and enqueuing per-image variant sync jobs
+type VariantBackfillEnqueueJobPayload struct {
+	CursorID uint `json:"cursor_id"` // last processed Image.ID; 0 = start
+}
+
+func (p VariantBackfillEnqueueJobPayload) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"cursor_id": p.CursorID,
+	}
+}
+
+func VariantBackfillEnqueueJobPayloadFromMap(data map[string]interface{}) (*VariantBackfillEnqueueJobPayload, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload VariantBackfillEnqueueJobPayload
+	err = json.Unmarshal(jsonData, &payload)
+	return &payload, err
+}
+
+// SyncVariantsJobPayload contains payload for bringing the variants of a single image in line with the variant profiles
+type SyncVariantsJobPayload struct {
+	ImageID   uint   `json:"image_id"`
+	ImageUUID string `json:"image_uuid"`
+}
+
+func (p SyncVariantsJobPayload) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"image_id":   p.ImageID,
+		"image_uuid": p.ImageUUID,
+	}
+}
+
+func SyncVariantsJobPayloadFromMap(data map[string]interface{}) (*SyncVariantsJobPayload, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload SyncVariantsJobPayload
+	err = json.Unmarshal(jsonData, &payload)
+	return &payload, err
+}
+
 // DeleteImageJobPayload contains payload for deleting an image and its variants/files asynchronously
 type DeleteImageJobPayload struct {
 	ImageID       uint   `json:"image_id"`
@@ -220,3 +278,288 @@ func (j *Job) MarkAsRetrying() {
 	j.Status = JobStatusRetrying
 	j.UpdatedAt = time.Now()
 }
+
+// MetadataScrubEnqueueJobPayload contains payload for scanning all images and enqueuing per-image metadata scrub jobs
+type MetadataScrubEnqueueJobPayload struct {
+	CursorID uint `json:"cursor_id"` // last processed Image.ID; 0 = start
+}
+
+func (p MetadataScrubEnqueueJobPayload) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"cursor_id": p.CursorID,
+	}
+}
+
+func MetadataScrubEnqueueJobPayloadFromMap(data map[string]interface{}) (*MetadataScrubEnqueueJobPayload, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload MetadataScrubEnqueueJobPayload
+	err = json.Unmarshal(jsonData, &payload)
+	return &payload, err
+}
+
+// ScrubMetadataJobPayload contains payload for applying the metadata policy to the original of a single image
+type ScrubMetadataJobPayload struct {
+	ImageID   uint   `json:"image_id"`
+	ImageUUID string `json:"image_uuid"`
+}
+
+func (p ScrubMetadataJobPayload) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"image_id":   p.ImageID,
+		"image_uuid": p.ImageUUID,
+	}
+}
+
+func ScrubMetadataJobPayloadFromMap(data map[string]interface{}) (*ScrubMetadataJobPayload, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload ScrubMetadataJobPayload
+	err = json.Unmarshal(jsonData, &payload)
+	return &payload, err
+}
+
+// PerceptualHashEnqueueJobPayload contains payload for scanning images without perceptual hash and enqueuing per-image hash jobs
+type PerceptualHashEnqueueJobPayload struct {
+	CursorID uint `json:"cursor_id"` // last processed Image.ID; 0 = start
+}
+
+func (p PerceptualHashEnqueueJobPayload) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"cursor_id": p.CursorID,
+	}
+}
+
+func PerceptualHashEnqueueJobPayloadFromMap(data map[string]interface{}) (*PerceptualHashEnqueueJobPayload, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload PerceptualHashEnqueueJobPayload
+	err = json.Unmarshal(jsonData, &payload)
+	return &payload, err
+}
+
+// ComputePerceptualHashJobPayload contains payload for computing the perceptual hash of a single image
+type ComputePerceptualHashJobPayload struct {
+	ImageID   uint   `json:"image_id"`
+	ImageUUID string `json:"image_uuid"`
+}
+
+func (p ComputePerceptualHashJobPayload) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"image_id":   p.ImageID,
+		"image_uuid": p.ImageUUID,
+	}
+}
+
+func ComputePerceptualHashJobPayloadFromMap(data map[string]interface{}) (*ComputePerceptualHashJobPayload, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload ComputePerceptualHashJobPayload
+	err = json.Unmarshal(jsonData, &payload)
+	return &payload, err
+}
+
+// PlaceholderBackfillEnqueueJobPayload contains payload for scanning images without BlurHash and enqueuing per-image placeholder jobs
+type PlaceholderBackfillEnqueueJobPayload struct {
+	CursorID uint `json:"cursor_id"` // last processed Image.ID; 0 = start
+}
+
+func (p PlaceholderBackfillEnqueueJobPayload) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"cursor_id": p.CursorID,
+	}
+}
+
+func PlaceholderBackfillEnqueueJobPayloadFromMap(data map[string]interface{}) (*PlaceholderBackfillEnqueueJobPayload, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload PlaceholderBackfillEnqueueJobPayload
+	err = json.Unmarshal(jsonData, &payload)
+	return &payload, err
+}
+
+// ComputePlaceholderJobPayload contains payload for computing BlurHash and colors of a single image
+type ComputePlaceholderJobPayload struct {
+	ImageID   uint   `json:"image_id"`
+	ImageUUID string `json:"image_uuid"`
+}
+
+func (p ComputePlaceholderJobPayload) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"image_id":   p.ImageID,
+		"image_uuid": p.ImageUUID,
+	}
+}
+
+func ComputePlaceholderJobPayloadFromMap(data map[string]interface{}) (*ComputePlaceholderJobPayload, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload ComputePlaceholderJobPayload
+	err = json.Unmarshal(jsonData, &payload)
+	return &payload, err
+}
+
+// WatermarkRerenderEnqueueJobPayload contains payload for scanning the images of a user (or one album)
+// and enqueuing per-image watermark re-render jobs
+type WatermarkRerenderEnqueueJobPayload struct {
+	UserID   uint `json:"user_id"`
+	AlbumID  uint `json:"album_id"`  // 0 = all images of the user
+	CursorID uint `json:"cursor_id"` // last processed Image.ID; 0 = start
+}
+
+func (p WatermarkRerenderEnqueueJobPayload) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"user_id":   p.UserID,
+		"album_id":  p.AlbumID,
+		"cursor_id": p.CursorID,
+	}
+}
+
+func WatermarkRerenderEnqueueJobPayloadFromMap(data map[string]interface{}) (*WatermarkRerenderEnqueueJobPayload, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload WatermarkRerenderEnqueueJobPayload
+	err = json.Unmarshal(jsonData, &payload)
+	return &payload, err
+}
+
+// RerenderWatermarkJobPayload contains payload for re-rendering the watermarked variants of a single image
+type RerenderWatermarkJobPayload struct {
+	ImageID   uint   `json:"image_id"`
+	ImageUUID string `json:"image_uuid"`
+}
+
+func (p RerenderWatermarkJobPayload) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"image_id":   p.ImageID,
+		"image_uuid": p.ImageUUID,
+	}
+}
+
+func RerenderWatermarkJobPayloadFromMap(data map[string]interface{}) (*RerenderWatermarkJobPayload, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload RerenderWatermarkJobPayload
+	err = json.Unmarshal(jsonData, &payload)
+	return &payload, err
+}
+
+// Phases of an integrity scrub
+const (
+	ScrubPhaseImages  = "images"  // stat and re-hash the files referenced by images and variants
+	ScrubPhaseOrphans = "orphans" // list the pool for files without a database row
+)
+
+// IntegrityScrubJobPayload contains payload for one batch of the integrity scrub of a storage pool
+type IntegrityScrubJobPayload struct {
+	PoolID   uint   `json:"pool_id"`
+	ReportID uint   `json:"report_id"`
+	Phase    string `json:"phase"`
+	CursorID uint   `json:"cursor_id"` // last checked Image.ID; 0 = start
+}
+
+func (p IntegrityScrubJobPayload) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"pool_id":   p.PoolID,
+		"report_id": p.ReportID,
+		"phase":     p.Phase,
+		"cursor_id": p.CursorID,
+	}
+}
+
+func IntegrityScrubJobPayloadFromMap(data map[string]interface{}) (*IntegrityScrubJobPayload, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload IntegrityScrubJobPayload
+	err = json.Unmarshal(jsonData, &payload)
+	return &payload, err
+}
+
+// ScrubRepairJobPayload contains payload for repairing a single integrity scrub finding
+type ScrubRepairJobPayload struct {
+	FindingID uint   `json:"finding_id"`
+	Action    string `json:"action"` // models.ScrubRepair*
+}
+
+func (p ScrubRepairJobPayload) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"finding_id": p.FindingID,
+		"action":     p.Action,
+	}
+}
+
+func ScrubRepairJobPayloadFromMap(data map[string]interface{}) (*ScrubRepairJobPayload, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload ScrubRepairJobPayload
+	err = json.Unmarshal(jsonData, &payload)
+	return &payload, err
+}
+
+// ReplicateImageJobPayload contains payload for bringing an image back to the replica count of its tier
+type ReplicateImageJobPayload struct {
+	ImageID      uint `json:"image_id"`
+	TargetPoolID uint `json:"target_pool_id"` // 0 = pick the missing replicas
+}
+
+func (p ReplicateImageJobPayload) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"image_id":       p.ImageID,
+		"target_pool_id": p.TargetPoolID,
+	}
+}
+
+func ReplicateImageJobPayloadFromMap(data map[string]interface{}) (*ReplicateImageJobPayload, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload ReplicateImageJobPayload
+	err = json.Unmarshal(jsonData, &payload)
+	return &payload, err
+}
+
+// DropReplicaJobPayload contains payload for deleting the files of an image replica from its pool
+type DropReplicaJobPayload struct {
+	ImageID uint     `json:"image_id"`
+	PoolID  uint     `json:"pool_id"`
+	Paths   []string `json:"paths"` // relative to the pool root
+}
+
+func (p DropReplicaJobPayload) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"image_id": p.ImageID,
+		"pool_id":  p.PoolID,
+		"paths":    p.Paths,
+	}
+}
+
+func DropReplicaJobPayloadFromMap(data map[string]interface{}) (*DropReplicaJobPayload, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload DropReplicaJobPayload
+	err = json.Unmarshal(jsonData, &payload)
+	return &payload, err
+}
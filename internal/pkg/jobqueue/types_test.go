@@ -20,6 +20,16 @@ func TestJobType(t *testing.T) {
 		{"Move Image", JobTypeMoveImage, "move_image"},
 		{"Delete Image", JobTypeDeleteImage, "delete_image"},
 		{"Reconcile Variants", JobTypeReconcileVariants, "reconcile_variants"},
+		{"Variant Backfill Enqueue", JobTypeVariantBackfillEnqueue, "variant_backfill_enqueue"},
+		{"Sync Variants", JobTypeSyncVariants, "sync_variants"},
+		{"Metadata Scrub Enqueue", JobTypeMetadataScrubEnqueue, "metadata_scrub_enqueue"},
+		{"Scrub Metadata", JobTypeScrubMetadata, "scrub_metadata"},
+		{"Perceptual Hash Enqueue", JobTypePerceptualHashEnqueue, "perceptual_hash_enqueue"},
+		{"Compute Perceptual Hash", JobTypeComputePerceptualHash, "compute_perceptual_hash"},
+		{"Placeholder Backfill Enqueue", JobTypePlaceholderBackfillEnqueue, "placeholder_backfill_enqueue"},
+		{"Compute Placeholder", JobTypeComputePlaceholder, "compute_placeholder"},
+		{"Watermark Rerender Enqueue", JobTypeWatermarkRerenderEnqueue, "watermark_rerender_enqueue"},
+		{"Rerender Watermark", JobTypeRerenderWatermark, "rerender_watermark"},
 	}
 
 	for _, tt := range tests {
@@ -243,6 +253,130 @@ func TestPayloadRoundTrip(t *testing.T) {
 
 		assert.Equal(t, &original, result)
 	})
+
+	t.Run("SyncVariantsJobPayload", func(t *testing.T) {
+		original := SyncVariantsJobPayload{
+			ImageID:   42,
+			ImageUUID: "sync-variants-test",
+		}
+
+		result, err := SyncVariantsJobPayloadFromMap(original.ToMap())
+		require.NoError(t, err)
+
+		assert.Equal(t, &original, result)
+	})
+
+	t.Run("ScrubMetadataJobPayload", func(t *testing.T) {
+		original := ScrubMetadataJobPayload{
+			ImageID:   7,
+			ImageUUID: "scrub-metadata-test",
+		}
+
+		result, err := ScrubMetadataJobPayloadFromMap(original.ToMap())
+		require.NoError(t, err)
+
+		assert.Equal(t, &original, result)
+	})
+
+	t.Run("ComputePerceptualHashJobPayload", func(t *testing.T) {
+		original := ComputePerceptualHashJobPayload{
+			ImageID:   9,
+			ImageUUID: "perceptual-hash-test",
+		}
+
+		result, err := ComputePerceptualHashJobPayloadFromMap(original.ToMap())
+		require.NoError(t, err)
+
+		assert.Equal(t, &original, result)
+	})
+
+	t.Run("ComputePlaceholderJobPayload", func(t *testing.T) {
+		original := ComputePlaceholderJobPayload{
+			ImageID:   11,
+			ImageUUID: "placeholder-test",
+		}
+
+		result, err := ComputePlaceholderJobPayloadFromMap(original.ToMap())
+		require.NoError(t, err)
+
+		assert.Equal(t, &original, result)
+	})
+
+	t.Run("WatermarkRerenderEnqueueJobPayload", func(t *testing.T) {
+		original := WatermarkRerenderEnqueueJobPayload{
+			UserID:   3,
+			AlbumID:  5,
+			CursorID: 120,
+		}
+
+		result, err := WatermarkRerenderEnqueueJobPayloadFromMap(original.ToMap())
+		require.NoError(t, err)
+
+		assert.Equal(t, &original, result)
+	})
+
+	t.Run("RerenderWatermarkJobPayload", func(t *testing.T) {
+		original := RerenderWatermarkJobPayload{
+			ImageID:   13,
+			ImageUUID: "watermark-test",
+		}
+
+		result, err := RerenderWatermarkJobPayloadFromMap(original.ToMap())
+		require.NoError(t, err)
+
+		assert.Equal(t, &original, result)
+	})
+
+	t.Run("IntegrityScrubJobPayload", func(t *testing.T) {
+		original := IntegrityScrubJobPayload{
+			PoolID:   2,
+			ReportID: 17,
+			Phase:    ScrubPhaseOrphans,
+			CursorID: 940,
+		}
+
+		result, err := IntegrityScrubJobPayloadFromMap(original.ToMap())
+		require.NoError(t, err)
+
+		assert.Equal(t, &original, result)
+	})
+
+	t.Run("ScrubRepairJobPayload", func(t *testing.T) {
+		original := ScrubRepairJobPayload{
+			FindingID: 31,
+			Action:    "quarantine",
+		}
+
+		result, err := ScrubRepairJobPayloadFromMap(original.ToMap())
+		require.NoError(t, err)
+
+		assert.Equal(t, &original, result)
+	})
+
+	t.Run("ReplicateImageJobPayload", func(t *testing.T) {
+		original := ReplicateImageJobPayload{
+			ImageID:      12,
+			TargetPoolID: 4,
+		}
+
+		result, err := ReplicateImageJobPayloadFromMap(original.ToMap())
+		require.NoError(t, err)
+
+		assert.Equal(t, &original, result)
+	})
+
+	t.Run("DropReplicaJobPayload", func(t *testing.T) {
+		original := DropReplicaJobPayload{
+			ImageID: 12,
+			PoolID:  4,
+			Paths:   []string{"original/2024/01/02/abc.jpg", "variants/2024/01/02/abc_small.webp"},
+		}
+
+		result, err := DropReplicaJobPayloadFromMap(original.ToMap())
+		require.NoError(t, err)
+
+		assert.Equal(t, &original, result)
+	})
 }
 
 func TestJobJSONSerialization(t *testing.T) {
@@ -0,0 +1,106 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+)
+
+// EnqueueVariantBackfill enqueues a scan over all images that syncs their variants with the variant profiles
+func (q *Queue) EnqueueVariantBackfill() (*Job, error) {
+	return q.EnqueueJob(JobTypeVariantBackfillEnqueue, VariantBackfillEnqueueJobPayload{}.ToMap())
+}
+
+// processVariantBackfillEnqueueJob scans images in batches and enqueues per-image variant sync jobs
+func (q *Queue) processVariantBackfillEnqueueJob(job *Job) error {
+	payload, err := VariantBackfillEnqueueJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid variant backfill payload: %w", err)
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	const batchSize = 200
+	var images []models.Image
+	if err := db.Select("id", "uuid").Where("id > ?", payload.CursorID).
+		Order("id ASC").Limit(batchSize).Find(&images).Error; err != nil {
+		return fmt.Errorf("failed to list images for variant backfill: %w", err)
+	}
+	if len(images) == 0 {
+		log.Infof("[VariantBackfill] No more images to enqueue (cursor %d)", payload.CursorID)
+		return nil
+	}
+	// Enqueue per-image sync jobs
+	for _, img := range images {
+		p := SyncVariantsJobPayload{ImageID: img.ID, ImageUUID: img.UUID}
+		if _, err := q.EnqueueJob(JobTypeSyncVariants, p.ToMap()); err != nil {
+			log.Errorf("[VariantBackfill] Failed to enqueue sync job for image %d: %v", img.ID, err)
+		}
+	}
+	// Re-enqueue enqueuer with next cursor if there might be more
+	next := VariantBackfillEnqueueJobPayload{CursorID: images[len(images)-1].ID}
+	if _, err := q.EnqueueJob(JobTypeVariantBackfillEnqueue, next.ToMap()); err != nil {
+		log.Errorf("[VariantBackfill] Failed to enqueue next batch: %v", err)
+		// not fatal for this batch
+	}
+	return nil
+}
+
+// processSyncVariantsJob generates missing and removes obsolete variants of a single image
+func (q *Queue) processSyncVariantsJob(ctx context.Context, job *Job) error {
+	payload, err := SyncVariantsJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid sync variants payload: %w", err)
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	var image models.Image
+	if err := db.Preload("StoragePool").First(&image, payload.ImageID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Image was deleted in the meantime; nothing to sync
+			log.Warnf("[SyncVariants] Image %d not found; skipping job %s", payload.ImageID, job.ID)
+			return nil
+		}
+		return fmt.Errorf("image not found: %w", err)
+	}
+
+	// Node routing: variants are written next to the original, so run on the pool's node
+	nodeID := strings.TrimSpace(env.GetEnv("NODE_ID", ""))
+	if nodeID != "" && image.StoragePool != nil {
+		poolNode := strings.TrimSpace(image.StoragePool.NodeID)
+		if poolNode != "" && !strings.EqualFold(nodeID, poolNode) {
+			if err := q.requeueJob(ctx, job); err != nil {
+				log.Errorf("[SyncVariants] Failed to requeue job %s for node routing: %v", job.ID, err)
+			}
+			return ErrRequeue
+		}
+	}
+
+	// Images still in the upload pipeline get their variants from the processing job
+	if status, err := imageprocessor.GetImageStatus(image.UUID); err == nil &&
+		(status == imageprocessor.STATUS_PENDING || status == imageprocessor.STATUS_PROCESSING) {
+		return fmt.Errorf("image processing not complete yet")
+	}
+
+	created, removed, err := imageprocessor.SyncVariantProfiles(&image)
+	if err != nil {
+		return fmt.Errorf("sync variants failed for image %d: %w", image.ID, err)
+	}
+	if created > 0 || removed > 0 {
+		log.Infof("[SyncVariants] Image %d: created %d, removed %d variants", image.ID, created, removed)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/imageprocessor"
+)
+
+// EnqueueWatermarkRerender enqueues the re-rendering of all images of a user after the watermark
+// changed. With albumID > 0 only the images of that album are re-rendered.
+func (q *Queue) EnqueueWatermarkRerender(userID, albumID uint) (*Job, error) {
+	return q.EnqueueJob(JobTypeWatermarkRerenderEnqueue, WatermarkRerenderEnqueueJobPayload{UserID: userID, AlbumID: albumID}.ToMap())
+}
+
+// EnqueueImagesWatermarkRerender enqueues the re-rendering of single images, e.g. after they were
+// added to or removed from an album with a watermark override
+func (q *Queue) EnqueueImagesWatermarkRerender(imageIDs []uint) {
+	for _, id := range imageIDs {
+		if _, err := q.EnqueueJob(JobTypeRerenderWatermark, RerenderWatermarkJobPayload{ImageID: id}.ToMap()); err != nil {
+			log.Errorf("[Watermark] Failed to enqueue rerender job for image %d: %v", id, err)
+		}
+	}
+}
+
+// processWatermarkRerenderEnqueueJob scans the affected images in batches and enqueues per-image re-render jobs
+func (q *Queue) processWatermarkRerenderEnqueueJob(job *Job) error {
+	payload, err := WatermarkRerenderEnqueueJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid watermark rerender payload: %w", err)
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	const batchSize = 200
+	query := db.Model(&models.Image{}).Select("images.id", "images.uuid").
+		Where("images.user_id = ? AND images.id > ?", payload.UserID, payload.CursorID)
+	if payload.AlbumID > 0 {
+		query = query.Joins("JOIN album_images ON album_images.image_id = images.id").
+			Where("album_images.album_id = ?", payload.AlbumID)
+	}
+	var images []models.Image
+	if err := query.Order("images.id ASC").Limit(batchSize).Find(&images).Error; err != nil {
+		return fmt.Errorf("failed to list images for watermark rerender: %w", err)
+	}
+	if len(images) == 0 {
+		log.Infof("[Watermark] No more images to enqueue for user %d (cursor %d)", payload.UserID, payload.CursorID)
+		return nil
+	}
+	for _, img := range images {
+		p := RerenderWatermarkJobPayload{ImageID: img.ID, ImageUUID: img.UUID}
+		if _, err := q.EnqueueJob(JobTypeRerenderWatermark, p.ToMap()); err != nil {
+			log.Errorf("[Watermark] Failed to enqueue rerender job for image %d: %v", img.ID, err)
+		}
+	}
+	next := *payload
+	next.CursorID = images[len(images)-1].ID
+	if _, err := q.EnqueueJob(JobTypeWatermarkRerenderEnqueue, next.ToMap()); err != nil {
+		log.Errorf("[Watermark] Failed to enqueue next batch: %v", err)
+	}
+	return nil
+}
+
+// processRerenderWatermarkJob renders the watermarked variants of a single image again
+func (q *Queue) processRerenderWatermarkJob(ctx context.Context, job *Job) error {
+	payload, err := RerenderWatermarkJobPayloadFromMap(job.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid rerender watermark payload: %w", err)
+	}
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	var image models.Image
+	if err := db.Preload("StoragePool").First(&image, payload.ImageID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warnf("[Watermark] Image %d not found; skipping job %s", payload.ImageID, job.ID)
+			return nil
+		}
+		return fmt.Errorf("image not found: %w", err)
+	}
+
+	// Node routing: the original is read from disk, so run on the pool's node
+	nodeID := strings.TrimSpace(env.GetEnv("NODE_ID", ""))
+	if nodeID != "" && image.StoragePool != nil {
+		poolNode := strings.TrimSpace(image.StoragePool.NodeID)
+		if poolNode != "" && !strings.EqualFold(nodeID, poolNode) {
+			if err := q.requeueJob(ctx, job); err != nil {
+				log.Errorf("[Watermark] Failed to requeue job %s for node routing: %v", job.ID, err)
+			}
+			return ErrRequeue
+		}
+	}
+
+	if err := imageprocessor.RerenderWatermark(&image); err != nil {
+		return fmt.Errorf("watermark rerender failed for image %d: %w", image.ID, err)
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 const (
 	imageViewsKey      = "image:counters:views"
 	imageDownloadsKey  = "image:counters:downloads"
+	imageLikesKey      = "image:counters:likes"
 	albumViewsKey      = "album:counters:views"
 	imageLastViewedKey = "image:counters:last_viewed"
 )
@@ -33,6 +34,24 @@ func AddImageDownload(imageID uint) error {
 	return cache.GetClient().HIncrBy(ctx, imageDownloadsKey, field, 1).Err()
 }
 
+// AddImageLike adds delta (+1 like, -1 unlike) to the pending like counter for an image in Redis
+func AddImageLike(imageID uint, delta int64) error {
+	ctx := context.Background()
+	field := strconv.FormatUint(uint64(imageID), 10)
+	return cache.GetClient().HIncrBy(ctx, imageLikesKey, field, delta).Err()
+}
+
+// PendingImageLikes returns the like delta of an image that has not been flushed to the database yet
+func PendingImageLikes(imageID uint) int64 {
+	ctx := context.Background()
+	field := strconv.FormatUint(uint64(imageID), 10)
+	n, err := cache.GetClient().HGet(ctx, imageLikesKey, field).Int64()
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
 // FlushAll flushes both views and downloads to the database
 func FlushAll() error {
 	if err := flushHashToTable(imageViewsKey, "images", "view_count"); err != nil {
@@ -41,6 +60,9 @@ func FlushAll() error {
 	if err := flushHashToTable(imageDownloadsKey, "images", "download_count"); err != nil {
 		return err
 	}
+	if err := flushHashToTable(imageLikesKey, "images", "like_count"); err != nil {
+		return err
+	}
 	if err := flushHashToTable(albumViewsKey, "albums", "view_count"); err != nil {
 		return err
 	}
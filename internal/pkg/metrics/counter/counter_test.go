@@ -0,0 +1,107 @@
+package counter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/ManuelReschke/PixelFox/internal/pkg/cache"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+)
+
+// requireTestRedis skips unless the cache configured with CACHE_* answers
+func requireTestRedis(t *testing.T) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := cache.GetClient().Ping(ctx).Err(); err != nil {
+		t.Skipf("Skipping Redis-dependent test: %v", err)
+	}
+}
+
+// requireTestDB points the database package at the MySQL database configured with DB_* and skips without one
+func requireTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	if database.GetDB() != nil {
+		return database.GetDB()
+	}
+	if os.Getenv("DB_USER") == "" || os.Getenv("DB_NAME") == "" {
+		t.Skip("Skipping MySQL-dependent test: DB_USER and DB_NAME are not set")
+	}
+	host, port := os.Getenv("DB_HOST"), os.Getenv("DB_PORT")
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	if port == "" {
+		port = "3306"
+	}
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local&timeout=2s",
+		os.Getenv("DB_USER"), os.Getenv("DB_PASSWORD"), host, port, os.Getenv("DB_NAME"))
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Skipf("Skipping MySQL-dependent test: %v", err)
+	}
+	database.DB = db
+	return db
+}
+
+func TestAddImageLikeTracksPendingDelta(t *testing.T) {
+	requireTestRedis(t)
+	imageID := uint(900000000 + time.Now().UnixNano()%1000000)
+	field := strconv.FormatUint(uint64(imageID), 10)
+	t.Cleanup(func() { cache.GetClient().HDel(context.Background(), imageLikesKey, field) })
+
+	assert.Zero(t, PendingImageLikes(imageID))
+	require.NoError(t, AddImageLike(imageID, 1))
+	require.NoError(t, AddImageLike(imageID, 1))
+	require.NoError(t, AddImageLike(imageID, -1))
+	assert.Equal(t, int64(1), PendingImageLikes(imageID))
+}
+
+func TestFlushHashToTableAppliesDeltas(t *testing.T) {
+	requireTestRedis(t)
+	db := requireTestDB(t)
+
+	table := fmt.Sprintf("counter_flush_test_%d", time.Now().UnixNano())
+	require.NoError(t, db.Exec("CREATE TABLE "+table+" (id INT UNSIGNED PRIMARY KEY, like_count BIGINT NOT NULL DEFAULT 0)").Error)
+	t.Cleanup(func() { db.Exec("DROP TABLE " + table) })
+	require.NoError(t, db.Exec("INSERT INTO "+table+" (id, like_count) VALUES (1, 5), (2, 0), (3, 7)").Error)
+
+	ctx := context.Background()
+	redisKey := table + ":likes"
+	rdb := cache.GetClient()
+	t.Cleanup(func() { rdb.Del(ctx, redisKey) })
+	require.NoError(t, rdb.HIncrBy(ctx, redisKey, "1", 2).Err())
+	require.NoError(t, rdb.HIncrBy(ctx, redisKey, "2", 1).Err())
+	require.NoError(t, rdb.HIncrBy(ctx, redisKey, "3", -1).Err())
+
+	require.NoError(t, flushHashToTable(redisKey, table, "like_count"))
+
+	var rows []struct {
+		ID        uint
+		LikeCount int64
+	}
+	require.NoError(t, db.Table(table).Order("id ASC").Scan(&rows).Error)
+	require.Len(t, rows, 3)
+	assert.Equal(t, int64(7), rows[0].LikeCount)
+	assert.Equal(t, int64(1), rows[1].LikeCount)
+	assert.Equal(t, int64(6), rows[2].LikeCount)
+
+	// The hash is drained, a second flush changes nothing
+	exists, err := rdb.Exists(ctx, redisKey).Result()
+	require.NoError(t, err)
+	assert.Zero(t, exists)
+	require.NoError(t, flushHashToTable(redisKey, table, "like_count"))
+	var total int64
+	require.NoError(t, db.Table(table).Select("SUM(like_count)").Scan(&total).Error)
+	assert.Equal(t, int64(14), total)
+}
@@ -2,9 +2,9 @@ package middleware
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"strings"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -16,7 +16,8 @@ import (
 )
 
 // APIKeyAuthMiddleware authenticates requests carrying a user API key header.
-func APIKeyAuthMiddleware() fiber.Handler {
+// The key must grant all requiredScopes, otherwise the request is rejected with 403.
+func APIKeyAuthMiddleware(requiredScopes ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		apiKey := extractAPIKeyFromHeader(c)
 		if apiKey == "" {
@@ -30,8 +31,8 @@ func APIKeyAuthMiddleware() fiber.Handler {
 		}
 
 		hash := models.HashAPIKey(apiKey)
-		repo := repository.GetGlobalFactory().GetUserRepository()
-		user, settings, err := repo.GetByAPIKeyHash(hash)
+		repo := repository.GetGlobalFactory().GetAPIKeyRepository()
+		key, user, err := repo.GetActiveByHash(hash)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized", "message": "Invalid API key"})
@@ -44,16 +45,24 @@ func APIKeyAuthMiddleware() fiber.Handler {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "forbidden", "message": "User inactive"})
 		}
 
+		for _, scope := range requiredScopes {
+			if !key.HasScope(scope) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "insufficient_scope", "message": fmt.Sprintf("API key lacks scope %s", scope)})
+			}
+		}
+
+		settings, err := models.GetOrCreateUserSettings(db, user.ID)
+		if err != nil {
+			log.Printf("api key middleware: failed to load settings for user %d: %v", user.ID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_server_error", "message": "API key verification failed"})
+		}
 		if settings.Plan == "" {
 			settings.Plan = "free"
 		}
 
-		// Refresh last-used timestamp best-effort.
-		now := time.Now()
-		if err := db.Model(&models.UserSettings{}).
-			Where("id = ?", settings.ID).
-			Updates(map[string]any{"api_key_last_used_at": now}).Error; err != nil {
-			log.Printf("failed to update api key usage timestamp for user %d: %v", user.ID, err)
+		// Refresh last-used metadata best-effort.
+		if err := repo.TouchUsage(key.ID, c.IP()); err != nil {
+			log.Printf("failed to update api key usage for key %d: %v", key.ID, err)
 		}
 
 		userCtx := usercontext.UserContext{
@@ -73,6 +82,18 @@ func APIKeyAuthMiddleware() fiber.Handler {
 	}
 }
 
+// OptionalAPIKeyAuthMiddleware authenticates the request if an API key header is present
+// and lets anonymous requests pass through.
+func OptionalAPIKeyAuthMiddleware(requiredScopes ...string) fiber.Handler {
+	auth := APIKeyAuthMiddleware(requiredScopes...)
+	return func(c *fiber.Ctx) error {
+		if extractAPIKeyFromHeader(c) == "" {
+			return c.Next()
+		}
+		return auth(c)
+	}
+}
+
 func extractAPIKeyFromHeader(c *fiber.Ctx) string {
 	apiKey := strings.TrimSpace(c.Get("X-API-Key"))
 	if apiKey != "" {
@@ -1,8 +1,11 @@
 package middleware
 
 import (
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/session"
 	icuser "github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
 	"github.com/gofiber/fiber/v2"
+	"github.com/sujit-baniya/flash"
 )
 
 // RequireAuth ensures a logged-in web session; redirects to /login if missing.
@@ -31,6 +34,13 @@ func RequireAdmin(c *fiber.Ctx) error {
 	if isAdmin, ok := c.Locals(icuser.KeyIsAdmin).(bool); !ok || !isAdmin {
 		return c.Redirect("/", fiber.StatusSeeOther)
 	}
+	// Optionally enforce that admin sessions passed the second factor
+	if app := models.GetAppSettings(); app != nil && app.IsAdmin2FARequired() {
+		if session.GetSessionValue(c, icuser.KeyTwoFactorVerified) != "true" {
+			flash.WithError(c, fiber.Map{"message": "Für den Admin-Bereich ist die Zwei-Faktor-Authentifizierung erforderlich"})
+			return c.Redirect("/user/settings/2fa", fiber.StatusSeeOther)
+		}
+	}
 	return c.Next()
 }
 
@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
+)
+
+// replicaFailoverParam marks a request redirected to a replica's node
+const replicaFailoverParam = "failover"
+
+// uploadFileUUID matches the image UUID at the start of original and variant file names
+var uploadFileUUID = regexp.MustCompile(`^([0-9a-fA-F-]{36})(?:[_.]|$)`)
+
+// DeliverFromStoragePool serves uploads from the storage pool that currently holds them, so URLs
+// keep working after an image was moved, e.g. demoted to an S3 pool. Requests it cannot resolve,
+// and files the pool does not hold (yet), fall through to the static uploads handler.
+func DeliverFromStoragePool(c *fiber.Ctx) error {
+	if c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead {
+		return c.Next()
+	}
+	relPath, ok := uploadRelativePath(c.Path())
+	if !ok {
+		return c.Next()
+	}
+	m := uploadFileUUID.FindStringSubmatch(path.Base(relPath))
+	if m == nil {
+		return c.Next()
+	}
+	db := database.GetDB()
+	if db == nil {
+		return c.Next()
+	}
+
+	var image models.Image
+	if err := db.Preload("StoragePool").Select("id", "uuid", "user_id", "is_watermarked", "storage_pool_id", "file_hash", "content_hash").
+		Where("uuid = ?", strings.ToLower(m[1])).Limit(1).Find(&image).Error; err != nil || image.ID == 0 {
+		return c.Next()
+	}
+	// Originals of watermarked images are owner-only, whichever handler ends up serving them
+	if isOriginalPath(relPath) && image.IsWatermarked && !mayViewOriginal(c, image.UserID) {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+	pool := image.StoragePool
+	if !isOriginalPath(relPath) {
+		// Variants may be in another pool than the original while a move is in progress
+		var variant models.ImageVariant
+		if err := db.Preload("StoragePool").Select("id", "storage_pool_id").
+			Where("image_id = ? AND file_name = ?", image.ID, path.Base(relPath)).Limit(1).Find(&variant).Error; err != nil || variant.ID == 0 {
+			return c.Next()
+		}
+		if variant.StoragePool != nil {
+			pool = variant.StoragePool
+		}
+	}
+	if pool == nil {
+		return c.Next()
+	}
+
+	// Replicas hold the original and the variants stored next to it in the image's primary pool
+	primary := pool.ID == image.StoragePoolID
+	if primary && !storage.PoolHealthy(pool.ID) {
+		log.Warnf("[Delivery] Pool %s is unhealthy, serving %s from a replica", pool.Name, relPath)
+		if served, err := deliverFromReplica(c, db, &image, relPath); served {
+			return err
+		}
+	}
+
+	err := storage.Deliver(c, pool, relPath)
+	if err != nil && primary {
+		if served, rerr := deliverFromReplica(c, db, &image, relPath); served {
+			log.Warnf("[Delivery] Serving %s from a replica, pool %s failed: %v", relPath, pool.Name, err)
+			return rerr
+		}
+	}
+	if errors.Is(err, storage.ErrFileNotFound) {
+		return c.Next()
+	}
+	if err != nil {
+		log.Errorf("[Delivery] Failed to deliver %s from pool %s: %v", relPath, pool.Name, err)
+		return c.SendStatus(fiber.StatusBadGateway)
+	}
+	return nil
+}
+
+// deliverFromReplica serves the file from the first healthy replica that holds the image's current
+// original. Local replicas of other nodes are reached by redirecting to the node once; the failover
+// query parameter keeps that node from redirecting again. It reports whether a replica answered.
+func deliverFromReplica(c *fiber.Ctx, db *gorm.DB, image *models.Image, relPath string) (bool, error) {
+	replicas, err := models.FindImageReplicas(db, image.ID)
+	if err != nil {
+		log.Errorf("[Delivery] Failed to load replicas of image %d: %v", image.ID, err)
+		return false, nil
+	}
+	nodeID := strings.TrimSpace(env.GetEnv("NODE_ID", ""))
+	redirected := c.Query(replicaFailoverParam) != ""
+	for _, r := range replicas {
+		replica := r.StoragePool
+		if replica == nil || r.StoragePoolID == image.StoragePoolID || !r.IsCurrent(image) || !storage.PoolHealthy(replica.ID) {
+			continue
+		}
+		isLocal := replica.StorageType == models.StorageTypeLocal || replica.StorageType == models.StorageTypeNFS
+		replicaNode := strings.TrimSpace(replica.NodeID)
+		if isLocal && nodeID != "" && replicaNode != "" && !strings.EqualFold(nodeID, replicaNode) {
+			if redirected || strings.TrimSpace(replica.PublicBaseURL) == "" {
+				continue
+			}
+			backend, err := storage.BackendFor(replica)
+			if err != nil {
+				continue
+			}
+			if target := backend.PublicURL(relPath); target != "" {
+				return true, c.Redirect(target+"?"+replicaFailoverParam+"=1", fiber.StatusFound)
+			}
+			continue
+		}
+		err := storage.Deliver(c, replica, relPath)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, storage.ErrFileNotFound) {
+			log.Errorf("[Delivery] Failed to deliver %s from replica pool %s: %v", relPath, replica.Name, err)
+		}
+	}
+	return false, nil
+}
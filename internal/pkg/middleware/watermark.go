@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/constants"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/session"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/usercontext"
+	"github.com/gofiber/fiber/v2"
+)
+
+// uploadRelativePath returns the path below the uploads route as the static handler resolves it:
+// percent-decoded and cleaned, so neither "%2E" nor "//" can hide an original from the checks.
+// It reports false for paths that cannot be decoded or leave the uploads route.
+func uploadRelativePath(rawPath string) (string, bool) {
+	decoded, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return "", false
+	}
+	clean := path.Clean("/" + decoded)
+	prefix := constants.UploadsRoute + "/"
+	if len(clean) <= len(prefix) || !strings.EqualFold(clean[:len(prefix)], prefix) {
+		return "", false
+	}
+	return clean[len(prefix):], true
+}
+
+// isOriginalPath reports whether an uploads path points below original/
+func isOriginalPath(relPath string) bool {
+	return strings.HasPrefix(strings.ToLower(relPath), "original/")
+}
+
+// findWatermarkedImage returns the image with the UUID if it is watermarked, nil otherwise. Replaceable in tests.
+var findWatermarkedImage = func(uuid string) *models.Image {
+	db := database.GetDB()
+	if db == nil {
+		return nil
+	}
+	var image models.Image
+	if err := db.Select("id", "user_id", "is_watermarked").Where("uuid = ?", strings.ToLower(uuid)).Limit(1).Find(&image).Error; err != nil || image.ID == 0 || !image.IsWatermarked {
+		return nil
+	}
+	return &image
+}
+
+// mayViewOriginal reports whether the session belongs to the owner or an admin. Replaceable in tests.
+var mayViewOriginal = func(c *fiber.Ctx, ownerID uint) bool {
+	sess, err := session.GetSessionStore().Get(c)
+	if err != nil {
+		return false
+	}
+	if isAdmin, ok := sess.Get(usercontext.KeyIsAdmin).(bool); ok && isAdmin {
+		return true
+	}
+	userID, ok := sess.Get(usercontext.KeyUserID).(uint)
+	return ok && userID == ownerID
+}
+
+// ProtectWatermarkedOriginals keeps the unwatermarked originals of watermarked images owner-only.
+// It guards the whole uploads route, so encoded or doubled slashes that the static handler
+// normalizes cannot reach an original; everybody but the owner and admins gets a 404.
+func ProtectWatermarkedOriginals(c *fiber.Ctx) error {
+	relPath, ok := uploadRelativePath(c.Path())
+	if !ok {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+	if !isOriginalPath(relPath) {
+		return c.Next()
+	}
+	m := uploadFileUUID.FindStringSubmatch(path.Base(relPath))
+	if m == nil {
+		return c.Next()
+	}
+	image := findWatermarkedImage(m[1])
+	if image == nil || mayViewOriginal(c, image.UserID) {
+		return c.Next()
+	}
+	return c.SendStatus(fiber.StatusNotFound)
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/constants"
+)
+
+const watermarkTestUUID = "0f8fad5b-d9cb-469f-a165-70867728950e"
+
+func TestUploadRelativePath(t *testing.T) {
+	cases := map[string]string{
+		"/uploads/original/2024/01/02/" + watermarkTestUUID + ".jpg":   "original/2024/01/02/" + watermarkTestUUID + ".jpg",
+		"/uploads/original/" + watermarkTestUUID + "%2Ejpg":            "original/" + watermarkTestUUID + ".jpg",
+		"/uploads//original/" + watermarkTestUUID + ".jpg":             "original/" + watermarkTestUUID + ".jpg",
+		"/uploads/variants/../original/" + watermarkTestUUID + ".jpg":  "original/" + watermarkTestUUID + ".jpg",
+		"/uploads/%6Friginal/" + watermarkTestUUID + ".jpg":            "original/" + watermarkTestUUID + ".jpg",
+		"/UPLOADS/original/" + watermarkTestUUID + ".jpg":              "original/" + watermarkTestUUID + ".jpg",
+		"/uploads/variants/" + watermarkTestUUID + "_thumb_small.webp": "variants/" + watermarkTestUUID + "_thumb_small.webp",
+	}
+	for raw, want := range cases {
+		got, ok := uploadRelativePath(raw)
+		assert.True(t, ok, raw)
+		assert.Equal(t, want, got, raw)
+		assert.Equal(t, want[:9] == "original/", isOriginalPath(got), raw)
+	}
+
+	for _, raw := range []string{"/uploads", "/uploads/", "/uploads/../secret", "/uploads/%zz", "/public/file.jpg"} {
+		_, ok := uploadRelativePath(raw)
+		assert.False(t, ok, raw)
+	}
+}
+
+func TestProtectWatermarkedOriginalsNormalizedPaths(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "original"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "original", watermarkTestUUID+".jpg"), []byte("original"), 0o644))
+
+	origFind, origMay := findWatermarkedImage, mayViewOriginal
+	t.Cleanup(func() { findWatermarkedImage, mayViewOriginal = origFind, origMay })
+	findWatermarkedImage = func(uuid string) *models.Image {
+		if uuid == watermarkTestUUID {
+			return &models.Image{ID: 1, UserID: 7, IsWatermarked: true}
+		}
+		return nil
+	}
+	viewer := uint(0)
+	mayViewOriginal = func(c *fiber.Ctx, ownerID uint) bool { return viewer == ownerID }
+
+	app := fiber.New()
+	app.Use(constants.UploadsRoute, ProtectWatermarkedOriginals)
+	app.Static(constants.UploadsRoute, root)
+
+	paths := []string{
+		"/uploads/original/" + watermarkTestUUID + ".jpg",
+		"/uploads/original/" + watermarkTestUUID + "%2Ejpg",
+		"/uploads//original/" + watermarkTestUUID + ".jpg",
+		"/uploads/%6Friginal/" + watermarkTestUUID + ".jpg",
+	}
+	for _, p := range paths {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, p, nil))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode, p)
+	}
+
+	viewer = 7
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, paths[0], nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
@@ -59,9 +59,16 @@ func (h ApiRouter) InstallRouter(app *fiber.App) {
 				requiresAPIKey := strings.HasPrefix(p, "/api/v1/user/") ||
 					p == "/api/v1/upload/sessions" ||
 					strings.HasPrefix(p, "/api/v1/upload/sessions/") ||
+					p == "/api/v1/albums" ||
+					strings.HasPrefix(p, "/api/v1/albums/") ||
+					p == "/api/v1/images" ||
 					strings.HasPrefix(p, "/api/v1/images/")
 				if requiresAPIKey {
-					return appmw.APIKeyAuthMiddleware()(c)
+					return appmw.APIKeyAuthMiddleware(requiredAPIScopes(c.Method(), p)...)(c)
+				}
+				// Search is public; an API key additionally includes the caller's private content
+				if p == "/api/v1/search" {
+					return appmw.OptionalAPIKeyAuthMiddleware(models.APIScopeImagesRead)(c)
 				}
 				return c.Next()
 			},
@@ -106,6 +113,29 @@ func (h ApiRouter) InstallRouter(app *fiber.App) {
 	internalAPI.Get("/images/:uuid/status", controllers.HandleImageStatusJSON)
 }
 
+// requiredAPIScopes maps an API key protected v1 route to the scopes the key must grant.
+// Account profile reads only need a valid key.
+func requiredAPIScopes(method, path string) []string {
+	read := method == fiber.MethodGet || method == fiber.MethodHead
+	switch {
+	case path == "/api/v1/upload/sessions" || strings.HasPrefix(path, "/api/v1/upload/sessions/"):
+		return []string{models.APIScopeUpload}
+	case path == "/api/v1/albums" || strings.HasPrefix(path, "/api/v1/albums/"):
+		if read {
+			return []string{models.APIScopeImagesRead}
+		}
+		return []string{models.APIScopeAlbumsWrite}
+	case path == "/api/v1/images" || strings.HasPrefix(path, "/api/v1/images/"):
+		if read {
+			return []string{models.APIScopeImagesRead}
+		}
+		return []string{models.APIScopeImagesWrite}
+	case path == "/api/v1/user/likes":
+		return []string{models.APIScopeImagesRead}
+	}
+	return nil
+}
+
 func NewApiRouter() *ApiRouter {
 	return &ApiRouter{}
 }
@@ -0,0 +1,31 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+)
+
+func TestRequiredAPIScopes(t *testing.T) {
+	cases := []struct {
+		method string
+		path   string
+		want   []string
+	}{
+		{fiber.MethodGet, "/api/v1/user/profile", nil},
+		{fiber.MethodGet, "/api/v1/user/likes", []string{models.APIScopeImagesRead}},
+		{fiber.MethodPost, "/api/v1/upload/sessions", []string{models.APIScopeUpload}},
+		{fiber.MethodGet, "/api/v1/images", []string{models.APIScopeImagesRead}},
+		{fiber.MethodGet, "/api/v1/images/abc/status", []string{models.APIScopeImagesRead}},
+		{fiber.MethodPatch, "/api/v1/images/abc", []string{models.APIScopeImagesWrite}},
+		{fiber.MethodDelete, "/api/v1/images/abc/like", []string{models.APIScopeImagesWrite}},
+		{fiber.MethodGet, "/api/v1/albums/3", []string{models.APIScopeImagesRead}},
+		{fiber.MethodPut, "/api/v1/albums/3/images/order", []string{models.APIScopeAlbumsWrite}},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, requiredAPIScopes(tc.method, tc.path), "%s %s", tc.method, tc.path)
+	}
+}
@@ -32,10 +32,16 @@ func (h HttpRouter) registerCSRFProtectedRoutes(app *fiber.App) {
 	group.Post("/upload/batch/:id/album", middleware.RequireAuth, controllers.HandleUploadBatchSaveAsAlbum)
 	group.Get("/login", loggedInMiddleware, controllers.HandleAuthLogin)
 	group.Post("/login", loggedInMiddleware, controllers.HandleAuthLogin)
+	group.Get("/login/2fa", loggedInMiddleware, controllers.HandleAuthLoginTwoFactor)
+	group.Post("/login/2fa", loggedInMiddleware, controllers.HandleAuthLoginTwoFactor)
 	group.Get("/register", loggedInMiddleware, controllers.HandleAuthRegister)
 	group.Post("/register", loggedInMiddleware, controllers.HandleAuthRegister)
 	group.Get("/activate", loggedInMiddleware, controllers.HandleAuthActivate)
 	group.Post("/activate", loggedInMiddleware, controllers.HandleAuthActivate)
+	group.Get("/forgot-password", loggedInMiddleware, controllers.HandleAuthForgotPassword)
+	group.Post("/forgot-password", loggedInMiddleware, controllers.HandleAuthForgotPassword)
+	group.Get("/reset-password", loggedInMiddleware, controllers.HandleAuthResetPassword)
+	group.Post("/reset-password", loggedInMiddleware, controllers.HandleAuthResetPassword)
 	group.Get("/user/profile", middleware.RequireAuth, controllers.HandleUserProfile)
 	group.Get("/user/profile/edit", middleware.RequireAuth, controllers.HandleUserProfileEdit)
 	group.Post("/user/profile/edit", middleware.RequireAuth, controllers.HandleUserProfileEditPost)
@@ -45,8 +51,13 @@ func (h HttpRouter) registerCSRFProtectedRoutes(app *fiber.App) {
 	group.Get("/user/settings", middleware.RequireAuth, controllers.HandleUserSettings)
 	group.Get("/user/settings/membership", middleware.RequireAuth, controllers.HandleUserMembership)
 	group.Post("/user/settings", middleware.RequireAuth, controllers.HandleUserSettingsPost)
+	group.Post("/user/settings/watermark", middleware.RequireAuth, controllers.HandleUserWatermarkSettings)
 	group.Post("/user/settings/api-key", middleware.RequireAuth, controllers.HandleUserAPIKeyGenerate)
-	group.Post("/user/settings/api-key/revoke", middleware.RequireAuth, controllers.HandleUserAPIKeyRevoke)
+	group.Post("/user/settings/api-key/:id/revoke", middleware.RequireAuth, controllers.HandleUserAPIKeyRevoke)
+	group.Get("/user/settings/2fa", middleware.RequireAuth, controllers.HandleUserTwoFactor)
+	group.Post("/user/settings/2fa/enable", middleware.RequireAuth, controllers.HandleUserTwoFactorEnable)
+	group.Post("/user/settings/2fa/disable", middleware.RequireAuth, controllers.HandleUserTwoFactorDisable)
+	group.Post("/user/settings/2fa/recovery-codes", middleware.RequireAuth, controllers.HandleUserTwoFactorRecoveryCodes)
 	group.Get("/user/settings/billing/patreon/connect", middleware.RequireAuth, controllers.HandlePatreonConnect)
 	group.Get("/user/settings/billing/patreon/callback", middleware.RequireAuth, controllers.HandlePatreonCallback)
 	group.Post("/user/settings/billing/resync", middleware.RequireAuth, controllers.HandleUserBillingResync)
@@ -54,7 +65,18 @@ func (h HttpRouter) registerCSRFProtectedRoutes(app *fiber.App) {
 	group.Get("/user/images/load", middleware.RequireAuth, controllers.HandleLoadMoreImages)
 	group.Get("/user/images/edit/:uuid", middleware.RequireAuth, controllers.HandleUserImageEdit)
 	group.Post("/user/images/update/:uuid", middleware.RequireAuth, controllers.HandleUserImageUpdate)
+	group.Post("/user/images/revise/:uuid", middleware.RequireAuth, controllers.HandleUserImageRevise)
+	group.Post("/user/images/revert/:uuid", middleware.RequireAuth, controllers.HandleUserImageRevert)
 	group.Post("/user/images/delete/:uuid", middleware.RequireAuth, controllers.HandleUserImageDelete)
+	group.Get("/tags/suggest", middleware.RequireAuth, controllers.HandleTagSuggest)
+	group.Get("/user/favorites", middleware.RequireAuth, controllers.HandleUserFavorites)
+
+	// Notification center
+	group.Get("/user/notifications", middleware.RequireAuth, controllers.HandleUserNotifications)
+	group.Get("/user/notifications/bell", middleware.RequireAuth, controllers.HandleNotificationBell)
+	group.Post("/user/notifications/read-all", middleware.RequireAuth, controllers.HandleNotificationReadAll)
+	group.Get("/user/notifications/:id/open", middleware.RequireAuth, controllers.HandleNotificationOpen)
+	group.Post("/user/notifications/:id/read", middleware.RequireAuth, controllers.HandleNotificationRead)
 
 	// User albums
 	group.Get("/user/albums", middleware.RequireAuth, controllers.HandleUserAlbums)
@@ -68,6 +90,15 @@ func (h HttpRouter) registerCSRFProtectedRoutes(app *fiber.App) {
 	group.Post("/user/albums/:id/set-cover", middleware.RequireAuth, controllers.HandleUserAlbumSetCover)
 	group.Post("/user/albums/:id/remove-image/:image_id", middleware.RequireAuth, controllers.HandleUserAlbumRemoveImage)
 
+	// Image comments (listing is public, writing requires login)
+	group.Get("/image/:uuid/comments", controllers.HandleImageComments)
+	group.Post("/image/:uuid/comments", middleware.RequireAuth, controllers.HandleImageCommentCreate)
+	group.Post("/image/:uuid/comments/:id/delete", middleware.RequireAuth, controllers.HandleImageCommentDelete)
+
+	// Image likes (button is public, toggling requires login)
+	group.Get("/image/:uuid/like", controllers.HandleImageLikeButton)
+	group.Post("/image/:uuid/like", middleware.RequireAuth, controllers.HandleImageLikeToggle)
+
 	// Image reports (guest allowed)
 	group.Get("/image/:uuid/report", loggedInMiddleware, controllers.HandleImageReportForm)
 	group.Post("/image/:uuid/report", loggedInMiddleware, controllers.HandleImageReportSubmit)
@@ -87,8 +118,25 @@ func (h HttpRouter) registerCSRFProtectedRoutes(app *fiber.App) {
 	group.Post("/admin/storage/edit/:id", middleware.RequireAdmin, controllers.HandleAdminEditStoragePoolPost)
 	group.Get("/admin/storage/move/:id", middleware.RequireAdmin, controllers.HandleAdminMoveStoragePool)
 	group.Post("/admin/storage/move/:id", middleware.RequireAdmin, controllers.HandleAdminMoveStoragePoolPost)
+	group.Get("/admin/storage/integrity", middleware.RequireAdmin, controllers.HandleAdminStorageIntegrity)
+	group.Post("/admin/storage/integrity/start/:id", middleware.RequireAdmin, controllers.HandleAdminStorageIntegrityStart)
+	group.Post("/admin/storage/integrity/findings/:id/repair", middleware.RequireAdmin, controllers.HandleAdminStorageIntegrityRepair)
+	group.Post("/admin/storage/integrity/repair-all/:id", middleware.RequireAdmin, controllers.HandleAdminStorageIntegrityRepairAll)
 	group.Get("/admin/reports", middleware.RequireAdmin, controllers.HandleAdminReports)
 	group.Get("/admin/reports/:id", middleware.RequireAdmin, controllers.HandleAdminReportShow)
 	group.Post("/admin/reports/:id/resolve", middleware.RequireAdmin, controllers.HandleAdminReportResolve)
 	group.Post("/admin/reports/:id/dismiss", middleware.RequireAdmin, controllers.HandleAdminReportDismiss)
+	group.Post("/admin/reports/:id/comments/:commentID/delete", middleware.RequireAdmin, controllers.HandleAdminReportCommentDelete)
+	group.Get("/admin/transform-presets", middleware.RequireAdmin, controllers.HandleAdminTransformPresets)
+	group.Post("/admin/transform-presets/create", middleware.RequireAdmin, controllers.HandleAdminTransformPresetCreate)
+	group.Post("/admin/transform-presets/delete/:id", middleware.RequireAdmin, controllers.HandleAdminTransformPresetDelete)
+	group.Get("/admin/variant-profiles", middleware.RequireAdmin, controllers.HandleAdminVariantProfiles)
+	group.Post("/admin/variant-profiles/create", middleware.RequireAdmin, controllers.HandleAdminVariantProfileCreate)
+	group.Post("/admin/variant-profiles/toggle/:id", middleware.RequireAdmin, controllers.HandleAdminVariantProfileToggle)
+	group.Post("/admin/variant-profiles/delete/:id", middleware.RequireAdmin, controllers.HandleAdminVariantProfileDelete)
+	group.Post("/admin/variant-profiles/backfill", middleware.RequireAdmin, controllers.HandleAdminVariantProfileBackfill)
+	group.Post("/admin/images/scrub-metadata", middleware.RequireAdmin, controllers.HandleAdminImageScrubMetadata)
+	group.Post("/admin/images/placeholders", middleware.RequireAdmin, controllers.HandleAdminImagePlaceholders)
+	group.Get("/admin/images/near-duplicates", middleware.RequireAdmin, controllers.HandleAdminNearDuplicates)
+	group.Post("/admin/images/near-duplicates/backfill", middleware.RequireAdmin, controllers.HandleAdminNearDuplicatesBackfill)
 }
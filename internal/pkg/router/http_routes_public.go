@@ -27,6 +27,15 @@ func (h HttpRouter) registerPublicRoutes(app *fiber.App) {
 	app.Get("/i/:sharelink", loggedInMiddleware, controllers.HandleShareLink)
 	app.Get("/a/:sharelink", loggedInMiddleware, controllers.HandleAlbumShareLink)
 
+	// Signed on-the-fly transformations (no session needed, served by the pool's node)
+	app.Get("/t/:signature/:spec/:sharelink", controllers.HandleTransform)
+
+	// Public tag galleries
+	app.Get("/tag/:name", loggedInMiddleware, controllers.HandleTagGallery)
+
+	// Public search
+	app.Get("/search", loggedInMiddleware, controllers.HandleSearch)
+
 	// Public page display
 	app.Get("/page/:slug", loggedInMiddleware, controllers.HandlePageDisplay)
 
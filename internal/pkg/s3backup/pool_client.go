@@ -11,8 +11,10 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -235,6 +237,166 @@ func (pc *PoolClient) GetFileSize(s3Key string) (int64, error) {
 	return size, nil
 }
 
+// ErrNotModified is returned by GetObject when the object still matches the requested ETag
+var ErrNotModified = errors.New("object not modified")
+
+// ErrRangeNotSatisfiable is returned by GetObject when the requested byte range is outside the object
+var ErrRangeNotSatisfiable = errors.New("requested range not satisfiable")
+
+// ObjectRequest describes a conditional and/or partial object download
+type ObjectRequest struct {
+	Range       string // HTTP Range header, e.g. "bytes=0-1023"
+	IfNoneMatch string // HTTP If-None-Match header
+}
+
+// Object is a streamed object; the caller must close Body
+type Object struct {
+	Body          io.ReadCloser
+	ContentLength int64
+	ContentType   string
+	ContentRange  string // set for partial responses
+	ETag          string
+	LastModified  time.Time
+}
+
+// GetObject streams an object from the S3 storage pool. Missing objects return os.ErrNotExist.
+func (pc *PoolClient) GetObject(ctx context.Context, s3Key string, req ObjectRequest) (*Object, error) {
+	fullKey := pc.resolveKey(s3Key)
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(*pc.pool.S3BucketName),
+		Key:    aws.String(fullKey),
+	}
+	if req.Range != "" {
+		input.Range = aws.String(req.Range)
+	}
+	if req.IfNoneMatch != "" {
+		input.IfNoneMatch = aws.String(req.IfNoneMatch)
+	}
+
+	out, err := pc.s3Client.GetObject(ctx, input)
+	if err != nil {
+		if isS3NotFoundError(err) {
+			return nil, os.ErrNotExist
+		}
+		var respErr *awshttp.ResponseError
+		if errors.As(err, &respErr) {
+			switch respErr.HTTPStatusCode() {
+			case http.StatusNotModified:
+				return nil, ErrNotModified
+			case http.StatusRequestedRangeNotSatisfiable:
+				return nil, ErrRangeNotSatisfiable
+			case http.StatusNotFound:
+				return nil, os.ErrNotExist
+			}
+		}
+		return nil, fmt.Errorf("failed to get %s from S3 pool %s: %w", fullKey, pc.pool.Name, err)
+	}
+
+	obj := &Object{
+		Body:          out.Body,
+		ContentLength: aws.ToInt64(out.ContentLength),
+		ContentType:   aws.ToString(out.ContentType),
+		ContentRange:  aws.ToString(out.ContentRange),
+		ETag:          aws.ToString(out.ETag),
+	}
+	if out.LastModified != nil {
+		obj.LastModified = *out.LastModified
+	}
+	return obj, nil
+}
+
+// PresignGetURL returns a presigned URL to download an object that is valid for ttl
+func (pc *PoolClient) PresignGetURL(ctx context.Context, s3Key string, ttl time.Duration) (string, error) {
+	fullKey := pc.resolveKey(s3Key)
+	req, err := s3.NewPresignClient(pc.s3Client).PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(*pc.pool.S3BucketName),
+		Key:    aws.String(fullKey),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign %s in S3 pool %s: %w", fullKey, pc.pool.Name, err)
+	}
+	return req.URL, nil
+}
+
+// ObjectInfo describes a stored object; Key is relative to the pool's path prefix
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// StatObject returns size and modification time of an object. Missing objects return os.ErrNotExist.
+func (pc *PoolClient) StatObject(s3Key string) (ObjectInfo, error) {
+	fullKey := pc.resolveKey(s3Key)
+	out, err := pc.s3Client.HeadObject(context.TODO(), &s3.HeadObjectInput{
+		Bucket: aws.String(*pc.pool.S3BucketName),
+		Key:    aws.String(fullKey),
+	})
+	if err != nil {
+		if isS3NotFoundError(err) {
+			return ObjectInfo{}, os.ErrNotExist
+		}
+		return ObjectInfo{}, fmt.Errorf("failed to stat %s in S3 pool %s: %w", fullKey, pc.pool.Name, err)
+	}
+	info := ObjectInfo{Key: s3Key, Size: aws.ToInt64(out.ContentLength)}
+	if out.LastModified != nil {
+		info.LastModified = *out.LastModified
+	}
+	return info, nil
+}
+
+// ListObjects calls fn for every object below prefix, page by page
+func (pc *PoolClient) ListObjects(prefix string, fn func(ObjectInfo) error) error {
+	fullPrefix := pc.resolveKey(prefix)
+	if fullPrefix != "" && !strings.HasSuffix(fullPrefix, "/") {
+		fullPrefix += "/"
+	}
+	poolPrefix := strings.TrimSuffix(pc.resolveKey(""), "/")
+
+	paginator := s3.NewListObjectsV2Paginator(pc.s3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(*pc.pool.S3BucketName),
+		Prefix: aws.String(fullPrefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return fmt.Errorf("failed to list %s in S3 pool %s: %w", fullPrefix, pc.pool.Name, err)
+		}
+		for _, obj := range page.Contents {
+			key := aws.ToString(obj.Key)
+			if poolPrefix != "" {
+				key = strings.TrimPrefix(strings.TrimPrefix(key, poolPrefix), "/")
+			}
+			info := ObjectInfo{Key: key, Size: aws.ToInt64(obj.Size)}
+			if obj.LastModified != nil {
+				info.LastModified = *obj.LastModified
+			}
+			if err := fn(info); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CopyObject copies an object within the S3 storage pool without downloading it
+func (pc *PoolClient) CopyObject(srcKey, dstKey string) error {
+	fullSrc := pc.resolveKey(srcKey)
+	fullDst := pc.resolveKey(dstKey)
+	_, err := pc.s3Client.CopyObject(context.TODO(), &s3.CopyObjectInput{
+		Bucket:     aws.String(*pc.pool.S3BucketName),
+		Key:        aws.String(fullDst),
+		CopySource: aws.String(path.Join(*pc.pool.S3BucketName, fullSrc)),
+	})
+	if err != nil {
+		if isS3NotFoundError(err) {
+			return os.ErrNotExist
+		}
+		return fmt.Errorf("failed to copy %s to %s in S3 pool %s: %w", fullSrc, fullDst, pc.pool.Name, err)
+	}
+	return nil
+}
+
 // GetBucketName returns the bucket name for this storage pool
 func (pc *PoolClient) GetBucketName() string {
 	if pc.pool.S3BucketName == nil {
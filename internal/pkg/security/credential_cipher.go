@@ -0,0 +1,117 @@
+package security
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// encryptedCredentialPrefix marks stored values as "enc:<key id>:<base64 nonce+ciphertext>"
+const encryptedCredentialPrefix = "enc:"
+
+// ErrUnknownCredentialKey is returned when a value was encrypted with a key that is not configured
+var ErrUnknownCredentialKey = errors.New("credential encrypted with unknown key")
+
+// CredentialCipher encrypts credentials with AES-256-GCM. Values name the key they were encrypted
+// with, so older keys can stay configured for decryption while new values use the current key.
+type CredentialCipher struct {
+	currentID string
+	keys      map[string]cipher.AEAD
+}
+
+// NewCredentialCipher parses a key list "id:base64key,id:base64key" of 32 byte keys.
+// The first key encrypts, all keys decrypt. An empty list returns nil.
+func NewCredentialCipher(spec string) (*CredentialCipher, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+	c := &CredentialCipher{keys: make(map[string]cipher.AEAD)}
+	for _, entry := range strings.Split(spec, ",") {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		id = strings.TrimSpace(id)
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid credential key %q: expected id:base64key", entry)
+		}
+		if strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid credential key id %q", id)
+		}
+		if _, exists := c.keys[id]; exists {
+			return nil, fmt.Errorf("duplicate credential key id %q", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("credential key %q is not valid base64: %w", id, err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("credential key %q must be 32 bytes, got %d", id, len(key))
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		c.keys[id] = aead
+		if c.currentID == "" {
+			c.currentID = id
+		}
+	}
+	return c, nil
+}
+
+// CurrentKeyID returns the ID of the key new values are encrypted with
+func (c *CredentialCipher) CurrentKeyID() string {
+	return c.currentID
+}
+
+// Encrypt encrypts a credential with the current key. The purpose, e.g. the column name, is
+// authenticated so a value cannot be moved to another field.
+func (c *CredentialCipher) Encrypt(plaintext, purpose string) (string, error) {
+	aead := c.keys[c.currentID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(purpose))
+	return encryptedCredentialPrefix + c.currentID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plaintext of an encrypted credential; values without the prefix are returned as they are
+func (c *CredentialCipher) Decrypt(value, purpose string) (string, error) {
+	id, ok := CredentialKeyID(value)
+	if !ok {
+		return value, nil
+	}
+	aead, known := c.keys[id]
+	if !known {
+		return "", fmt.Errorf("%w %q", ErrUnknownCredentialKey, id)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(value[len(encryptedCredentialPrefix)+len(id)+1:])
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted credential: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("invalid encrypted credential: too short")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(purpose))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt credential with key %q: %w", id, err)
+	}
+	return string(plaintext), nil
+}
+
+// CredentialKeyID returns the key ID of an encrypted credential and false for plaintext values
+func CredentialKeyID(value string) (string, bool) {
+	if !strings.HasPrefix(value, encryptedCredentialPrefix) {
+		return "", false
+	}
+	id, _, ok := strings.Cut(value[len(encryptedCredentialPrefix):], ":")
+	return id, ok && id != ""
+}
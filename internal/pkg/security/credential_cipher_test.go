@@ -0,0 +1,80 @@
+package security
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCredentialKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), 32)))
+}
+
+func TestCredentialCipherRoundtrip(t *testing.T) {
+	c, err := NewCredentialCipher("k1:" + testCredentialKey('a'))
+	require.NoError(t, err)
+
+	encrypted, err := c.Encrypt("secret", "purpose")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "enc:k1:"))
+	assert.NotContains(t, encrypted, "secret")
+
+	id, ok := CredentialKeyID(encrypted)
+	assert.True(t, ok)
+	assert.Equal(t, "k1", id)
+
+	plaintext, err := c.Decrypt(encrypted, "purpose")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", plaintext)
+
+	// The value is bound to its purpose
+	_, err = c.Decrypt(encrypted, "other")
+	assert.Error(t, err)
+
+	// Plaintext passes through
+	plaintext, err = c.Decrypt("AKIA123", "purpose")
+	require.NoError(t, err)
+	assert.Equal(t, "AKIA123", plaintext)
+}
+
+func TestCredentialCipherRotation(t *testing.T) {
+	old, err := NewCredentialCipher("k1:" + testCredentialKey('a'))
+	require.NoError(t, err)
+	encrypted, err := old.Encrypt("secret", "purpose")
+	require.NoError(t, err)
+
+	rotated, err := NewCredentialCipher("k2:" + testCredentialKey('b') + ", k1:" + testCredentialKey('a'))
+	require.NoError(t, err)
+	assert.Equal(t, "k2", rotated.CurrentKeyID())
+	plaintext, err := rotated.Decrypt(encrypted, "purpose")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", plaintext)
+
+	reencrypted, err := rotated.Encrypt(plaintext, "purpose")
+	require.NoError(t, err)
+	id, _ := CredentialKeyID(reencrypted)
+	assert.Equal(t, "k2", id)
+
+	_, err = old.Decrypt(reencrypted, "purpose")
+	assert.ErrorIs(t, err, ErrUnknownCredentialKey)
+}
+
+func TestNewCredentialCipherInvalid(t *testing.T) {
+	c, err := NewCredentialCipher("  ")
+	require.NoError(t, err)
+	assert.Nil(t, c)
+
+	for _, spec := range []string{
+		"nokey",
+		":" + testCredentialKey('a'),
+		"k1:not-base64!",
+		"k1:" + base64.StdEncoding.EncodeToString([]byte("short")),
+		"k1:" + testCredentialKey('a') + ",k1:" + testCredentialKey('b'),
+	} {
+		_, err := NewCredentialCipher(spec)
+		assert.Error(t, err, spec)
+	}
+}
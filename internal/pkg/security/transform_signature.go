@@ -0,0 +1,30 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// transformSignatureBytes is the truncated HMAC length used in transformation URLs (22 base64 chars)
+const transformSignatureBytes = 16
+
+// SignTransform returns the URL signature for a canonical transformation spec of a share link
+func SignTransform(spec, shareLink, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(spec + "/" + shareLink))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:transformSignatureBytes])
+}
+
+// VerifyTransformSignature checks a transformation URL signature in constant time
+func VerifyTransformSignature(signature, spec, shareLink, secret string) bool {
+	if secret == "" {
+		return false
+	}
+	got, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	expected, _ := base64.RawURLEncoding.DecodeString(SignTransform(spec, shareLink, secret))
+	return hmac.Equal(got, expected)
+}
@@ -0,0 +1,20 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformSignature(t *testing.T) {
+	const spec = "800x600,fit=cover,fmt=webp,q=80"
+	sig := SignTransform(spec, "abc123", "secret")
+
+	assert.Len(t, sig, 22)
+	assert.True(t, VerifyTransformSignature(sig, spec, "abc123", "secret"))
+	assert.False(t, VerifyTransformSignature(sig, "800x601,fit=cover,fmt=webp,q=80", "abc123", "secret"))
+	assert.False(t, VerifyTransformSignature(sig, spec, "abc124", "secret"))
+	assert.False(t, VerifyTransformSignature(sig, spec, "abc123", "other"))
+	assert.False(t, VerifyTransformSignature(sig, spec, "abc123", ""))
+	assert.False(t, VerifyTransformSignature("not*base64", spec, "abc123", "secret"))
+}
@@ -1,7 +1,9 @@
 package session
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"net"
 	"strconv"
 	"time"
@@ -16,6 +18,10 @@ import (
 
 var sessionStore *session.Store
 
+// userSessionsTTL bounds how long the per-user session index is kept. Sessions are
+// refreshed on every request, so the index has to outlive the cookie expiration.
+const userSessionsTTL = 30 * 24 * time.Hour
+
 func NewSessionStore() *session.Store {
 	// Get Redis client configuration from existing cache setup
 	cacheClient := cache.GetClient()
@@ -98,6 +104,50 @@ func GetSessionValue(c *fiber.Ctx, key string) string {
 	return ""
 }
 
+// userSessionsKey returns the cache key holding all session IDs of a user
+func userSessionsKey(userID uint) string {
+	return fmt.Sprintf("user:sessions:%d", userID)
+}
+
+// TrackUserSession remembers the current session ID for the given user so that
+// all of the user's sessions can be destroyed later (e.g. after a password reset).
+func TrackUserSession(c *fiber.Ctx, userID uint) error {
+	if sessionStore == nil {
+		return fmt.Errorf("session store not initialized")
+	}
+	sess, err := sessionStore.Get(c)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %v", err)
+	}
+	ctx := context.Background()
+	key := userSessionsKey(userID)
+	cli := cache.GetClient()
+	if err := cli.SAdd(ctx, key, sess.ID()).Err(); err != nil {
+		return err
+	}
+	return cli.Expire(ctx, key, userSessionsTTL).Err()
+}
+
+// DestroyUserSessions deletes every tracked session of the given user from the session storage.
+func DestroyUserSessions(userID uint) error {
+	if sessionStore == nil {
+		return fmt.Errorf("session store not initialized")
+	}
+	ctx := context.Background()
+	key := userSessionsKey(userID)
+	cli := cache.GetClient()
+	ids, err := cli.SMembers(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := sessionStore.Delete(id); err != nil {
+			log.Printf("failed to delete session for user %d: %v", userID, err)
+		}
+	}
+	return cli.Del(ctx, key).Err()
+}
+
 // Legacy functions for backward compatibility - DEPRECATED
 // These should not be used in new code as they are not multi-user safe
 var globalKeyValueStore map[string]string
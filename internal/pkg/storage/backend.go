@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/constants"
+)
+
+// Backend gives access to the files of a storage pool. All paths are relative to the pool root,
+// e.g. "original/2025/08/10/<uuid>.jpg" or "variants/2025/08/10/<uuid>_thumb_small.webp".
+type Backend interface {
+	// Open returns a reader for the file; missing files return an error wrapping os.ErrNotExist
+	Open(relativePath string) (io.ReadCloser, error)
+	// Create writes the file, replacing an existing one, and returns the number of bytes written
+	Create(relativePath string, data io.Reader) (int64, error)
+	// Stat returns size and modification time; missing files return an error wrapping os.ErrNotExist
+	Stat(relativePath string) (FileInfo, error)
+	// Delete removes the file; deleting a missing file is not an error
+	Delete(relativePath string) error
+	// List calls fn for every file below prefix
+	List(prefix string, fn func(FileInfo) error) error
+	// Copy duplicates a file within the pool
+	Copy(srcRelativePath, dstRelativePath string) error
+	// PublicURL returns the URL the file is served under
+	PublicURL(relativePath string) string
+}
+
+// LocalBackend is implemented by backends whose files are on a filesystem of this node,
+// so they can be processed in place
+type LocalBackend interface {
+	Backend
+	LocalPath(relativePath string) string
+}
+
+// FileInfo describes a file of a storage pool
+type FileInfo struct {
+	Path    string // relative to the pool root
+	Size    int64
+	ModTime time.Time
+}
+
+// BackendFactory creates the backend for a storage pool
+type BackendFactory func(pool *models.StoragePool) (Backend, error)
+
+var backendFactories = struct {
+	sync.RWMutex
+	byType map[string]BackendFactory
+}{byType: make(map[string]BackendFactory)}
+
+// RegisterBackend makes a backend available for pools of the given storage type
+func RegisterBackend(storageType string, factory BackendFactory) {
+	backendFactories.Lock()
+	defer backendFactories.Unlock()
+	backendFactories.byType[storageType] = factory
+}
+
+// BackendFor returns the backend of a storage pool
+func BackendFor(pool *models.StoragePool) (Backend, error) {
+	if pool == nil {
+		return nil, fmt.Errorf("storage pool is nil")
+	}
+	backendFactories.RLock()
+	factory, ok := backendFactories.byType[pool.StorageType]
+	backendFactories.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no storage backend registered for type '%s' (pool '%s')", pool.StorageType, pool.Name)
+	}
+	return factory(pool)
+}
+
+// LocalPath returns the filesystem path of a file if the pool keeps its files on this node
+func LocalPath(pool *models.StoragePool, relativePath string) (string, bool) {
+	backend, err := BackendFor(pool)
+	if err != nil {
+		return "", false
+	}
+	local, ok := backend.(LocalBackend)
+	if !ok {
+		return "", false
+	}
+	return local.LocalPath(relativePath), true
+}
+
+// Checksum returns the hex SHA-256 and the size of a file, matching Image.FileHash
+func Checksum(backend Backend, relativePath string) (string, int64, error) {
+	r, err := backend.Open(relativePath)
+	if err != nil {
+		return "", 0, err
+	}
+	defer r.Close()
+	hasher := sha256.New()
+	n, err := io.Copy(hasher, r)
+	if err != nil {
+		return "", n, fmt.Errorf("failed to read %s: %w", relativePath, err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), n, nil
+}
+
+// publicURL builds the URL of a file served by the uploads route, on the pool's public domain if set
+func publicURL(pool *models.StoragePool, relativePath string) string {
+	clean, err := cleanRelativeStoragePath(relativePath)
+	if err != nil {
+		return ""
+	}
+	webPath := constants.UploadsRoute + "/" + strings.TrimPrefix(clean, constants.UploadsPath+"/")
+	if base := strings.TrimRight(strings.TrimSpace(pool.PublicBaseURL), "/"); base != "" {
+		return base + webPath
+	}
+	return webPath
+}
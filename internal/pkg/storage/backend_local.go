@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+)
+
+func init() {
+	RegisterBackend(models.StorageTypeLocal, newFilesystemBackend)
+	RegisterBackend(models.StorageTypeNFS, newFilesystemBackend)
+}
+
+// filesystemBackend stores files below the base path of a local or NFS pool
+type filesystemBackend struct {
+	pool *models.StoragePool
+}
+
+func newFilesystemBackend(pool *models.StoragePool) (Backend, error) {
+	if pool.BasePath == "" {
+		return nil, fmt.Errorf("storage pool '%s' has no base path", pool.Name)
+	}
+	return &filesystemBackend{pool: pool}, nil
+}
+
+// LocalPath returns the absolute path of a file; invalid paths resolve to the pool root
+func (b *filesystemBackend) LocalPath(relativePath string) string {
+	clean, err := cleanRelativeStoragePath(relativePath)
+	if err != nil {
+		return b.pool.BasePath
+	}
+	return filepath.Join(b.pool.BasePath, filepath.FromSlash(clean))
+}
+
+func (b *filesystemBackend) fullPath(relativePath string) (string, error) {
+	clean, err := cleanRelativeStoragePath(relativePath)
+	if err != nil {
+		return "", fmt.Errorf("invalid file path %q: %w", relativePath, err)
+	}
+	return filepath.Join(b.pool.BasePath, filepath.FromSlash(clean)), nil
+}
+
+func (b *filesystemBackend) Open(relativePath string) (io.ReadCloser, error) {
+	fullPath, err := b.fullPath(relativePath)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(fullPath)
+}
+
+// Create writes to a temporary file next to the target and renames it, so readers never see partial files
+func (b *filesystemBackend) Create(relativePath string, data io.Reader) (int64, error) {
+	fullPath, err := b.fullPath(relativePath)
+	if err != nil {
+		return 0, err
+	}
+	dir := filepath.Dir(fullPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".pixelfox-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create file in %s: %w", dir, err)
+	}
+	tmpPath := tmp.Name()
+	written, err := io.Copy(tmp, data)
+	if closeErr := tmp.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, fullPath)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return 0, fmt.Errorf("failed to write file %s: %w", fullPath, err)
+	}
+	return written, nil
+}
+
+func (b *filesystemBackend) Stat(relativePath string) (FileInfo, error) {
+	fullPath, err := b.fullPath(relativePath)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	if info.IsDir() {
+		return FileInfo{}, fmt.Errorf("%s is a directory: %w", fullPath, os.ErrNotExist)
+	}
+	clean, _ := cleanRelativeStoragePath(relativePath)
+	return FileInfo{Path: clean, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+func (b *filesystemBackend) Delete(relativePath string) error {
+	fullPath, err := b.fullPath(relativePath)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file %s: %w", fullPath, err)
+	}
+	return nil
+}
+
+func (b *filesystemBackend) List(prefix string, fn func(FileInfo) error) error {
+	root := b.pool.BasePath
+	if prefix != "" {
+		fullPath, err := b.fullPath(prefix)
+		if err != nil {
+			return err
+		}
+		root = fullPath
+	}
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == root {
+				return nil
+			}
+			return err
+		}
+		// Skip directories and unfinished writes of Create
+		if d.IsDir() || filepath.Base(p)[0] == '.' {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(b.pool.BasePath, p)
+		if err != nil {
+			return err
+		}
+		return fn(FileInfo{Path: filepath.ToSlash(rel), Size: info.Size(), ModTime: info.ModTime()})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", root, err)
+	}
+	return nil
+}
+
+func (b *filesystemBackend) Copy(srcRelativePath, dstRelativePath string) error {
+	src, err := b.Open(srcRelativePath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	_, err = b.Create(dstRelativePath, src)
+	return err
+}
+
+func (b *filesystemBackend) PublicURL(relativePath string) string {
+	return publicURL(b.pool, relativePath)
+}
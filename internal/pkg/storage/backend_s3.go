@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/s3backup"
+)
+
+func init() {
+	RegisterBackend(models.StorageTypeS3, newS3Backend)
+}
+
+// poolClientCache keeps one S3 client per pool; it is rebuilt when the pool is edited
+var poolClientCache = struct {
+	sync.Mutex
+	clients map[uint]cachedPoolClient
+}{clients: make(map[uint]cachedPoolClient)}
+
+type cachedPoolClient struct {
+	updatedAt time.Time
+	client    *s3backup.PoolClient
+}
+
+// poolClient returns a cached S3 client for the pool
+func poolClient(pool *models.StoragePool) (*s3backup.PoolClient, error) {
+	poolClientCache.Lock()
+	defer poolClientCache.Unlock()
+	if cached, ok := poolClientCache.clients[pool.ID]; ok && cached.updatedAt.Equal(pool.UpdatedAt) {
+		return cached.client, nil
+	}
+	client, err := s3backup.NewPoolClient(pool)
+	if err != nil {
+		return nil, err
+	}
+	if pool.ID > 0 {
+		poolClientCache.clients[pool.ID] = cachedPoolClient{updatedAt: pool.UpdatedAt, client: client}
+	}
+	return client, nil
+}
+
+// s3Backend stores files as objects below "uploads/" in the bucket of an S3 pool
+type s3Backend struct {
+	pool   *models.StoragePool
+	client *s3backup.PoolClient
+}
+
+func newS3Backend(pool *models.StoragePool) (Backend, error) {
+	client, err := poolClient(pool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize S3 client for pool '%s': %w", pool.Name, err)
+	}
+	return &s3Backend{pool: pool, client: client}, nil
+}
+
+func (b *s3Backend) objectKey(relativePath string) (string, error) {
+	clean, err := cleanRelativeStoragePath(relativePath)
+	if err != nil {
+		return "", fmt.Errorf("invalid file path %q: %w", relativePath, err)
+	}
+	return toS3ObjectKey(clean), nil
+}
+
+func (b *s3Backend) Open(relativePath string) (io.ReadCloser, error) {
+	key, err := b.objectKey(relativePath)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := b.client.GetObject(context.Background(), key, s3backup.ObjectRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return obj.Body, nil
+}
+
+// Create buffers the data in a temporary file because uploads need a known content length
+func (b *s3Backend) Create(relativePath string, data io.Reader) (int64, error) {
+	key, err := b.objectKey(relativePath)
+	if err != nil {
+		return 0, err
+	}
+	// Keep the extension so the object gets the right content type
+	tmpFile, err := os.CreateTemp("", "pixelfox-storage-upload-*"+path.Ext(key))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create temporary upload file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	written, err := io.Copy(tmpFile, data)
+	if closeErr := tmpFile.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to buffer upload for S3: %w", err)
+	}
+	if err := b.client.UploadFile(tmpPath, key); err != nil {
+		return 0, err
+	}
+	return written, nil
+}
+
+func (b *s3Backend) Stat(relativePath string) (FileInfo, error) {
+	key, err := b.objectKey(relativePath)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	info, err := b.client.StatObject(key)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	return FileInfo{Path: strings.TrimPrefix(key, "uploads/"), Size: info.Size, ModTime: info.LastModified}, nil
+}
+
+func (b *s3Backend) Delete(relativePath string) error {
+	key, err := b.objectKey(relativePath)
+	if err != nil {
+		return err
+	}
+	return b.client.DeleteFile(key)
+}
+
+func (b *s3Backend) List(prefix string, fn func(FileInfo) error) error {
+	keyPrefix := "uploads"
+	if prefix != "" {
+		key, err := b.objectKey(prefix)
+		if err != nil {
+			return err
+		}
+		keyPrefix = key
+	}
+	return b.client.ListObjects(keyPrefix, func(obj s3backup.ObjectInfo) error {
+		rel := strings.TrimPrefix(obj.Key, "uploads/")
+		if rel == obj.Key || rel == "" {
+			return nil
+		}
+		return fn(FileInfo{Path: rel, Size: obj.Size, ModTime: obj.LastModified})
+	})
+}
+
+func (b *s3Backend) Copy(srcRelativePath, dstRelativePath string) error {
+	srcKey, err := b.objectKey(srcRelativePath)
+	if err != nil {
+		return err
+	}
+	dstKey, err := b.objectKey(dstRelativePath)
+	if err != nil {
+		return err
+	}
+	return b.client.CopyObject(srcKey, dstKey)
+}
+
+// PublicURL points at the uploads route, which proxies the object or redirects to a presigned URL
+func (b *s3Backend) PublicURL(relativePath string) string {
+	return publicURL(b.pool, relativePath)
+}
@@ -0,0 +1,150 @@
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
+)
+
+// exerciseBackend runs the same round trip against every backend implementation
+func exerciseBackend(t *testing.T, backend storage.Backend) {
+	const original = "original/2025/08/10/0f8fad5b-d9cb-469f-a165-70867728950e.png"
+	const copied = "variants/2025/08/10/0f8fad5b-d9cb-469f-a165-70867728950e_copy.png"
+
+	written, err := backend.Create(original, bytes.NewReader(testPayload))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(testPayload)), written)
+
+	info, err := backend.Stat(original)
+	require.NoError(t, err)
+	assert.Equal(t, original, info.Path)
+	assert.Equal(t, int64(len(testPayload)), info.Size)
+	assert.False(t, info.ModTime.IsZero())
+
+	r, err := backend.Open(original)
+	require.NoError(t, err)
+	body, err := io.ReadAll(r)
+	require.NoError(t, r.Close())
+	require.NoError(t, err)
+	assert.Equal(t, testPayload, body)
+
+	require.NoError(t, backend.Copy(original, copied))
+	var listed []string
+	require.NoError(t, backend.List("", func(fi storage.FileInfo) error {
+		listed = append(listed, fi.Path)
+		return nil
+	}))
+	sort.Strings(listed)
+	assert.Equal(t, []string{original, copied}, listed)
+
+	listed = nil
+	require.NoError(t, backend.List("variants", func(fi storage.FileInfo) error {
+		listed = append(listed, fi.Path)
+		assert.Equal(t, int64(len(testPayload)), fi.Size)
+		return nil
+	}))
+	assert.Equal(t, []string{copied}, listed)
+
+	require.NoError(t, backend.Delete(original))
+	require.NoError(t, backend.Delete(original), "deleting a missing file is not an error")
+	_, err = backend.Stat(original)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "%v", err)
+	_, err = backend.Open(original)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "%v", err)
+
+	_, err = backend.Create(" ", bytes.NewReader(testPayload))
+	assert.Error(t, err)
+
+	assert.Equal(t, "/uploads/"+copied, backend.PublicURL(copied))
+}
+
+func TestFilesystemBackend(t *testing.T) {
+	base := t.TempDir()
+	pool := &models.StoragePool{ID: 9101, Name: "hot", StorageType: models.StorageTypeLocal, BasePath: base}
+	backend, err := storage.BackendFor(pool)
+	require.NoError(t, err)
+	exerciseBackend(t, backend)
+
+	path, ok := storage.LocalPath(pool, testObjectPath)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(base, filepath.FromSlash(testObjectPath)), path)
+
+	pool.PublicBaseURL = "https://s01.pixelfox.cc/"
+	assert.Equal(t, "https://s01.pixelfox.cc/uploads/"+testObjectPath, backend.PublicURL(testObjectPath))
+
+	nfs, err := storage.BackendFor(&models.StoragePool{Name: "nfs", StorageType: models.StorageTypeNFS, BasePath: base})
+	require.NoError(t, err)
+	_, isLocal := nfs.(storage.LocalBackend)
+	assert.True(t, isLocal)
+}
+
+func TestS3Backend(t *testing.T) {
+	pool := newS3Pool(t, 9102, models.S3DeliveryProxy)
+	backend, err := storage.BackendFor(pool)
+	require.NoError(t, err)
+
+	// newS3Pool already stored testObjectPath
+	require.NoError(t, backend.Delete(testObjectPath))
+	exerciseBackend(t, backend)
+
+	_, ok := storage.LocalPath(pool, testObjectPath)
+	assert.False(t, ok)
+}
+
+// memoryBackend shows that a new backend only needs to be registered
+type memoryBackend struct {
+	storage.Backend
+	files map[string][]byte
+}
+
+func (b *memoryBackend) Open(relativePath string) (io.ReadCloser, error) {
+	data, ok := b.files[relativePath]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (b *memoryBackend) Stat(relativePath string) (storage.FileInfo, error) {
+	data, ok := b.files[relativePath]
+	if !ok {
+		return storage.FileInfo{}, os.ErrNotExist
+	}
+	return storage.FileInfo{Path: relativePath, Size: int64(len(data))}, nil
+}
+
+func TestRegisterBackend(t *testing.T) {
+	_, err := storage.BackendFor(&models.StoragePool{Name: "tape", StorageType: "memory"})
+	require.Error(t, err)
+
+	mem := &memoryBackend{files: map[string][]byte{testObjectPath: testPayload}}
+	storage.RegisterBackend("memory", func(pool *models.StoragePool) (storage.Backend, error) {
+		return mem, nil
+	})
+	pool := &models.StoragePool{ID: 9103, Name: "tape", StorageType: "memory"}
+	backend, err := storage.BackendFor(pool)
+	require.NoError(t, err)
+	assert.Same(t, mem, backend)
+
+	// Delivery streams files of backends it has no special handling for
+	app := newDeliveryApp(pool)
+	resp := get(t, app, "/uploads/"+testObjectPath, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, strings.HasPrefix(resp.Header.Get("Content-Type"), "image/png"))
+	assert.Equal(t, testPayload, readBody(t, resp))
+
+	resp = get(t, app, "/uploads/variants/2025/08/10/missing.png", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/s3backup"
+)
+
+// DeliveryCacheControl is sent with every delivered upload; file names change with each revision
+const DeliveryCacheControl = "public, max-age=604800" // 7 days
+
+// ErrFileNotFound is returned by Deliver when the pool does not hold the file,
+// so callers can fall back to another source
+var ErrFileNotFound = errors.New("file not found in storage pool")
+
+// Deliver answers the request with a file of a storage pool. Local and NFS files are sent with
+// ETag, Last-Modified and Range support; S3 objects are either streamed through the application
+// or redirected to a presigned URL, depending on the pool's delivery mode. Files of other
+// backends are streamed as they are.
+// It returns ErrFileNotFound if the pool does not hold the file.
+func Deliver(c *fiber.Ctx, pool *models.StoragePool, relativePath string) error {
+	cleanRelPath, err := cleanRelativeStoragePath(relativePath)
+	if err != nil {
+		return ErrFileNotFound
+	}
+
+	backend, err := BackendFor(pool)
+	if err != nil {
+		return err
+	}
+	switch b := backend.(type) {
+	case LocalBackend:
+		return deliverLocalFile(c, b.LocalPath(cleanRelPath))
+	case *s3Backend:
+		key := toS3ObjectKey(cleanRelPath)
+		if pool.GetS3DeliveryMode() == models.S3DeliveryPresign {
+			return redirectPresigned(c, b.client, key, pool.GetS3PresignTTL())
+		}
+		return proxyS3Object(c, b.client, key)
+	}
+	return streamFile(c, backend, cleanRelPath)
+}
+
+// deliverLocalFile sends a file of a local or NFS pool
+func deliverLocalFile(c *fiber.Ctx, fullPath string) error {
+	info, err := os.Stat(fullPath)
+	if err != nil || info.IsDir() {
+		return ErrFileNotFound
+	}
+
+	c.Set(fiber.HeaderCacheControl, DeliveryCacheControl)
+	c.Set(fiber.HeaderETag, fmt.Sprintf(`"%x-%x"`, info.ModTime().Unix(), info.Size()))
+	c.Set(fiber.HeaderLastModified, info.ModTime().UTC().Format(http.TimeFormat))
+	if c.Fresh() {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	// SendFile answers Range requests itself
+	return c.SendFile(fullPath)
+}
+
+// streamFile sends a file of any other backend without conditional or partial responses
+func streamFile(c *fiber.Ctx, backend Backend, relPath string) error {
+	info, err := backend.Stat(relPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrFileNotFound
+	}
+	if err != nil {
+		return err
+	}
+	body, err := backend.Open(relPath)
+	if err != nil {
+		return err
+	}
+	if contentType := mime.TypeByExtension(path.Ext(relPath)); contentType != "" {
+		c.Set(fiber.HeaderContentType, contentType)
+	}
+	c.Set(fiber.HeaderCacheControl, DeliveryCacheControl)
+	if !info.ModTime.IsZero() {
+		c.Set(fiber.HeaderLastModified, info.ModTime.UTC().Format(http.TimeFormat))
+	}
+	return c.SendStream(body, int(info.Size))
+}
+
+// proxyS3Object streams an object through the application, passing Range and If-None-Match on to S3
+func proxyS3Object(c *fiber.Ctx, client *s3backup.PoolClient, key string) error {
+	ctx, cancel := context.WithCancel(c.UserContext())
+	obj, err := client.GetObject(ctx, key, s3backup.ObjectRequest{
+		Range:       c.Get(fiber.HeaderRange),
+		IfNoneMatch: c.Get(fiber.HeaderIfNoneMatch),
+	})
+	if err != nil {
+		cancel()
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			return ErrFileNotFound
+		case errors.Is(err, s3backup.ErrNotModified):
+			c.Set(fiber.HeaderCacheControl, DeliveryCacheControl)
+			c.Set(fiber.HeaderETag, c.Get(fiber.HeaderIfNoneMatch))
+			return c.SendStatus(fiber.StatusNotModified)
+		case errors.Is(err, s3backup.ErrRangeNotSatisfiable):
+			return c.SendStatus(fiber.StatusRequestedRangeNotSatisfiable)
+		}
+		return err
+	}
+
+	contentType := obj.ContentType
+	if contentType == "" || contentType == "application/octet-stream" {
+		if byExt := mime.TypeByExtension(path.Ext(key)); byExt != "" {
+			contentType = byExt
+		}
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderCacheControl, DeliveryCacheControl)
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	if obj.ETag != "" {
+		c.Set(fiber.HeaderETag, obj.ETag)
+	}
+	if !obj.LastModified.IsZero() {
+		c.Set(fiber.HeaderLastModified, obj.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if obj.ContentRange != "" {
+		c.Set(fiber.HeaderContentRange, obj.ContentRange)
+		c.Status(fiber.StatusPartialContent)
+	}
+	// The body is closed by fasthttp once it has been sent
+	return c.SendStream(&cancelOnClose{ReadCloser: obj.Body, cancel: cancel}, int(obj.ContentLength))
+}
+
+// redirectPresigned sends the client to a short-lived presigned URL of the object
+func redirectPresigned(c *fiber.Ctx, client *s3backup.PoolClient, key string, ttl time.Duration) error {
+	url, err := client.PresignGetURL(c.UserContext(), key, ttl)
+	if err != nil {
+		return err
+	}
+	// Browsers may reuse the redirect only while the URL is safely valid
+	c.Set(fiber.HeaderCacheControl, "private, max-age="+strconv.Itoa(int(ttl.Seconds()/2)))
+	return c.Redirect(url, fiber.StatusFound)
+}
+
+// cancelOnClose releases the request context of a streamed S3 body once it is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r *cancelOnClose) Close() error {
+	defer r.cancel()
+	return r.ReadCloser.Close()
+}
@@ -0,0 +1,158 @@
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ManuelReschke/PixelFox/app/models"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/s3backup"
+	"github.com/ManuelReschke/PixelFox/internal/pkg/storage"
+)
+
+const testObjectPath = "variants/2025/08/10/0f8fad5b-d9cb-469f-a165-70867728950e_thumb_small.png"
+
+// testPayload is larger than the ranges requested below
+var testPayload = bytes.Repeat([]byte("pixelfox"), 64)
+
+// newDeliveryApp serves a pool under /uploads like the delivery middleware
+func newDeliveryApp(pool *models.StoragePool) *fiber.App {
+	app := fiber.New()
+	app.Get("/uploads/*", func(c *fiber.Ctx) error {
+		err := storage.Deliver(c, pool, c.Params("*"))
+		if errors.Is(err, storage.ErrFileNotFound) {
+			return c.SendStatus(fiber.StatusNotFound)
+		}
+		return err
+	})
+	return app
+}
+
+// newS3Pool starts an in-memory S3 stand-in holding testPayload at testObjectPath
+func newS3Pool(t *testing.T, id uint, mode string) *models.StoragePool {
+	backend := s3mem.New()
+	require.NoError(t, backend.CreateBucket("pixelfox"))
+	srv := httptest.NewServer(gofakes3.New(backend).Server())
+	t.Cleanup(srv.Close)
+
+	accessKey, secretKey, region, bucket, endpoint, prefix := "key", "secret", "us-east-1", "pixelfox", srv.URL, "pool"
+	pool := &models.StoragePool{
+		ID:                id,
+		Name:              "cold",
+		StorageType:       models.StorageTypeS3,
+		S3AccessKeyID:     &accessKey,
+		S3SecretAccessKey: &secretKey,
+		S3Region:          &region,
+		S3BucketName:      &bucket,
+		S3EndpointURL:     &endpoint,
+		S3PathPrefix:      &prefix,
+		S3DeliveryMode:    mode,
+	}
+
+	local := filepath.Join(t.TempDir(), "upload.png")
+	require.NoError(t, os.WriteFile(local, testPayload, 0644))
+	client, err := s3backup.NewPoolClient(pool)
+	require.NoError(t, err)
+	require.NoError(t, client.UploadFile(local, "uploads/"+testObjectPath))
+	return pool
+}
+
+func get(t *testing.T, app *fiber.App, path string, headers map[string]string) *http.Response {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	return resp
+}
+
+func readBody(t *testing.T, resp *http.Response) []byte {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return body
+}
+
+func TestDeliverS3Proxy(t *testing.T) {
+	app := newDeliveryApp(newS3Pool(t, 9001, models.S3DeliveryProxy))
+
+	resp := get(t, app, "/uploads/"+testObjectPath, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, testPayload, readBody(t, resp))
+	assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+	assert.Equal(t, storage.DeliveryCacheControl, resp.Header.Get("Cache-Control"))
+	assert.NotEmpty(t, resp.Header.Get("Last-Modified"))
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	resp = get(t, app, "/uploads/"+testObjectPath, map[string]string{"Range": "bytes=8-15"})
+	assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	assert.Equal(t, "bytes 8-15/512", resp.Header.Get("Content-Range"))
+	assert.Equal(t, testPayload[8:16], readBody(t, resp))
+
+	resp = get(t, app, "/uploads/"+testObjectPath, map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Empty(t, readBody(t, resp))
+
+	resp = get(t, app, "/uploads/variants/2025/08/10/missing.png", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestDeliverS3Presign(t *testing.T) {
+	pool := newS3Pool(t, 9002, models.S3DeliveryPresign)
+	pool.S3PresignTTL = 120
+	app := newDeliveryApp(pool)
+
+	resp := get(t, app, "/uploads/"+testObjectPath, nil)
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	assert.Equal(t, "private, max-age=60", resp.Header.Get("Cache-Control"))
+	location := resp.Header.Get("Location")
+	assert.True(t, strings.HasPrefix(location, *pool.S3EndpointURL+"/pixelfox/pool/uploads/variants/"), location)
+	assert.Contains(t, location, "X-Amz-Expires=120")
+	assert.Contains(t, location, "X-Amz-Signature=")
+
+	presigned, err := http.Get(location)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, presigned.StatusCode)
+	assert.Equal(t, testPayload, readBody(t, presigned))
+}
+
+func TestDeliverLocalFile(t *testing.T) {
+	base := t.TempDir()
+	full := filepath.Join(base, filepath.FromSlash(testObjectPath))
+	require.NoError(t, os.MkdirAll(filepath.Dir(full), 0755))
+	require.NoError(t, os.WriteFile(full, testPayload, 0644))
+	app := newDeliveryApp(&models.StoragePool{ID: 9003, Name: "hot", StorageType: models.StorageTypeLocal, BasePath: base})
+
+	resp := get(t, app, "/uploads/"+testObjectPath, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, testPayload, readBody(t, resp))
+	assert.Equal(t, storage.DeliveryCacheControl, resp.Header.Get("Cache-Control"))
+	assert.NotEmpty(t, resp.Header.Get("Last-Modified"))
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	resp = get(t, app, "/uploads/"+testObjectPath, map[string]string{"Range": "bytes=0-7"})
+	assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	assert.Equal(t, "bytes 0-7/512", resp.Header.Get("Content-Range"))
+	assert.Equal(t, testPayload[:8], readBody(t, resp))
+
+	resp = get(t, app, "/uploads/"+testObjectPath, map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	resp = get(t, app, "/uploads/variants/2025/08/10/missing.png", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
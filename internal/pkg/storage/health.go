@@ -134,3 +134,17 @@ func runHealthCheckOnce() {
 		}
 	}
 }
+
+// PoolHealthy reports the health of a pool from the last heartbeat. Pools without a recent
+// heartbeat count as healthy, so delivery only fails over on a known outage. Replaceable in tests.
+var PoolHealthy = func(poolID uint) bool {
+	raw, err := cache.Get(fmt.Sprintf("storage_health:%d", poolID))
+	if err != nil || raw == "" {
+		return true
+	}
+	var ph PoolHealth
+	if err := json.Unmarshal([]byte(raw), &ph); err != nil {
+		return true
+	}
+	return ph.Healthy
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -14,7 +15,6 @@ import (
 
 	"github.com/ManuelReschke/PixelFox/app/models"
 	"github.com/ManuelReschke/PixelFox/internal/pkg/database"
-	"github.com/ManuelReschke/PixelFox/internal/pkg/s3backup"
 )
 
 // StorageManager handles all storage operations across multiple pools
@@ -144,71 +144,19 @@ func (sm *StorageManager) SaveFile(data io.Reader, filename string, poolID uint)
 		return operation, operation.Error
 	}
 
-	var bytesWritten int64
-	if pool.IsS3Storage() {
-		s3Client, err := s3backup.NewPoolClient(pool)
-		if err != nil {
-			operation.Error = fmt.Errorf("failed to initialize S3 client for pool '%s': %w", pool.Name, err)
-			operation.Duration = time.Since(startTime)
-			return operation, operation.Error
-		}
-
-		tmpFile, err := os.CreateTemp("", "pixelfox-storage-upload-*")
-		if err != nil {
-			operation.Error = fmt.Errorf("failed to create temporary upload file: %w", err)
-			operation.Duration = time.Since(startTime)
-			return operation, operation.Error
-		}
-		tmpPath := tmpFile.Name()
-		defer os.Remove(tmpPath)
-
-		bytesWritten, err = io.Copy(tmpFile, data)
-		if closeErr := tmpFile.Close(); closeErr != nil && err == nil {
-			err = closeErr
-		}
-		if err != nil {
-			operation.Error = fmt.Errorf("failed to buffer upload for S3: %w", err)
-			operation.Duration = time.Since(startTime)
-			return operation, operation.Error
-		}
-
-		s3Key := toS3ObjectKey(relativePath)
-		if err := s3Client.UploadFile(tmpPath, s3Key); err != nil {
-			operation.Error = fmt.Errorf("failed to upload file %s to S3 pool '%s': %w", s3Key, pool.Name, err)
-			operation.Duration = time.Since(startTime)
-			return operation, operation.Error
-		}
-		operation.FilePath = s3Key
-	} else {
-		fullPath := filepath.Join(pool.BasePath, filepath.FromSlash(relativePath))
-		operation.FilePath = fullPath
-
-		// Ensure directory exists
-		dir := filepath.Dir(fullPath)
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			operation.Error = fmt.Errorf("failed to create directory %s: %w", dir, err)
-			operation.Duration = time.Since(startTime)
-			return operation, operation.Error
-		}
-
-		// Create and write file
-		file, err := os.Create(fullPath)
-		if err != nil {
-			operation.Error = fmt.Errorf("failed to create file %s: %w", fullPath, err)
-			operation.Duration = time.Since(startTime)
-			return operation, operation.Error
-		}
-		defer file.Close()
+	backend, err := BackendFor(pool)
+	if err != nil {
+		operation.Error = err
+		operation.Duration = time.Since(startTime)
+		return operation, operation.Error
+	}
+	operation.FilePath = describeFilePath(backend, relativePath)
 
-		// Copy data to file and track size
-		bytesWritten, err = io.Copy(file, data)
-		if err != nil {
-			operation.Error = fmt.Errorf("failed to write file %s: %w", fullPath, err)
-			operation.Duration = time.Since(startTime)
-			// Clean up partial file
-			_ = os.Remove(fullPath)
-			return operation, operation.Error
-		}
+	bytesWritten, err := backend.Create(relativePath, data)
+	if err != nil {
+		operation.Error = fmt.Errorf("failed to save file %s to pool '%s': %w", relativePath, pool.Name, err)
+		operation.Duration = time.Since(startTime)
+		return operation, operation.Error
 	}
 
 	// Update pool usage
@@ -251,44 +199,25 @@ func (sm *StorageManager) DeleteFile(relativePath string, poolID uint) (*FileOpe
 		return operation, operation.Error
 	}
 
-	fileSize := int64(0)
-	if pool.IsS3Storage() {
-		s3Client, err := s3backup.NewPoolClient(pool)
-		if err != nil {
-			operation.Error = fmt.Errorf("failed to initialize S3 client for pool '%s': %w", pool.Name, err)
-			operation.Duration = time.Since(startTime)
-			return operation, operation.Error
-		}
+	backend, err := BackendFor(pool)
+	if err != nil {
+		operation.Error = err
+		operation.Duration = time.Since(startTime)
+		return operation, operation.Error
+	}
+	operation.FilePath = describeFilePath(backend, cleanRelPath)
 
-		s3Key := toS3ObjectKey(cleanRelPath)
-		operation.FilePath = s3Key
-		if size, err := s3Client.GetFileSize(s3Key); err == nil {
-			fileSize = size
-		}
-		if err := s3Client.DeleteFile(s3Key); err != nil {
-			operation.Error = fmt.Errorf("failed to delete S3 object %s: %w", s3Key, err)
-			operation.Duration = time.Since(startTime)
-			return operation, operation.Error
-		}
-	} else {
-		fullPath := filepath.Join(pool.BasePath, filepath.FromSlash(cleanRelPath))
-		operation.FilePath = fullPath
-
-		// Get file size before deletion for usage tracking
-		fileInfo, err := os.Stat(fullPath)
-		if err == nil {
-			fileSize = fileInfo.Size()
-		}
+	// Get file size before deletion for usage tracking
+	fileSize := int64(0)
+	if info, err := backend.Stat(cleanRelPath); err == nil {
+		fileSize = info.Size
+	}
 
-		// Delete the file
-		if err := os.Remove(fullPath); err != nil {
-			if !os.IsNotExist(err) {
-				operation.Error = fmt.Errorf("failed to delete file %s: %w", fullPath, err)
-				operation.Duration = time.Since(startTime)
-				return operation, operation.Error
-			}
-			// File doesn't exist, consider it successful
-		}
+	// Deleting a missing file is considered successful
+	if err := backend.Delete(cleanRelPath); err != nil {
+		operation.Error = err
+		operation.Duration = time.Since(startTime)
+		return operation, operation.Error
 	}
 
 	// Update pool usage (subtract file size)
@@ -365,11 +294,16 @@ func (sm *StorageManager) MigrateFile(relativePath string, sourcePoolID, targetP
 		return operation, operation.Error
 	}
 
-	// Local no-op if source and destination are identical
-	if !sourcePool.IsS3Storage() && !targetPool.IsS3Storage() {
-		sourcePath := filepath.Clean(filepath.Join(sourcePool.BasePath, filepath.FromSlash(relPath)))
-		targetPath := filepath.Clean(filepath.Join(targetPool.BasePath, filepath.FromSlash(relPath)))
-		if strings.EqualFold(sourcePath, targetPath) {
+	sourceBackend, err := BackendFor(sourcePool)
+	if err != nil {
+		operation.Error = err
+		operation.Duration = time.Since(startTime)
+		return operation, operation.Error
+	}
+
+	// No-op if source and destination are the same local file
+	if sourcePath, ok := LocalPath(sourcePool, relPath); ok {
+		if targetPath, ok := LocalPath(targetPool, relPath); ok && strings.EqualFold(filepath.Clean(sourcePath), filepath.Clean(targetPath)) {
 			operation.Success = true
 			operation.FilePath = targetPath
 			operation.Duration = time.Since(startTime)
@@ -378,49 +312,11 @@ func (sm *StorageManager) MigrateFile(relativePath string, sourcePoolID, targetP
 		}
 	}
 
-	// Open source stream depending on pool type
-	var (
-		sourceReader io.ReadCloser
-		tempFilePath string
-	)
-	if sourcePool.IsS3Storage() {
-		s3Client, err := s3backup.NewPoolClient(sourcePool)
-		if err != nil {
-			operation.Error = fmt.Errorf("failed to initialize S3 client for source pool '%s': %w", sourcePool.Name, err)
-			operation.Duration = time.Since(startTime)
-			return operation, operation.Error
-		}
-		tmpFile, err := os.CreateTemp("", "pixelfox-storage-migrate-*")
-		if err != nil {
-			operation.Error = fmt.Errorf("failed to create temp file for migration: %w", err)
-			operation.Duration = time.Since(startTime)
-			return operation, operation.Error
-		}
-		tempFilePath = tmpFile.Name()
-		_ = tmpFile.Close()
-		defer os.Remove(tempFilePath)
-
-		s3Key := toS3ObjectKey(relPath)
-		if err := s3Client.DownloadFile(s3Key, tempFilePath); err != nil {
-			operation.Error = fmt.Errorf("failed to download source object %s from pool '%s': %w", s3Key, sourcePool.Name, err)
-			operation.Duration = time.Since(startTime)
-			return operation, operation.Error
-		}
-		sourceReader, err = os.Open(tempFilePath)
-		if err != nil {
-			operation.Error = fmt.Errorf("failed to open temporary source file: %w", err)
-			operation.Duration = time.Since(startTime)
-			return operation, operation.Error
-		}
-	} else {
-		sourcePath := filepath.Join(sourcePool.BasePath, filepath.FromSlash(relPath))
-		f, err := os.Open(sourcePath)
-		if err != nil {
-			operation.Error = fmt.Errorf("failed to open source file %s: %w", sourcePath, err)
-			operation.Duration = time.Since(startTime)
-			return operation, operation.Error
-		}
-		sourceReader = f
+	sourceReader, err := sourceBackend.Open(relPath)
+	if err != nil {
+		operation.Error = fmt.Errorf("failed to open source file %s in pool '%s': %w", relPath, sourcePool.Name, err)
+		operation.Duration = time.Since(startTime)
+		return operation, operation.Error
 	}
 	defer sourceReader.Close()
 
@@ -451,29 +347,6 @@ func (sm *StorageManager) MigrateFile(relativePath string, sourcePoolID, targetP
 	return operation, nil
 }
 
-// copyFile copies a file from source to destination
-func (sm *StorageManager) copyFile(source, destination string) error {
-	sourceFile, err := os.Open(source)
-	if err != nil {
-		return err
-	}
-	defer sourceFile.Close()
-
-	destFile, err := os.Create(destination)
-	if err != nil {
-		return err
-	}
-	defer destFile.Close()
-
-	_, err = io.Copy(destFile, sourceFile)
-	if err != nil {
-		os.Remove(destination) // Clean up on error
-		return err
-	}
-
-	return destFile.Sync()
-}
-
 // GetPoolStats returns statistics for a specific storage pool
 func (sm *StorageManager) GetPoolStats(poolID uint) (*models.StoragePoolStats, error) {
 	return models.GetStoragePoolStats(sm.db, poolID)
@@ -502,7 +375,8 @@ func (sm *StorageManager) HealthCheck() (map[uint]bool, error) {
 	return healthStatus, nil
 }
 
-// GetFilePath returns the full file path for a file in the specified pool
+// GetFilePath returns the filesystem path of a file in the specified pool.
+// It fails for pools that do not keep their files on this node, e.g. S3 pools.
 func (sm *StorageManager) GetFilePath(relativePath string, poolID uint) (string, error) {
 	pool, err := models.FindStoragePoolByID(sm.db, poolID)
 	if err != nil {
@@ -513,18 +387,31 @@ func (sm *StorageManager) GetFilePath(relativePath string, poolID uint) (string,
 	if err != nil {
 		return "", err
 	}
-	if pool.IsS3Storage() {
-		return toS3ObjectKey(cleanRelPath), nil
+	fullPath, ok := LocalPath(pool, cleanRelPath)
+	if !ok {
+		return "", fmt.Errorf("storage pool '%s' has no local file paths", pool.Name)
 	}
+	return fullPath, nil
+}
 
-	return filepath.Join(pool.BasePath, filepath.FromSlash(cleanRelPath)), nil
+// Backend returns the storage pool and its backend
+func (sm *StorageManager) Backend(poolID uint) (*models.StoragePool, Backend, error) {
+	pool, err := models.FindStoragePoolByID(sm.db, poolID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find storage pool %d: %w", poolID, err)
+	}
+	backend, err := BackendFor(pool)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pool, backend, nil
 }
 
 // FileExists checks whether a file exists in the specified pool and returns its size.
 func (sm *StorageManager) FileExists(relativePath string, poolID uint) (bool, int64, error) {
-	pool, err := models.FindStoragePoolByID(sm.db, poolID)
+	_, backend, err := sm.Backend(poolID)
 	if err != nil {
-		return false, 0, fmt.Errorf("failed to find storage pool %d: %w", poolID, err)
+		return false, 0, err
 	}
 
 	cleanRelPath, err := cleanRelativeStoragePath(relativePath)
@@ -532,23 +419,14 @@ func (sm *StorageManager) FileExists(relativePath string, poolID uint) (bool, in
 		return false, 0, err
 	}
 
-	if pool.IsS3Storage() {
-		s3Client, err := s3backup.NewPoolClient(pool)
-		if err != nil {
-			return false, 0, fmt.Errorf("failed to initialize S3 client for pool '%s': %w", pool.Name, err)
-		}
-		return s3Client.FileInfo(toS3ObjectKey(cleanRelPath))
-	}
-
-	fullPath := filepath.Join(pool.BasePath, filepath.FromSlash(cleanRelPath))
-	info, err := os.Stat(fullPath)
+	info, err := backend.Stat(cleanRelPath)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, os.ErrNotExist) {
 			return false, 0, nil
 		}
-		return false, 0, fmt.Errorf("failed to stat %s: %w", fullPath, err)
+		return false, 0, fmt.Errorf("failed to stat %s: %w", cleanRelPath, err)
 	}
-	return true, info.Size(), nil
+	return true, info.Size, nil
 }
 
 // UpdatePoolUsage updates the used size of a storage pool
@@ -587,3 +465,11 @@ func toS3ObjectKey(relativePath string) string {
 	}
 	return path.Join("uploads", clean)
 }
+
+// describeFilePath returns the local path of a file for local backends and the relative path otherwise
+func describeFilePath(backend Backend, relativePath string) string {
+	if local, ok := backend.(LocalBackend); ok {
+		return local.LocalPath(relativePath)
+	}
+	return relativePath
+}
@@ -1,6 +1,8 @@
 package upload
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
 	"net/http"
 	"path/filepath"
@@ -15,6 +17,11 @@ var allowedExt = map[string]bool{
 	".webp": true,
 	".avif": true,
 	".bmp":  true,
+	".heic": true,
+	".heif": true,
+	".tif":  true,
+	".tiff": true,
+	".jxl":  true,
 	// Note: SVG is intentionally excluded due to XSS risk without sanitization
 }
 
@@ -25,15 +32,76 @@ var allowedMime = map[string]bool{
 	"image/webp": true,
 	"image/avif": true,
 	"image/bmp":  true,
+	"image/heic": true,
+	"image/heif": true,
+	"image/tiff": true,
+	"image/jxl":  true,
 	// "image/svg+xml": false // require sanitizer if enabled in future
 }
 
+// magicOnlyExt maps the formats Go's content sniffer does not know to the mime types their
+// magic bytes must match
+var magicOnlyExt = map[string][]string{
+	".heic": {"image/heic", "image/heif"},
+	".heif": {"image/heic", "image/heif"},
+	".tif":  {"image/tiff"},
+	".tiff": {"image/tiff"},
+	".jxl":  {"image/jxl"},
+}
+
+// heicBrands are the ISO BMFF brands of HEVC coded HEIF images
+var heicBrands = map[string]bool{"heic": true, "heix": true, "hevc": true, "hevx": true, "heim": true, "heis": true}
+
+// sniffImageMagic detects HEIC/HEIF, AVIF, TIFF and JPEG XL by their magic bytes.
+// It returns an empty string for other content.
+func sniffImageMagic(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, []byte("II*\x00")), bytes.HasPrefix(head, []byte("MM\x00*")):
+		return "image/tiff"
+	case bytes.HasPrefix(head, []byte{0xFF, 0x0A}), bytes.HasPrefix(head, []byte("\x00\x00\x00\x0cJXL \r\n\x87\n")):
+		return "image/jxl"
+	case len(head) >= 12 && string(head[4:8]) == "ftyp":
+		// The major brand is followed by a version and the compatible brands
+		boxSize := int(binary.BigEndian.Uint32(head[0:4]))
+		brands := []string{string(head[8:12])}
+		for i := 16; i+4 <= boxSize && i+4 <= len(head); i += 4 {
+			brands = append(brands, string(head[i:i+4]))
+		}
+		heif := false
+		for _, brand := range brands {
+			switch {
+			case brand == "avif" || brand == "avis":
+				return "image/avif"
+			case heicBrands[brand]:
+				return "image/heic"
+			case brand == "mif1" || brand == "msf1":
+				heif = true
+			}
+		}
+		if heif {
+			return "image/heif"
+		}
+	}
+	return ""
+}
+
 // ValidateImageBySniff checks the provided filename (extension) and the first bytes (head)
 // against a whitelist of image types. Returns detected mime or an error.
 func ValidateImageBySniff(filename string, head []byte) (string, error) {
 	ext := strings.ToLower(filepath.Ext(filename))
 	if !allowedExt[ext] {
-		return "", errors.New("Nur folgende Bildformate werden unterstützt: JPG, JPEG, PNG, GIF, WEBP, AVIF, BMP")
+		return "", errors.New("Nur folgende Bildformate werden unterstützt: JPG, JPEG, PNG, GIF, WEBP, AVIF, BMP, HEIC, HEIF, TIFF, JXL")
+	}
+
+	// HEIC/HEIF, TIFF and JPEG XL are only accepted if their magic bytes match the extension
+	if mimes, ok := magicOnlyExt[ext]; ok {
+		sniffed := sniffImageMagic(head)
+		for _, mime := range mimes {
+			if sniffed == mime {
+				return sniffed, nil
+			}
+		}
+		return "", errors.New("Der Dateiinhalt passt nicht zur Dateiendung")
 	}
 
 	detected := http.DetectContentType(head)
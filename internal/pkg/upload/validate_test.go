@@ -0,0 +1,64 @@
+package upload
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readFixtureHead(t *testing.T, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("..", "imageprocessor", "testdata", name))
+	require.NoError(t, err)
+	return data[:min(len(data), 512)]
+}
+
+func TestValidateImageBySniffMagicFormats(t *testing.T) {
+	cases := map[string]string{
+		"image.heic": "image/heic",
+		"image.heif": "image/heic",
+		"image.tiff": "image/tiff",
+		"image.jxl":  "image/jxl",
+	}
+	for name, want := range cases {
+		mime, err := ValidateImageBySniff(name, readFixtureHead(t, name))
+		require.NoError(t, err, name)
+		assert.Equal(t, want, mime, name)
+	}
+
+	// TIFF may use either extension and byte order
+	mime, err := ValidateImageBySniff("scan.TIF", []byte("MM\x00*\x00\x00\x00\x08"))
+	require.NoError(t, err)
+	assert.Equal(t, "image/tiff", mime)
+
+	// JPEG XL as bare codestream and in its container
+	mime, err = ValidateImageBySniff("photo.jxl", []byte{0xFF, 0x0A, 0xFA, 0x1F})
+	require.NoError(t, err)
+	assert.Equal(t, "image/jxl", mime)
+	mime, err = ValidateImageBySniff("photo.jxl", []byte("\x00\x00\x00\x0cJXL \r\n\x87\n\x00\x00\x00\x14ftypjxl "))
+	require.NoError(t, err)
+	assert.Equal(t, "image/jxl", mime)
+
+	// Plain HEIF (mif1 only) and AVIF brands
+	assert.Equal(t, "image/heif", sniffImageMagic([]byte("\x00\x00\x00\x10ftypmif1\x00\x00\x00\x00")))
+	assert.Equal(t, "image/avif", sniffImageMagic(readFixtureHead(t, "image.avif")))
+}
+
+func TestValidateImageBySniffRejectsMismatchedMagic(t *testing.T) {
+	png := readFixtureHead(t, "image.png")
+	for _, name := range []string{"fake.heic", "fake.heif", "fake.tiff", "fake.jxl"} {
+		_, err := ValidateImageBySniff(name, png)
+		assert.Error(t, err, name)
+	}
+
+	// AVIF content is no HEIC image
+	_, err := ValidateImageBySniff("photo.heic", readFixtureHead(t, "image.avif"))
+	assert.Error(t, err)
+
+	// Existing formats keep working
+	mime, err := ValidateImageBySniff("image.png", png)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", mime)
+}
@@ -7,4 +7,6 @@ const (
 	KeyUsername      = "username"
 	KeyIsAdmin       = "isAdmin"
 	KeyFromProtected = "from_protected"
+	// KeyTwoFactorVerified marks a session whose login passed the TOTP/recovery code step
+	KeyTwoFactorVerified = "two_factor_verified"
 )
@@ -0,0 +1,24 @@
+package viewmodel
+
+// Comment is a single rendered comment of an image
+type Comment struct {
+	ID         uint
+	AuthorName string
+	Content    string
+	CreatedAt  string
+	CanDelete  bool
+}
+
+// CommentThread holds one page of comments of an image and the state of the comment form
+type CommentThread struct {
+	ImageUUID  string
+	Comments   []Comment
+	Total      int64
+	NextPage   int // 0 if there are no older comments
+	IsLoggedIn bool
+	CanComment bool
+	Disabled   bool // owner turned comments off
+	CSRFToken  string
+	Error      string
+	Draft      string
+}
@@ -41,6 +41,16 @@ type Image struct {
 	// Original path (for download)
 	OriginalPath string
 
+	// Full-size browser-safe rendition of HEIC/HEIF, TIFF and JPEG XL originals (empty for other formats)
+	WebPath string
+
+	// Animated GIF/WebP: the viewer plays the video variants with the animated original as fallback
+	IsAnimated    bool
+	FrameCount    int
+	DurationMs    int
+	VideoMP4Path  string
+	VideoWebMPath string
+
 	// Additional metadata
 	Width  int
 	Height int
@@ -52,14 +62,16 @@ type Image struct {
 	IsProcessing bool
 
 	// Metadata fields
-	CameraModel  string
-	TakenAt      string
-	Latitude     string
-	Longitude    string
-	ExposureTime string
-	Aperture     string
-	ISO          string
-	FocalLength  string
+	CameraModel string
+	TakenAt     string
+	Latitude    string
+	Longitude   string
+	// GPS data was removed from the original by the owner's metadata policy
+	LocationHidden bool
+	ExposureTime   string
+	Aperture       string
+	ISO            string
+	FocalLength    string
 
 	// Human-readable sizes for each format and size category
 	OptimizedOriginalSize string
@@ -86,4 +98,21 @@ type Image struct {
 	SmallOriginalBytes int64
 	SmallWebPBytes     int64
 	SmallAVIFBytes     int64
+
+	// Whether the comment thread is shown below the image (public images only)
+	ShowComments bool
+	// Whether the like button is shown (public images or the owner's own images)
+	ShowLikes bool
+	// Normalized tag names linking to the public tag galleries
+	Tags []string
+
+	// Near-duplicates in the owner's library, shown as warning right after the upload
+	SimilarImages []SimilarImage
+}
+
+// SimilarImage is a near-duplicate of the displayed image in the owner's library
+type SimilarImage struct {
+	UUID        string
+	Title       string
+	PreviewPath string
 }
@@ -0,0 +1,11 @@
+package viewmodel
+
+// LikeButton holds the state of the like button of an image
+type LikeButton struct {
+	ImageUUID  string
+	Liked      bool
+	Count      int64
+	IsLoggedIn bool
+	CSRFToken  string
+	Compact    bool // small round variant for gallery overlays
+}
@@ -0,0 +1,28 @@
+package viewmodel
+
+// Notification is a single rendered notification
+type Notification struct {
+	ID        uint
+	Type      string
+	Content   string
+	Link      string
+	CreatedAt string
+	IsRead    bool
+}
+
+// NotificationBell holds the state of the navbar bell dropdown
+type NotificationBell struct {
+	Unread    int64
+	Items     []Notification
+	CSRFToken string
+}
+
+// NotificationList holds one page of the notification center
+type NotificationList struct {
+	Items      []Notification
+	Total      int64
+	Unread     int64
+	Page       int
+	TotalPages int
+	CSRFToken  string
+}
@@ -0,0 +1,112 @@
+package viewmodel
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SearchFilters holds the normalized query and filters of the search page
+type SearchFilters struct {
+	Query       string
+	Type        string // images, albums or users
+	From        string // YYYY-MM-DD
+	To          string // YYYY-MM-DD
+	Formats     []string
+	MinWidth    int
+	MinHeight   int
+	Orientation string
+	UserID      uint // restrict to the public content of a user
+	Mine        bool // restrict to the caller's own content
+}
+
+// HasFormat reports whether the given format filter is active
+func (f SearchFilters) HasFormat(format string) bool {
+	for _, v := range f.Formats {
+		if v == format {
+			return true
+		}
+	}
+	return false
+}
+
+// URL builds the search page link for the filters with another result type and page
+func (f SearchFilters) URL(searchType string, page int) string {
+	v := url.Values{}
+	if f.Query != "" {
+		v.Set("q", f.Query)
+	}
+	if searchType != "" {
+		v.Set("type", searchType)
+	}
+	if f.From != "" {
+		v.Set("from", f.From)
+	}
+	if f.To != "" {
+		v.Set("to", f.To)
+	}
+	if len(f.Formats) > 0 {
+		v.Set("format", strings.Join(f.Formats, ","))
+	}
+	if f.MinWidth > 0 {
+		v.Set("min_width", strconv.Itoa(f.MinWidth))
+	}
+	if f.MinHeight > 0 {
+		v.Set("min_height", strconv.Itoa(f.MinHeight))
+	}
+	if f.Orientation != "" {
+		v.Set("orientation", f.Orientation)
+	}
+	if f.Mine {
+		v.Set("mine", "1")
+	} else if f.UserID > 0 {
+		v.Set("user", strconv.FormatUint(uint64(f.UserID), 10))
+	}
+	if page > 1 {
+		v.Set("page", strconv.Itoa(page))
+	}
+	if len(v) == 0 {
+		return "/search"
+	}
+	return "/search?" + v.Encode()
+}
+
+// SearchImage is an image hit of the search page
+type SearchImage struct {
+	UUID        string
+	Title       string
+	PreviewPath string
+	Width       int
+	Height      int
+	IsPublic    bool
+}
+
+// SearchAlbum is an album hit of the search page
+type SearchAlbum struct {
+	Title       string
+	Description string
+	OwnerName   string
+	ImageCount  int64
+	URL         string
+	IsPublic    bool
+}
+
+// SearchUser is a user hit of the search page
+type SearchUser struct {
+	ID               uint
+	Name             string
+	PublicImageCount int64
+}
+
+// SearchResults holds one page of search results
+type SearchResults struct {
+	Filters    SearchFilters
+	Images     []SearchImage
+	Albums     []SearchAlbum
+	Users      []SearchUser
+	Total      int64
+	Page       int
+	TotalPages int
+	IsLoggedIn bool
+	Error      string
+}
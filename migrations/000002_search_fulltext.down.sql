@@ -0,0 +1,5 @@
+ALTER TABLE `users` DROP INDEX `ft_users_name`;
+ALTER TABLE `image_metadata` DROP INDEX `ft_image_metadata_camera_model`;
+ALTER TABLE `albums` DROP INDEX `ft_albums_title_description`;
+ALTER TABLE `tags` DROP INDEX `ft_tags_name`;
+ALTER TABLE `images` DROP INDEX `ft_images_title_description`;
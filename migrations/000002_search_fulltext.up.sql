@@ -0,0 +1,6 @@
+-- Full-text indexes for the public search (images, tags, albums, camera model, users)
+ALTER TABLE `images` ADD FULLTEXT INDEX `ft_images_title_description` (`title`, `description`);
+ALTER TABLE `tags` ADD FULLTEXT INDEX `ft_tags_name` (`name`);
+ALTER TABLE `albums` ADD FULLTEXT INDEX `ft_albums_title_description` (`title`, `description`);
+ALTER TABLE `image_metadata` ADD FULLTEXT INDEX `ft_image_metadata_camera_model` (`camera_model`);
+ALTER TABLE `users` ADD FULLTEXT INDEX `ft_users_name` (`name`);
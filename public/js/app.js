@@ -37,6 +37,9 @@ function initializeAllFunctions() {
 
     // Live-Validierung für Passwort-Änderung (Profiledit)
     initProfilePasswordValidation();
+
+    // BlurHash-Platzhalter für Galerie-Thumbnails
+    initBlurhashPlaceholders();
 }
 
 // HTMX-Event-Listener für Seitenwechsel
@@ -108,6 +111,14 @@ function initChangingWords() {
 /**
  * Initialisiert die Upload-Formular-Funktionalität
  */
+// Forward the per-upload metadata choice to direct-to-storage uploads
+function appendMetadataPolicy(fd) {
+    const select = document.getElementById('metadata_policy');
+    if (select && select.value) {
+        fd.append('metadata_policy', select.value);
+    }
+}
+
 function initUploadForm() {
     const uploadForm = document.getElementById('upload_form');
     if (!uploadForm) return;
@@ -137,6 +148,13 @@ function initUploadForm() {
         return;
     }
 
+    // HEIC/HEIF, TIFF and JPEG XL often come without mime type and browsers cannot preview them
+    const noPreviewExtensions = ['.heic', '.heif', '.tif', '.tiff', '.jxl'];
+    function hasNoPreviewExtension(name) {
+        const lower = (name || '').toLowerCase();
+        return noPreviewExtensions.some(ext => lower.endsWith(ext));
+    }
+
     // Prevent double-submits/race conditions
     let uploading = false;
 
@@ -169,9 +187,10 @@ function initUploadForm() {
 
             const file = this.files[0];
             // Prüfe Bildtyp
-            if (!file.type.startsWith('image/')) {
+            const noPreview = hasNoPreviewExtension(file.name);
+            if (!file.type.startsWith('image/') && !noPreview) {
                 errorMessage.classList.remove('hidden');
-                errorText.textContent = 'Nur Bildformate werden unterstützt (JPG, JPEG, PNG, GIF, WEBP, AVIF, BMP)';
+                errorText.textContent = 'Nur Bildformate werden unterstützt (JPG, JPEG, PNG, GIF, WEBP, AVIF, BMP, HEIC, HEIF, TIFF, JXL)';
                 uploadResult.classList.remove('hidden');
                 fileInput.value = '';
                 return;
@@ -180,6 +199,12 @@ function initUploadForm() {
             uploadButton.disabled = false;
             dropArea.classList.add('border-primary');
             dropArea.classList.remove('border-primary/50');
+            if (noPreview) {
+                uploadIcon.classList.remove('hidden');
+                inlineImagePreview.classList.add('hidden');
+                inlineImagePreview.src = '';
+                return;
+            }
             // Einzelvorschau
             const reader = new FileReader();
             reader.onload = function(e) {
@@ -316,6 +341,7 @@ function initUploadForm() {
                 };
                 const fd = new FormData();
                 fd.append('file', file);
+                appendMetadataPolicy(fd);
                 xhr.send(fd);
             });
 
@@ -460,6 +486,7 @@ function initUploadForm() {
                     };
                     const fd = new FormData();
                     fd.append('file', f);
+                    appendMetadataPolicy(fd);
                     xhr.send(fd);
                 });
 
@@ -1083,6 +1110,101 @@ document.addEventListener('click', (e) => {
     openImageModal();
 });
 
+/**
+ * BlurHash-Platzhalter: malt den BlurHash (data-blurhash) als Hintergrund hinter noch ladende Thumbnails
+ */
+const BLURHASH_CHARS = '0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~';
+
+function decodeBlurhash(hash, width, height) {
+    const decode83 = (str) => {
+        let value = 0;
+        for (const c of str) {
+            const digit = BLURHASH_CHARS.indexOf(c);
+            if (digit < 0) return NaN;
+            value = value * 83 + digit;
+        }
+        return value;
+    };
+    const srgbToLinear = (v) => {
+        v /= 255;
+        return v <= 0.04045 ? v / 12.92 : Math.pow((v + 0.055) / 1.055, 2.4);
+    };
+    const linearToSrgb = (v) => {
+        v = Math.max(0, Math.min(1, v));
+        return v <= 0.0031308 ? Math.round(v * 12.92 * 255) : Math.round((1.055 * Math.pow(v, 1 / 2.4) - 0.055) * 255);
+    };
+    const signPow = (v, exp) => Math.sign(v) * Math.pow(Math.abs(v), exp);
+
+    if (!hash || hash.length < 6) return null;
+    const size = decode83(hash[0]);
+    const nx = (size % 9) + 1;
+    const ny = Math.floor(size / 9) + 1;
+    if (hash.length !== 4 + 2 * nx * ny) return null;
+    const maxValue = (decode83(hash[1]) + 1) / 166;
+
+    const colors = [];
+    for (let i = 0; i < nx * ny; i++) {
+        if (i === 0) {
+            const dc = decode83(hash.substring(2, 6));
+            colors.push([srgbToLinear(dc >> 16), srgbToLinear((dc >> 8) & 255), srgbToLinear(dc & 255)]);
+        } else {
+            const ac = decode83(hash.substring(4 + i * 2, 6 + i * 2));
+            colors.push([
+                signPow((Math.floor(ac / 361) - 9) / 9, 2) * maxValue,
+                signPow((Math.floor(ac / 19) % 19 - 9) / 9, 2) * maxValue,
+                signPow((ac % 19 - 9) / 9, 2) * maxValue,
+            ]);
+        }
+    }
+    if (colors.some((c) => c.some(isNaN))) return null;
+
+    const pixels = new Uint8ClampedArray(width * height * 4);
+    for (let y = 0; y < height; y++) {
+        for (let x = 0; x < width; x++) {
+            let r = 0, g = 0, b = 0;
+            for (let j = 0; j < ny; j++) {
+                for (let i = 0; i < nx; i++) {
+                    const basis = Math.cos(Math.PI * x * i / width) * Math.cos(Math.PI * y * j / height);
+                    const color = colors[i + j * nx];
+                    r += color[0] * basis;
+                    g += color[1] * basis;
+                    b += color[2] * basis;
+                }
+            }
+            const idx = 4 * (x + y * width);
+            pixels[idx] = linearToSrgb(r);
+            pixels[idx + 1] = linearToSrgb(g);
+            pixels[idx + 2] = linearToSrgb(b);
+            pixels[idx + 3] = 255;
+        }
+    }
+    return pixels;
+}
+
+function initBlurhashPlaceholders() {
+    const size = 32;
+    document.querySelectorAll('img[data-blurhash]:not([data-blurhash-ready])').forEach((img) => {
+        img.setAttribute('data-blurhash-ready', '1');
+        // Bereits geladene Bilder brauchen keinen Platzhalter mehr
+        if (img.complete && img.naturalWidth > 0) return;
+        const pixels = decodeBlurhash(img.dataset.blurhash, size, size);
+        if (!pixels) return;
+        const canvas = document.createElement('canvas');
+        canvas.width = size;
+        canvas.height = size;
+        const ctx = canvas.getContext('2d');
+        if (!ctx) return;
+        ctx.putImageData(new ImageData(pixels, size, size), 0, 0);
+        img.style.backgroundImage = `url(${canvas.toDataURL()})`;
+        img.style.backgroundSize = '100% 100%';
+        // Nach dem Laden den Platzhalter entfernen (transparente Bereiche sollen nicht durchscheinen)
+        img.addEventListener('load', () => {
+            img.style.backgroundImage = '';
+            img.style.backgroundColor = '';
+        }, { once: true });
+    });
+}
+
 // Kept for potential external usage (opens single image without arrows)
 function viewImage(src) {
     Swal.fire({
@@ -308,6 +308,23 @@ function initializeEventListeners() {
 		previewImage.addEventListener('click', openImageModal);
 	}
 
+	// Animated images are previewed as video; fall back to the animated original if it cannot be played
+	const previewVideo = document.getElementById('preview-video');
+	if (previewVideo) {
+		previewVideo.removeEventListener('click', openImageModal);
+		previewVideo.addEventListener('click', openImageModal);
+		const sources = previewVideo.querySelectorAll('source');
+		if (sources.length > 0) {
+			sources[sources.length - 1].addEventListener('error', function() {
+				const fallback = previewVideo.querySelector('img');
+				if (fallback && previewVideo.parentNode) {
+					fallback.addEventListener('click', openImageModal);
+					previewVideo.parentNode.replaceChild(fallback, previewVideo);
+				}
+			});
+		}
+	}
+
 	// Update image paths when processed image element is loaded
 	const processedImageElement = document.getElementById('processed-image-element');
 	if (processedImageElement) {
@@ -14,7 +14,7 @@ import (
 	"github.com/ManuelReschke/PixelFox/internal/pkg/env"
 )
 
-func settingsContent(settings models.AppSettings, csrfToken string) templ.Component {
+func settingsContent(settings *models.AppSettings, csrfToken string) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -124,190 +124,331 @@ func settingsContent(settings models.AppSettings, csrfToken string) templ.Compon
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, "></label> <label class=\"label\"><span class=\"label-text-alt\">Validiert jede Server‑zu‑Server Replikation per SHA‑256 und bricht bei Mismatch ab (empfohlen).</span></label> <label class=\"label\"><span class=\"label-text-alt\">Hinweis: Der interne „Move to Pool“-Job sendet die Checksumme immer mit. Externe Replikations‑Clients müssen das Feld <code>sha256</code> im Request setzen.</span></label></div><!-- Tiering (Hot/Warm/Cold) Phase A --><div class=\"divider\">Automatisches Tiering (Hot → Warm/Cold)</div><div class=\"form-control\"><label class=\"label cursor-pointer\"><span class=\"label-text font-semibold\">Tiering aktivieren</span> <input type=\"checkbox\" name=\"tiering_enabled\" class=\"checkbox\"")
-		if templ_7745c5c3_Err != nil {
-			return templ_7745c5c3_Err
-		}
-		if settings.TieringEnabled {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, " checked")
-			if templ_7745c5c3_Err != nil {
-				return templ_7745c5c3_Err
-			}
-		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, "></label> <label class=\"label\"><span class=\"label-text-alt\">Inaktive Bilder werden automatisch aus Hot‑Storage in niedrigere Tiers verschoben.</span></label></div><div class=\"grid grid-cols-1 md:grid-cols-2 gap-4\"><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Mindestaufenthalt in Hot nach Upload (Tage)</span></label> <input type=\"number\" name=\"hot_keep_days_after_upload\" value=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, "></label> <label class=\"label\"><span class=\"label-text-alt\">Validiert jede Server‑zu‑Server Replikation per SHA‑256 und bricht bei Mismatch ab (empfohlen).</span></label> <label class=\"label\"><span class=\"label-text-alt\">Hinweis: Der interne „Move to Pool“-Job sendet die Checksumme immer mit. Externe Replikations‑Clients müssen das Feld <code>sha256</code> im Request setzen.</span></label></div><div class=\"grid grid-cols-1 md:grid-cols-3 gap-4\"><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Kopien Hot</span></label> <input type=\"number\" name=\"replicas_hot\" value=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var5 string
-		templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.HotKeepDaysAfterUpload))
+		templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.ReplicasHot))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 155, Col: 119}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 134, Col: 94}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "\" class=\"input input-bordered w-full\" placeholder=\"7\" min=\"0\" max=\"3650\" required> <label class=\"label\"><span class=\"label-text-alt\">Bilder verbleiben mindestens so lange in Hot, unabhängig von Views.</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Demote wenn keine Views seit (Tage)</span></label> <input type=\"number\" name=\"demote_if_no_views_days\" value=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, "\" class=\"input input-bordered w-full\" placeholder=\"1\" min=\"1\" max=\"5\" required></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Kopien Warm</span></label> <input type=\"number\" name=\"replicas_warm\" value=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var6 string
-		templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.DemoteIfNoViewsDays))
+		templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.ReplicasWarm))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 163, Col: 113}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 140, Col: 96}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, "\" class=\"input input-bordered w-full\" placeholder=\"30\" min=\"0\" max=\"3650\" required> <label class=\"label\"><span class=\"label-text-alt\">Wenn seit X Tagen keine Views stattfanden (oder nie), demote in Warm/Cold.</span></label></div></div><div class=\"grid grid-cols-1 md:grid-cols-3 gap-4\"><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Min. Verweildauer je Tier (Tage)</span></label> <input type=\"number\" name=\"min_dwell_days_per_tier\" value=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, "\" class=\"input input-bordered w-full\" placeholder=\"1\" min=\"1\" max=\"5\" required></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Kopien Cold</span></label> <input type=\"number\" name=\"replicas_cold\" value=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var7 string
-		templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.MinDwellDaysPerTier))
+		templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.ReplicasCold))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 173, Col: 113}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 146, Col: 96}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, "\" class=\"input input-bordered w-full\" placeholder=\"7\" min=\"0\" max=\"3650\" required> <label class=\"label\"><span class=\"label-text-alt\">Schutz vor Ping‑Pong (künftige Verwendung, Phase B).</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Hot Watermark HIGH (%)</span></label> <input type=\"number\" name=\"hot_watermark_high\" value=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "\" class=\"input input-bordered w-full\" placeholder=\"1\" min=\"1\" max=\"5\" required></div></div><label class=\"label\"><span class=\"label-text-alt\">Anzahl der Kopien je Bild inklusive Original (1 = keine Replikation). Kopien liegen in anderen aktiven Pools derselben Stufe auf verschiedenen Nodes. Fällt der Pool eines Bildes aus, wird aus einer Kopie ausgeliefert.</span></label><!-- Tiering (Hot/Warm/Cold) Phase A --><div class=\"divider\">Automatisches Tiering (Hot → Warm/Cold)</div><div class=\"form-control\"><label class=\"label cursor-pointer\"><span class=\"label-text font-semibold\">Tiering aktivieren</span> <input type=\"checkbox\" name=\"tiering_enabled\" class=\"checkbox\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if settings.TieringEnabled {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, " checked")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, "></label> <label class=\"label\"><span class=\"label-text-alt\">Inaktive Bilder werden automatisch aus Hot‑Storage in niedrigere Tiers verschoben.</span></label></div><div class=\"grid grid-cols-1 md:grid-cols-2 gap-4\"><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Mindestaufenthalt in Hot nach Upload (Tage)</span></label> <input type=\"number\" name=\"hot_keep_days_after_upload\" value=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var8 string
-		templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.HotWatermarkHigh))
+		templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.HotKeepDaysAfterUpload))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 181, Col: 105}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 178, Col: 119}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, "\" class=\"input input-bordered w-full\" placeholder=\"80\" min=\"1\" max=\"100\" required> <label class=\"label\"><span class=\"label-text-alt\">Ab dieser Hot‑Auslastung wird zusätzlich demotet (Hysterese).</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Hot Watermark LOW (%)</span></label> <input type=\"number\" name=\"hot_watermark_low\" value=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, "\" class=\"input input-bordered w-full\" placeholder=\"7\" min=\"0\" max=\"3650\" required> <label class=\"label\"><span class=\"label-text-alt\">Bilder verbleiben mindestens so lange in Hot, unabhängig von Views.</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Demote wenn keine Views seit (Tage)</span></label> <input type=\"number\" name=\"demote_if_no_views_days\" value=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var9 string
-		templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.HotWatermarkLow))
+		templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.DemoteIfNoViewsDays))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 189, Col: 103}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 186, Col: 113}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var9))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 21, "\" class=\"input input-bordered w-full\" placeholder=\"65\" min=\"0\" max=\"100\" required> <label class=\"label\"><span class=\"label-text-alt\">Unterhalb dieses Werts endet der Demote‑Druck (nächste Sweeps).</span></label></div></div><div class=\"grid grid-cols-1 md:grid-cols-2 gap-4\"><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Max. Kandidaten pro Sweep</span></label> <input type=\"number\" name=\"max_tiering_candidates_per_sweep\" value=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 21, "\" class=\"input input-bordered w-full\" placeholder=\"30\" min=\"0\" max=\"3650\" required> <label class=\"label\"><span class=\"label-text-alt\">Wenn seit X Tagen keine Views stattfanden (oder nie), demote in Warm/Cold.</span></label></div></div><div class=\"grid grid-cols-1 md:grid-cols-3 gap-4\"><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Min. Verweildauer je Tier (Tage)</span></label> <input type=\"number\" name=\"min_dwell_days_per_tier\" value=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var10 string
-		templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.MaxTieringCandidatesPerSweep))
+		templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.MinDwellDaysPerTier))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 199, Col: 131}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 196, Col: 113}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var10))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, "\" class=\"input input-bordered w-full\" placeholder=\"200\" min=\"1\" max=\"100000\" required> <label class=\"label\"><span class=\"label-text-alt\">Begrenzt die Anzahl verschobener Bilder pro Sweep (Backpressure).</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Sweep Intervall (Minuten)</span></label> <input type=\"number\" name=\"tiering_sweep_interval_minutes\" value=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, "\" class=\"input input-bordered w-full\" placeholder=\"7\" min=\"0\" max=\"3650\" required> <label class=\"label\"><span class=\"label-text-alt\">Schutz vor Ping‑Pong (künftige Verwendung, Phase B).</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Hot Watermark HIGH (%)</span></label> <input type=\"number\" name=\"hot_watermark_high\" value=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var11 string
-		templ_7745c5c3_Var11, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.TieringSweepIntervalMinutes))
+		templ_7745c5c3_Var11, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.HotWatermarkHigh))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 207, Col: 128}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 204, Col: 105}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var11))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, "\" class=\"input input-bordered w-full\" placeholder=\"15\" min=\"1\" max=\"1440\" required> <label class=\"label\"><span class=\"label-text-alt\">Wie oft soll das Tiering prüfen/demoten?</span></label></div></div><!-- API Einstellungen --><div class=\"divider\">API</div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">API Rate Limit (Requests/Minute)</span></label> <input type=\"number\" name=\"api_rate_limit_per_minute\" value=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, "\" class=\"input input-bordered w-full\" placeholder=\"80\" min=\"1\" max=\"100\" required> <label class=\"label\"><span class=\"label-text-alt\">Ab dieser Hot‑Auslastung wird zusätzlich demotet (Hysterese).</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Hot Watermark LOW (%)</span></label> <input type=\"number\" name=\"hot_watermark_low\" value=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var12 string
-		templ_7745c5c3_Var12, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.APIRateLimitPerMinute))
+		templ_7745c5c3_Var12, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.HotWatermarkLow))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 221, Col: 63}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 212, Col: 103}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var12))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, "\" class=\"input input-bordered w-full\" placeholder=\"120\" min=\"0\" max=\"100000\" required> <label class=\"label\"><span class=\"label-text-alt\">Globales API‑Limit für Routen unter <code>/api</code> (0 = unbegrenzt). Änderungen greifen nach einem Neustart des App‑Servers.</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">API Upload Rate Limit (Uploads/Minute)</span></label> <input type=\"number\" name=\"upload_rate_limit_per_minute\" value=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, "\" class=\"input input-bordered w-full\" placeholder=\"65\" min=\"0\" max=\"100\" required> <label class=\"label\"><span class=\"label-text-alt\">Unterhalb dieses Werts endet der Demote‑Druck (nächste Sweeps).</span></label></div></div><div class=\"grid grid-cols-1 md:grid-cols-2 gap-4\"><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Max. Kandidaten pro Sweep</span></label> <input type=\"number\" name=\"max_tiering_candidates_per_sweep\" value=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var13 string
-		templ_7745c5c3_Var13, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.UploadRateLimitPerMinute))
+		templ_7745c5c3_Var13, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.MaxTieringCandidatesPerSweep))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 240, Col: 66}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 222, Col: 131}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var13))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, "\" class=\"input input-bordered w-full\" placeholder=\"60\" min=\"0\" max=\"100000\" required> <label class=\"label\"><span class=\"label-text-alt\">Maximale Anzahl an Uploads pro Minute pro IP am Storage‑Endpoint. 0 = kein Limit.</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">API Upload Rate Limit pro Benutzer (Uploads/Minute)</span></label> <input type=\"number\" name=\"upload_user_rate_limit_per_minute\" value=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, "\" class=\"input input-bordered w-full\" placeholder=\"200\" min=\"1\" max=\"100000\" required> <label class=\"label\"><span class=\"label-text-alt\">Begrenzt die Anzahl verschobener Bilder pro Sweep (Backpressure).</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Sweep Intervall (Minuten)</span></label> <input type=\"number\" name=\"tiering_sweep_interval_minutes\" value=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var14 string
-		templ_7745c5c3_Var14, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.UploadUserRateLimitPerMinute))
+		templ_7745c5c3_Var14, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.TieringSweepIntervalMinutes))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 259, Col: 70}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 230, Col: 128}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var14))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, "\" class=\"input input-bordered w-full\" placeholder=\"60\" min=\"0\" max=\"100000\" required> <label class=\"label\"><span class=\"label-text-alt\">Zusätzliches Limit pro Benutzer-ID am Storage‑Endpoint. 0 = kein Limit.</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Job Queue Worker Anzahl</span></label> <input type=\"number\" name=\"job_queue_worker_count\" value=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, "\" class=\"input input-bordered w-full\" placeholder=\"15\" min=\"1\" max=\"1440\" required> <label class=\"label\"><span class=\"label-text-alt\">Wie oft soll das Tiering prüfen/demoten?</span></label></div></div><!-- Sicherheit --><div class=\"divider\">Sicherheit</div><div class=\"form-control\"><label class=\"label cursor-pointer\"><span class=\"label-text font-semibold\">2FA für Administratoren erzwingen</span> <input type=\"checkbox\" name=\"require_admin_2fa\" class=\"checkbox\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if settings.RequireAdmin2FA {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, " checked")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 28, "></label> <label class=\"label\"><span class=\"label-text-alt\">Admins ohne aktivierte Zwei-Faktor-Authentifizierung werden aus dem Admin-Bereich zur Einrichtung weitergeleitet.</span></label></div><!-- Transformationen --><div class=\"divider\">Transformationen</div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Transformations-Cache je Speicherpool (MB)</span></label> <input type=\"number\" name=\"transform_cache_max_mb_per_pool\" value=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var15 string
-		templ_7745c5c3_Var15, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.JobQueueWorkerCount))
+		templ_7745c5c3_Var15, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.TransformCacheMaxMBPerPool))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 278, Col: 61}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 260, Col: 127}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var15))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, "\" class=\"input input-bordered w-full\" placeholder=\"5\" min=\"1\" max=\"20\" required> <label class=\"label\"><span class=\"label-text-alt\">Anzahl der gleichzeitigen Background-Prozesse (1-20). Bei 5 Workern werden 5 Jobs parallel abgearbeitet - nicht nacheinander</span></label></div><!-- Thumbnail Format Settings --><div class=\"divider\">Thumbnail-Format Einstellungen</div><div class=\"form-control\"><label class=\"label cursor-pointer\"><span class=\"label-text font-semibold\">Original-Format Thumbnails</span> <input type=\"checkbox\" name=\"thumbnail_original_enabled\" class=\"checkbox\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 29, "\" class=\"input input-bordered w-full\" placeholder=\"1024\" min=\"0\" max=\"10000000\" required> <label class=\"label\"><span class=\"label-text-alt\">Bei Überschreitung werden die am längsten nicht abgerufenen Varianten gelöscht (LRU, 0 = unbegrenzt). Erlaubte Formate verwaltest du unter <a href=\"/admin/transform-presets\" class=\"link\">Transformations-Presets</a>.</span></label></div><!-- Ähnliche Bilder --><div class=\"divider\">Ähnliche Bilder</div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Maximale Hash-Distanz</span></label> <input type=\"number\" name=\"similar_image_max_distance\" value=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var16 string
+		templ_7745c5c3_Var16, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.SimilarImageMaxDistance))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 272, Col: 119}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var16))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 30, "\" class=\"input input-bordered w-full\" placeholder=\"7\" min=\"0\" max=\"32\" required> <label class=\"label\"><span class=\"label-text-alt\">Hamming-Distanz der Wahrnehmungs-Hashes (64 Bit), bis zu der zwei Bilder als nahezu identisch gelten (0 = nur gleicher Hash). Ab 8 vergleicht der Duplikat-Bericht alle Bilder eines Nutzers und wird entsprechend langsamer.</span></label></div><div class=\"form-control\"><label class=\"label cursor-pointer\"><span class=\"label-text font-semibold\">Beim Upload vor ähnlichen Bildern warnen</span> <input type=\"checkbox\" name=\"near_duplicate_upload_warning\" class=\"checkbox\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if settings.NearDuplicateUploadWarning {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 31, " checked")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 32, "></label> <label class=\"label\"><span class=\"label-text-alt\">Zeigt nach dem Upload einen Hinweis, wenn der Nutzer bereits ein sehr ähnliches Bild hochgeladen hat.</span></label></div><!-- Optimierung --><div class=\"divider\">Optimierung</div><div class=\"form-control\"><label class=\"label cursor-pointer\"><span class=\"label-text font-semibold\">Varianten im Originalformat optimieren</span> <input type=\"checkbox\" name=\"optimization_enabled\" class=\"checkbox\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if settings.OptimizationEnabled {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 33, " checked")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 34, "></label> <label class=\"label\"><span class=\"label-text-alt\">Verkleinert JPEG- und PNG-Varianten nach dem Speichern mit jpegoptim bzw. pngquant. Ohne pngquant werden PNGs verlustfrei neu komprimiert.</span></label></div><div class=\"form-control\"><label class=\"label cursor-pointer\"><span class=\"label-text font-semibold\">Auch Originale optimieren</span> <input type=\"checkbox\" name=\"optimize_originals\" class=\"checkbox\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if settings.OptimizeOriginals {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 35, " checked")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 36, "></label> <label class=\"label\"><span class=\"label-text-alt\">Optimiert hochgeladene JPEG-Originale verlustfrei mit jpegoptim; Metadaten bleiben erhalten. Andere Formate bleiben unverändert.</span></label></div><!-- Integritätsprüfung --><div class=\"divider\">Integritätsprüfung</div><div class=\"form-control\"><label class=\"label cursor-pointer\"><span class=\"label-text font-semibold\">Speicherpools regelmäßig prüfen</span> <input type=\"checkbox\" name=\"integrity_scrub_enabled\" class=\"checkbox\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if settings.IntegrityScrubEnabled {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 37, " checked")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 38, "></label> <label class=\"label\"><span class=\"label-text-alt\">Prüft, ob alle Originale und Varianten vorhanden sind, vergleicht die Prüfsummen der Originale und sucht verwaiste Dateien. Ergebnisse unter Speicherverwaltung → Integrität.</span></label></div><div class=\"grid grid-cols-1 md:grid-cols-2 gap-4\"><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Intervall (Stunden)</span></label> <input type=\"number\" name=\"integrity_scrub_interval_hours\" value=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var17 string
+		templ_7745c5c3_Var17, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.IntegrityScrubIntervalHours))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 352, Col: 128}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var17))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 39, "\" class=\"input input-bordered w-full\" placeholder=\"168\" min=\"1\" max=\"8760\" required> <label class=\"label\"><span class=\"label-text-alt\">Abstand zwischen zwei Prüfungen eines Pools</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Dateien pro Sekunde</span></label> <input type=\"number\" name=\"integrity_scrub_files_per_second\" value=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var18 string
+		templ_7745c5c3_Var18, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.IntegrityScrubFilesPerSecond))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 359, Col: 131}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var18))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 40, "\" class=\"input input-bordered w-full\" placeholder=\"20\" min=\"1\" max=\"10000\" required> <label class=\"label\"><span class=\"label-text-alt\">Drosselt die Prüfung je Pool, damit Uploads und Auslieferung nicht leiden</span></label></div></div><!-- API Einstellungen --><div class=\"divider\">API</div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">API Rate Limit (Requests/Minute)</span></label> <input type=\"number\" name=\"api_rate_limit_per_minute\" value=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var19 string
+		templ_7745c5c3_Var19, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.APIRateLimitPerMinute))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 373, Col: 63}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var19))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 41, "\" class=\"input input-bordered w-full\" placeholder=\"120\" min=\"0\" max=\"100000\" required> <label class=\"label\"><span class=\"label-text-alt\">Globales API‑Limit für Routen unter <code>/api</code> (0 = unbegrenzt). Änderungen greifen nach einem Neustart des App‑Servers.</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">API Upload Rate Limit (Uploads/Minute)</span></label> <input type=\"number\" name=\"upload_rate_limit_per_minute\" value=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var20 string
+		templ_7745c5c3_Var20, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.UploadRateLimitPerMinute))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 392, Col: 66}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var20))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 42, "\" class=\"input input-bordered w-full\" placeholder=\"60\" min=\"0\" max=\"100000\" required> <label class=\"label\"><span class=\"label-text-alt\">Maximale Anzahl an Uploads pro Minute pro IP am Storage‑Endpoint. 0 = kein Limit.</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">API Upload Rate Limit pro Benutzer (Uploads/Minute)</span></label> <input type=\"number\" name=\"upload_user_rate_limit_per_minute\" value=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var21 string
+		templ_7745c5c3_Var21, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.UploadUserRateLimitPerMinute))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 411, Col: 70}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var21))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 43, "\" class=\"input input-bordered w-full\" placeholder=\"60\" min=\"0\" max=\"100000\" required> <label class=\"label\"><span class=\"label-text-alt\">Zusätzliches Limit pro Benutzer-ID am Storage‑Endpoint. 0 = kein Limit.</span></label></div><div class=\"form-control\"><label class=\"label\"><span class=\"label-text font-semibold\">Job Queue Worker Anzahl</span></label> <input type=\"number\" name=\"job_queue_worker_count\" value=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var22 string
+		templ_7745c5c3_Var22, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.JobQueueWorkerCount))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/admin_views/settings.templ`, Line: 430, Col: 61}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var22))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 44, "\" class=\"input input-bordered w-full\" placeholder=\"5\" min=\"1\" max=\"20\" required> <label class=\"label\"><span class=\"label-text-alt\">Anzahl der gleichzeitigen Background-Prozesse (1-20). Bei 5 Workern werden 5 Jobs parallel abgearbeitet - nicht nacheinander</span></label></div><!-- Thumbnail Format Settings --><div class=\"divider\">Thumbnail-Format Einstellungen</div><div class=\"form-control\"><label class=\"label cursor-pointer\"><span class=\"label-text font-semibold\">Original-Format Thumbnails</span> <input type=\"checkbox\" name=\"thumbnail_original_enabled\" class=\"checkbox\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		if settings.ThumbnailOriginalEnabled {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 28, " checked")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 45, " checked")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 29, "></label> <label class=\"label\"><span class=\"label-text-alt\">Generiert Thumbnails im ursprünglichen Dateiformat (JPG, PNG, etc.).</span></label></div><div class=\"form-control\"><label class=\"label cursor-pointer\"><span class=\"label-text font-semibold\">WebP-Format Thumbnails</span> <input type=\"checkbox\" name=\"thumbnail_webp_enabled\" class=\"checkbox\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 46, "></label> <label class=\"label\"><span class=\"label-text-alt\">Generiert Thumbnails im ursprünglichen Dateiformat (JPG, PNG, etc.).</span></label></div><div class=\"form-control\"><label class=\"label cursor-pointer\"><span class=\"label-text font-semibold\">WebP-Format Thumbnails</span> <input type=\"checkbox\" name=\"thumbnail_webp_enabled\" class=\"checkbox\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		if settings.ThumbnailWebPEnabled {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 30, " checked")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 47, " checked")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 31, "></label> <label class=\"label\"><span class=\"label-text-alt\">Generiert optimierte Thumbnails im WebP-Format für bessere Kompression.</span></label></div><div class=\"form-control\"><label class=\"label cursor-pointer\"><span class=\"label-text font-semibold\">AVIF-Format Thumbnails</span> <input type=\"checkbox\" name=\"thumbnail_avif_enabled\" class=\"checkbox\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 48, "></label> <label class=\"label\"><span class=\"label-text-alt\">Generiert optimierte Thumbnails im WebP-Format für bessere Kompression.</span></label></div><div class=\"form-control\"><label class=\"label cursor-pointer\"><span class=\"label-text font-semibold\">AVIF-Format Thumbnails</span> <input type=\"checkbox\" name=\"thumbnail_avif_enabled\" class=\"checkbox\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		if settings.ThumbnailAVIFEnabled {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 32, " checked")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 49, " checked")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 33, "></label> <label class=\"label\"><span class=\"label-text-alt\">Generiert hochoptimierte Thumbnails im AVIF-Format (erfordert FFmpeg).</span></label></div><!-- Actions --><div class=\"flex justify-end space-x-4 pt-6\"><a href=\"/admin\" class=\"btn btn-ghost\">Abbrechen</a> <button type=\"submit\" class=\"btn btn-primary\">Einstellungen speichern</button></div></form></div></div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 50, "></label> <label class=\"label\"><span class=\"label-text-alt\">Generiert hochoptimierte Thumbnails im AVIF-Format (erfordert FFmpeg).</span></label></div><!-- Actions --><div class=\"flex justify-end space-x-4 pt-6\"><a href=\"/admin\" class=\"btn btn-ghost\">Abbrechen</a> <button type=\"submit\" class=\"btn btn-primary\">Einstellungen speichern</button></div></form></div></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -315,7 +456,7 @@ func settingsContent(settings models.AppSettings, csrfToken string) templ.Compon
 	})
 }
 
-func Settings(settings models.AppSettings, csrfToken string) templ.Component {
+func Settings(settings *models.AppSettings, csrfToken string) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -331,9 +472,9 @@ func Settings(settings models.AppSettings, csrfToken string) templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var16 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var16 == nil {
-			templ_7745c5c3_Var16 = templ.NopComponent
+		templ_7745c5c3_Var23 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var23 == nil {
+			templ_7745c5c3_Var23 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
 		templ_7745c5c3_Err = AdminLayout(settingsContent(settings, csrfToken)).Render(ctx, templ_7745c5c3_Buffer)
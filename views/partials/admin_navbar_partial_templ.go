@@ -29,7 +29,7 @@ func AdminNavbar() templ.Component {
 			templ_7745c5c3_Var1 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div class=\"navbar bg-base-100 shadow-md mb-6 rounded-box\"><div class=\"navbar-start\"><div class=\"dropdown\"><div tabindex=\"0\" role=\"button\" class=\"btn btn-ghost lg:hidden\"><svg xmlns=\"http://www.w3.org/2000/svg\" class=\"h-5 w-5\" fill=\"none\" viewBox=\"0 0 24 24\" stroke=\"currentColor\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M4 6h16M4 12h8m-8 6h16\"></path></svg></div><ul tabindex=\"0\" class=\"menu menu-sm dropdown-content mt-3 z-[1] p-2 shadow bg-base-100 rounded-box w-52\"><li><a href=\"/admin/news\" class=\"font-medium\">News</a></li><li><a href=\"/admin/users\" class=\"font-medium\">Benutzer</a></li><li><details><summary class=\"font-medium\">Bilder</summary><ul class=\"p-2\"><li><a href=\"/admin/images\">Übersicht</a></li><li><a href=\"/admin/reports\">Meldungen</a></li></ul></details></li><li><a href=\"/admin/storage\" class=\"font-medium\">Speicher</a></li><li><a href=\"/admin/pages\" class=\"font-medium\">Seiten</a></li><li><a href=\"/admin/settings\" class=\"font-medium\">Einstellungen</a></li><li><a href=\"/admin/queues\" class=\"font-medium\">Cache-Monitor</a></li></ul></div><a href=\"/admin\" class=\"btn btn-ghost text-xl\">Admin-Dashboard</a></div><div class=\"navbar-center hidden lg:flex\"><ul class=\"menu menu-horizontal px-1\"><li><a href=\"/admin/news\" class=\"font-medium\">News</a></li><li><a href=\"/admin/users\" class=\"font-medium\">Benutzer</a></li><li><details><summary class=\"font-medium\">Bilder</summary><ul class=\"p-2 bg-base-100 rounded-box\"><li><a href=\"/admin/images\">Übersicht</a></li><li><a href=\"/admin/reports\">Meldungen</a></li></ul></details></li><li><a href=\"/admin/storage\" class=\"font-medium\">Speicher</a></li><li><a href=\"/admin/pages\" class=\"font-medium\">Seiten</a></li><li><a href=\"/admin/settings\" class=\"font-medium\">Einstellungen</a></li><li><a href=\"/admin/queues\" class=\"font-medium\">Cache-Monitor</a></li></ul></div><div class=\"navbar-end\"><form action=\"/admin/search\" method=\"GET\" class=\"flex items-center space-x-2\"><select name=\"type\" class=\"select select-bordered select-sm\"><option value=\"users\">Benutzer</option> <option value=\"images\">Bilder</option></select><div class=\"form-control\"><input type=\"text\" name=\"q\" placeholder=\"Suchen...\" class=\"input input-bordered input-sm w-full max-w-xs\"></div><button type=\"submit\" class=\"btn btn-sm btn-primary\"><svg xmlns=\"http://www.w3.org/2000/svg\" class=\"h-5 w-5\" fill=\"none\" viewBox=\"0 0 24 24\" stroke=\"currentColor\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M21 21l-6-6m2-5a7 7 0 11-14 0 7 7 0 0114 0z\"></path></svg></button></form></div></div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div class=\"navbar bg-base-100 shadow-md mb-6 rounded-box\"><div class=\"navbar-start\"><div class=\"dropdown\"><div tabindex=\"0\" role=\"button\" class=\"btn btn-ghost lg:hidden\"><svg xmlns=\"http://www.w3.org/2000/svg\" class=\"h-5 w-5\" fill=\"none\" viewBox=\"0 0 24 24\" stroke=\"currentColor\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M4 6h16M4 12h8m-8 6h16\"></path></svg></div><ul tabindex=\"0\" class=\"menu menu-sm dropdown-content mt-3 z-[1] p-2 shadow bg-base-100 rounded-box w-52\"><li><a href=\"/admin/news\" class=\"font-medium\">News</a></li><li><a href=\"/admin/users\" class=\"font-medium\">Benutzer</a></li><li><details><summary class=\"font-medium\">Bilder</summary><ul class=\"p-2\"><li><a href=\"/admin/images\">Übersicht</a></li><li><a href=\"/admin/reports\">Meldungen</a></li><li><a href=\"/admin/images/near-duplicates\">Ähnliche Bilder</a></li><li><a href=\"/admin/transform-presets\">Transformationen</a></li><li><a href=\"/admin/variant-profiles\">Varianten-Profile</a></li></ul></details></li><li><a href=\"/admin/storage\" class=\"font-medium\">Speicher</a></li><li><a href=\"/admin/pages\" class=\"font-medium\">Seiten</a></li><li><a href=\"/admin/settings\" class=\"font-medium\">Einstellungen</a></li><li><a href=\"/admin/queues\" class=\"font-medium\">Cache-Monitor</a></li></ul></div><a href=\"/admin\" class=\"btn btn-ghost text-xl\">Admin-Dashboard</a></div><div class=\"navbar-center hidden lg:flex\"><ul class=\"menu menu-horizontal px-1\"><li><a href=\"/admin/news\" class=\"font-medium\">News</a></li><li><a href=\"/admin/users\" class=\"font-medium\">Benutzer</a></li><li><details><summary class=\"font-medium\">Bilder</summary><ul class=\"p-2 bg-base-100 rounded-box\"><li><a href=\"/admin/images\">Übersicht</a></li><li><a href=\"/admin/reports\">Meldungen</a></li><li><a href=\"/admin/images/near-duplicates\">Ähnliche Bilder</a></li><li><a href=\"/admin/transform-presets\">Transformationen</a></li><li><a href=\"/admin/variant-profiles\">Varianten-Profile</a></li></ul></details></li><li><a href=\"/admin/storage\" class=\"font-medium\">Speicher</a></li><li><a href=\"/admin/pages\" class=\"font-medium\">Seiten</a></li><li><a href=\"/admin/settings\" class=\"font-medium\">Einstellungen</a></li><li><a href=\"/admin/queues\" class=\"font-medium\">Cache-Monitor</a></li></ul></div><div class=\"navbar-end\"><form action=\"/admin/search\" method=\"GET\" class=\"flex items-center space-x-2\"><select name=\"type\" class=\"select select-bordered select-sm\"><option value=\"users\">Benutzer</option> <option value=\"images\">Bilder</option> <option value=\"tags\">Bilder nach Tag</option></select><div class=\"form-control\"><input type=\"text\" name=\"q\" placeholder=\"Suchen...\" class=\"input input-bordered input-sm w-full max-w-xs\"></div><button type=\"submit\" class=\"btn btn-sm btn-primary\"><svg xmlns=\"http://www.w3.org/2000/svg\" class=\"h-5 w-5\" fill=\"none\" viewBox=\"0 0 24 24\" stroke=\"currentColor\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M21 21l-6-6m2-5a7 7 0 11-14 0 7 7 0 0114 0z\"></path></svg></button></form></div></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -45,7 +45,7 @@ func Navbar(appName string, layout viewmodel.Layout) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</a></div><div class=\"navbar-end\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</a></div><div class=\"navbar-end\"><a hx-swap=\"transition:true\" class=\"btn btn-ghost btn-circle hover:bg-base-200 hover:text-base-content\" href=\"/search\" title=\"Suche\"><svg xmlns=\"http://www.w3.org/2000/svg\" fill=\"none\" viewBox=\"0 0 24 24\" stroke-width=\"1.5\" stroke=\"currentColor\" class=\"w-6 h-6\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" d=\"m21 21-5.197-5.197m0 0A7.5 7.5 0 1 0 5.196 5.196a7.5 7.5 0 0 0 10.607 10.607Z\"></path></svg></a> ")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -57,13 +57,13 @@ func Navbar(appName string, layout viewmodel.Layout) templ.Component {
 			var templ_7745c5c3_Var3 string
 			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(layout.Username)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/partials/navbar_partial.templ`, Line: 17, Col: 85}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/partials/navbar_partial.templ`, Line: 22, Col: 85}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</span></a> <a hx-swap=\"transition:true\" class=\"btn btn-ghost text-base hover:bg-base-200 hover:text-base-content\" href=\"/user/images\">Meine Bilder</a> <a hx-swap=\"transition:true\" class=\"btn btn-ghost text-base hover:bg-base-200 hover:text-base-content\" href=\"/user/albums\">Meine Alben</a> ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</span></a> <a hx-swap=\"transition:true\" class=\"btn btn-ghost text-base hover:bg-base-200 hover:text-base-content\" href=\"/user/images\">Meine Bilder</a> <a hx-swap=\"transition:true\" class=\"btn btn-ghost text-base hover:bg-base-200 hover:text-base-content\" href=\"/user/albums\">Meine Alben</a> <a hx-swap=\"transition:true\" class=\"btn btn-ghost text-base hover:bg-base-200 hover:text-base-content\" href=\"/user/favorites\">Favoriten</a> ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -73,7 +73,7 @@ func Navbar(appName string, layout viewmodel.Layout) templ.Component {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, " <div class=\"dropdown dropdown-end\"><div tabindex=\"0\" role=\"button\" class=\"btn btn-ghost btn-circle avatar hover:bg-base-200\"><div class=\"w-10 rounded-full\"><img alt=\"Profil Bild\" src=\"/img/avatar-default.jpg\"></div></div><ul tabindex=\"0\" class=\"menu menu-sm dropdown-content bg-base-100 text-base-content rounded-box z-[1] mt-3 w-52 p-2 shadow\"><li><a href=\"/user/profile\" class=\"justify-between hover:bg-base-200\">Profil <span class=\"badge\">New</span></a></li><li><a href=\"/user/settings\" class=\"hover:bg-base-200\">Einstellungen</a></li><li><a href=\"/user/settings/membership\" class=\"hover:bg-base-200\">Mitgliedschaft</a></li>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, " <div id=\"notification-bell\" hx-get=\"/user/notifications/bell\" hx-trigger=\"load, notifications-changed from:body\" hx-swap=\"innerHTML\"></div><div class=\"dropdown dropdown-end\"><div tabindex=\"0\" role=\"button\" class=\"btn btn-ghost btn-circle avatar hover:bg-base-200\"><div class=\"w-10 rounded-full\"><img alt=\"Profil Bild\" src=\"/img/avatar-default.jpg\"></div></div><ul tabindex=\"0\" class=\"menu menu-sm dropdown-content bg-base-100 text-base-content rounded-box z-[1] mt-3 w-52 p-2 shadow\"><li><a href=\"/user/profile\" class=\"justify-between hover:bg-base-200\">Profil <span class=\"badge\">New</span></a></li><li><a href=\"/user/settings\" class=\"hover:bg-base-200\">Einstellungen</a></li><li><a href=\"/user/settings/membership\" class=\"hover:bg-base-200\">Mitgliedschaft</a></li>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}